@@ -19,6 +19,7 @@ func InitConf() {
 	UserInvoiceMonth = viper.GetBool("user_invoice_month")
 	GitHubProxy = viper.GetString("github_proxy")
 	MCP_ENABLE = viper.GetBool("mcp.enable") != false
+	MultiTenantEnabled = viper.GetBool("multi_tenant.enable")
 	UPTIMEKUMA_ENABLE = viper.GetBool("uptime_kuma.enable") != false
 	UPTIMEKUMA_DOMAIN = viper.GetString("uptime_kuma.domain")
 	UPTIMEKUMA_STATUS_PAGE_NAME = viper.GetString("uptime_kuma.status_page_name")
@@ -31,11 +32,13 @@ func setEnv() {
 
 func defaultConfig() {
 	viper.SetDefault("port", "3001")
+	viper.SetDefault("shutdown_timeout", 30)
 	viper.SetDefault("gin_mode", "release")
 	viper.SetDefault("log_dir", "./logs")
 	viper.SetDefault("sqlite_path", "done-hub.db")
 	viper.SetDefault("sqlite_busy_timeout", 3000)
 	viper.SetDefault("sync_frequency", 600)
+	viper.SetDefault("session_store", "auto")
 	viper.SetDefault("batch_update_interval", 5)
 	viper.SetDefault("global.api_rate_limit", 300)
 	viper.SetDefault("global.web_rate_limit", 300)
@@ -48,7 +51,25 @@ func defaultConfig() {
 	viper.SetDefault("favicon", "")
 	viper.SetDefault("user_invoice_month", false)
 	viper.SetDefault("mcp.enable", false)
+	viper.SetDefault("grpc.enable", false)
+	viper.SetDefault("grpc.port", "3002")
+	viper.SetDefault("grpc.insecure", false)
 	viper.SetDefault("uptime_kuma.enable", false)
 	viper.SetDefault("uptime_kuma.domain", "")
 	viper.SetDefault("uptime_kuma.status_page_name", "")
+
+	// 中继接口默认允许任意来源跨域调用，管理后台接口默认不允许跨域
+	viper.SetDefault("cors.relay.allow_origins", "*")
+	viper.SetDefault("cors.relay.allow_methods", "GET,POST,PUT,DELETE,OPTIONS")
+	viper.SetDefault("cors.relay.allow_headers", "*")
+	// 添加 Vary 头部以防止 CDN 缓存问题
+	viper.SetDefault("cors.relay.expose_headers", "Vary,Cache-Control,X-Request-ID")
+	viper.SetDefault("cors.relay.allow_credentials", true)
+	viper.SetDefault("cors.relay.max_age", 43200)
+	viper.SetDefault("cors.api.allow_origins", "")
+	viper.SetDefault("cors.api.allow_methods", "GET,POST,PUT,DELETE,OPTIONS")
+	viper.SetDefault("cors.api.allow_headers", "Origin,Content-Length,Content-Type,Authorization")
+	viper.SetDefault("cors.api.expose_headers", "Vary,Cache-Control,X-Request-ID")
+	viper.SetDefault("cors.api.allow_credentials", true)
+	viper.SetDefault("cors.api.max_age", 43200)
 }
@@ -54,9 +54,23 @@ var LinuxDoOAuthDynamicTrustLevel = true // 动态限制已注册用户的信任
 // 是否开启内容审查
 var EnableSafe = false
 
-// 默认使用系统自带关键词审查工具
+// 默认使用系统自带关键词审查工具，多个工具用逗号分隔，如 Keyword,Regex,Moderation
 var SafeToolName = "Keyword"
 
+// 命中后的处理方式：block 拦截，flag 记录日志后放行，redact 替换命中内容后放行
+var SafeAction = "block"
+
+// 需要审查的分组，逗号分隔，为空时审查所有分组；令牌可在设置中单独开启审查
+var SafeGroups = ""
+
+// 正则审查规则，每行一条
+var SafeRegexRules = []string{}
+
+// 外部审查模型（OpenAI moderations 兼容接口）
+var SafeModerationBaseURL = "https://api.openai.com"
+var SafeModerationSecret = ""
+var SafeModerationModel = "omni-moderation-latest"
+
 // 系统自带关键词审查默认字典
 var SafeKeyWords = []string{
 	"fuck",
@@ -153,12 +167,67 @@ var MemoryCacheEnabled = false
 
 var LogConsumeEnabled = true
 
+// 在消费日志中记录原始请求体（超过 LogRequestBodyMaxSize 字节时不记录），用于管理员回放请求
+var LogRequestBodyEnabled = false
+var LogRequestBodyMaxSize = 65536
+
+// 消费异常检测：当日消费达到前一日的 UsageAnomalyRatio 倍且不低于 UsageAnomalyMinQuota 时视为异常
+var UsageAnomalyNotifyEnabled = false
+var UsageAnomalyRatio = 10.0
+var UsageAnomalyMinQuota = 500000
+
+// 上游余额定时检查：每 ChannelBalanceCheckInterval 分钟查询一次，余额不高于 ChannelBalanceThreshold 时视为耗尽
+var ChannelBalanceCheckEnabled = false
+var ChannelBalanceCheckInterval = 60
+var ChannelBalanceThreshold = 0.0
+var ChannelBalanceAutoDisable = false
+
 var SMTPServer = ""
 var SMTPPort = 587
 var SMTPAccount = ""
 var SMTPFrom = ""
 var SMTPToken = ""
 
+// 邮件发送方式：smtp / sendgrid / mailgun / ses，发件人统一使用 SMTPFrom
+var EmailProvider = "smtp"
+var SendGridToken = ""
+var MailgunDomain = ""
+var MailgunRegion = "us"
+var MailgunToken = ""
+var SESRegion = ""
+var SESAccessKeyId = ""
+var SESSecret = ""
+
+// 邮件发送失败后的重试次数
+var EmailRetryTimes = 3
+
+// 计费事件 Webhook：每个计费请求完成后推送一次，使用 BillingWebhookSecret 进行 HMAC-SHA256 签名
+var BillingWebhookEnabled = false
+var BillingWebhookURL = ""
+var BillingWebhookSecret = ""
+var BillingWebhookRetryTimes = 3
+
+// 扩展钩子：在 HookStages 指定的阶段（为空时所有阶段）把请求事件 POST 到 HookURL，HookTimeout 单位为毫秒，
+// HookFailOpen 为 true 时钩子调用失败放行请求，否则拒绝请求
+var HookEnabled = false
+var HookURL = ""
+var HookStages = ""
+var HookSecret = ""
+var HookTimeout = 1000
+var HookFailOpen = true
+
+// 日志导出：每 LogExportInterval 分钟为一个批次，把已结束批次的日志按日期分区导出到 S3 / GCS
+var LogExportEnabled = false
+var LogExportFormat = "jsonl"
+var LogExportInterval = 60
+var LogExportStorage = "s3"
+var LogExportEndpoint = ""
+var LogExportRegion = ""
+var LogExportBucket = ""
+var LogExportPrefix = "done-hub/logs"
+var LogExportAccessKeyId = ""
+var LogExportAccessKeySecret = ""
+
 var ChatImageRequestProxy = ""
 
 var GitHubProxy = ""
@@ -191,6 +260,9 @@ var QuotaForInviter = 0
 var QuotaForInvitee = 0
 var InviterRewardType = "fixed" // "fixed" 或 "percentage"
 var InviterRewardValue = 0
+
+// 邀请奖励（QuotaForInviter / QuotaForInvitee）的发放时机："signup" 注册时发放，"first_topup" 被邀请人首次充值时发放
+var InviteRewardTrigger = "signup"
 var ChannelDisableThreshold = 5.0
 var AutomaticDisableChannelEnabled = false
 var AutomaticEnableChannelEnabled = false
@@ -202,6 +274,9 @@ var DisableTokenEncoders = false
 var RetryTimes = 0
 var RetryTimeOut = 10
 
+// 流式请求等待上游首个字节的超时时间（秒），超时后切换渠道重试，0 表示不限制
+var StreamFirstByteTimeout = 0
+
 // 统一请求响应模型（响应中显示用户请求的原始模型名称）
 var UnifiedRequestResponseModelEnabled = false
 
@@ -211,9 +286,41 @@ var ModelNameCaseInsensitiveEnabled = false
 var DefaultChannelWeight = uint(1)
 var RetryCooldownSeconds = 5
 
+// 渠道熔断器：按滚动窗口错误率自动摘除渠道，冷却后半开探测
+var ChannelCircuitBreakerEnabled = false
+var ChannelCircuitBreakerErrorRate = 0.5    // 触发熔断的错误率
+var ChannelCircuitBreakerMinRequests = 10   // 窗口内最少请求数，不足时不触发
+var ChannelCircuitBreakerWindowSeconds = 60 // 滚动窗口长度（秒）
+var ChannelCircuitBreakerOpenSeconds = 60   // 熔断持续时间（秒）
+var ChannelCircuitBreakerHalfOpenProbes = 3 // 半开状态下恢复所需的连续成功探测数
+
+// 流式保活：上游长时间无输出时向客户端发送 SSE 注释行（: ping），避免被 CDN/反向代理按空闲超时断开，0 为关闭
+var StreamKeepAliveSeconds = 0
+
+// 代理池：上游返回 403 时是否暂停使用该代理，403 也可能是密钥或权限问题，默认只在连接失败和 407 时暂停
+var ProxyCooldownOnForbidden = false
+
+// 不活跃账户自动暂停：超过 InactiveUserDays 天未登录且未使用令牌的账户会被标记并通知，
+// 宽限期 InactiveUserGraceDays 天后仍未活跃则暂停其令牌
+var InactiveUserPauseEnabled = false
+var InactiveUserDays = 90
+var InactiveUserGraceDays = 7
+var InactiveUserExemptGroups = ""         // 豁免分组，逗号分隔
+var InactiveUserReclaimPromoQuota = false // 暂停时是否回收未使用的赠送额度
+
 var CFWorkerImageUrl = ""
 var CFWorkerImageKey = ""
 
+// 将上游返回的图片、音频临时链接转存到配置的存储中，并替换为自有链接
+var StorageMirrorEnabled = false
+
+// 图片输入规范化：需要 base64 的上游在转发前下载图片，超出限制时缩放并重新编码
+var ImageInputMaxSizeMB = 0      // 单张图片最大大小，0 为仅使用上游自身的限制
+var ImageInputMaxDimension = 0   // 最长边最大像素，0 为仅使用上游自身的限制
+var ImageInputJPEGQuality = 85   // 重新编码为 JPEG 时的初始质量
+var ImageInputCacheSeconds = 300 // 远程图片缓存时间，0 为不缓存
+var ImageInputCacheMaxMB = 64    // 远程图片缓存的最大容量
+
 var RootUserEmail = ""
 
 var IsMasterNode = true
@@ -225,6 +332,9 @@ var BatchUpdateInterval = 5
 
 var MCP_ENABLE = false
 
+// 多租户模式，开启后用户、渠道按租户隔离
+var MultiTenantEnabled = false
+
 var UPTIMEKUMA_ENABLE = false
 var UPTIMEKUMA_DOMAIN = ""
 var UPTIMEKUMA_STATUS_PAGE_NAME = ""
@@ -244,6 +354,24 @@ const (
 
 var RateLimitKeyExpirationDuration = 20 * time.Minute
 
+// 按客户端 IP 限流，与令牌、用户分组的限流相互独立，0 为不限制
+var IPRateLimitEnabled = false
+var IPRelayRateLimit = 0      // 中继接口每个 IP 每分钟的最大请求数
+var IPAuthRateLimit = 0       // 登录、注册等认证接口每个 IP 每分钟的最大请求数
+var IPRateLimitWhitelist = "" // 不受限制的 IP 或网段，多个用逗号分隔
+
+// 地区访问限制：令牌或分组限定了允许的地区时，其他地区的请求按 GeoRestrictionAction 处理，"reject" 拒绝 / "flag" 放行并在日志中标记
+var GeoRestrictionAction = "reject"
+
+// 中继请求大小限制，令牌与分组可单独设置更严格的限制，0 为不限制
+var RequestMaxBodySize = 0     // 请求体最大大小（MB）
+var RequestMaxMessages = 0     // 单次请求的最大消息数
+var RequestMaxImageSize = 0    // 请求中内嵌图片（base64）的总大小上限（MB）
+var RequestMaxOutputTokens = 0 // 请求允许设置的最大输出 token 数
+
+// 带 Idempotency-Key 请求头的中继请求，成功响应的缓存时间（秒），0 为不启用
+var IdempotencyKeyTTL = 3600
+
 const (
 	UserStatusEnabled  = 1 // don't use 0, 0 is the default value!
 	UserStatusDisabled = 2 // also don't use 0
@@ -254,6 +382,7 @@ const (
 	TokenStatusDisabled  = 2 // also don't use 0
 	TokenStatusExpired   = 3
 	TokenStatusExhausted = 4
+	TokenStatusPaused    = 5 // 账户长期不活跃被自动暂停，登录后恢复
 )
 
 const (
@@ -331,6 +460,7 @@ const (
 	ChannelTypeCodex           = 59
 	ChannelTypeAntigravity     = 60
 	ChannelTypeVertexAIExpress = 61
+	ChannelTypeCopilot         = 62
 )
 
 const (
@@ -351,6 +481,7 @@ const (
 	RelayModeChatRealtime
 	RelayModeKling
 	RelayModeResponses
+	RelayModeOCR
 )
 
 type ContextKey string
@@ -8,4 +8,7 @@ const (
 
 	GinProcessedBytesKey        = "processed_request_bytes"
 	GinProcessedBytesIsVertexAI = "processed_bytes_is_vertexai"
+
+	// GinRelayFailedKey 最后一次中继是否失败，流式响应开始输出后失败时状态码仍为 200
+	GinRelayFailedKey = "relay_failed"
 )
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// reasoningReverseEfforts 由思考预算反推推理力度时可选的取值，minimal 仅部分模型支持，不参与反推
+var reasoningReverseEfforts = []string{"low", "medium", "high"}
+
+type ReasoningSettings struct {
+	// EffortBudgets 推理力度与思考预算（token 数）的对照表
+	EffortBudgets map[string]int
+}
+
+var ReasoningSettingsInstance = ReasoningSettings{
+	EffortBudgets: defaultReasoningEffortBudgets(),
+}
+
+func defaultReasoningEffortBudgets() map[string]int {
+	return map[string]int{
+		"minimal": 512,
+		"low":     1024,
+		"medium":  8192,
+		"high":    24576,
+	}
+}
+
+func (r *ReasoningSettings) SetEffortBudgets(data string) error {
+	if data == "" {
+		r.EffortBudgets = defaultReasoningEffortBudgets()
+		return nil
+	}
+
+	var budgets map[string]int
+	if err := json.Unmarshal([]byte(data), &budgets); err != nil {
+		return err
+	}
+
+	for _, budget := range budgets {
+		if budget < 0 {
+			return errors.New("思考预算不能为负数")
+		}
+	}
+	r.EffortBudgets = budgets
+	return nil
+}
+
+// GetBudgetByEffort 根据推理力度获取思考预算
+func (r *ReasoningSettings) GetBudgetByEffort(effort string) (int, bool) {
+	budget, ok := r.EffortBudgets[effort]
+	return budget, ok
+}
+
+// GetEffortByBudget 根据思考预算反推推理力度，取预算不超过给定值的最高档，低于所有档位时返回 low
+func (r *ReasoningSettings) GetEffortByBudget(budget int) string {
+	effort := reasoningReverseEfforts[0]
+	for _, candidate := range reasoningReverseEfforts {
+		if value, ok := r.EffortBudgets[candidate]; ok && budget >= value {
+			effort = candidate
+		}
+	}
+	return effort
+}
+
+func (r *ReasoningSettings) GetEffortBudgetsJSONString() string {
+	str, err := json.Marshal(r.EffortBudgets)
+	if err != nil {
+		return ""
+	}
+	return string(str)
+}
@@ -0,0 +1,114 @@
+package geoip
+
+import (
+	"done-hub/common/logger"
+	"net"
+	"strings"
+
+	"github.com/oschwald/geoip2-golang"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ContextKey 请求解析出的地理位置在 gin context 中的键
+	ContextKey = "geo_location"
+	// FlaggedContextKey 请求来自不允许的地区但按配置放行时设置，记录到日志中
+	FlaggedContextKey = "geo_flagged"
+)
+
+// Location IP 对应的地理位置，Country 为 ISO 3166-1 国家代码（如 US），
+// Region 为 ISO 3166-2 一级行政区代码（如 US-CA），使用国家级数据库时为空
+type Location struct {
+	Country string `json:"country"`
+	Region  string `json:"region,omitempty"`
+}
+
+var (
+	reader *geoip2.Reader
+	isCity bool
+)
+
+// InitGeoIP 加载 MaxMind 格式（.mmdb）的 GeoIP 数据库，支持 GeoLite2 / GeoIP2 的 Country 与 City 数据库
+func InitGeoIP() {
+	path := viper.GetString("geoip_db_path")
+	if path == "" {
+		return
+	}
+
+	db, err := geoip2.Open(path)
+	if err != nil {
+		logger.SysError("failed to open GeoIP database: " + err.Error())
+		return
+	}
+
+	reader = db
+	isCity = strings.Contains(db.Metadata().DatabaseType, "City")
+	logger.SysLog("GeoIP database loaded: " + db.Metadata().DatabaseType)
+}
+
+func IsEnabled() bool {
+	return reader != nil
+}
+
+// Lookup 查询 IP 的地理位置，未加载数据库、内网地址或查询不到时返回 nil
+func Lookup(ip string) *Location {
+	if reader == nil {
+		return nil
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsUnspecified() {
+		return nil
+	}
+
+	location := &Location{}
+	if isCity {
+		record, err := reader.City(parsed)
+		if err != nil {
+			return nil
+		}
+		location.Country = record.Country.IsoCode
+		if len(record.Subdivisions) > 0 && record.Subdivisions[0].IsoCode != "" {
+			location.Region = record.Country.IsoCode + "-" + record.Subdivisions[0].IsoCode
+		}
+	} else {
+		record, err := reader.Country(parsed)
+		if err != nil {
+			return nil
+		}
+		location.Country = record.Country.IsoCode
+	}
+
+	if location.Country == "" {
+		return nil
+	}
+	return location
+}
+
+// Match 判断位置是否在允许列表中，列表项可以是国家代码（US）或行政区代码（US-CA），不区分大小写
+func Match(location *Location, allowed []string) bool {
+	if location == nil {
+		return false
+	}
+
+	for _, item := range allowed {
+		item = strings.TrimSpace(item)
+		if strings.EqualFold(item, location.Country) || (location.Region != "" && strings.EqualFold(item, location.Region)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRegions 解析以逗号或换行分隔的地区列表
+func ParseRegions(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' '
+	})
+
+	regions := make([]string, 0, len(fields))
+	for _, field := range fields {
+		regions = append(regions, strings.ToUpper(field))
+	}
+	return regions
+}
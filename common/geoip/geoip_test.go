@@ -0,0 +1,37 @@
+package geoip
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	location := &Location{Country: "US", Region: "US-CA"}
+
+	cases := []struct {
+		allowed []string
+		want    bool
+	}{
+		{[]string{"us"}, true},
+		{[]string{"US-CA"}, true},
+		{[]string{"US-NY"}, false},
+		{[]string{"CN", "JP"}, false},
+		{nil, false},
+	}
+	for _, tc := range cases {
+		if got := Match(location, tc.allowed); got != tc.want {
+			t.Errorf("Match(%v) = %v, want %v", tc.allowed, got, tc.want)
+		}
+	}
+
+	if Match(nil, []string{"US"}) {
+		t.Error("unknown location should not match")
+	}
+	if Match(&Location{Country: "US"}, []string{"US-CA"}) {
+		t.Error("country-only location should not match a region rule")
+	}
+}
+
+func TestParseRegions(t *testing.T) {
+	regions := ParseRegions("cn, us-ca\nJP")
+	if len(regions) != 3 || regions[0] != "CN" || regions[1] != "US-CA" || regions[2] != "JP" {
+		t.Fatalf("unexpected regions: %v", regions)
+	}
+}
@@ -0,0 +1,42 @@
+package graceful
+
+import (
+	"context"
+	"done-hub/common/logger"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+var backgroundWG sync.WaitGroup
+
+// GoBackground 启动需要在退出前完成的后台任务（如扣费、写日志），
+// 退出时会等待这些任务执行完毕
+func GoBackground(f func()) {
+	backgroundWG.Add(1)
+	go func() {
+		defer backgroundWG.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.SysError(fmt.Sprintf("background task panic occured: error: %v, stack: %s", r, string(debug.Stack())))
+			}
+		}()
+		f()
+	}()
+}
+
+// WaitBackground 等待所有后台任务完成，超时返回 ctx 的错误
+func WaitBackground(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		backgroundWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,39 @@
+package graceful
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitBackground(t *testing.T) {
+	var finished atomic.Bool
+	GoBackground(func() {
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := WaitBackground(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !finished.Load() {
+		t.Fatal("background task not finished")
+	}
+}
+
+func TestWaitBackgroundTimeout(t *testing.T) {
+	release := make(chan struct{})
+	GoBackground(func() {
+		<-release
+	})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := WaitBackground(ctx); err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
@@ -0,0 +1,117 @@
+package hook
+
+import (
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Event 传给钩子的请求信息，各阶段只填充当时已知的字段
+type Event struct {
+	Stage     string `json:"stage"`
+	RequestId string `json:"request_id"`
+	CreatedAt int64  `json:"created_at"`
+
+	Method   string            `json:"method,omitempty"`
+	Path     string            `json:"path,omitempty"`
+	ClientIp string            `json:"client_ip,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"` // 只在 pre_auth 阶段提供，仅包含允许的请求头
+
+	UserId      int    `json:"user_id,omitempty"`
+	TokenId     int    `json:"token_id,omitempty"`
+	TokenName   string `json:"token_name,omitempty"`
+	Group       string `json:"group,omitempty"`
+	Model       string `json:"model,omitempty"`
+	ChannelId   int    `json:"channel_id,omitempty"`
+	ChannelType int    `json:"channel_type,omitempty"`
+	Attempt     int    `json:"attempt,omitempty"`
+	IsStream    bool   `json:"is_stream,omitempty"`
+
+	// post_upstream 阶段的上游结果
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// post_billing 阶段的计费结果
+	PromptTokens     int `json:"prompt_tokens,omitempty"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
+	Quota            int `json:"quota,omitempty"`
+
+	// 之前的钩子返回的元数据
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// 当前请求的上下文，只提供给 Go 插件，post_billing 阶段为 nil
+	Context *gin.Context `json:"-"`
+}
+
+// 传给钩子的请求头，只提供不含认证信息的常用请求头，避免把密钥发送到外部钩子
+var allowedHeaders = map[string]bool{
+	"accept":            true,
+	"accept-language":   true,
+	"anthropic-beta":    true,
+	"anthropic-version": true,
+	"content-length":    true,
+	"content-type":      true,
+	"origin":            true,
+	"referer":           true,
+	"user-agent":        true,
+	"x-request-id":      true,
+}
+
+// NewEvent 根据当前请求构造事件
+func NewEvent(c *gin.Context, stage string) *Event {
+	event := &Event{
+		Stage:       stage,
+		RequestId:   c.GetString(logger.RequestIdKey),
+		CreatedAt:   utils.GetTimestamp(),
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.Path,
+		ClientIp:    c.ClientIP(),
+		UserId:      c.GetInt("id"),
+		TokenId:     c.GetInt("token_id"),
+		TokenName:   c.GetString("token_name"),
+		Group:       c.GetString("token_group"),
+		Model:       c.GetString("original_model"),
+		ChannelId:   c.GetInt("channel_id"),
+		ChannelType: c.GetInt("channel_type"),
+		Attempt:     max(c.GetInt("attempt_count"), 1),
+		IsStream:    c.GetBool("is_stream"),
+		Context:     c,
+	}
+	if event.Group == "" {
+		event.Group = c.GetString("group")
+	}
+	if metadata, ok := utils.GetGinValue[map[string]any](c, MetadataContextKey); ok {
+		event.Metadata = metadata
+	}
+
+	if stage == StagePreAuth {
+		event.Headers = make(map[string]string, len(c.Request.Header))
+		for name, values := range c.Request.Header {
+			if !allowedHeaders[strings.ToLower(name)] {
+				continue
+			}
+			event.Headers[name] = strings.Join(values, ", ")
+		}
+	}
+	return event
+}
+
+// RunWithContext 执行当前请求的钩子，并把返回的元数据保存到请求上下文
+func RunWithContext(c *gin.Context, event *Event) *Result {
+	result := Run(c.Request.Context(), event)
+	if len(result.Metadata) > 0 {
+		c.Set(MetadataContextKey, event.Metadata)
+	}
+	return result
+}
+
+// RejectStatusCode 返回拒绝请求时使用的状态码，钩子返回的状态码无效时使用 403
+func (r *Result) RejectStatusCode() int {
+	if r.StatusCode < http.StatusBadRequest || r.StatusCode > 599 {
+		return http.StatusForbidden
+	}
+	return r.StatusCode
+}
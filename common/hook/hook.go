@@ -0,0 +1,195 @@
+package hook
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 扩展点
+const (
+	StagePreAuth      = "pre_auth"      // 鉴权之前，可以拒绝请求
+	StagePreUpstream  = "pre_upstream"  // 选定渠道、发送上游请求之前，可以拒绝请求或跳过当前渠道
+	StagePostUpstream = "post_upstream" // 上游请求结束之后，只能记录元数据
+	StagePostBilling  = "post_billing"  // 计费完成之后，异步执行，只用于通知
+)
+
+var Stages = []string{StagePreAuth, StagePreUpstream, StagePostUpstream, StagePostBilling}
+
+// MetadataContextKey 钩子返回的元数据，合并后记录在消费日志的 hook 字段中
+const MetadataContextKey = "hook_metadata"
+
+// ErrCodeSkipChannel 钩子要求跳过当前渠道时返回的错误码，会切换到其他渠道重试
+const ErrCodeSkipChannel = "hook_skip_channel"
+
+// Result 钩子的处理结果，返回 nil 表示不做处理
+type Result struct {
+	// 拒绝请求，只在 pre_auth、pre_upstream 阶段生效
+	Reject     bool   `json:"reject,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	// 跳过当前渠道，只在 pre_upstream 阶段生效
+	SkipChannel bool `json:"skip_channel,omitempty"`
+	// 附加到本次请求的元数据，后执行的钩子覆盖同名字段
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Handler 扩展逻辑，返回错误时按 HookFailOpen 决定放行还是拒绝请求
+type Handler interface {
+	Handle(ctx context.Context, event *Event) (*Result, error)
+}
+
+type HandlerFunc func(ctx context.Context, event *Event) (*Result, error)
+
+func (f HandlerFunc) Handle(ctx context.Context, event *Event) (*Result, error) {
+	return f(ctx, event)
+}
+
+type plugin struct {
+	name    string
+	stages  map[string]bool
+	handler Handler
+}
+
+var (
+	pluginsLock sync.RWMutex
+	plugins     []*plugin
+)
+
+// Register 注册编译进程序的 Go 插件，一般在插件包的 init 中调用，未指定阶段时在所有阶段执行。
+// 插件按注册顺序执行，配置的 HTTP 钩子在所有插件之后执行
+func Register(name string, handler Handler, stages ...string) {
+	if len(stages) == 0 {
+		stages = Stages
+	}
+
+	p := &plugin{name: name, stages: make(map[string]bool), handler: handler}
+	for _, stage := range stages {
+		p.stages[stage] = true
+	}
+
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+	plugins = append(plugins, p)
+}
+
+// Enabled 判断阶段是否有需要执行的钩子，没有时调用方可以跳过构造事件
+func Enabled(stage string) bool {
+	if httpHookEnabled(stage) {
+		return true
+	}
+
+	pluginsLock.RLock()
+	defer pluginsLock.RUnlock()
+	for _, p := range plugins {
+		if p.stages[stage] {
+			return true
+		}
+	}
+	return false
+}
+
+// Run 依次执行阶段的钩子，遇到拒绝或跳过渠道时停止，返回合并后的结果
+func Run(ctx context.Context, event *Event) *Result {
+	pluginsLock.RLock()
+	handlers := make([]*plugin, 0, len(plugins)+1)
+	for _, p := range plugins {
+		if p.stages[event.Stage] {
+			handlers = append(handlers, p)
+		}
+	}
+	pluginsLock.RUnlock()
+
+	if httpHookEnabled(event.Stage) {
+		handlers = append(handlers, &plugin{name: "http", handler: HandlerFunc(callHTTPHook)})
+	}
+
+	merged := &Result{}
+	for _, p := range handlers {
+		result, err := safeHandle(ctx, p.handler, event)
+		if err != nil {
+			logger.LogError(ctx, fmt.Sprintf("hook_error stage=%s hook=%s error=\"%s\"", event.Stage, p.name, err.Error()))
+			if config.HookFailOpen || !canReject(event.Stage) {
+				continue
+			}
+			result = &Result{Reject: true, StatusCode: http.StatusServiceUnavailable, Message: "扩展钩子执行失败，请稍后再试"}
+		}
+		if result == nil {
+			continue
+		}
+
+		if len(result.Metadata) > 0 {
+			if merged.Metadata == nil {
+				merged.Metadata = make(map[string]any, len(result.Metadata))
+			}
+			// 复制后再合并，event.Metadata 可能与请求上下文共用
+			metadata := make(map[string]any, len(event.Metadata)+len(result.Metadata))
+			for key, value := range event.Metadata {
+				metadata[key] = value
+			}
+			for key, value := range result.Metadata {
+				metadata[key] = value
+				merged.Metadata[key] = value
+			}
+			event.Metadata = metadata
+		}
+
+		if result.Reject && canReject(event.Stage) {
+			merged.Reject = true
+			merged.StatusCode = result.RejectStatusCode()
+			merged.Message = result.Message
+			if merged.Message == "" {
+				merged.Message = "请求被扩展钩子拒绝"
+			}
+			logger.LogWarn(ctx, fmt.Sprintf("hook_reject stage=%s hook=%s status_code=%d message=\"%s\"", event.Stage, p.name, merged.StatusCode, merged.Message))
+			return merged
+		}
+
+		if result.SkipChannel && event.Stage == StagePreUpstream {
+			merged.SkipChannel = true
+			merged.Message = result.Message
+			if merged.Message == "" {
+				merged.Message = "当前渠道被扩展钩子跳过"
+			}
+			return merged
+		}
+	}
+	return merged
+}
+
+// safeHandle 执行钩子，避免插件 panic 影响请求
+func safeHandle(ctx context.Context, handler Handler, event *Event) (result *Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
+
+func canReject(stage string) bool {
+	return stage == StagePreAuth || stage == StagePreUpstream
+}
+
+func IsValidStages(value string) bool {
+	for _, stage := range strings.Split(value, ",") {
+		if stage = strings.TrimSpace(stage); stage == "" {
+			continue
+		}
+		valid := false
+		for _, s := range Stages {
+			if stage == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package hook
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/webhook"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func resetHooks() {
+	logger.Logger = zap.NewNop()
+	pluginsLock.Lock()
+	plugins = nil
+	pluginsLock.Unlock()
+	config.HookEnabled = false
+	config.HookURL = ""
+	config.HookStages = ""
+	config.HookSecret = ""
+	config.HookFailOpen = true
+}
+
+func TestRunPlugins(t *testing.T) {
+	resetHooks()
+	defer resetHooks()
+
+	calls := make([]string, 0)
+	Register("audit", HandlerFunc(func(ctx context.Context, event *Event) (*Result, error) {
+		calls = append(calls, "audit")
+		return &Result{Metadata: map[string]any{"audit": event.Stage}}, nil
+	}))
+	Register("broken", HandlerFunc(func(ctx context.Context, event *Event) (*Result, error) {
+		panic("boom")
+	}), StagePreUpstream)
+	Register("guard", HandlerFunc(func(ctx context.Context, event *Event) (*Result, error) {
+		calls = append(calls, "guard")
+		if event.Metadata["audit"] != StagePreUpstream {
+			t.Errorf("metadata from previous hook should be visible, got %v", event.Metadata)
+		}
+		return &Result{SkipChannel: true}, nil
+	}), StagePreUpstream)
+
+	if Enabled(StagePostUpstream) != true || Enabled(StagePreAuth) != true {
+		t.Fatal("audit plugin should be enabled for all stages")
+	}
+
+	// 插件 panic 时放行，跳过渠道时返回前面钩子的元数据
+	result := Run(context.Background(), &Event{Stage: StagePreUpstream})
+	if !result.SkipChannel || result.Metadata["audit"] != StagePreUpstream || len(calls) != 2 {
+		t.Fatalf("unexpected result: %+v, calls: %v", result, calls)
+	}
+
+	// 跳过渠道与拒绝只在对应阶段生效
+	result = Run(context.Background(), &Event{Stage: StagePostUpstream})
+	if result.SkipChannel || result.Reject {
+		t.Fatalf("post_upstream should not skip or reject: %+v", result)
+	}
+
+	// 关闭 fail open 时，插件出错会拒绝请求
+	config.HookFailOpen = false
+	result = Run(context.Background(), &Event{Stage: StagePreUpstream})
+	if !result.Reject || result.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("failed hook should reject when fail open is disabled: %+v", result)
+	}
+}
+
+func TestHTTPHook(t *testing.T) {
+	resetHooks()
+	defer resetHooks()
+
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(webhook.EventHeader) != "hook.pre_auth" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.Unmarshal(body, &received)
+		w.Write([]byte(`{"reject": true, "status_code": 200, "message": "blocked"}`))
+	}))
+	defer server.Close()
+
+	config.HookEnabled = true
+	config.HookURL = server.URL
+	config.HookSecret = "secret"
+	config.HookStages = "pre_auth, post_billing"
+
+	if Enabled(StagePreUpstream) {
+		t.Fatal("http hook should only run in configured stages")
+	}
+
+	result := Run(context.Background(), &Event{Stage: StagePreAuth, RequestId: "req-1", Path: "/v1/chat/completions"})
+	if !result.Reject || result.StatusCode != http.StatusForbidden || result.Message != "blocked" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if received.RequestId != "req-1" || received.Path != "/v1/chat/completions" {
+		t.Fatalf("unexpected event: %+v", received)
+	}
+
+	// 钩子不可用时默认放行
+	config.HookURL = "http://127.0.0.1:1"
+	if result = Run(context.Background(), &Event{Stage: StagePreAuth}); result.Reject {
+		t.Fatalf("unavailable hook should fail open: %+v", result)
+	}
+}
+
+func TestIsValidStages(t *testing.T) {
+	if !IsValidStages("") || !IsValidStages("pre_auth, post_billing") {
+		t.Fatal("valid stages rejected")
+	}
+	if IsValidStages("pre_auth,before_auth") {
+		t.Fatal("invalid stage accepted")
+	}
+	if _, err := safeHandle(context.Background(), HandlerFunc(func(ctx context.Context, event *Event) (*Result, error) {
+		return nil, errors.New("failed")
+	}), &Event{}); err == nil {
+		t.Fatal("handler error should be returned")
+	}
+}
+
+func TestNewEventHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	c.Request.Header.Set("Authorization", "Bearer sk-secret")
+	c.Request.Header.Set("Mj-Api-Secret", "secret")
+	c.Request.Header.Set("X-Custom-Key", "secret")
+	c.Request.Header.Set("User-Agent", "test")
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// 只传递允许的请求头
+	event := NewEvent(c, StagePreAuth)
+	if len(event.Headers) != 2 || event.Headers["User-Agent"] != "test" || event.Headers["Content-Type"] != "application/json" {
+		t.Fatalf("unexpected headers: %v", event.Headers)
+	}
+
+	if event = NewEvent(c, StagePreUpstream); event.Headers != nil {
+		t.Fatalf("headers should only be provided at pre_auth, got %v", event.Headers)
+	}
+}
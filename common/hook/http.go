@@ -0,0 +1,94 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/webhook"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 响应体最大读取长度
+const maxHTTPHookResponseSize = 1 << 20
+
+var httpClient = &http.Client{}
+
+// httpHookEnabled 判断是否在阶段调用外部 HTTP 钩子，HookStages 为空时所有阶段都调用
+func httpHookEnabled(stage string) bool {
+	if !config.HookEnabled || config.HookURL == "" {
+		return false
+	}
+	if strings.TrimSpace(config.HookStages) == "" {
+		return true
+	}
+	for _, s := range strings.Split(config.HookStages, ",") {
+		if strings.TrimSpace(s) == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// callHTTPHook 以 POST 方式把事件发送到 HookURL，签名方式与计费 Webhook 相同，
+// 响应 2xx 且响应体为空时视为不做处理，否则按 Result 解析响应体
+func callHTTPHook(ctx context.Context, event *Event) (*Result, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(config.HookTimeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	// post_billing 在请求结束后执行，不能使用已经结束的请求上下文
+	if event.Stage == StagePostBilling {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.HookURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, "hook."+event.Stage)
+	req.Header.Set(webhook.DeliveryHeader, event.RequestId)
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if config.HookSecret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(config.HookSecret, timestamp, payload))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("hook responded with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPHookResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	result := &Result{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("invalid hook response: %s", err.Error())
+	}
+	return result, nil
+}
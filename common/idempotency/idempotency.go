@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/redis"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	recordKeyPrefix = "idempotency:record:"
+	lockKeyPrefix   = "idempotency:lock:"
+)
+
+// Record 已完成请求的响应，相同 Idempotency-Key 的重试直接返回该响应
+type Record struct {
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// Get 获取已缓存的响应，不存在时返回 nil
+func Get(key string) (*Record, error) {
+	var data []byte
+	if config.RedisEnabled {
+		value, err := redis.RedisGet(recordKeyPrefix + key)
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		data = []byte(value)
+	} else {
+		value, ok := memory.get(recordKeyPrefix + key)
+		if !ok {
+			return nil, nil
+		}
+		data = value
+	}
+
+	record := &Record{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Save 缓存请求的最终响应
+func Save(key string, record *Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if config.RedisEnabled {
+		return redis.RedisSet(recordKeyPrefix+key, string(data), ttl)
+	}
+	memory.set(recordKeyPrefix+key, data, ttl)
+	return nil
+}
+
+// Acquire 标记请求处理中，同一个 key 同时只允许一个请求执行
+func Acquire(key string, ttl time.Duration) (bool, error) {
+	if config.RedisEnabled {
+		return redis.RedisSetNX(lockKeyPrefix+key, "1", ttl)
+	}
+	return memory.setNX(lockKeyPrefix+key, []byte("1"), ttl), nil
+}
+
+// Release 请求处理结束后释放处理中标记
+func Release(key string) error {
+	if config.RedisEnabled {
+		return redis.RedisDel(lockKeyPrefix + key)
+	}
+	memory.delete(lockKeyPrefix + key)
+	return nil
+}
+
+type memoryEntry struct {
+	value    []byte
+	expireAt time.Time
+}
+
+// memoryStore 未启用 Redis 时使用的本地存储，写入时顺带清理过期数据
+type memoryStore struct {
+	sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+var memory = &memoryStore{entries: make(map[string]memoryEntry)}
+
+func (s *memoryStore) get(key string) ([]byte, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expireAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (s *memoryStore) set(key string, value []byte, ttl time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.sweep()
+	s.entries[key] = memoryEntry{value: value, expireAt: time.Now().Add(ttl)}
+}
+
+func (s *memoryStore) setNX(key string, value []byte, ttl time.Duration) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	s.sweep()
+	if entry, ok := s.entries[key]; ok && time.Now().Before(entry.expireAt) {
+		return false
+	}
+	s.entries[key] = memoryEntry{value: value, expireAt: time.Now().Add(ttl)}
+	return true
+}
+
+func (s *memoryStore) delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *memoryStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, entry := range s.entries {
+		if now.After(entry.expireAt) {
+			delete(s.entries, key)
+		}
+	}
+}
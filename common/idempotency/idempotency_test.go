@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	acquired, err := Acquire("k1", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("expected acquire, got %v %v", acquired, err)
+	}
+	if acquired, _ = Acquire("k1", time.Minute); acquired {
+		t.Fatal("expected second acquire to fail")
+	}
+
+	if record, _ := Get("k1"); record != nil {
+		t.Fatal("expected no record")
+	}
+
+	if err := Save("k1", &Record{Fingerprint: "fp", StatusCode: 200, ContentType: "application/json", Body: []byte(`{"ok":true}`)}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	Release("k1")
+
+	record, err := Get("k1")
+	if err != nil || record == nil {
+		t.Fatalf("expected record, got %v %v", record, err)
+	}
+	if record.Fingerprint != "fp" || record.StatusCode != 200 || string(record.Body) != `{"ok":true}` {
+		t.Fatalf("unexpected record %+v", record)
+	}
+
+	if acquired, _ = Acquire("k1", time.Minute); !acquired {
+		t.Fatal("expected acquire after release")
+	}
+}
+
+func TestMemoryStoreExpire(t *testing.T) {
+	Save("k2", &Record{StatusCode: 200}, -time.Second)
+	if record, _ := Get("k2"); record != nil {
+		t.Fatal("expected expired record to be ignored")
+	}
+}
@@ -0,0 +1,113 @@
+package image
+
+import (
+	"done-hub/common/config"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type cachedImage struct {
+	mimeType  string
+	data      string
+	expiresAt time.Time
+}
+
+// imageCache 远程图片的进程内缓存，避免同一张图片在多轮对话中被重复下载
+var imageCache = struct {
+	sync.Mutex
+	items map[string]*cachedImage
+	size  int
+}{items: make(map[string]*cachedImage)}
+
+var imageFetchGroup singleflight.Group
+
+// getCachedImageFromUrl 获取图片的 base64 数据，远程图片按设置缓存
+func getCachedImageFromUrl(url string) (string, string, error) {
+	if !strings.HasPrefix(url, "http") || config.ImageInputCacheSeconds <= 0 {
+		return GetImageFromUrl(url)
+	}
+
+	if item := getImageCache(url); item != nil {
+		return item.mimeType, item.data, nil
+	}
+
+	result, err, _ := imageFetchGroup.Do(url, func() (any, error) {
+		mimeType, data, err := GetImageFromUrl(url)
+		if err != nil {
+			return nil, err
+		}
+		item := &cachedImage{
+			mimeType:  mimeType,
+			data:      data,
+			expiresAt: time.Now().Add(time.Duration(config.ImageInputCacheSeconds) * time.Second),
+		}
+		setImageCache(url, item)
+		return item, nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	item := result.(*cachedImage)
+	return item.mimeType, item.data, nil
+}
+
+func getImageCache(url string) *cachedImage {
+	imageCache.Lock()
+	defer imageCache.Unlock()
+
+	item, ok := imageCache.items[url]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(item.expiresAt) {
+		removeImageCache(url, item)
+		return nil
+	}
+	return item
+}
+
+func setImageCache(url string, item *cachedImage) {
+	maxSize := config.ImageInputCacheMaxMB * 1024 * 1024
+	if maxSize <= 0 || len(item.data) > maxSize {
+		return
+	}
+
+	imageCache.Lock()
+	defer imageCache.Unlock()
+
+	if old, ok := imageCache.items[url]; ok {
+		removeImageCache(url, old)
+	}
+
+	// 超出容量时先清理过期的，仍不足则淘汰最早过期的
+	if imageCache.size+len(item.data) > maxSize {
+		now := time.Now()
+		for key, cached := range imageCache.items {
+			if now.After(cached.expiresAt) {
+				removeImageCache(key, cached)
+			}
+		}
+	}
+	for imageCache.size+len(item.data) > maxSize {
+		var oldestKey string
+		var oldest *cachedImage
+		for key, cached := range imageCache.items {
+			if oldest == nil || cached.expiresAt.Before(oldest.expiresAt) {
+				oldestKey, oldest = key, cached
+			}
+		}
+		removeImageCache(oldestKey, oldest)
+	}
+
+	imageCache.items[url] = item
+	imageCache.size += len(item.data)
+}
+
+func removeImageCache(url string, item *cachedImage) {
+	delete(imageCache.items, url)
+	imageCache.size -= len(item.data)
+}
@@ -9,11 +9,8 @@ import (
 	"time"
 )
 
+// ImageHttpClients 下载图片使用的客户端，Transport 由 requester.InitHttpClient 替换为共享连接池
 var ImageHttpClients = &http.Client{
-	Transport: &http.Transport{
-		DialContext: utils.Socks5ProxyFunc,
-		Proxy:       utils.ProxyFunc,
-	},
 	Timeout: 15 * time.Second,
 }
 
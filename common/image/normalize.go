@@ -0,0 +1,187 @@
+package image
+
+import (
+	"bytes"
+	"done-hub/common/config"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Limit 上游对图片输入的限制，字段为 0 表示不限制
+type Limit struct {
+	MaxBytes     int // 解码后的最大字节数
+	MaxDimension int // 最长边的最大像素
+}
+
+// ClaudeLimit Claude 单张图片不能超过 5MB，最长边不能超过 8000 像素
+var ClaudeLimit = Limit{MaxBytes: 5 * 1024 * 1024, MaxDimension: 8000}
+
+// 上游普遍支持的图片格式，其他格式会转换为 PNG/JPEG
+var supportedMimeTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+const minNormalizeQuality = 40
+
+// 需要解码的图片最多包含的像素数，避免尺寸极大的图片解码时耗尽内存
+const maxNormalizePixels = 8192 * 8192
+
+// GetImageFromUrlWithLimit 获取图片并按上游限制进行缩放和格式转换，返回 base64 数据
+func GetImageFromUrlWithLimit(url string, limit Limit) (mimeType string, data string, err error) {
+	mimeType, data, err = getCachedImageFromUrl(url)
+	if err != nil {
+		return
+	}
+
+	return NormalizeImage(mimeType, data, mergeLimit(limit))
+}
+
+// mergeLimit 合并上游限制与系统设置，取更严格的值
+func mergeLimit(limit Limit) Limit {
+	if maxBytes := config.ImageInputMaxSizeMB * 1024 * 1024; maxBytes > 0 && (limit.MaxBytes == 0 || maxBytes < limit.MaxBytes) {
+		limit.MaxBytes = maxBytes
+	}
+	if maxDimension := config.ImageInputMaxDimension; maxDimension > 0 && (limit.MaxDimension == 0 || maxDimension < limit.MaxDimension) {
+		limit.MaxDimension = maxDimension
+	}
+	return limit
+}
+
+// NormalizeImage 图片超出限制或格式不受支持时重新编码，否则原样返回
+func NormalizeImage(mimeType, data string, limit Limit) (string, string, error) {
+	mimeType = strings.ToLower(strings.TrimSpace(strings.Split(mimeType, ";")[0]))
+	if !strings.HasPrefix(mimeType, "image/") {
+		return mimeType, data, nil
+	}
+
+	size := base64.StdEncoding.DecodedLen(len(data))
+	overSize := limit.MaxBytes > 0 && size > limit.MaxBytes
+	if !overSize && limit.MaxDimension == 0 && supportedMimeTypes[mimeType] {
+		return mimeType, data, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", "", err
+	}
+
+	imgConfig, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		if supportedMimeTypes[mimeType] && !overSize {
+			// 无法识别的图片交由上游处理
+			return mimeType, data, nil
+		}
+		return "", "", errors.New("unsupported image format: " + mimeType)
+	}
+
+	overDimension := limit.MaxDimension > 0 && max(imgConfig.Width, imgConfig.Height) > limit.MaxDimension
+	if !overSize && !overDimension && supportedMimeTypes[mimeType] {
+		return mimeType, data, nil
+	}
+
+	if int64(imgConfig.Width)*int64(imgConfig.Height) > maxNormalizePixels {
+		return "", "", errors.New("image has too many pixels to process")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return "", "", err
+	}
+
+	if overDimension {
+		img = resizeImage(img, limit.MaxDimension)
+	}
+
+	mimeType, raw, err = encodeImage(img, mimeType == "image/png" && hasAlpha(img), limit.MaxBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	return mimeType, base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// resizeImage 等比缩放到最长边不超过 maxDimension
+func resizeImage(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width >= height {
+		height = max(height*maxDimension/width, 1)
+		width = maxDimension
+	} else {
+		width = max(width*maxDimension/height, 1)
+		height = maxDimension
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// encodeImage 透明 PNG 保持 PNG，其余编码为 JPEG；超出大小时逐步降低质量和尺寸
+func encodeImage(img image.Image, keepPNG bool, maxBytes int) (string, []byte, error) {
+	quality := config.ImageInputJPEGQuality
+	if quality <= 0 || quality > 100 {
+		quality = 85
+	}
+
+	for {
+		buffer := bytes.NewBuffer(nil)
+		mimeType := "image/jpeg"
+		var err error
+		if keepPNG {
+			mimeType = "image/png"
+			err = png.Encode(buffer, img)
+		} else {
+			err = jpeg.Encode(buffer, flattenImage(img), &jpeg.Options{Quality: quality})
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		if maxBytes <= 0 || buffer.Len() <= maxBytes {
+			return mimeType, buffer.Bytes(), nil
+		}
+
+		// 先降低 JPEG 质量，质量到底后缩小尺寸
+		if !keepPNG && quality > minNormalizeQuality {
+			quality = max(quality-15, minNormalizeQuality)
+			continue
+		}
+
+		bounds := img.Bounds()
+		longest := max(bounds.Dx(), bounds.Dy())
+		if longest <= 64 {
+			return "", nil, errors.New("image is too large to fit the upstream size limit")
+		}
+		img = resizeImage(img, longest*3/4)
+	}
+}
+
+func hasAlpha(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return !opaque.Opaque()
+	}
+	return true
+}
+
+// flattenImage JPEG 不支持透明通道，透明部分填充白色
+func flattenImage(img image.Image) image.Image {
+	if !hasAlpha(img) {
+		return img
+	}
+	bounds := img.Bounds()
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, bounds, img, bounds.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,85 @@
+package image_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	img "done-hub/common/image"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeTestPNG(t *testing.T, width, height int, alpha uint8) string {
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			rgba.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x + y), A: alpha})
+		}
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.NoError(t, png.Encode(buffer, rgba))
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+func TestNormalizeImage(t *testing.T) {
+	data := encodeTestPNG(t, 400, 200, 255)
+
+	// 未超出限制时原样返回
+	mimeType, result, err := img.NormalizeImage("image/png", data, img.Limit{MaxDimension: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, data, result)
+
+	// 超出尺寸时等比缩放，不透明图片转为 JPEG
+	mimeType, result, err = img.NormalizeImage("image/png", data, img.Limit{MaxDimension: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", mimeType)
+	width, height, err := img.GetImageSizeFromBase64(result)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, width)
+	assert.Equal(t, 50, height)
+
+	// 透明图片保持 PNG
+	mimeType, _, err = img.NormalizeImage("image/png", encodeTestPNG(t, 400, 200, 128), img.Limit{MaxDimension: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+
+	// 超出大小时压缩到限制以内
+	_, result, err = img.NormalizeImage("image/png", data, img.Limit{MaxBytes: 4 * 1024})
+	assert.NoError(t, err)
+	raw, _ := base64.StdEncoding.DecodeString(result)
+	assert.LessOrEqual(t, len(raw), 4*1024)
+
+	// 非图片内容不处理
+	mimeType, result, err = img.NormalizeImage("application/pdf", "JVBERi0=", img.Limit{MaxBytes: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", mimeType)
+	assert.Equal(t, "JVBERi0=", result)
+}
+
+// encodeHugePNGHeader 只包含 IHDR 的 PNG，声明的尺寸远超实际数据
+func encodeHugePNGHeader(width, height uint32) string {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12], ihdr[13] = 8, 6
+
+	buffer := bytes.NewBuffer([]byte("\x89PNG\r\n\x1a\n"))
+	binary.Write(buffer, binary.BigEndian, uint32(13))
+	buffer.Write(ihdr)
+	binary.Write(buffer, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+func TestNormalizeImageTooManyPixels(t *testing.T) {
+	// 像素数超出上限时在解码前拒绝
+	_, _, err := img.NormalizeImage("image/png", encodeHugePNGHeader(100000, 100000), img.Limit{MaxDimension: 8000})
+	assert.EqualError(t, err, "image has too many pixels to process")
+}
@@ -0,0 +1,159 @@
+// Package keybundle 使用 age / PGP 公钥加密导出数据，导入时用服务端生成的 age 私钥解密，
+// 管理员的私钥不需要上传到服务端
+package keybundle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const (
+	FormatAge = "age"
+	FormatPGP = "pgp"
+
+	pgpMessageType = "PGP MESSAGE"
+)
+
+var (
+	ErrEmptyRecipient   = errors.New("公钥不能为空")
+	ErrUnknownRecipient = errors.New("无法识别的公钥，仅支持 age 公钥（age1...）或 ASCII 格式的 PGP 公钥")
+	ErrUnknownIdentity  = errors.New("无法识别的私钥，仅支持 age 私钥（AGE-SECRET-KEY-1...）")
+	ErrUnknownBundle    = errors.New("无法识别的加密数据，仅支持使用导入公钥加密的 age 数据")
+)
+
+// DetectFormat 根据公钥/私钥/密文内容判断加密格式
+func DetectFormat(text string) string {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "age1"), strings.HasPrefix(text, "AGE-SECRET-KEY-1"), strings.HasPrefix(text, armor.Header):
+		return FormatAge
+	case strings.HasPrefix(text, "-----BEGIN PGP"):
+		return FormatPGP
+	default:
+		return ""
+	}
+}
+
+// Encrypt 使用公钥加密数据，返回 ASCII 格式的密文；多个 age 公钥可用换行或逗号分隔
+func Encrypt(recipient string, plaintext []byte) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return "", ErrEmptyRecipient
+	}
+
+	switch DetectFormat(recipient) {
+	case FormatAge:
+		return encryptAge(recipient, plaintext)
+	case FormatPGP:
+		return encryptPGP(recipient, plaintext)
+	default:
+		return "", ErrUnknownRecipient
+	}
+}
+
+// GenerateIdentity 生成一对 age 密钥，用于接收导入数据
+func GenerateIdentity() (identity, recipient string, err error) {
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", err
+	}
+	return key.String(), key.Recipient().String(), nil
+}
+
+// Decrypt 使用 age 私钥解密 Encrypt 生成的密文，PGP 密文需要管理员在本地解密后重新加密
+func Decrypt(bundle, identity string) ([]byte, error) {
+	bundle = strings.TrimSpace(bundle)
+	identity = strings.TrimSpace(identity)
+
+	if DetectFormat(bundle) != FormatAge {
+		return nil, ErrUnknownBundle
+	}
+	if DetectFormat(identity) != FormatAge {
+		return nil, ErrUnknownIdentity
+	}
+
+	return decryptAge(bundle, identity)
+}
+
+func encryptAge(recipient string, plaintext []byte) (string, error) {
+	var recipients []age.Recipient
+	for _, item := range strings.FieldsFunc(recipient, func(r rune) bool { return r == ',' || r == '\n' }) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parsed, err := age.ParseX25519Recipient(item)
+		if err != nil {
+			return "", fmt.Errorf("解析 age 公钥失败: %w", err)
+		}
+		recipients = append(recipients, parsed)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	writer, err := age.Encrypt(armorWriter, recipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(plaintext); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	if err = armorWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func decryptAge(bundle, identity string) ([]byte, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(identity))
+	if err != nil {
+		return nil, fmt.Errorf("解析 age 私钥失败: %w", err)
+	}
+
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(bundle)), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("解密失败: %w", err)
+	}
+
+	return io.ReadAll(reader)
+}
+
+func encryptPGP(recipient string, plaintext []byte) (string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(recipient))
+	if err != nil {
+		return "", fmt.Errorf("解析 PGP 公钥失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	armorWriter, err := pgparmor.Encode(&buf, pgpMessageType, nil)
+	if err != nil {
+		return "", err
+	}
+	writer, err := openpgp.Encrypt(armorWriter, entities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(plaintext); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	if err = armorWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,83 @@
+package keybundle
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func TestAgeRoundTrip(t *testing.T) {
+	identity, recipient, err := GenerateIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := Encrypt(recipient, []byte("sk-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains([]byte(bundle), []byte("sk-test")) {
+		t.Fatal("bundle contains plaintext")
+	}
+
+	plaintext, err := Decrypt(bundle, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "sk-test" {
+		t.Fatalf("unexpected plaintext: %s", plaintext)
+	}
+
+	other, _, _ := GenerateIdentity()
+	if _, err = Decrypt(bundle, other); err == nil {
+		t.Fatal("expected decrypt error with wrong identity")
+	}
+}
+
+func TestPGPEncrypt(t *testing.T) {
+	entity, err := openpgp.NewEntity("done-hub", "", "admin@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var public bytes.Buffer
+	writer, _ := pgparmor.Encode(&public, openpgp.PublicKeyType, nil)
+	if err = entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	bundle, err := Encrypt(public.String(), []byte("sk-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// PGP 密文只能由管理员在本地解密，服务端不接受
+	identity, _, _ := GenerateIdentity()
+	if _, err = Decrypt(bundle, identity); err != ErrUnknownBundle {
+		t.Fatalf("expected ErrUnknownBundle, got %v", err)
+	}
+
+	block, err := pgparmor.Decode(strings.NewReader(bundle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, _ := io.ReadAll(message.UnverifiedBody)
+	if string(plaintext) != "sk-test" {
+		t.Fatalf("unexpected plaintext: %s", plaintext)
+	}
+}
+
+func TestEncryptRejectsUnknownRecipient(t *testing.T) {
+	if _, err := Encrypt("not-a-key", []byte("sk-test")); err != ErrUnknownRecipient {
+		t.Fatalf("expected ErrUnknownRecipient, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package logexport
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
+// parquetRowGroupSize 每个行组在内存中缓冲的最大字节数，写满后立即落盘
+const parquetRowGroupSize = 32 << 20
+
+// Record 导出的单条日志，字段与 logs 表一致，metadata 以 JSON 字符串保存
+type Record struct {
+	Id               int64  `json:"id" parquet:"name=id, type=INT64"`
+	CreatedAt        int64  `json:"created_at" parquet:"name=created_at, type=INT64"`
+	Type             int64  `json:"type" parquet:"name=type, type=INT64"`
+	UserId           int64  `json:"user_id" parquet:"name=user_id, type=INT64"`
+	Username         string `json:"username" parquet:"name=username, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TokenName        string `json:"token_name" parquet:"name=token_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ModelName        string `json:"model_name" parquet:"name=model_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ChannelId        int64  `json:"channel_id" parquet:"name=channel_id, type=INT64"`
+	Quota            int64  `json:"quota" parquet:"name=quota, type=INT64"`
+	PromptTokens     int64  `json:"prompt_tokens" parquet:"name=prompt_tokens, type=INT64"`
+	CompletionTokens int64  `json:"completion_tokens" parquet:"name=completion_tokens, type=INT64"`
+	RequestTime      int64  `json:"request_time" parquet:"name=request_time, type=INT64"`
+	FirstTokenTime   int64  `json:"first_token_time" parquet:"name=first_token_time, type=INT64"`
+	UpstreamTime     int64  `json:"upstream_time" parquet:"name=upstream_time, type=INT64"`
+	RetryCount       int64  `json:"retry_count" parquet:"name=retry_count, type=INT64"`
+	IsStream         bool   `json:"is_stream" parquet:"name=is_stream, type=BOOLEAN"`
+	SourceIp         string `json:"source_ip" parquet:"name=source_ip, type=BYTE_ARRAY, convertedtype=UTF8"`
+	RequestId        string `json:"request_id" parquet:"name=request_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Content          string `json:"content" parquet:"name=content, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Metadata         string `json:"metadata" parquet:"name=metadata, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+func IsValidFormat(format string) bool {
+	return format == FormatJSONL || format == FormatParquet
+}
+
+// Encoder 逐条写入日志，Close 后输出才完整
+type Encoder interface {
+	Write(record *Record) error
+	Close() error
+}
+
+// NewEncoder 按格式创建写入 w 的编码器，返回扩展名与 Content-Type。JSONL 使用 gzip 压缩，
+// Parquet 使用 GZIP 压缩的列块，行组写满后即落盘，不会把整批日志保留在内存中
+func NewEncoder(format string, w io.Writer) (Encoder, string, string, error) {
+	switch format {
+	case FormatParquet:
+		pw, err := writer.NewParquetWriterFromWriter(w, new(Record), 1)
+		if err != nil {
+			return nil, "", "", err
+		}
+		pw.RowGroupSize = parquetRowGroupSize
+		pw.CompressionType = parquet.CompressionCodec_GZIP
+		return &parquetEncoder{writer: pw}, ".parquet", "application/vnd.apache.parquet", nil
+
+	case FormatJSONL:
+		zw := gzip.NewWriter(w)
+		bw := bufio.NewWriter(zw)
+		encoder := json.NewEncoder(bw)
+		encoder.SetEscapeHTML(false)
+		return &jsonlEncoder{gzip: zw, buf: bw, encoder: encoder}, ".jsonl.gz", "application/gzip", nil
+	}
+
+	return nil, "", "", fmt.Errorf("unsupported log export format: %s", format)
+}
+
+type parquetEncoder struct {
+	writer *writer.ParquetWriter
+}
+
+func (e *parquetEncoder) Write(record *Record) error {
+	return e.writer.Write(record)
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.writer.WriteStop()
+}
+
+type jsonlEncoder struct {
+	gzip    *gzip.Writer
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Write(record *Record) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *jsonlEncoder) Close() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	return e.gzip.Close()
+}
+
+// ObjectKey 生成按日期分区（Hive 风格 dt=YYYY-MM-DD，UTC）的对象路径，
+// 文件名包含批次的起止时间，同一批次重复导出时会覆盖而不会产生重复数据
+func ObjectKey(prefix string, start, end int64, ext string) string {
+	startTime := time.Unix(start, 0).UTC()
+	endTime := time.Unix(end, 0).UTC()
+
+	key := fmt.Sprintf("dt=%s/logs_%s_%s%s",
+		startTime.Format("2006-01-02"),
+		startTime.Format("20060102T1504Z"),
+		endTime.Format("20060102T1504Z"),
+		ext,
+	)
+	if prefix != "" {
+		key = prefix + "/" + key
+	}
+	return key
+}
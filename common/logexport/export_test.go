@@ -0,0 +1,97 @@
+package logexport
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func encodeRecords(t *testing.T, format string, records []*Record) ([]byte, string) {
+	var buf bytes.Buffer
+	encoder, ext, _, err := NewEncoder(format, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if err := encoder.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), ext
+}
+
+func TestEncodeParquet(t *testing.T) {
+	records := []*Record{
+		{Id: 1, ModelName: "gpt-4o", IsStream: true, Quota: 100, Metadata: `{"a":1}`},
+		{Id: 2, ModelName: "claude", Quota: 200, Content: "内容"},
+	}
+
+	data, ext := encodeRecords(t, FormatParquet, records)
+	if ext != ".parquet" {
+		t.Fatalf("unexpected extension: %s", ext)
+	}
+
+	file, err := buffer.NewBufferFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetReader(file, new(Record), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+
+	if pr.GetNumRows() != int64(len(records)) {
+		t.Fatalf("unexpected row count: %d", pr.GetNumRows())
+	}
+	decoded := make([]Record, len(records))
+	if err := pr.Read(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	for i, record := range records {
+		if decoded[i] != *record {
+			t.Fatalf("record %d mismatch: %+v", i, decoded[i])
+		}
+	}
+}
+
+func TestEncodeJSONL(t *testing.T) {
+	records := []*Record{{Id: 1, Content: "<a>"}, {Id: 2}}
+
+	data, ext := encodeRecords(t, FormatJSONL, records)
+	if ext != ".jsonl.gz" {
+		t.Fatalf("unexpected extension: %s", ext)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(zr)
+	var lines []Record
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, record)
+	}
+	if len(lines) != 2 || lines[0].Content != "<a>" || lines[1].Id != 2 {
+		t.Fatalf("unexpected records: %+v", lines)
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	key := ObjectKey("logs", 1760659200, 1760662800, ".parquet")
+	if key != "logs/dt=2025-10-17/logs_20251017T0000Z_20251017T0100Z.parquet" {
+		t.Fatalf("unexpected key: %s", key)
+	}
+}
@@ -0,0 +1,84 @@
+package logexport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	StorageS3  = "s3"
+	StorageGCS = "gcs"
+
+	// GCS 的 S3 兼容接口（XML API），需要使用 HMAC 密钥
+	gcsEndpoint = "https://storage.googleapis.com"
+)
+
+// StorageConfig 对象存储配置，Endpoint 为空时 S3 使用 AWS 官方地址，GCS 使用 storage.googleapis.com
+type StorageConfig struct {
+	Storage         string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyId     string
+	AccessKeySecret string
+}
+
+func IsValidStorage(storage string) bool {
+	return storage == StorageS3 || storage == StorageGCS
+}
+
+// Upload 上传文件到对象存储，同名对象会被覆盖
+func Upload(cfg *StorageConfig, key string, body io.ReadSeeker, contentType string) error {
+	if cfg.Bucket == "" || cfg.AccessKeyId == "" || cfg.AccessKeySecret == "" {
+		return errors.New("log export storage is not configured")
+	}
+
+	awsConfig := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKeyId, cfg.AccessKeySecret, ""),
+		Region:      aws.String(cfg.Region),
+	}
+
+	switch cfg.Storage {
+	case StorageS3:
+		if cfg.Region == "" {
+			awsConfig.Region = aws.String("us-east-1")
+		}
+		if cfg.Endpoint != "" {
+			// 自定义地址一般是 MinIO、R2 等兼容服务，使用路径风格访问
+			awsConfig.Endpoint = aws.String(cfg.Endpoint)
+			awsConfig.S3ForcePathStyle = aws.Bool(true)
+		}
+	case StorageGCS:
+		endpoint := cfg.Endpoint
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.Region = aws.String("auto")
+	default:
+		return fmt.Errorf("unsupported log export storage: %s", cfg.Storage)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	_, err = s3.New(sess).PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(cfg.Bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %v", key, err)
+	}
+
+	return nil
+}
@@ -28,6 +28,8 @@ const (
 )
 const (
 	RequestIdKey = "X-Oneapi-Request-Id"
+	// RequestIdHeader 对外通用的请求 ID 头，可由调用方传入，也会透传给上游
+	RequestIdHeader = "X-Request-ID"
 )
 
 // LogEntry represents a single log entry in memory
@@ -247,6 +249,15 @@ func LogDebug(ctx context.Context, msg string) {
 	logHelper(ctx, loggerDEBUG, msg)
 }
 
+// GetRequestId 从 context 中取出请求 ID
+func GetRequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(RequestIdKey).(string)
+	return id
+}
+
 func logHelper(ctx context.Context, level string, msg string) {
 	id := "unknown"
 	userId := 0
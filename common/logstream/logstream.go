@@ -0,0 +1,251 @@
+package logstream
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/redis"
+	"done-hub/common/utils"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	EventTypeConsume = "consume" // 请求完成并计费
+	EventTypeError   = "error"   // 中继请求失败，每次失败的渠道尝试都会推送
+	EventTypeDropped = "dropped" // 订阅方处理过慢，期间丢弃的事件数
+)
+
+const (
+	// 多节点部署时通过 Redis 转发事件，只有存在订阅方时才会发布
+	redisChannel    = "done-hub:log_stream"
+	watchingKey     = "done-hub:log_stream:watching"
+	watchingTTL     = 30 * time.Second
+	watchingRefresh = 10 * time.Second
+
+	subscriberBuffer = 256
+	MaxSubscribers   = 50
+)
+
+var ErrTooManySubscribers = errors.New("实时日志连接数已达上限")
+
+// Event 实时日志事件
+type Event struct {
+	Type             string `json:"type"`
+	CreatedAt        int64  `json:"created_at"`
+	Node             string `json:"node,omitempty"`
+	RequestId        string `json:"request_id,omitempty"`
+	UserId           int    `json:"user_id,omitempty"`
+	Username         string `json:"username,omitempty"`
+	TenantId         int    `json:"tenant_id,omitempty"`
+	TokenName        string `json:"token_name,omitempty"`
+	ModelName        string `json:"model_name,omitempty"`
+	ChannelId        int    `json:"channel_id,omitempty"`
+	PromptTokens     int    `json:"prompt_tokens,omitempty"`
+	CompletionTokens int    `json:"completion_tokens,omitempty"`
+	Quota            int    `json:"quota,omitempty"`
+	RequestTime      int    `json:"request_time,omitempty"`
+	IsStream         bool   `json:"is_stream,omitempty"`
+	SourceIp         string `json:"source_ip,omitempty"`
+	Attempt          int    `json:"attempt,omitempty"`
+	StatusCode       int    `json:"status_code,omitempty"`
+	Error            string `json:"error,omitempty"`
+	Dropped          int64  `json:"dropped,omitempty"`
+}
+
+// Filter 服务端过滤条件，零值表示不过滤
+type Filter struct {
+	UserId     int    `form:"user_id"`
+	Username   string `form:"username"`
+	ChannelId  int    `form:"channel_id"`
+	ModelName  string `form:"model_name"`
+	ErrorsOnly bool   `form:"errors_only"`
+	// 可查看的租户，-1 为全部租户
+	TenantScope int `form:"-"`
+}
+
+func (f *Filter) Match(event *Event) bool {
+	switch {
+	case f.ErrorsOnly && event.Type != EventTypeError,
+		f.UserId != 0 && event.UserId != f.UserId,
+		f.Username != "" && event.Username != f.Username,
+		f.ChannelId != 0 && event.ChannelId != f.ChannelId,
+		f.ModelName != "" && event.ModelName != f.ModelName,
+		config.MultiTenantEnabled && f.TenantScope >= 0 && event.TenantId != f.TenantScope:
+		return false
+	}
+	return true
+}
+
+// Subscriber 一个实时日志订阅，处理过慢时丢弃事件而不阻塞中继请求
+type Subscriber struct {
+	events  chan *Event
+	filter  Filter
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+type redisMessage struct {
+	Node  string `json:"node"`
+	Event *Event `json:"event"`
+}
+
+var (
+	subscribersLock sync.RWMutex
+	subscribers     = make(map[*Subscriber]struct{})
+
+	// 是否有节点存在订阅方，定时从 Redis 同步
+	remoteWatching atomic.Bool
+
+	nodeId   = utils.GetUUID()
+	nodeName = getNodeName()
+)
+
+func getNodeName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return hostname
+}
+
+// Subscribe 订阅实时日志，使用完后需要调用 Close
+func Subscribe(filter Filter) (*Subscriber, error) {
+	subscribersLock.Lock()
+	defer subscribersLock.Unlock()
+
+	if len(subscribers) >= MaxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	s := &Subscriber{
+		events: make(chan *Event, subscriberBuffer),
+		filter: filter,
+	}
+	subscribers[s] = struct{}{}
+
+	if config.RedisEnabled {
+		go markWatching()
+	}
+	return s, nil
+}
+
+func (s *Subscriber) Events() <-chan *Event {
+	return s.events
+}
+
+// TakeDropped 返回上次调用以来丢弃的事件数
+func (s *Subscriber) TakeDropped() int64 {
+	return s.dropped.Swap(0)
+}
+
+func (s *Subscriber) Close() {
+	s.once.Do(func() {
+		subscribersLock.Lock()
+		delete(subscribers, s)
+		subscribersLock.Unlock()
+	})
+}
+
+// Active 判断是否有人在查看实时日志，没有时发布方可以跳过构造事件
+func Active() bool {
+	subscribersLock.RLock()
+	count := len(subscribers)
+	subscribersLock.RUnlock()
+	return count > 0 || remoteWatching.Load()
+}
+
+// Publish 推送事件给当前节点的订阅方，其他节点有订阅方时通过 Redis 转发
+func Publish(event *Event) {
+	if event.CreatedAt == 0 {
+		event.CreatedAt = utils.GetTimestamp()
+	}
+	if event.Node == "" {
+		event.Node = nodeName
+	}
+
+	deliver(event)
+
+	if config.RedisEnabled && remoteWatching.Load() {
+		payload, err := json.Marshal(&redisMessage{Node: nodeId, Event: event})
+		if err != nil {
+			return
+		}
+		if err := redis.RedisPublish(redisChannel, string(payload)); err != nil {
+			logger.SysError("failed to publish log stream event: " + err.Error())
+		}
+	}
+}
+
+func deliver(event *Event) {
+	subscribersLock.RLock()
+	defer subscribersLock.RUnlock()
+
+	for s := range subscribers {
+		if !s.filter.Match(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Init 订阅其他节点转发的事件，需要在 Redis 初始化之后调用
+func Init() {
+	if !config.RedisEnabled {
+		return
+	}
+
+	redis.RedisSubscribe(context.Background(), redisChannel, handleRedisMessage)
+	go func() {
+		ticker := time.NewTicker(watchingRefresh)
+		defer ticker.Stop()
+		for range ticker.C {
+			syncWatching()
+		}
+	}()
+}
+
+func handleRedisMessage(payload string) {
+	var message redisMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil || message.Event == nil {
+		return
+	}
+	if message.Node == nodeId {
+		return
+	}
+	deliver(message.Event)
+}
+
+// syncWatching 当前节点有订阅方时续期 Redis 中的标记，并同步是否有节点在查看实时日志
+func syncWatching() {
+	subscribersLock.RLock()
+	count := len(subscribers)
+	subscribersLock.RUnlock()
+
+	if count > 0 {
+		markWatching()
+		return
+	}
+
+	exists, err := redis.RedisExists(watchingKey)
+	if err != nil {
+		return
+	}
+	remoteWatching.Store(exists)
+}
+
+func markWatching() {
+	if err := redis.RedisSet(watchingKey, nodeId, watchingTTL); err != nil {
+		logger.SysError("failed to mark log stream watching: " + err.Error())
+		return
+	}
+	remoteWatching.Store(true)
+}
@@ -0,0 +1,87 @@
+package logstream
+
+import (
+	"done-hub/common/config"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	event := &Event{Type: EventTypeConsume, UserId: 1, Username: "alice", ChannelId: 2, ModelName: "gpt-4o", TenantId: 3}
+
+	matched := []Filter{
+		{TenantScope: -1},
+		{UserId: 1, ChannelId: 2, ModelName: "gpt-4o", TenantScope: -1},
+		{Username: "alice", TenantScope: 3},
+	}
+	for _, filter := range matched {
+		if !filter.Match(event) {
+			t.Errorf("filter %+v should match", filter)
+		}
+	}
+
+	unmatched := []Filter{
+		{ErrorsOnly: true, TenantScope: -1},
+		{UserId: 2, TenantScope: -1},
+		{ModelName: "gpt-4o-mini", TenantScope: -1},
+	}
+	for _, filter := range unmatched {
+		if filter.Match(event) {
+			t.Errorf("filter %+v should not match", filter)
+		}
+	}
+
+	// 只在开启多租户时按租户过滤
+	config.MultiTenantEnabled = true
+	defer func() { config.MultiTenantEnabled = false }()
+	if (&Filter{TenantScope: 4}).Match(event) {
+		t.Error("events of other tenants should be filtered")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	subscriber, err := Subscribe(Filter{ChannelId: 1, TenantScope: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Active() {
+		t.Fatal("stream should be active with a subscriber")
+	}
+
+	Publish(&Event{Type: EventTypeError, ChannelId: 2})
+	Publish(&Event{Type: EventTypeError, ChannelId: 1, StatusCode: 500})
+	event := <-subscriber.Events()
+	if event.ChannelId != 1 || event.CreatedAt == 0 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	// 订阅方处理过慢时丢弃事件
+	for i := 0; i < subscriberBuffer+3; i++ {
+		Publish(&Event{Type: EventTypeConsume, ChannelId: 1})
+	}
+	if dropped := subscriber.TakeDropped(); dropped != 3 {
+		t.Fatalf("expected 3 dropped events, got %d", dropped)
+	}
+
+	subscriber.Close()
+	subscriber.Close()
+	if Active() {
+		t.Fatal("stream should be inactive after closing subscriber")
+	}
+
+	subscribers := make([]*Subscriber, 0, MaxSubscribers)
+	defer func() {
+		for _, s := range subscribers {
+			s.Close()
+		}
+	}()
+	for i := 0; i < MaxSubscribers; i++ {
+		s, err := Subscribe(Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		subscribers = append(subscribers, s)
+	}
+	if _, err := Subscribe(Filter{}); err != ErrTooManySubscribers {
+		t.Fatalf("expected too many subscribers error, got %v", err)
+	}
+}
@@ -31,8 +31,8 @@ func (e *Email) Send(_ context.Context, title, message string) error {
 		to = config.RootUserEmail
 	}
 
-	if config.SMTPServer == "" || config.SMTPAccount == "" || config.SMTPToken == "" || to == "" {
-		return errors.New("smtp config is not set, skip send email notifier")
+	if to == "" {
+		return errors.New("email address is not set, skip send email notifier")
 	}
 
 	p := parser.NewWithExtensions(parser.CommonExtensions | parser.DefinitionLists | parser.OrderedListStart)
@@ -44,7 +44,5 @@ func (e *Email) Send(_ context.Context, title, message string) error {
 
 	body := markdown.Render(doc, renderer)
 
-	emailClient := stmp.NewStmp(config.SMTPServer, config.SMTPPort, config.SMTPAccount, config.SMTPToken, config.SMTPFrom)
-
-	return emailClient.Send(to, title, string(body))
+	return stmp.SendAsync(to, title, string(body))
 }
@@ -0,0 +1,168 @@
+package pathrewrite
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Rule 一条路径重写规则
+// 源路径中 :name 匹配单个路径段，末尾的 * 匹配剩余的全部路径
+// 目标路径中可以引用源路径的 :name 和 *
+// 例如 /openai/deployments/:deployment/* => /v1/*
+type Rule struct {
+	From string
+	To   string
+
+	segments []string
+	wildcard bool
+}
+
+var rules = struct {
+	sync.RWMutex
+	raw   string
+	rules []*Rule
+}{}
+
+// Parse 解析重写规则，每行一条，格式为 `源路径 => 目标路径`，# 开头的行为注释
+func Parse(raw string) ([]*Rule, error) {
+	result := make([]*Rule, 0)
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		from, to, found := strings.Cut(line, "=>")
+		if !found {
+			return nil, fmt.Errorf("第 %d 行规则格式错误，应为：源路径 => 目标路径", i+1)
+		}
+
+		rule, err := newRule(strings.TrimSpace(from), strings.TrimSpace(to))
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行规则错误：%s", i+1, err.Error())
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+func newRule(from, to string) (*Rule, error) {
+	if !strings.HasPrefix(from, "/") || !strings.HasPrefix(to, "/") {
+		return nil, fmt.Errorf("路径必须以 / 开头")
+	}
+
+	rule := &Rule{From: from, To: to}
+	rule.segments = strings.Split(strings.Trim(from, "/"), "/")
+	last := len(rule.segments) - 1
+	params := make(map[string]bool)
+	for i, segment := range rule.segments {
+		switch {
+		case segment == "*":
+			if i != last {
+				return nil, fmt.Errorf("* 只能出现在源路径末尾")
+			}
+			rule.wildcard = true
+		case strings.HasPrefix(segment, ":"):
+			if len(segment) == 1 {
+				return nil, fmt.Errorf("参数名不能为空")
+			}
+			params[segment] = true
+		case strings.Contains(segment, "*"):
+			return nil, fmt.Errorf("* 必须单独作为一个路径段")
+		}
+	}
+	if rule.wildcard {
+		rule.segments = rule.segments[:last]
+	}
+
+	for _, segment := range strings.Split(strings.Trim(to, "/"), "/") {
+		if segment == "*" && !rule.wildcard {
+			return nil, fmt.Errorf("源路径没有 *，目标路径不能引用 *")
+		}
+		if strings.HasPrefix(segment, ":") && !params[segment] {
+			return nil, fmt.Errorf("目标路径引用了未定义的参数 %s", segment)
+		}
+	}
+
+	return rule, nil
+}
+
+// Apply 路径匹配规则时返回重写后的路径
+func (r *Rule) Apply(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < len(r.segments) || (!r.wildcard && len(parts) != len(r.segments)) {
+		return "", false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, ":") {
+			params[segment] = parts[i]
+			continue
+		}
+		if segment != parts[i] {
+			return "", false
+		}
+	}
+
+	rest := ""
+	if r.wildcard {
+		rest = strings.Join(parts[len(r.segments):], "/")
+	}
+
+	target := strings.Split(strings.Trim(r.To, "/"), "/")
+	result := make([]string, 0, len(target))
+	for _, segment := range target {
+		switch {
+		case segment == "*":
+			if rest != "" {
+				result = append(result, rest)
+			}
+		case strings.HasPrefix(segment, ":"):
+			result = append(result, params[segment])
+		default:
+			result = append(result, segment)
+		}
+	}
+
+	newPath := "/" + strings.Join(result, "/")
+	if strings.HasSuffix(path, "/") && rest != "" && !strings.HasSuffix(newPath, "/") {
+		newPath += "/"
+	}
+	return newPath, true
+}
+
+// SetRules 更新全局的重写规则
+func SetRules(raw string) error {
+	parsed, err := Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	rules.Lock()
+	defer rules.Unlock()
+	rules.raw = raw
+	rules.rules = parsed
+	return nil
+}
+
+// GetRules 返回当前规则的原始配置
+func GetRules() string {
+	rules.RLock()
+	defer rules.RUnlock()
+	return rules.raw
+}
+
+// Rewrite 按顺序匹配规则，使用第一条匹配的规则重写路径
+func Rewrite(path string) (string, bool) {
+	rules.RLock()
+	defer rules.RUnlock()
+
+	for _, rule := range rules.rules {
+		if newPath, ok := rule.Apply(path); ok {
+			return newPath, true
+		}
+	}
+	return path, false
+}
@@ -0,0 +1,55 @@
+package pathrewrite
+
+import "testing"
+
+// TestRewrite 测试路径重写规则的解析与匹配
+func TestRewrite(t *testing.T) {
+	err := SetRules(`
+# OpenAI 兼容前缀
+/openai/v1/* => /v1/*
+/openai/deployments/:deployment/* => /v1/*
+/custom/:model/chat => /v1/chat/completions
+`)
+	if err != nil {
+		t.Fatalf("SetRules() error = %v", err)
+	}
+	defer SetRules("")
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOk bool
+	}{
+		{"/openai/v1/chat/completions", "/v1/chat/completions", true},
+		{"/openai/v1", "/v1", true},
+		{"/openai/deployments/gpt-4o/chat/completions", "/v1/chat/completions", true},
+		{"/custom/gpt-4o/chat", "/v1/chat/completions", true},
+		{"/custom/gpt-4o/chat/extra", "/custom/gpt-4o/chat/extra", false},
+		{"/v1/chat/completions", "/v1/chat/completions", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Rewrite(tt.path)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("Rewrite(%s) = %s, %v, want %s, %v", tt.path, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+// TestParseInvalid 测试无效规则的校验
+func TestParseInvalid(t *testing.T) {
+	invalid := []string{
+		"/openai/v1/*",
+		"openai/* => /v1/*",
+		"/a/*/b => /v1",
+		"/a/b* => /v1",
+		"/a => /v1/*",
+		"/a/:x => /v1/:y",
+	}
+
+	for _, raw := range invalid {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) expected error", raw)
+		}
+	}
+}
@@ -4,37 +4,37 @@ import (
 	"context"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 )
 
-var RDB *redis.Client
+var RDB redis.UniversalClient
 
 const Nil = redis.Nil
 
 // InitRedisClient This function is called after init()
 func InitRedisClient() (err error) {
-	redisConn := viper.GetString("redis_conn_string")
+	client, mode, err := newRedisClient()
+	if err != nil {
+		logger.FatalLog("failed to parse Redis config: " + err.Error())
+		return
+	}
 
-	if redisConn == "" {
+	if client == nil {
 		logger.SysLog("REDIS_CONN_STRING not set, Redis is not enabled")
 		return nil
 	}
 	if viper.GetInt("sync_frequency") == 0 {
 		logger.SysLog("SYNC_FREQUENCY not set, Redis is disabled")
+		client.Close()
 		return nil
 	}
-	logger.SysLog("Redis is enabled")
-	opt, err := redis.ParseURL(redisConn)
-	if err != nil {
-		logger.FatalLog("failed to parse Redis connection string: " + err.Error())
-		return
-	}
-
-	opt.DB = viper.GetInt("redis_db")
-	RDB = redis.NewClient(opt)
+	logger.SysLog("Redis is enabled, mode: " + mode)
+	RDB = client
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -51,6 +51,65 @@ func InitRedisClient() (err error) {
 	return err
 }
 
+// newRedisClient 根据配置创建 Redis 客户端，支持单机、Sentinel 和 Cluster 三种模式
+// Sentinel 和 Cluster 模式下 REDIS_CONN_STRING 可选，设置后沿用其中的用户名、密码和 TLS 配置，地址部分会被忽略
+func newRedisClient() (redis.UniversalClient, string, error) {
+	connString := viper.GetString("redis_conn_string")
+	base := &redis.Options{}
+	if connString != "" {
+		opt, err := redis.ParseURL(connString)
+		if err != nil {
+			return nil, "", err
+		}
+		base = opt
+	}
+
+	if addrs := splitRedisAddrs(viper.GetString("redis_cluster_addrs")); len(addrs) > 0 {
+		if viper.GetInt("redis_db") != 0 {
+			logger.SysLog("Redis Cluster does not support REDIS_DB, ignored")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Username:  base.Username,
+			Password:  base.Password,
+			TLSConfig: base.TLSConfig,
+		}), "cluster", nil
+	}
+
+	if masterName := viper.GetString("redis_sentinel_master_name"); masterName != "" {
+		addrs := splitRedisAddrs(viper.GetString("redis_sentinel_addrs"))
+		if len(addrs) == 0 {
+			return nil, "", errors.New("REDIS_SENTINEL_ADDRS is required when REDIS_SENTINEL_MASTER_NAME is set")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       masterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: viper.GetString("redis_sentinel_password"),
+			Username:         base.Username,
+			Password:         base.Password,
+			DB:               viper.GetInt("redis_db"),
+			TLSConfig:        base.TLSConfig,
+		}), "sentinel", nil
+	}
+
+	if connString == "" {
+		return nil, "", nil
+	}
+
+	base.DB = viper.GetInt("redis_db")
+	return redis.NewClient(base), "standalone", nil
+}
+
+func splitRedisAddrs(value string) []string {
+	addrs := make([]string, 0)
+	for _, addr := range strings.Split(value, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
 func ParseRedisOption() *redis.Options {
 	opt, err := redis.ParseURL(viper.GetString("redis_conn_string"))
 	if err != nil {
@@ -64,6 +123,12 @@ func RedisSet(key string, value string, expiration time.Duration) error {
 	return RDB.Set(ctx, key, value, expiration).Err()
 }
 
+// RedisSetNX 仅在 key 不存在时写入，返回是否写入成功
+func RedisSetNX(key string, value string, expiration time.Duration) (bool, error) {
+	ctx := context.Background()
+	return RDB.SetNX(ctx, key, value, expiration).Result()
+}
+
 func RedisGet(key string) (string, error) {
 	ctx := context.Background()
 	return RDB.Get(ctx, key).Result()
@@ -83,7 +148,7 @@ func NewScript(script string) *redis.Script {
 	return redis.NewScript(script)
 }
 
-func GetRedisClient() *redis.Client {
+func GetRedisClient() redis.UniversalClient {
 	return RDB
 }
 
@@ -253,3 +318,35 @@ func ExtendStickySessionMappingTTL(sessionHash string, channelType int, fullTTL
 
 	return nil
 }
+
+// RedisPublish 发布消息，Redis 未启用时直接返回
+func RedisPublish(channel string, message string) error {
+	if !config.RedisEnabled || RDB == nil {
+		return nil
+	}
+	ctx := context.Background()
+	return RDB.Publish(ctx, channel, message).Err()
+}
+
+// RedisSubscribe 订阅频道并在后台处理消息，连接断开后由 go-redis 自动重连并重新订阅
+func RedisSubscribe(ctx context.Context, channel string, handler func(payload string)) {
+	if !config.RedisEnabled || RDB == nil {
+		return
+	}
+
+	pubsub := RDB.Subscribe(ctx, channel)
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-pubsub.Channel():
+				if !ok {
+					return
+				}
+				handler(msg.Payload)
+			}
+		}
+	}()
+}
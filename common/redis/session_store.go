@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/base32"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionKeyPrefix = "session:"
+	// 会话 Cookie（MaxAge 为 0）在 Redis 中的保留时间
+	sessionDefaultTTL = 24 * time.Hour
+)
+
+// SessionStore 基于 Redis 的会话存储，Cookie 中只保存签名后的会话 ID，
+// 会话数据放在共享的 Redis 中，多节点部署时无需会话粘滞，重启后登录状态也不会丢失
+type SessionStore struct {
+	client  redis.UniversalClient
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+}
+
+// NewSessionStore 使用当前的 Redis 客户端创建会话存储，keyPairs 与 cookie.NewStore 含义一致
+func NewSessionStore(keyPairs ...[]byte) *SessionStore {
+	return newSessionStore(RDB, keyPairs...)
+}
+
+func newSessionStore(client redis.UniversalClient, keyPairs ...[]byte) *SessionStore {
+	return &SessionStore{
+		client: client,
+		codecs: securecookie.CodecsFromPairs(keyPairs...),
+		options: &gsessions.Options{
+			Path:   "/",
+			MaxAge: 86400 * 30,
+		},
+	}
+}
+
+func (s *SessionStore) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+}
+
+func (s *SessionStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+func (s *SessionStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	options := *s.options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	// 签名校验失败时按新会话处理，同时把错误返回给调用方
+	if err = securecookie.DecodeMulti(name, cookie.Value, &session.ID, s.codecs...); err != nil {
+		session.ID = ""
+		return session, err
+	}
+
+	found, err := s.load(r.Context(), session)
+	if err != nil || !found {
+		// 会话已过期或不存在时重新生成 ID，避免沿用客户端提供的 ID
+		session.ID = ""
+		return session, err
+	}
+	session.IsNew = false
+
+	return session, nil
+}
+
+func (s *SessionStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	// MaxAge < 0 表示注销，删除 Redis 中的数据并清除 Cookie
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.client.Del(r.Context(), sessionKeyPrefix+session.ID).Err(); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		session.ID = strings.TrimRight(base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+	}
+
+	if err := s.save(r.Context(), session); err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+
+	return nil
+}
+
+func (s *SessionStore) save(ctx context.Context, session *gsessions.Session) error {
+	if s.client == nil {
+		return errors.New("redis is not enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session.Values); err != nil {
+		return err
+	}
+
+	ttl := time.Duration(session.Options.MaxAge) * time.Second
+	if ttl == 0 {
+		ttl = sessionDefaultTTL
+	}
+
+	return s.client.Set(ctx, sessionKeyPrefix+session.ID, buf.Bytes(), ttl).Err()
+}
+
+func (s *SessionStore) load(ctx context.Context, session *gsessions.Session) (bool, error) {
+	if s.client == nil {
+		return false, errors.New("redis is not enabled")
+	}
+
+	data, err := s.client.Get(ctx, sessionKeyPrefix+session.ID).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&session.Values); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gorilla/securecookie"
+)
+
+func TestSessionStoreNew(t *testing.T) {
+	store := newSessionStore(nil, []byte("secret"))
+	store.Options(sessions.Options{Path: "/", MaxAge: 3600, HttpOnly: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	session, err := store.New(req, "session")
+	if err != nil || !session.IsNew || session.ID != "" {
+		t.Fatalf("expected new session, got %+v err=%v", session, err)
+	}
+	if session.Options.MaxAge != 3600 || !session.Options.HttpOnly {
+		t.Fatalf("options not applied: %+v", session.Options)
+	}
+
+	// 使用其他密钥签名的 Cookie 不应被接受
+	forged, _ := securecookie.EncodeMulti("session", "forged-id", securecookie.CodecsFromPairs([]byte("other"))...)
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: forged})
+	session, err = store.New(req, "session")
+	if err == nil || !session.IsNew || session.ID != "" {
+		t.Fatalf("forged cookie should be rejected, got %+v err=%v", session, err)
+	}
+}
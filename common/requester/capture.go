@@ -0,0 +1,175 @@
+package requester
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// captureBodyLimit 单个请求/响应体最多记录的字节数
+const captureBodyLimit = 1 << 20
+
+type captureContextKey struct{}
+
+// Capture 记录一次调用中所有上游请求与响应的原始内容，用于请求回放调试
+type Capture struct {
+	mu        sync.Mutex
+	exchanges []*CapturedExchange
+}
+
+// CapturedExchange 一次上游 HTTP 交互
+type CapturedExchange struct {
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"request_headers"`
+	RequestBody     string            `json:"request_body"`
+	StatusCode      int               `json:"status_code"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body"`
+	Duration        int64             `json:"duration"` // 毫秒，到收到响应头为止
+	Error           string            `json:"error,omitempty"`
+
+	responseBody bytes.Buffer
+}
+
+// WithCapture 返回携带 Capture 的 context，使用该 context 发出的上游请求都会被记录
+func WithCapture(ctx context.Context, capture *Capture) context.Context {
+	return context.WithValue(ctx, captureContextKey{}, capture)
+}
+
+// Exchanges 返回已记录的上游交互，调用前应确保响应体已经读取完毕
+func (c *Capture) Exchanges() []*CapturedExchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, exchange := range c.exchanges {
+		exchange.ResponseBody = exchange.responseBody.String()
+	}
+	return c.exchanges
+}
+
+func (c *Capture) add(exchange *CapturedExchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchanges = append(c.exchanges, exchange)
+}
+
+func (c *Capture) writeResponse(exchange *CapturedExchange, p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if remain := captureBodyLimit - exchange.responseBody.Len(); remain > 0 {
+		if len(p) > remain {
+			p = p[:remain]
+		}
+		exchange.responseBody.Write(p)
+	}
+}
+
+// captureTransport 只在 context 中携带 Capture 时记录请求，其余请求直接透传
+type captureTransport struct {
+	base http.RoundTripper
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	capture, ok := req.Context().Value(captureContextKey{}).(*Capture)
+	if !ok || capture == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	exchange := &CapturedExchange{
+		Method:         req.Method,
+		URL:            maskURL(req.URL),
+		RequestHeaders: flattenHeaders(req.Header),
+	}
+	capture.add(exchange)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			exchange.Error = err.Error()
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if len(body) > captureBodyLimit {
+			body = body[:captureBodyLimit]
+		}
+		exchange.RequestBody = string(body)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	exchange.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		exchange.Error = err.Error()
+		return nil, err
+	}
+
+	exchange.StatusCode = resp.StatusCode
+	exchange.ResponseHeaders = flattenHeaders(resp.Header)
+	resp.Body = &captureReadCloser{ReadCloser: resp.Body, capture: capture, exchange: exchange}
+
+	return resp, nil
+}
+
+type captureReadCloser struct {
+	io.ReadCloser
+	capture  *Capture
+	exchange *CapturedExchange
+}
+
+func (r *captureReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.capture.writeResponse(r.exchange, p[:n])
+	}
+	return n, err
+}
+
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"x-api-key":           true,
+	"api-key":             true,
+	"x-goog-api-key":      true,
+	"cookie":              true,
+	"set-cookie":          true,
+}
+
+// flattenHeaders 合并多值请求头并隐藏密钥中间部分，避免在调试报告中泄露完整凭证
+func flattenHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[strings.ToLower(key)] {
+			value = maskSecret(value)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// maskURL 隐藏通过查询参数传递的密钥，如 Gemini 的 key 参数
+func maskURL(u *url.URL) string {
+	query := u.Query()
+	if query.Get("key") == "" {
+		return u.String()
+	}
+
+	masked := *u
+	query.Set("key", maskSecret(query.Get("key")))
+	masked.RawQuery = query.Encode()
+	return masked.String()
+}
+
+func maskSecret(value string) string {
+	if len(value) <= 12 {
+		return "****"
+	}
+	return value[:6] + "****" + value[len(value)-4:]
+}
@@ -0,0 +1,61 @@
+package requester
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCaptureTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", "1")
+		w.Write([]byte("echo:" + string(body)))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &captureTransport{base: http.DefaultTransport}}
+	capture := &Capture{}
+	ctx := WithCapture(context.Background(), capture)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/test?key=AIzaSyExampleSecretKey", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Authorization", "Bearer sk-1234567890abcdef")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `echo:{"a":1}` {
+		t.Fatalf("request body not forwarded: %s", body)
+	}
+
+	exchanges := capture.Exchanges()
+	if len(exchanges) != 1 {
+		t.Fatalf("expected 1 exchange, got %d", len(exchanges))
+	}
+	exchange := exchanges[0]
+	if exchange.RequestBody != `{"a":1}` || exchange.ResponseBody != `echo:{"a":1}` || exchange.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected exchange: %+v", exchange)
+	}
+	if exchange.RequestHeaders["Authorization"] != "Bearer****cdef" || exchange.ResponseHeaders["X-Upstream"] != "1" {
+		t.Fatalf("unexpected headers: %+v %+v", exchange.RequestHeaders, exchange.ResponseHeaders)
+	}
+	if strings.Contains(exchange.URL, "ExampleSecret") {
+		t.Fatalf("url key not masked: %s", exchange.URL)
+	}
+
+	// 未携带 Capture 的请求不记录
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(capture.Exchanges()) != 1 {
+		t.Fatal("request without capture should not be recorded")
+	}
+}
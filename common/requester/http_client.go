@@ -1,6 +1,7 @@
 package requester
 
 import (
+	"done-hub/common/image"
 	"done-hub/common/logger"
 	"done-hub/common/utils"
 	"fmt"
@@ -12,37 +13,15 @@ var HTTPClient *http.Client
 var relayRequestTimeout time.Duration
 
 func InitHttpClient() {
-	// TLS 握手超时配置，默认 30 秒，可通过环境变量 TLS_HANDSHAKE_TIMEOUT 配置
-	tlsHandshakeSeconds := utils.GetOrDefault("tls_handshake_timeout", 30)
-	tlsHandshakeTimeout := time.Duration(tlsHandshakeSeconds) * time.Second
-	// 响应头超时配置，默认 120 秒，防止请求体发送完成后上游长时间不返回响应头
-	responseHeaderSeconds := utils.GetOrDefault("response_header_timeout", 120)
-	responseHeaderTimeout := time.Duration(responseHeaderSeconds) * time.Second
-
-	trans := &http.Transport{
-		DialContext: utils.Socks5ProxyFunc,
-		Proxy:       utils.ProxyFunc,
-
-		MaxIdleConns:        200,
-		MaxIdleConnsPerHost: 50,
-		MaxConnsPerHost:     100,
-		IdleConnTimeout:     60 * time.Second,
-
-		// 超时配置
-		TLSHandshakeTimeout:   tlsHandshakeTimeout,
-		ExpectContinueTimeout: 1 * time.Second,
-		ResponseHeaderTimeout: responseHeaderTimeout,
-
-		// 连接复用优化
-		DisableKeepAlives:  false,
-		DisableCompression: false,
-		ForceAttemptHTTP2:  true,
-	}
+	settings = loadTransportSettings()
+	resetTransports()
 
 	HTTPClient = &http.Client{
-		Transport: trans,
+		Transport: &captureTransport{base: &proxyTransport{}},
 		Timeout:   0,
 	}
+	// common/image 不能引用 requester（循环引用），在这里让图片下载同样使用按代理共享的连接池
+	image.ImageHttpClients.Transport = &proxyTransport{}
 
 	// 全局请求超时，默认 600 秒（10 分钟），覆盖整个请求生命周期（含流式 body 读取），设为 0 可禁用
 	relayTimeout := utils.GetOrDefault("relay_timeout", 600)
@@ -56,6 +35,6 @@ func InitHttpClient() {
 		relayRequestTimeout = time.Duration(requestTimeout) * time.Second
 	}
 
-	logger.SysLog(fmt.Sprintf("HTTP Client: relay_timeout=%ds, response_header_timeout=%ds, relay_request_timeout=%ds, tls_handshake_timeout=%ds",
-		relayTimeout, responseHeaderSeconds, requestTimeout, tlsHandshakeSeconds))
+	logger.SysLog(fmt.Sprintf("HTTP Client: relay_timeout=%ds, response_header_timeout=%v, relay_request_timeout=%ds, tls_handshake_timeout=%v, max_idle_conns_per_host=%d, http2=%t",
+		relayTimeout, settings.responseHeaderTimeout, requestTimeout, settings.tlsHandshakeTimeout, settings.maxIdleConnsPerHost, settings.http2))
 }
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"done-hub/common"
+	"done-hub/common/transform"
 	"done-hub/common/utils"
 	"done-hub/types"
 	"encoding/json"
@@ -13,6 +14,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,6 +29,12 @@ type HTTPRequester struct {
 	proxyAddr         string
 	Context           context.Context
 	IsOpenAI          bool
+	// FirstByteTimeout 流式请求等待上游首个字节的超时时间，0 表示不限制
+	FirstByteTimeout time.Duration
+	// ProxyRotation 设置了多个代理时的轮换方式
+	ProxyRotation string
+	// Transform 渠道配置的请求、响应转换规则
+	Transform *transform.Rules
 }
 
 // NewHTTPRequester 创建一个新的 HTTPRequester 实例。
@@ -52,7 +61,7 @@ type requestOptions struct {
 type requestOption func(*requestOptions)
 
 func (r *HTTPRequester) setProxy() context.Context {
-	return utils.SetProxy(r.proxyAddr, r.Context)
+	return utils.SetProxy(SelectProxy(r.proxyAddr, r.ProxyRotation), r.Context)
 }
 
 // 创建请求
@@ -69,6 +78,12 @@ func (r *HTTPRequester) NewRequest(method, url string, setters ...requestOption)
 		return nil, err
 	}
 
+	if r.Transform != nil {
+		if err = r.transformRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
 	return req, nil
 }
 
@@ -82,6 +97,7 @@ func (r *HTTPRequester) SendRequest(req *http.Request, response any, outputResp
 	}
 
 	resp, err := HTTPClient.Do(req)
+	ReportProxyResult(proxyFromContext(req.Context()), resp, err)
 	if err != nil {
 		return nil, common.ErrorWrapper(err, "http_request_failed", http.StatusInternalServerError)
 	}
@@ -94,6 +110,7 @@ func (r *HTTPRequester) SendRequest(req *http.Request, response any, outputResp
 	if r.IsFailureStatusCode(resp) {
 		return nil, HandleErrorResp(resp, r.ErrorHandler, r.IsOpenAI)
 	}
+	r.transformResponse(resp)
 
 	// 解析响应
 	if response == nil {
@@ -120,8 +137,13 @@ func (r *HTTPRequester) SendRequest(req *http.Request, response any, outputResp
 
 // 发送请求 RAW
 func (r *HTTPRequester) SendRequestRaw(req *http.Request) (*http.Response, *types.OpenAIErrorWithStatusCode) {
+	if r.FirstByteTimeout > 0 {
+		return r.sendRequestWithFirstByteTimeout(req)
+	}
+
 	// 发送请求
 	resp, err := HTTPClient.Do(req)
+	ReportProxyResult(proxyFromContext(req.Context()), resp, err)
 	if err != nil {
 		return nil, common.ErrorWrapper(err, "http_request_failed", http.StatusInternalServerError)
 	}
@@ -130,10 +152,72 @@ func (r *HTTPRequester) SendRequestRaw(req *http.Request) (*http.Response, *type
 	if r.IsFailureStatusCode(resp) {
 		return nil, HandleErrorResp(resp, r.ErrorHandler, r.IsOpenAI)
 	}
+	r.transformResponse(resp)
 
 	return resp, nil
 }
 
+// ErrCodeFirstByteTimeout 上游在超时时间内没有返回任何数据，此时尚未向客户端输出，可以切换渠道重试
+const ErrCodeFirstByteTimeout = "stream_first_byte_timeout"
+
+// sendRequestWithFirstByteTimeout 发送请求并等待响应体的首个字节，
+// 在返回前确认上游已开始输出，超时则断开连接并返回错误
+func (r *HTTPRequester) sendRequestWithFirstByteTimeout(req *http.Request) (*http.Response, *types.OpenAIErrorWithStatusCode) {
+	ctx, cancel := context.WithCancel(req.Context())
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(r.FirstByteTimeout, func() {
+		timedOut.Store(true)
+		cancel()
+	})
+
+	timeoutErr := func() *types.OpenAIErrorWithStatusCode {
+		return common.StringErrorWrapper(fmt.Sprintf("upstream did not respond within %s", r.FirstByteTimeout), ErrCodeFirstByteTimeout, http.StatusGatewayTimeout)
+	}
+
+	resp, err := HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		timer.Stop()
+		cancel()
+		if timedOut.Load() {
+			return nil, timeoutErr()
+		}
+		ReportProxyResult(proxyFromContext(ctx), nil, err)
+		return nil, common.ErrorWrapper(err, "http_request_failed", http.StatusInternalServerError)
+	}
+
+	ReportProxyResult(proxyFromContext(ctx), resp, nil)
+
+	if r.IsFailureStatusCode(resp) {
+		timer.Stop()
+		defer cancel()
+		return nil, HandleErrorResp(resp, r.ErrorHandler, r.IsOpenAI)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	_, peekErr := reader.Peek(1)
+	timer.Stop()
+	if peekErr != nil && timedOut.Load() {
+		resp.Body.Close()
+		cancel()
+		return nil, timeoutErr()
+	}
+
+	resp.Body = &cancelReadCloser{Reader: reader, closer: resp.Body, cancel: cancel}
+	r.transformResponse(resp)
+	return resp, nil
+}
+
+type cancelReadCloser struct {
+	io.Reader
+	closer io.Closer
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.closer.Close()
+}
+
 // 获取流式响应
 func RequestStream[T streamable](requester *HTTPRequester, resp *http.Response, handlerPrefix HandlerPrefix[T]) (*streamReader[T], *types.OpenAIErrorWithStatusCode) {
 	// 如果返回的头是json格式 说明有错误
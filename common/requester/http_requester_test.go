@@ -0,0 +1,94 @@
+package requester
+
+import (
+	"done-hub/common/transform"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendRequestRawFirstByteTimeout(t *testing.T) {
+	HTTPClient = &http.Client{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		w.Write([]byte("data: ok\n\n"))
+	}))
+	defer server.Close()
+
+	requester := NewHTTPRequester("", nil)
+	requester.FirstByteTimeout = 100 * time.Millisecond
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/slow", nil)
+	if _, errWithCode := requester.SendRequestRaw(req); errWithCode == nil || errWithCode.Code != ErrCodeFirstByteTimeout {
+		t.Fatalf("expected first byte timeout, got %v", errWithCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/fast", nil)
+	resp, errWithCode := requester.SendRequestRaw(req)
+	if errWithCode != nil {
+		t.Fatalf("unexpected error: %v", errWithCode)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "data: ok\n\n" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestTransformRules(t *testing.T) {
+	HTTPClient = &http.Client{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"request":` + string(body) + `,"header":"` + r.Header.Get("X-Upstream") + `","id":"1"}`))
+	}))
+	defer server.Close()
+
+	rules, err := transform.Parse(`{
+		"request": [{"op": "delete", "path": "user"}],
+		"response": [{"op": "delete", "path": "id"}],
+		"headers": {"X-Upstream": "yes", "X-Remove": ""}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requester := NewHTTPRequester("", nil)
+	requester.Transform = rules
+
+	req, err := requester.NewRequest(http.MethodPost, server.URL, requester.WithBody(map[string]any{"model": "m", "user": "u"}), requester.WithHeader(map[string]string{
+		"Content-Type": "application/json",
+		"X-Remove":     "1",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("X-Remove") != "" {
+		t.Fatal("header should be removed")
+	}
+
+	resp, errWithCode := requester.SendRequestRaw(req)
+	if errWithCode != nil {
+		t.Fatalf("unexpected error: %v", errWithCode)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"request":{"model":"m"},"header":"yes"}` {
+		t.Fatalf("unexpected response: %s", body)
+	}
+}
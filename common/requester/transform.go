@@ -0,0 +1,74 @@
+package requester
+
+import (
+	"bytes"
+	"done-hub/common/transform"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// transformRequest 按渠道规则注入请求头并转换 JSON 请求体
+func (r *HTTPRequester) transformRequest(req *http.Request) error {
+	for name, value := range r.Transform.Headers {
+		if value == "" {
+			req.Header.Del(name)
+		} else {
+			req.Header.Set(name, value)
+		}
+	}
+
+	if len(r.Transform.Request) == 0 || req.Body == nil || !strings.Contains(req.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	body, err = transform.Apply(body, r.Transform.Request)
+	if err != nil {
+		return err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return nil
+}
+
+// transformResponse 按渠道规则转换成功响应的 JSON 或 SSE 响应体，转换失败时保留原始响应
+func (r *HTTPRequester) transformResponse(resp *http.Response) {
+	if r.Transform == nil || len(r.Transform.Response) == 0 {
+		return
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "event-stream"):
+		resp.Body = transform.NewStreamReader(resp.Body, r.Transform.Response)
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+	case strings.Contains(contentType, "json"):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return
+		}
+
+		if transformed, err := transform.Apply(body, r.Transform.Response); err == nil {
+			body = transformed
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		if resp.Header.Get("Content-Length") != "" {
+			resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package requester
+
+import (
+	"context"
+	"crypto/tls"
+	"done-hub/common/utils"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// transportSettings 上游连接池配置，所有代理共用同一套配置
+type transportSettings struct {
+	maxIdleConns          int
+	maxIdleConnsPerHost   int
+	maxConnsPerHost       int
+	idleConnTimeout       time.Duration
+	dialTimeout           time.Duration
+	keepAlive             time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+	http2                 bool
+}
+
+var (
+	settings   = loadTransportSettings()
+	transports sync.Map // 代理地址 -> *http.Transport，空字符串为直连
+)
+
+func loadTransportSettings() transportSettings {
+	return transportSettings{
+		maxIdleConns:        utils.GetOrDefault("http_max_idle_conns", 200),
+		maxIdleConnsPerHost: utils.GetOrDefault("http_max_idle_conns_per_host", 50),
+		maxConnsPerHost:     utils.GetOrDefault("http_max_conns_per_host", 100),
+		idleConnTimeout:     time.Duration(utils.GetOrDefault("http_idle_conn_timeout", 60)) * time.Second,
+		dialTimeout:         time.Duration(utils.GetOrDefault("connect_timeout", 5)) * time.Second,
+		keepAlive:           time.Duration(utils.GetOrDefault("http_keep_alive", 30)) * time.Second,
+		// TLS 握手超时，默认 30 秒
+		tlsHandshakeTimeout: time.Duration(utils.GetOrDefault("tls_handshake_timeout", 30)) * time.Second,
+		// 响应头超时，默认 120 秒，防止请求体发送完成后上游长时间不返回响应头
+		responseHeaderTimeout: time.Duration(utils.GetOrDefault("response_header_timeout", 120)) * time.Second,
+		http2:                 utils.GetOrDefault("http2_enabled", true),
+	}
+}
+
+// GetTransport 返回指定代理共用的 Transport，相同代理的请求复用连接池，避免每次请求重新握手
+func GetTransport(proxyAddr string) (*http.Transport, error) {
+	if cached, ok := transports.Load(proxyAddr); ok {
+		return cached.(*http.Transport), nil
+	}
+
+	trans, err := newTransport(proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, loaded := transports.LoadOrStore(proxyAddr, trans)
+	if loaded {
+		trans.CloseIdleConnections()
+	}
+	return cached.(*http.Transport), nil
+}
+
+// NewProxyClient 创建使用共享连接池的 HTTP 客户端，设置了多个代理时按顺序选择可用的代理，代理地址无效时使用直连
+func NewProxyClient(proxyAddr string, timeout time.Duration) *http.Client {
+	trans, err := GetTransport(SelectProxy(proxyAddr, ProxyRotationFailover))
+	if err != nil {
+		trans, _ = GetTransport("")
+	}
+
+	return &http.Client{
+		Transport: trans,
+		Timeout:   timeout,
+	}
+}
+
+// NewContextProxyClient 创建按请求上下文中的代理地址选择共享连接池的 HTTP 客户端
+func NewContextProxyClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &proxyTransport{},
+		Timeout:   timeout,
+	}
+}
+
+// resetTransports 关闭并清空已缓存的 Transport，配置变更后重新创建
+func resetTransports() {
+	transports.Range(func(key, value any) bool {
+		value.(*http.Transport).CloseIdleConnections()
+		transports.Delete(key)
+		return true
+	})
+}
+
+func newTransport(proxyAddr string) (*http.Transport, error) {
+	dialer := &net.Dialer{
+		Timeout:   settings.dialTimeout,
+		KeepAlive: settings.keepAlive,
+	}
+
+	trans := &http.Transport{
+		DialContext: dialer.DialContext,
+
+		MaxIdleConns:        settings.maxIdleConns,
+		MaxIdleConnsPerHost: settings.maxIdleConnsPerHost,
+		MaxConnsPerHost:     settings.maxConnsPerHost,
+		IdleConnTimeout:     settings.idleConnTimeout,
+
+		TLSHandshakeTimeout:   settings.tlsHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		ResponseHeaderTimeout: settings.responseHeaderTimeout,
+
+		ForceAttemptHTTP2: settings.http2,
+	}
+	if !settings.http2 {
+		// 非 nil 的空 map 会禁用 HTTP/2 自动升级
+		trans.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if proxyAddr == "" {
+		return trans, nil
+	}
+
+	proxyURL, err := url.Parse(proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proxy address: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https":
+		trans.Proxy = http.ProxyURL(proxyURL)
+	case "socks5", "socks5h":
+		proxyDialer, err := proxy.FromURL(proxyURL, dialer)
+		if err != nil {
+			return nil, fmt.Errorf("error creating proxy dialer: %w", err)
+		}
+		if contextDialer, ok := proxyDialer.(proxy.ContextDialer); ok {
+			trans.DialContext = contextDialer.DialContext
+		} else {
+			trans.DialContext = func(_ context.Context, network, addr string) (net.Conn, error) {
+				return proxyDialer.Dial(network, addr)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", proxyURL.Scheme)
+	}
+
+	return trans, nil
+}
+
+// proxyTransport 按请求上下文中的代理地址选择共享 Transport，
+// 不同代理的连接分别建池，避免 SOCKS5 代理的连接被其他代理或直连请求复用
+type proxyTransport struct{}
+
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trans, err := GetTransport(proxyFromContext(req.Context()))
+	if err != nil {
+		return nil, err
+	}
+	return trans.RoundTrip(req)
+}
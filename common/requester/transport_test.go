@@ -0,0 +1,75 @@
+package requester
+
+import (
+	"context"
+	"done-hub/common/image"
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestGetTransport(t *testing.T) {
+	defer resetTransports()
+
+	direct, err := GetTransport("")
+	if err != nil || direct.Proxy != nil {
+		t.Fatalf("unexpected direct transport: %v", err)
+	}
+	if again, _ := GetTransport(""); again != direct {
+		t.Fatal("direct transport should be reused")
+	}
+
+	httpProxy, err := GetTransport("http://127.0.0.1:8080")
+	if err != nil || httpProxy.Proxy == nil || httpProxy == direct {
+		t.Fatalf("unexpected http proxy transport: %v", err)
+	}
+
+	socks, err := GetTransport("socks5://127.0.0.1:1080")
+	if err != nil || socks.Proxy != nil || socks == direct {
+		t.Fatalf("unexpected socks5 proxy transport: %v", err)
+	}
+	if !socks.ForceAttemptHTTP2 || socks.MaxIdleConnsPerHost != settings.maxIdleConnsPerHost {
+		t.Fatal("proxy transport should use shared settings")
+	}
+
+	if _, err := GetTransport("ftp://127.0.0.1"); err == nil {
+		t.Fatal("unsupported scheme should return error")
+	}
+	if client := NewProxyClient("ftp://127.0.0.1", 0); client.Transport != direct {
+		t.Fatal("invalid proxy should fall back to direct transport")
+	}
+}
+
+func TestContextProxyClient(t *testing.T) {
+	defer resetTransports()
+	logger.Logger = zap.NewNop()
+
+	// 作为 HTTP 代理的服务端，收到的是目标地址的完整 URL
+	var proxiedHost string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+	}))
+	defer proxyServer.Close()
+
+	ctx := context.WithValue(context.Background(), utils.ProxyHTTPAddrKey, proxyServer.URL)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://upstream.example/file.png", nil)
+	resp, err := NewContextProxyClient(time.Second).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if proxiedHost != "upstream.example" {
+		t.Fatalf("request should go through the proxy in context, got host %q", proxiedHost)
+	}
+
+	// 图片下载与中继请求共用连接池
+	InitHttpClient()
+	if _, ok := image.ImageHttpClients.Transport.(*proxyTransport); !ok {
+		t.Fatal("image client should use the shared proxy transport")
+	}
+}
@@ -18,6 +18,7 @@ func GetWSClient(proxyAddr string) *websocket.Dialer {
 		HandshakeTimeout: time.Duration(utils.GetOrDefault("connect_timeout", 5)) * time.Second,
 	}
 
+	proxyAddr = SelectProxy(proxyAddr, ProxyRotationFailover)
 	if proxyAddr != "" {
 		err := setWSProxy(dialer, proxyAddr)
 		if err != nil {
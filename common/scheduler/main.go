@@ -78,3 +78,11 @@ func (tm *TaskManager) GetJob(name string) *JobInfo {
 
 	return tm.jobs[name]
 }
+
+// Stop 停止调度器，不再触发新任务，并等待正在执行的任务结束
+func (tm *TaskManager) Stop() error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	return tm.scheduler.Shutdown()
+}
@@ -0,0 +1,108 @@
+package stmp
+
+import (
+	"done-hub/common/config"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const (
+	DriverSMTP     = "smtp"
+	DriverSendGrid = "sendgrid"
+	DriverMailgun  = "mailgun"
+	DriverSES      = "ses"
+)
+
+// Driver 邮件发送驱动
+type Driver interface {
+	Name() string
+	Send(to, subject, body string) error
+}
+
+// DeliveryError 邮件服务商接口返回的错误
+type DeliveryError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("status code: %d, message: %s", e.StatusCode, e.Message)
+}
+
+// Retryable 限流和服务端错误可以重试，其他错误（如鉴权失败、参数错误）重试也不会成功
+func (e *DeliveryError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+func IsValidDriver(name string) bool {
+	switch name {
+	case DriverSMTP, DriverSendGrid, DriverMailgun, DriverSES:
+		return true
+	}
+	return false
+}
+
+// GetSystemDriver 根据系统设置获取邮件发送驱动
+func GetSystemDriver() (Driver, error) {
+	switch config.EmailProvider {
+	case DriverSendGrid:
+		if config.SendGridToken == "" || getSystemFrom() == "" {
+			return nil, errors.New("SendGrid 信息未配置")
+		}
+		return NewSendGrid(config.SendGridToken, getSystemFrom()), nil
+	case DriverMailgun:
+		if config.MailgunDomain == "" || config.MailgunToken == "" || getSystemFrom() == "" {
+			return nil, errors.New("Mailgun 信息未配置")
+		}
+		return NewMailgun(config.MailgunDomain, config.MailgunRegion, config.MailgunToken, getSystemFrom()), nil
+	case DriverSES:
+		if config.SESRegion == "" || config.SESAccessKeyId == "" || config.SESSecret == "" || getSystemFrom() == "" {
+			return nil, errors.New("Amazon SES 信息未配置")
+		}
+		return NewSES(config.SESRegion, config.SESAccessKeyId, config.SESSecret, getSystemFrom()), nil
+	default:
+		return GetSystemStmp()
+	}
+}
+
+// getSystemFrom 获取发件人，未设置时使用 SMTP 账号
+func getSystemFrom() string {
+	if config.SMTPFrom != "" {
+		return config.SMTPFrom
+	}
+	return config.SMTPAccount
+}
+
+// parseAddress 解析 "Name <user@example.com>" 格式的发件人
+func parseAddress(address string) (name, email string) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", address
+	}
+	return parsed.Name, parsed.Address
+}
+
+func doRequest(req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &DeliveryError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+}
@@ -0,0 +1,77 @@
+package stmp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendGridSend(t *testing.T) {
+	var payload sendGridRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sg-token" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := NewSendGrid("sg-token", "Done Hub <noreply@example.com>")
+	sender.BaseURL = server.URL
+	if err := sender.Send("user@example.com", "subject", "<p>body</p>"); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.From.Email != "noreply@example.com" || payload.From.Name != "Done Hub" {
+		t.Errorf("unexpected from: %+v", payload.From)
+	}
+	if payload.Personalizations[0].To[0].Email != "user@example.com" || payload.Content[0].Value != "<p>body</p>" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestMailgunSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mg.example.com/messages" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if user, pass, _ := r.BasicAuth(); user != "api" || pass != "mg-token" {
+			t.Errorf("unexpected basic auth: %s:%s", user, pass)
+		}
+		if r.FormValue("to") != "user@example.com" {
+			t.Errorf("unexpected to: %s", r.FormValue("to"))
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Forbidden"))
+	}))
+	defer server.Close()
+
+	sender := NewMailgun("mg.example.com", "us", "mg-token", "noreply@example.com")
+	sender.BaseURL = server.URL
+	err := sender.Send("user@example.com", "subject", "<p>body</p>")
+
+	var deliveryErr *DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isRetryable(err) {
+		t.Error("unauthorized error should not be retried")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !isRetryable(&DeliveryError{StatusCode: http.StatusTooManyRequests}) {
+		t.Error("429 should be retried")
+	}
+	if !isRetryable(&DeliveryError{StatusCode: http.StatusBadGateway}) {
+		t.Error("502 should be retried")
+	}
+	if !isRetryable(errors.New("dial failed: connection refused")) {
+		t.Error("network error should be retried")
+	}
+}
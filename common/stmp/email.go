@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/wneessen/go-mail"
 )
@@ -38,6 +39,10 @@ func NewStmp(host string, port int, username string, password string, from strin
 	}
 }
 
+func (s *StmpConfig) Name() string {
+	return DriverSMTP
+}
+
 func (s *StmpConfig) Send(to, subject, body string) error {
 	message := mail.NewMsg()
 	message.From(s.From)
@@ -94,12 +99,6 @@ func GetSystemStmp() (*StmpConfig, error) {
 }
 
 func SendPasswordResetEmail(userName, email, link string) error {
-	stmp, err := GetSystemStmp()
-
-	if err != nil {
-		return err
-	}
-
 	contentTemp := `<p style="font-size: 30px">Hi <strong>%s,</strong></p>
 	<p>
 		您正在进行密码重置。点击下方按钮以重置密码。
@@ -117,16 +116,10 @@ func SendPasswordResetEmail(userName, email, link string) error {
 	subject := fmt.Sprintf("%s密码重置", config.SystemName)
 	content := fmt.Sprintf(contentTemp, userName, link, link, common.VerificationValidMinutes)
 
-	return stmp.Render(email, subject, content)
+	return SendAsync(email, subject, getDefaultTemplate(content))
 }
 
 func SendVerificationCodeEmail(email, code string) error {
-	stmp, err := GetSystemStmp()
-
-	if err != nil {
-		return err
-	}
-
 	contentTemp := `
 	<p>
 		您正在进行邮箱验证。您的验证码为: 
@@ -143,16 +136,10 @@ func SendVerificationCodeEmail(email, code string) error {
 	subject := fmt.Sprintf("%s邮箱验证邮件", config.SystemName)
 	content := fmt.Sprintf(contentTemp, code, common.VerificationValidMinutes)
 
-	return stmp.Render(email, subject, content)
+	return SendAsync(email, subject, getDefaultTemplate(content))
 }
 
 func SendQuotaWarningCodeEmail(userName, email string, quota int, noMoreQuota bool) error {
-	stmp, err := GetSystemStmp()
-
-	if err != nil {
-		return err
-	}
-
 	contentTemp := `<p style="font-size: 30px">Hi <strong>%s,</strong></p>
 		<p>
 			%s，当前剩余额度为 %d，为了不影响您的使用，请及时充值。
@@ -174,7 +161,33 @@ func SendQuotaWarningCodeEmail(userName, email string, quota int, noMoreQuota bo
 
 	content := fmt.Sprintf(contentTemp, userName, subject, quota, topUpLink, topUpLink)
 
-	return stmp.Render(email, subject, content)
+	return SendAsync(email, subject, getDefaultTemplate(content))
+}
+
+func SendInactiveAccountEmail(userName, email string, inactiveDays int, pauseTime int64) error {
+	contentTemp := `<p style="font-size: 30px">Hi <strong>%s,</strong></p>
+		<p>
+			您的账户已超过 %d 天未活跃，系统将于 %s 暂停您的全部令牌。
+		</p>
+		<p>
+			如需继续使用，请在此之前登录账户，令牌暂停后登录也会自动恢复。
+		</p>
+
+		<p style="text-align: center; font-size: 13px;">
+			<a target="__blank" href="%s" class="button" style="color: #ffffff;">立即登录</a>
+		</p>
+
+		<p style="color: #858585; padding-top: 15px;">
+			如果链接无法点击，请尝试点击下面的链接或将其复制到浏览器中打开<br> %s
+		</p>`
+
+	subject := "您的账户即将因长期未活跃被暂停"
+	loginLink := fmt.Sprintf("%s/login", config.ServerAddress)
+	pauseAt := time.Unix(pauseTime, 0).Format("2006-01-02 15:04:05")
+
+	content := fmt.Sprintf(contentTemp, userName, inactiveDays, pauseAt, loginLink, loginLink)
+
+	return SendAsync(email, subject, getDefaultTemplate(content))
 }
 
 func DialAndSend(c *mail.Client, messages ...*mail.Msg) error {
@@ -192,3 +205,8 @@ func DialAndSend(c *mail.Client, messages ...*mail.Msg) error {
 	}
 	return nil
 }
+
+// SendWithDriver 使用默认模板同步发送邮件，不经过发送队列
+func SendWithDriver(driver Driver, to, subject, content string) error {
+	return driver.Send(to, subject, getDefaultTemplate(content))
+}
@@ -0,0 +1,52 @@
+package stmp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Mailgun struct {
+	BaseURL string
+	Domain  string
+	Token   string
+	From    string
+}
+
+// NewMailgun region 为 eu 时使用欧洲区接口
+func NewMailgun(domain, region, token, from string) *Mailgun {
+	baseURL := "https://api.mailgun.net"
+	if strings.EqualFold(region, "eu") {
+		baseURL = "https://api.eu.mailgun.net"
+	}
+
+	return &Mailgun{
+		BaseURL: baseURL,
+		Domain:  domain,
+		Token:   token,
+		From:    from,
+	}
+}
+
+func (m *Mailgun) Name() string {
+	return DriverMailgun
+}
+
+func (m *Mailgun) Send(to, subject, body string) error {
+	form := url.Values{}
+	form.Set("from", m.From)
+	form.Set("to", to)
+	form.Set("subject", subject)
+	form.Set("html", body)
+
+	requestURL := fmt.Sprintf("%s/v3/%s/messages", m.BaseURL, m.Domain)
+	req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", m.Token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doRequest(req)
+}
@@ -0,0 +1,172 @@
+package stmp
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DeliveryStatusPending  = "pending"
+	DeliveryStatusRetrying = "retrying"
+	DeliveryStatusSent     = "sent"
+	DeliveryStatusFailed   = "failed"
+)
+
+const (
+	emailQueueSize    = 1000
+	emailQueueWorkers = 2
+)
+
+// DeliveryRecord 邮件投递记录，不包含邮件正文
+type DeliveryRecord struct {
+	MessageId string
+	To        string
+	Subject   string
+	Provider  string
+	Status    string
+	Attempts  int
+	Error     string
+}
+
+type emailTask struct {
+	record DeliveryRecord
+	body   string
+}
+
+var (
+	emailQueue     = make(chan *emailTask, emailQueueSize)
+	emailQueueOnce sync.Once
+	retryBaseDelay = 5 * time.Second
+
+	// emailPending 已入队但还没有发送成功或放弃的邮件数，包括等待重试的邮件
+	emailPending  atomic.Int64
+	emailDraining atomic.Bool
+
+	deliveryRecorder func(record DeliveryRecord)
+)
+
+// SetDeliveryRecorder 设置投递记录的持久化方法，每次状态变化都会调用
+func SetDeliveryRecorder(recorder func(record DeliveryRecord)) {
+	deliveryRecorder = recorder
+}
+
+// SendAsync 将邮件加入发送队列，发送失败时按指数退避重试
+// 只在邮件服务未配置或队列已满时返回错误
+func SendAsync(to, subject, body string) error {
+	driver, err := GetSystemDriver()
+	if err != nil {
+		return err
+	}
+
+	emailQueueOnce.Do(func() {
+		for i := 0; i < emailQueueWorkers; i++ {
+			go emailQueueWorker()
+		}
+	})
+
+	task := &emailTask{
+		record: DeliveryRecord{
+			MessageId: utils.GetUUID(),
+			To:        to,
+			Subject:   subject,
+			Provider:  driver.Name(),
+			Status:    DeliveryStatusPending,
+		},
+		body: body,
+	}
+	saveDeliveryRecord(task.record)
+
+	emailPending.Add(1)
+	select {
+	case emailQueue <- task:
+		return nil
+	default:
+		emailPending.Add(-1)
+		task.record.Status = DeliveryStatusFailed
+		task.record.Error = "邮件发送队列已满"
+		saveDeliveryRecord(task.record)
+		return errors.New(task.record.Error)
+	}
+}
+
+// DrainEmail 退出前等待队列中的邮件发送完毕，等待期间的重试不再退避，超时返回 ctx 的错误
+func DrainEmail(ctx context.Context) error {
+	emailDraining.Store(true)
+	defer emailDraining.Store(false)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for emailPending.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d emails not sent: %w", emailPending.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func emailQueueWorker() {
+	for task := range emailQueue {
+		deliver(task)
+	}
+}
+
+func deliver(task *emailTask) {
+	// 每次发送都重新读取配置，重试期间修改的邮件设置可以立即生效
+	driver, err := GetSystemDriver()
+	if err == nil {
+		task.record.Provider = driver.Name()
+		err = driver.Send(task.record.To, task.record.Subject, task.body)
+	}
+	task.record.Attempts++
+
+	if err == nil {
+		task.record.Status = DeliveryStatusSent
+		task.record.Error = ""
+		saveDeliveryRecord(task.record)
+		emailPending.Add(-1)
+		return
+	}
+
+	task.record.Error = err.Error()
+	if !isRetryable(err) || task.record.Attempts > config.EmailRetryTimes {
+		task.record.Status = DeliveryStatusFailed
+		saveDeliveryRecord(task.record)
+		emailPending.Add(-1)
+		logger.SysError(fmt.Sprintf("failed to send email to %s via %s after %d attempts: %s", task.record.To, task.record.Provider, task.record.Attempts, task.record.Error))
+		return
+	}
+
+	task.record.Status = DeliveryStatusRetrying
+	saveDeliveryRecord(task.record)
+
+	delay := retryBaseDelay << (task.record.Attempts - 1)
+	if emailDraining.Load() {
+		delay = 0
+	}
+	time.AfterFunc(delay, func() {
+		emailQueue <- task
+	})
+}
+
+func isRetryable(err error) bool {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Retryable()
+	}
+	return true
+}
+
+func saveDeliveryRecord(record DeliveryRecord) {
+	if deliveryRecorder != nil {
+		deliveryRecorder(record)
+	}
+}
@@ -0,0 +1,56 @@
+package stmp
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"net"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDrainEmail(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	// 使用已关闭的端口，连接立即失败并进入重试
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var attempts []int
+	SetDeliveryRecorder(func(record DeliveryRecord) {
+		if record.Status == DeliveryStatusRetrying || record.Status == DeliveryStatusFailed {
+			attempts = append(attempts, record.Attempts)
+		}
+	})
+
+	provider, server, smtpPort, account, token, retryTimes := config.EmailProvider, config.SMTPServer, config.SMTPPort, config.SMTPAccount, config.SMTPToken, config.EmailRetryTimes
+	config.EmailProvider = DriverSMTP
+	config.SMTPServer, config.SMTPPort, config.SMTPAccount, config.SMTPToken = "127.0.0.1", port, "noreply@example.com", "token"
+	config.EmailRetryTimes = 1
+	// 退避时间远大于等待时间，退出时的重试必须立即执行
+	retryBaseDelay = time.Hour
+	defer func() {
+		config.EmailProvider, config.SMTPServer, config.SMTPPort, config.SMTPAccount, config.SMTPToken, config.EmailRetryTimes = provider, server, smtpPort, account, token, retryTimes
+		retryBaseDelay = 5 * time.Second
+		SetDeliveryRecorder(nil)
+	}()
+
+	if err := SendAsync("user@example.com", "subject", "body"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := DrainEmail(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 2 || attempts[1] != 2 || emailPending.Load() != 0 {
+		t.Fatalf("expected 2 attempts and no pending emails, got %v, %d pending", attempts, emailPending.Load())
+	}
+}
@@ -0,0 +1,70 @@
+package stmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+type SendGrid struct {
+	BaseURL string
+	Token   string
+	From    string
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func NewSendGrid(token, from string) *SendGrid {
+	return &SendGrid{
+		BaseURL: sendGridURL,
+		Token:   token,
+		From:    from,
+	}
+}
+
+func (s *SendGrid) Name() string {
+	return DriverSendGrid
+}
+
+func (s *SendGrid) Send(to, subject, body string) error {
+	fromName, fromEmail := parseAddress(s.From)
+	payload, err := json.Marshal(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: to}}}},
+		From:             sendGridAddress{Email: fromEmail, Name: fromName},
+		Subject:          subject,
+		Content:          []sendGridContent{{Type: "text/html", Value: body}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.BaseURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doRequest(req)
+}
@@ -0,0 +1,88 @@
+package stmp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// SES 使用 Amazon SES v2 接口发送邮件
+type SES struct {
+	BaseURL         string
+	Region          string
+	AccessKeyId     string
+	SecretAccessKey string
+	From            string
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Html sesContent `json:"Html"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+func NewSES(region, accessKeyId, secretAccessKey, from string) *SES {
+	return &SES{
+		BaseURL:         fmt.Sprintf("https://email.%s.amazonaws.com", region),
+		Region:          region,
+		AccessKeyId:     accessKeyId,
+		SecretAccessKey: secretAccessKey,
+		From:            from,
+	}
+}
+
+func (s *SES) Name() string {
+	return DriverSES
+}
+
+func (s *SES) Send(to, subject, body string) error {
+	request := sesRequest{FromEmailAddress: s.From}
+	request.Destination.ToAddresses = []string{to}
+	request.Content.Simple.Subject = sesContent{Data: subject, Charset: "UTF-8"}
+	request.Content.Simple.Body.Html = sesContent{Data: body, Charset: "UTF-8"}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.BaseURL+"/v2/email/outbound-emails", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	payloadHash := sha256.Sum256(payload)
+	credentials := aws.Credentials{
+		AccessKeyID:     s.AccessKeyId,
+		SecretAccessKey: s.SecretAccessKey,
+	}
+	err = v4.NewSigner().SignHTTP(context.Background(), credentials, req, hex.EncodeToString(payloadHash[:]), "ses", s.Region, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return doRequest(req)
+}
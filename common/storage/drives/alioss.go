@@ -3,6 +3,8 @@ package drives
 import (
 	"bytes"
 	"fmt"
+	"strings"
+
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 )
 
@@ -11,14 +13,21 @@ type AliOSSUpload struct {
 	AccessKeyId     string
 	AccessKeySecret string
 	BucketName      string
+	CustomDomain    string // 配置后返回公共访问链接，不再签名
+	SignExpire      int64  // 签名链接的有效期（秒）
 }
 
-func NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName string) *AliOSSUpload {
+func NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl string, signExpireSeconds int64) *AliOSSUpload {
+	if signExpireSeconds <= 0 {
+		signExpireSeconds = 3600
+	}
 	return &AliOSSUpload{
 		Endpoint:        endpoint,
 		AccessKeyId:     accessKeyId,
 		AccessKeySecret: accessKeySecret,
 		BucketName:      bucketName,
+		CustomDomain:    strings.TrimSuffix(cdnurl, "/"),
+		SignExpire:      signExpireSeconds,
 	}
 }
 
@@ -41,13 +50,17 @@ func (a *AliOSSUpload) Upload(data []byte, fileName string) (string, error) {
 
 	// Upload File
 	reader := bytes.NewReader(data)
-	err = bucket.PutObject(fileName, reader)
+	err = bucket.PutObject(fileName, reader, oss.ContentType(contentType(fileName)))
 	if err != nil {
 		return "", fmt.Errorf("uploading file: %w", err)
 	}
 
+	if a.CustomDomain != "" {
+		return fmt.Sprintf("%s/%s", a.CustomDomain, fileName), nil
+	}
+
 	// Get Object URL
-	objectURL, err := bucket.SignURL(fileName, oss.HTTPGet, 3600)
+	objectURL, err := bucket.SignURL(fileName, oss.HTTPGet, a.SignExpire)
 	if err != nil {
 		return "", fmt.Errorf("signing object URL: %w", err)
 	}
@@ -0,0 +1,14 @@
+package drives
+
+import (
+	"mime"
+	"path"
+)
+
+// contentType 根据文件名获取上传时使用的 Content-Type，便于浏览器直接播放或预览
+func contentType(fileName string) string {
+	if contentType := mime.TypeByExtension(path.Ext(fileName)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -18,9 +18,10 @@ type S3Upload struct {
 	AccessKeySecret string
 	BucketName      string
 	expirationDays  int
+	signExpire      time.Duration // 大于 0 时返回带签名的临时链接，用于私有 Bucket
 }
 
-func NewS3Upload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl string, expirationDays int) *S3Upload {
+func NewS3Upload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl string, expirationDays, signExpireSeconds int) *S3Upload {
 	_cdnurl := cdnurl
 	if _cdnurl == "" {
 		_cdnurl = endpoint
@@ -32,6 +33,7 @@ func NewS3Upload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl stri
 		AccessKeyId:     accessKeyId,
 		AccessKeySecret: accessKeySecret,
 		expirationDays:  expirationDays,
+		signExpire:      time.Duration(signExpireSeconds) * time.Second,
 	}
 }
 
@@ -73,15 +75,16 @@ func (a *S3Upload) Upload(data []byte, s3Key string) (string, error) {
 
 	if err == nil {
 		// 文件已存在，直接返回自定义域名 URL
-		return fmt.Sprintf("%s/%s", a.CustomDomain, datedKey), nil
+		return a.objectURL(svc, datedKey)
 	}
 	fileBytes := bytes.NewReader(data)
 
 	// 准备上传参数
 	putObjectInput := &s3.PutObjectInput{
-		Bucket: aws.String(a.BucketName),
-		Key:    aws.String(datedKey),
-		Body:   fileBytes,
+		Bucket:      aws.String(a.BucketName),
+		Key:         aws.String(datedKey),
+		Body:        fileBytes,
+		ContentType: aws.String(contentType(s3Key)),
 	}
 
 	// 如果设置了过期时间，则添加过期策略
@@ -98,5 +101,21 @@ func (a *S3Upload) Upload(data []byte, s3Key string) (string, error) {
 		return "", fmt.Errorf("failed to upload file to S3: %v", err)
 	}
 
-	return fmt.Sprintf("%s/%s", a.CustomDomain, datedKey), nil
+	return a.objectURL(svc, datedKey)
+}
+
+func (a *S3Upload) objectURL(svc *s3.S3, key string) (string, error) {
+	if a.signExpire <= 0 {
+		return fmt.Sprintf("%s/%s", a.CustomDomain, key), nil
+	}
+
+	req, _ := svc.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(a.BucketName),
+		Key:    aws.String(key),
+	})
+	signedURL, err := req.Presign(a.signExpire)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign S3 url: %v", err)
+	}
+	return signedURL, nil
 }
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/requester"
+	"done-hub/common/utils"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// 转存文件的最大大小
+const maxMirrorFileSize = 100 * 1024 * 1024
+
+var mirrorHttpClient = requester.NewContextProxyClient(120 * time.Second)
+
+// Enabled 是否配置了可用的存储
+func Enabled() bool {
+	return len(storageDrives.drives) > 0
+}
+
+// MirrorEnabled 是否需要将上游返回的临时链接转存到自有存储
+func MirrorEnabled() bool {
+	return config.StorageMirrorEnabled && Enabled()
+}
+
+// MirrorURL 下载上游返回的临时链接并上传到存储，返回自有链接，失败时返回原链接
+func MirrorURL(ctx context.Context, fileUrl string) string {
+	if !MirrorEnabled() || !strings.HasPrefix(fileUrl, "http") {
+		return fileUrl
+	}
+
+	data, contentType, err := downloadFile(ctx, fileUrl)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("mirror file %s failed: %s", fileUrl, err.Error()))
+		return fileUrl
+	}
+
+	newUrl := storageDrives.Upload(ctx, data, utils.GetUUID()+fileExtension(fileUrl, contentType))
+	if newUrl == "" {
+		return fileUrl
+	}
+	return newUrl
+}
+
+func downloadFile(ctx context.Context, fileUrl string) ([]byte, string, error) {
+	req, err := utils.RequestBuilder(utils.SetProxy(config.ChatImageRequestProxy, ctx), http.MethodGet, fileUrl, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := mirrorHttpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	n, err := buffer.ReadFrom(io.LimitReader(resp.Body, maxMirrorFileSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if n > maxMirrorFileSize {
+		return nil, "", fmt.Errorf("file exceeds %d bytes", maxMirrorFileSize)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" || strings.HasPrefix(contentType, "application/octet-stream") {
+		contentType = http.DetectContentType(buffer.Bytes())
+	}
+
+	return buffer.Bytes(), contentType, nil
+}
+
+// fileExtension 优先使用链接中的扩展名，没有时根据 Content-Type 推断
+func fileExtension(fileUrl, contentType string) string {
+	if parsed, err := url.Parse(fileUrl); err == nil {
+		if ext := path.Ext(parsed.Path); ext != "" && len(ext) <= 6 {
+			return strings.ToLower(ext)
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg":
+		return ".jpg"
+	case "audio/mpeg":
+		return ".mp3"
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
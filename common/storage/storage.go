@@ -36,7 +36,10 @@ func InitALIOSSStorage() {
 		return
 	}
 
-	aliUpload := drives.NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName)
+	cdnurl := viper.GetString("storage.alioss.cdnurl")
+	signExpireSeconds := viper.GetInt64("storage.alioss.signExpireSeconds")
+
+	aliUpload := drives.NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl, signExpireSeconds)
 	AddStorageDrive(aliUpload)
 }
 
@@ -83,7 +86,8 @@ func InitS3Storage() {
 	}
 
 	expirationDays := viper.GetInt("storage.s3.expirationDays")
+	signExpireSeconds := viper.GetInt("storage.s3.signExpireSeconds")
 
-	s3Upload := drives.NewS3Upload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl, expirationDays)
+	s3Upload := drives.NewS3Upload(endpoint, accessKeyId, accessKeySecret, bucketName, cdnurl, expirationDays, signExpireSeconds)
 	AddStorageDrive(s3Upload)
 }
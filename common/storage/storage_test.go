@@ -28,7 +28,7 @@ func TestALIOSSUpload(t *testing.T) {
 	accessKeyId := viper.GetString("storage.alioss.accessKeyId")
 	accessKeySecret := viper.GetString("storage.alioss.accessKeySecret")
 	bucketName := viper.GetString("storage.alioss.bucketName")
-	aliUpload := drives.NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName)
+	aliUpload := drives.NewAliOSSUpload(endpoint, accessKeyId, accessKeySecret, bucketName, "", 0)
 
 	image, err := base64.StdEncoding.DecodeString(testImageB64)
 	if err != nil {
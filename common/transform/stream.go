@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+var dataPrefix = []byte("data:")
+
+// streamReader 逐行读取 SSE 响应，对 data 行中的 JSON 执行转换操作，其他行原样输出
+type streamReader struct {
+	reader  *bufio.Reader
+	closer  io.Closer
+	ops     []*Operation
+	pending []byte
+	err     error
+}
+
+// NewStreamReader 包装 SSE 响应体，转换失败的事件原样输出
+func NewStreamReader(body io.ReadCloser, ops []*Operation) io.ReadCloser {
+	return &streamReader{
+		reader: bufio.NewReader(body),
+		closer: body,
+		ops:    ops,
+	}
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		var line []byte
+		line, s.err = s.reader.ReadBytes('\n')
+		s.pending = s.transformLine(line)
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+func (s *streamReader) Close() error {
+	return s.closer.Close()
+}
+
+func (s *streamReader) transformLine(line []byte) []byte {
+	if !bytes.HasPrefix(line, dataPrefix) {
+		return line
+	}
+
+	content := bytes.TrimRight(line[len(dataPrefix):], "\r\n")
+	ending := line[len(dataPrefix)+len(content):]
+	payload := bytes.TrimLeft(content, " ")
+	if len(payload) == 0 || payload[0] != '{' {
+		return line
+	}
+
+	transformed, err := Apply(payload, s.ops)
+	if err != nil {
+		return line
+	}
+
+	result := make([]byte, 0, len(dataPrefix)+len(transformed)+len(ending)+1)
+	result = append(result, dataPrefix...)
+	result = append(result, ' ')
+	result = append(result, transformed...)
+	return append(result, ending...)
+}
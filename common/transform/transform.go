@@ -0,0 +1,261 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	OpSet    = "set"    // 设置字段的值，字段不存在时创建
+	OpDelete = "delete" // 删除字段
+	OpRename = "rename" // 移动字段到新路径，字段不存在时忽略
+)
+
+// Operation 一条转换操作
+// 路径使用 sjson 语法（如 messages.0.content），路径段为 * 时匹配数组的所有元素或对象的所有字段，
+// rename 的目标路径中的 * 依次替换为源路径中匹配到的路径段
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	To    string          `json:"to,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+
+	segments []string
+}
+
+// Rules 渠道的请求、响应转换规则
+type Rules struct {
+	Request  []*Operation `json:"request,omitempty"`
+	Response []*Operation `json:"response,omitempty"`
+	// 注入的请求头，覆盖供应商设置的同名请求头，值为空时删除该请求头
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// 已解析的规则，按原始配置缓存，避免每次请求重复解析
+var parsed sync.Map
+
+// Parse 解析并校验转换规则
+func Parse(raw string) (*Rules, error) {
+	rules := &Rules{}
+	if strings.TrimSpace(raw) == "" {
+		return rules, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), rules); err != nil {
+		return nil, fmt.Errorf("规则格式错误：%s", err.Error())
+	}
+
+	for name, ops := range map[string][]*Operation{"request": rules.Request, "response": rules.Response} {
+		for i, op := range ops {
+			if err := op.init(); err != nil {
+				return nil, fmt.Errorf("%s 第 %d 条规则错误：%s", name, i+1, err.Error())
+			}
+		}
+	}
+
+	for name := range rules.Headers {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("请求头名称不能为空")
+		}
+	}
+
+	return rules, nil
+}
+
+// Get 返回缓存的转换规则，规则为空或无效时返回 nil
+func Get(raw string) *Rules {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	if cached, ok := parsed.Load(raw); ok {
+		return cached.(*Rules)
+	}
+
+	rules, err := Parse(raw)
+	if err != nil || rules.IsEmpty() {
+		rules = nil
+	}
+	parsed.Store(raw, rules)
+	return rules
+}
+
+func (r *Rules) IsEmpty() bool {
+	return r == nil || (len(r.Request) == 0 && len(r.Response) == 0 && len(r.Headers) == 0)
+}
+
+func (op *Operation) init() error {
+	if op.Path == "" {
+		return fmt.Errorf("path 不能为空")
+	}
+	op.segments = splitPath(op.Path)
+	for _, segment := range op.segments {
+		if segment == "" {
+			return fmt.Errorf("路径 %s 格式错误", op.Path)
+		}
+	}
+
+	switch op.Op {
+	case OpSet:
+		if len(op.Value) == 0 {
+			return fmt.Errorf("set 需要指定 value")
+		}
+	case OpDelete:
+	case OpRename:
+		if op.To == "" {
+			return fmt.Errorf("rename 需要指定 to")
+		}
+		if countWildcards(splitPath(op.To)) > countWildcards(op.segments) {
+			return fmt.Errorf("to 中的 * 不能多于 path")
+		}
+	default:
+		return fmt.Errorf("不支持的操作 %s，只能为 set、delete 或 rename", op.Op)
+	}
+	return nil
+}
+
+// Apply 依次对 JSON 执行转换操作
+func Apply(body []byte, ops []*Operation) ([]byte, error) {
+	var err error
+	for _, op := range ops {
+		if op.segments == nil {
+			if err = op.init(); err != nil {
+				return nil, err
+			}
+		}
+		if body, err = op.apply(body); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Op, op.Path, err)
+		}
+	}
+	return body, nil
+}
+
+func (op *Operation) apply(body []byte) ([]byte, error) {
+	matches := expandPath(body, op.segments)
+	var err error
+
+	// 倒序处理，删除数组元素时不影响前面元素的下标
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		switch op.Op {
+		case OpSet:
+			body, err = sjson.SetRawBytes(body, match.path, op.Value)
+		case OpDelete:
+			if gjson.GetBytes(body, match.path).Exists() {
+				body, err = sjson.DeleteBytes(body, match.path)
+			}
+		case OpRename:
+			value := gjson.GetBytes(body, match.path)
+			if !value.Exists() {
+				continue
+			}
+			if body, err = sjson.DeleteBytes(body, match.path); err == nil {
+				body, err = sjson.SetRawBytes(body, fillWildcards(op.To, match.captures), []byte(value.Raw))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+type pathMatch struct {
+	path     string
+	captures []string
+}
+
+// expandPath 将包含 * 的路径展开为具体路径
+func expandPath(body []byte, segments []string) []pathMatch {
+	if countWildcards(segments) == 0 {
+		return []pathMatch{{path: strings.Join(segments, ".")}}
+	}
+
+	matches := make([]pathMatch, 0)
+	var walk func(prefix []string, rest []string, captures []string)
+	walk = func(prefix []string, rest []string, captures []string) {
+		if len(rest) == 0 {
+			matches = append(matches, pathMatch{path: strings.Join(prefix, "."), captures: captures})
+			return
+		}
+		if rest[0] != "*" {
+			walk(append(prefix, rest[0]), rest[1:], captures)
+			return
+		}
+
+		parent := gjson.ParseBytes(body)
+		if len(prefix) > 0 {
+			parent = gjson.GetBytes(body, strings.Join(prefix, "."))
+		}
+		keys := make([]string, 0)
+		switch {
+		case parent.IsArray():
+			for i := range parent.Array() {
+				keys = append(keys, strconv.Itoa(i))
+			}
+		case parent.IsObject():
+			parent.ForEach(func(key, _ gjson.Result) bool {
+				keys = append(keys, escapeKey(key.String()))
+				return true
+			})
+		}
+		for _, key := range keys {
+			next := append(append([]string{}, prefix...), key)
+			walk(next, rest[1:], append(append([]string{}, captures...), key))
+		}
+	}
+	walk(nil, segments, nil)
+	return matches
+}
+
+// splitPath 按未转义的 . 拆分路径
+func splitPath(path string) []string {
+	segments := make([]string, 0)
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path):
+			current.WriteByte(path[i])
+			current.WriteByte(path[i+1])
+			i++
+		case path[i] == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	return append(segments, current.String())
+}
+
+func fillWildcards(path string, captures []string) string {
+	segments := splitPath(path)
+	for i, segment := range segments {
+		if segment == "*" && len(captures) > 0 {
+			segments[i] = captures[0]
+			captures = captures[1:]
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+func countWildcards(segments []string) int {
+	count := 0
+	for _, segment := range segments {
+		if segment == "*" {
+			count++
+		}
+	}
+	return count
+}
+
+var keyEscaper = strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`, "|", `\|`, "#", `\#`, "@", `\@`)
+
+func escapeKey(key string) string {
+	return keyEscaper.Replace(key)
+}
@@ -0,0 +1,88 @@
+package transform
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	rules, err := Parse(`{
+		"request": [
+			{"op": "rename", "path": "max_tokens", "to": "max_completion_tokens"},
+			{"op": "delete", "path": "messages.*.name"},
+			{"op": "set", "path": "stream_options.include_usage", "value": true},
+			{"op": "rename", "path": "messages.*.reasoning", "to": "messages.*.reasoning_content"},
+			{"op": "delete", "path": "missing.field"}
+		]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"model":"m","max_tokens":10,"messages":[{"role":"user","name":"a","content":"hi"},{"role":"assistant","name":"b","reasoning":"r"}]}`
+	result, err := Apply([]byte(body), rules.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"model":"m","messages":[{"role":"user","content":"hi"},{"role":"assistant","reasoning_content":"r"}],"max_completion_tokens":10,"stream_options":{"include_usage":true}}`
+	if string(result) != expected {
+		t.Fatalf("unexpected result:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestApplyDeleteArrayElements(t *testing.T) {
+	rules, err := Parse(`{"response": [{"op": "delete", "path": "choices.*"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Apply([]byte(`{"choices":[1,2,3]}`), rules.Response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != `{"choices":[]}` {
+		t.Fatalf("unexpected result: %s", result)
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	invalid := []string{
+		`not json`,
+		`{"request": [{"op": "copy", "path": "a"}]}`,
+		`{"request": [{"op": "set", "path": "a"}]}`,
+		`{"request": [{"op": "rename", "path": "a"}]}`,
+		`{"request": [{"op": "rename", "path": "a", "to": "b.*"}]}`,
+		`{"response": [{"op": "delete", "path": "a..b"}]}`,
+		`{"headers": {" ": "x"}}`,
+	}
+	for _, raw := range invalid {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("expected error for %s", raw)
+		}
+	}
+
+	if Get(`{"request": [{"op": "copy", "path": "a"}]}`) != nil || Get("") != nil {
+		t.Error("invalid or empty rules should not be applied")
+	}
+}
+
+func TestStreamReader(t *testing.T) {
+	rules, err := Parse(`{"response": [{"op": "rename", "path": "choices.*.delta.reasoning", "to": "choices.*.delta.reasoning_content"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := "event: message\r\ndata: {\"choices\":[{\"delta\":{\"reasoning\":\"x\"}}]}\r\n\r\ndata: [DONE]\n\n"
+	reader := NewStreamReader(io.NopCloser(strings.NewReader(stream)), rules.Response)
+	result, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "event: message\r\ndata: {\"choices\":[{\"delta\":{\"reasoning_content\":\"x\"}}]}\r\n\r\ndata: [DONE]\n\n"
+	if string(result) != expected {
+		t.Fatalf("unexpected stream:\n%q\nwant:\n%q", result, expected)
+	}
+}
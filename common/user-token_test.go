@@ -11,6 +11,9 @@ import (
 func prepareUserTokenTest(t *testing.T, sessionSecret string) {
 	t.Helper()
 
+	// 回退时会把密钥写入工作目录，切到临时目录避免在源码目录生成密钥文件
+	t.Chdir(t.TempDir())
+
 	oldSessionSecret := config.SessionSecret
 	viper.Reset()
 	config.SessionSecret = sessionSecret
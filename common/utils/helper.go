@@ -410,6 +410,16 @@ func GetLocalTimezone() string {
 	return "Asia/Shanghai"
 }
 
+// GetLocalLocation 返回 TZ 环境变量指定的时区，未设置或无效时使用 time.Local
+func GetLocalLocation() *time.Location {
+	if tz := os.Getenv("TZ"); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
 func IntSliceToStringSlice(intSlice []int) []string {
 	stringSlice := make([]string, len(intSlice))
 	for i, v := range intSlice {
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetLocalLocation(t *testing.T) {
+	t.Setenv("TZ", "America/New_York")
+	if loc := GetLocalLocation(); loc.String() != "America/New_York" {
+		t.Fatalf("expected America/New_York, got %s", loc)
+	}
+
+	// 无效或未设置的时区使用 time.Local
+	t.Setenv("TZ", "Invalid/Zone")
+	if loc := GetLocalLocation(); loc != time.Local {
+		t.Fatalf("expected time.Local for invalid TZ, got %s", loc)
+	}
+	t.Setenv("TZ", "")
+	if loc := GetLocalLocation(); loc != time.Local {
+		t.Fatalf("expected time.Local for empty TZ, got %s", loc)
+	}
+}
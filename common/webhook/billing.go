@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	BillingEventType = "request.completed"
+
+	SignatureHeader = "X-DoneHub-Signature"
+	TimestampHeader = "X-DoneHub-Timestamp"
+	EventHeader     = "X-DoneHub-Event"
+	DeliveryHeader  = "X-DoneHub-Delivery"
+)
+
+const (
+	billingQueueSize    = 10000
+	billingQueueWorkers = 4
+)
+
+// BillingEvent 单个计费请求完成后推送的事件，金额单位为美元
+type BillingEvent struct {
+	Id               string  `json:"id"`
+	Type             string  `json:"type"`
+	CreatedAt        int64   `json:"created_at"`
+	RequestId        string  `json:"request_id"`
+	UserId           int     `json:"user_id"`
+	Username         string  `json:"username"`
+	TokenId          int     `json:"token_id"`
+	TokenName        string  `json:"token_name"`
+	ChannelId        int     `json:"channel_id"`
+	Model            string  `json:"model"`
+	Group            string  `json:"group"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	Quota            int     `json:"quota"`
+	Cost             float64 `json:"cost"`
+	IsStream         bool    `json:"is_stream"`
+}
+
+type billingTask struct {
+	event    *BillingEvent
+	payload  []byte
+	attempts int
+}
+
+var (
+	billingQueue     = make(chan *billingTask, billingQueueSize)
+	billingQueueOnce sync.Once
+	retryBaseDelay   = 2 * time.Second
+
+	// billingPending 已入队但还没有送达或放弃的事件数，包括等待重试的事件
+	billingPending  atomic.Int64
+	billingDraining atomic.Bool
+
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// EmitBilling 将计费事件加入推送队列，未开启或队列已满时直接丢弃
+func EmitBilling(event *BillingEvent) {
+	if !config.BillingWebhookEnabled || config.BillingWebhookURL == "" {
+		return
+	}
+
+	event.Id = utils.GetUUID()
+	event.Type = BillingEventType
+	if event.CreatedAt == 0 {
+		event.CreatedAt = utils.GetTimestamp()
+	}
+	if event.Cost == 0 && event.Quota > 0 {
+		event.Cost = float64(event.Quota) / config.QuotaPerUnit
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	billingQueueOnce.Do(func() {
+		for i := 0; i < billingQueueWorkers; i++ {
+			go billingQueueWorker()
+		}
+	})
+
+	billingPending.Add(1)
+	select {
+	case billingQueue <- &billingTask{event: event, payload: payload}:
+	default:
+		billingPending.Add(-1)
+		logger.SysError("billing webhook queue is full, event dropped: " + event.Id)
+	}
+}
+
+// DrainBilling 退出前等待队列中的事件推送完毕，等待期间的重试不再退避，超时返回 ctx 的错误
+func DrainBilling(ctx context.Context) error {
+	billingDraining.Store(true)
+	defer billingDraining.Store(false)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for billingPending.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d billing webhook events not delivered: %w", billingPending.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func billingQueueWorker() {
+	for task := range billingQueue {
+		deliverBilling(task)
+	}
+}
+
+func deliverBilling(task *billingTask) {
+	task.attempts++
+	err := send(config.BillingWebhookURL, config.BillingWebhookSecret, task.event.Id, task.payload)
+	if err == nil {
+		billingPending.Add(-1)
+		return
+	}
+
+	if !isRetryable(err) || task.attempts > config.BillingWebhookRetryTimes {
+		billingPending.Add(-1)
+		logger.SysError(fmt.Sprintf("failed to deliver billing webhook %s after %d attempts: %s", task.event.Id, task.attempts, err.Error()))
+		return
+	}
+
+	delay := retryBaseDelay << (task.attempts - 1)
+	if billingDraining.Load() {
+		delay = 0
+	}
+	time.AfterFunc(delay, func() {
+		billingQueue <- task
+	})
+}
+
+// Sign 计算签名：HMAC-SHA256(secret, timestamp + "." + body)，以 sha256= 开头的十六进制字符串
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d", e.statusCode)
+}
+
+func send(url, secret, deliveryId string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	// 时间戳参与签名，接收方可以据此拒绝过期的重放请求
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, BillingEventType)
+	req.Header.Set(DeliveryHeader, deliveryId)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, payload))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{statusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// isRetryable 网络错误、429 和 5xx 需要重试，其余 4xx 视为接收方拒绝
+func isRetryable(err error) bool {
+	if statusErr, ok := err.(*statusError); ok {
+		return statusErr.statusCode == http.StatusTooManyRequests || statusErr.statusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestEmitBillingSignedWithRetry(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if r.Header.Get(SignatureHeader) != Sign("secret", timestamp, body) {
+			t.Errorf("invalid signature: %s", r.Header.Get(SignatureHeader))
+		}
+		if r.Header.Get(EventHeader) != BillingEventType {
+			t.Errorf("unexpected event header: %s", r.Header.Get(EventHeader))
+		}
+		received <- body
+	}))
+	defer server.Close()
+
+	config.BillingWebhookEnabled = true
+	config.BillingWebhookURL = server.URL
+	config.BillingWebhookSecret = "secret"
+	config.BillingWebhookRetryTimes = 2
+	retryBaseDelay = 10 * time.Millisecond
+	defer func() {
+		config.BillingWebhookEnabled = false
+		config.BillingWebhookURL = ""
+		config.BillingWebhookSecret = ""
+	}()
+
+	EmitBilling(&BillingEvent{UserId: 1, Model: "gpt-4o", Quota: 500000, PromptTokens: 10, CompletionTokens: 20})
+
+	select {
+	case body := <-received:
+		if attempts.Load() != 2 {
+			t.Fatalf("expected 2 attempts, got %d", attempts.Load())
+		}
+		if len(body) == 0 {
+			t.Fatal("empty payload")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if isRetryable(&statusError{statusCode: http.StatusBadRequest}) {
+		t.Fatal("4xx should not be retried")
+	}
+	if !isRetryable(&statusError{statusCode: http.StatusTooManyRequests}) || !isRetryable(&statusError{statusCode: http.StatusBadGateway}) {
+		t.Fatal("429 and 5xx should be retried")
+	}
+}
+
+func TestDrainBilling(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	config.BillingWebhookEnabled = true
+	config.BillingWebhookURL = server.URL
+	config.BillingWebhookRetryTimes = 2
+	// 退避时间远大于等待时间，退出时的重试必须立即执行
+	retryBaseDelay = time.Hour
+	defer func() {
+		config.BillingWebhookEnabled = false
+		config.BillingWebhookURL = ""
+		retryBaseDelay = 2 * time.Second
+	}()
+
+	EmitBilling(&BillingEvent{UserId: 1, Model: "gpt-4o", Quota: 1000})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := DrainBilling(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if attempts.Load() != 2 || billingPending.Load() != 0 {
+		t.Fatalf("expected 2 attempts and no pending events, got %d attempts, %d pending", attempts.Load(), billingPending.Load())
+	}
+
+	// 接收方响应过慢时超时返回
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+	}))
+	defer slowServer.Close()
+	config.BillingWebhookURL = slowServer.URL
+	EmitBilling(&BillingEvent{UserId: 1, Model: "gpt-4o", Quota: 1000})
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := DrainBilling(ctx); err == nil {
+		t.Fatal("drain should time out while events are pending")
+	}
+}
@@ -1,10 +1,11 @@
 package controller
 
 import (
+	"done-hub/common"
+	"done-hub/common/utils"
 	"done-hub/model"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -147,14 +148,7 @@ func GetRechargeStatisticsByTimeRange(c *gin.Context) {
 	if timeRange != "all" {
 		// 优先使用系统本地时区（Docker中通过TZ环境变量设置）
 		// 如果Docker设置了TZ=Asia/Shanghai，time.Local会自动使用该时区
-		location := time.Local
-
-		// 也可以通过环境变量TZ覆盖，如果有需要的话
-		if tzEnv := os.Getenv("TZ"); tzEnv != "" {
-			if loc, err := time.LoadLocation(tzEnv); err == nil {
-				location = loc
-			}
-		}
+		location := utils.GetLocalLocation()
 
 		now := time.Now().In(location)
 
@@ -284,3 +278,79 @@ func GetRechargeStatisticsByTimeRange(c *gin.Context) {
 		"data":    rechargeStats,
 	})
 }
+
+// GetChannelPerformance 按渠道统计真实请求的首字时间、上游耗时和重试次数
+func GetChannelPerformance(c *gin.Context) {
+	var params model.ChannelPerformanceParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if checkLogTimeRange(c, params.StartTimestamp, params.EndTimestamp) {
+		return
+	}
+
+	performance, err := model.GetChannelPerformance(&params, c.GetInt("tenant_scope"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    performance,
+	})
+}
+
+// GetTopConsumers 按消费额或请求数对用户、令牌、渠道排行
+func GetTopConsumers(c *gin.Context) {
+	var params model.TopConsumersParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if checkLogTimeRange(c, params.StartTimestamp, params.EndTimestamp) {
+		return
+	}
+
+	consumers, err := model.GetTopConsumers(&params, c.GetInt("tenant_scope"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    consumers,
+	})
+}
+
+// GetUsageAnomalies 查询指定日期消费较前一日突增的用户、令牌或渠道，默认查询当日
+func GetUsageAnomalies(c *gin.Context) {
+	var params model.UsageAnomalyParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if params.Date == "" {
+		location := utils.GetLocalLocation()
+		params.Date = time.Now().In(location).Format("2006-01-02")
+	}
+
+	anomalies, err := model.GetUsageAnomalies(&params, c.GetInt("tenant_scope"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    anomalies,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"done-hub/common"
 	"done-hub/common/cache"
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 	"done-hub/providers/antigravity"
 	"encoding/base64"
 	"encoding/json"
@@ -310,7 +311,7 @@ func exchangeAntigravityToken(code string, proxyURL string) (*AntigravityTokenRe
 	data.Set("grant_type", "authorization_code")
 	data.Set("redirect_uri", redirectURI)
 
-	client := createAntigravityHTTPClient(proxyURL, 30*time.Second)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	req, err := http.NewRequest("POST", antigravity.TokenEndpoint, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -433,7 +434,7 @@ func callAntigravityOnboardUser(ctx context.Context, accessToken, proxyURL strin
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	client := createAntigravityHTTPClient(proxyURL, 30*time.Second)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	// 像 demo 一样：每次轮询都调用 onboardUser，直到返回 done: true
 	maxAttempts := 5
@@ -521,7 +522,7 @@ func getAntigravityLoadCodeAssistResponse(accessToken, proxyURL string) (*antigr
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", antigravity.AntigravityUserAgent)
 
-	client := createAntigravityHTTPClient(proxyURL, 30*time.Second)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -546,24 +547,6 @@ func getAntigravityLoadCodeAssistResponse(accessToken, proxyURL string) (*antigr
 	return &loadResp, nil
 }
 
-// createAntigravityHTTPClient 创建 HTTP 客户端
-func createAntigravityHTTPClient(proxyURL string, timeout time.Duration) *http.Client {
-	client := &http.Client{
-		Timeout: timeout,
-	}
-
-	if proxyURL != "" {
-		proxyURLParsed, err := url.Parse(proxyURL)
-		if err == nil {
-			client.Transport = &http.Transport{
-				Proxy: http.ProxyURL(proxyURLParsed),
-			}
-		}
-	}
-
-	return client
-}
-
 // renderAntigravityOAuthResult 渲染 OAuth 结果页面
 func renderAntigravityOAuthResult(c *gin.Context, success bool, message, projectID, credentials, state string) {
 	successStr := "true"
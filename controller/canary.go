@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/model"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetAllCanaryRules(c *gin.Context) {
+	rules, err := model.GetAllCanaryRules()
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    rules,
+	})
+}
+
+func CreateCanaryRule(c *gin.Context) {
+	var rule model.CanaryRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := validateCanaryRule(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	rule.Id = 0
+	if err := model.CreateCanaryRule(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    rule,
+	})
+}
+
+func UpdateCanaryRule(c *gin.Context) {
+	var rule model.CanaryRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if _, err := model.GetCanaryRule(rule.Id); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := validateCanaryRule(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := model.UpdateCanaryRule(&rule); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+func DeleteCanaryRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := model.DeleteCanaryRule(id); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+func validateCanaryRule(rule *model.CanaryRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	for _, target := range rule.Targets {
+		if _, err := model.GetChannelById(target.ChannelId); err != nil {
+			return fmt.Errorf("渠道 #%d 不存在", target.ChannelId)
+		}
+	}
+
+	return nil
+}
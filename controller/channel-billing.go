@@ -4,10 +4,7 @@ import (
 	"done-hub/common/config"
 	"done-hub/model"
 	"done-hub/providers"
-	providersBase "done-hub/providers/base"
-	"errors"
 	"net/http"
-	"net/http/httptest"
 	"strconv"
 	"time"
 
@@ -47,28 +44,46 @@ type OpenAIUsageResponse struct {
 }
 
 func updateChannelBalance(channel *model.Channel) (float64, error) {
-	req, err := http.NewRequest("POST", "/balance", nil)
+	detail, err := providers.QueryChannelBalance(channel)
 	if err != nil {
 		return 0, err
 	}
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
 
-	req.Header.Set("Content-Type", "application/json")
+	return detail.ChannelBalance, nil
+}
 
-	provider := providers.GetProvider(channel, c)
-	if provider == nil {
-		return 0, errors.New("provider not found")
+// GetChannelBalance 统一的上游余额查询接口，返回余额明细并更新渠道余额
+// GET /api/channel/:id/balance
+func GetChannelBalance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
 	}
-
-	balanceProvider, ok := provider.(providersBase.BalanceInterface)
-	if !ok {
-		return 0, errors.New("provider not implemented")
+	channel, err := model.GetChannelById(id)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
 	}
-
-	return balanceProvider.Balance()
-
+	detail, err := providers.QueryChannelBalance(channel)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    detail,
+	})
 }
 
 func UpdateChannelBalance(c *gin.Context) {
@@ -158,3 +173,35 @@ func UpdateAllChannelsBalance(c *gin.Context) {
 // 		common.SysLog("channels update done")
 // 	}
 // }
+
+// GetChannelBalanceHistory 获取渠道余额历史，用于绘制余额趋势
+// GET /api/channel/:id/balance_history
+func GetChannelBalanceHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+	startTimestamp, _ := strconv.ParseInt(c.Query("start_timestamp"), 10, 64)
+	endTimestamp, _ := strconv.ParseInt(c.Query("end_timestamp"), 10, 64)
+	if startTimestamp == 0 {
+		startTimestamp = time.Now().AddDate(0, 0, -30).Unix()
+	}
+
+	histories, err := model.GetChannelBalanceHistory(id, startTimestamp, endTimestamp)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    histories,
+	})
+}
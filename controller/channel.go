@@ -3,6 +3,8 @@ package controller
 import (
 	"done-hub/common"
 	"done-hub/common/config"
+	"done-hub/common/requester"
+	"done-hub/common/transform"
 	"done-hub/common/utils"
 	"done-hub/model"
 	"encoding/json"
@@ -24,11 +26,16 @@ func GetChannelsList(c *gin.Context) {
 		return
 	}
 
-	channels, err := model.GetChannelsList(&params)
+	channels, err := model.GetChannelsList(&params, c.GetInt("tenant_scope"))
 	if err != nil {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
 	}
+	if channels.Data != nil {
+		for _, channel := range *channels.Data {
+			channel.CircuitBreaker = model.ChannelCircuitBreaker.Status(channel.Id)
+		}
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "",
@@ -53,6 +60,7 @@ func GetChannel(c *gin.Context) {
 		})
 		return
 	}
+	channel.CircuitBreaker = model.ChannelCircuitBreaker.Status(channel.Id)
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "",
@@ -60,6 +68,113 @@ func GetChannel(c *gin.Context) {
 	})
 }
 
+func GetChannelCircuitBreaker(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    model.ChannelCircuitBreaker.Status(id),
+	})
+}
+
+func ResetChannelCircuitBreaker(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	model.ChannelCircuitBreaker.Reset(id)
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+// GetChannelProxyHealth 查询渠道各代理在当前节点的健康状态
+func GetChannelProxyHealth(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	channel, err := model.GetChannelById(id)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	channel.SetProxy()
+
+	proxy := ""
+	if channel.Proxy != nil {
+		proxy = *channel.Proxy
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    requester.GetProxyHealth(proxy),
+	})
+}
+
+// validateChannelSettings 检查渠道的代理设置与转换规则
+func validateChannelSettings(channel *model.Channel) error {
+	if !requester.IsValidProxyRotation(channel.ProxyRotation) {
+		return errors.New("代理轮换方式只能为 failover 或 round_robin")
+	}
+	if channel.Proxy != nil {
+		if err := requester.ValidateProxyList(*channel.Proxy); err != nil {
+			return err
+		}
+	}
+	if _, err := transform.Parse(channel.GetTransformRules()); err != nil {
+		return fmt.Errorf("转换规则无效：%s", err.Error())
+	}
+	return nil
+}
+
+type TransformPreviewRequest struct {
+	Rules  string          `json:"rules"`
+	Target string          `json:"target"` // request / response
+	Body   json.RawMessage `json:"body" binding:"required"`
+}
+
+// PreviewChannelTransform 使用示例请求体或响应体预览转换规则的效果
+func PreviewChannelTransform(c *gin.Context) {
+	var params TransformPreviewRequest
+	if err := c.ShouldBindJSON(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	rules, err := transform.Parse(params.Rules)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	ops := rules.Request
+	if params.Target == "response" {
+		ops = rules.Response
+	}
+	body, err := transform.Apply(params.Body, ops)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"body":    json.RawMessage(body),
+			"headers": rules.Headers,
+		},
+	})
+}
+
 func AddChannel(c *gin.Context) {
 	channel := model.Channel{}
 	err := c.ShouldBindJSON(&channel)
@@ -70,7 +185,15 @@ func AddChannel(c *gin.Context) {
 		})
 		return
 	}
+	if err := validateChannelSettings(&channel); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
 	channel.CreatedTime = utils.GetTimestamp()
+	// 租户管理员只能在所属租户下创建渠道，超级管理员可指定租户
+	if c.GetInt("role") < config.RoleRootUser {
+		channel.TenantId = c.GetInt("tenant_id")
+	}
 	keys, parseMode, err := parseBatchChannelKeys(channel.Key, channel.Type)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{
@@ -366,6 +489,22 @@ func UpdateChannel(c *gin.Context) {
 		})
 		return
 	}
+	if err := validateChannelSettings(&channel); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	if config.MultiTenantEnabled {
+		originChannel, err := model.GetChannelById(channel.Id)
+		if err != nil || !model.TenantAccessible(c.GetInt("tenant_scope"), originChannel.TenantId) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "无权操作其他租户的数据",
+			})
+			return
+		}
+		// 渠道所属租户只在创建时指定
+		channel.TenantId = originChannel.TenantId
+	}
 	if channel.Models == "" {
 		err = channel.Update(false)
 	} else {
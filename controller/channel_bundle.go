@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/common/cache"
+	"done-hub/common/keybundle"
+	"done-hub/common/utils"
+	"done-hub/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const channelBundleVersion = 1
+
+const (
+	channelImportKeyCachePrefix = "channel_import_key:"
+	channelImportKeyDuration    = time.Hour
+)
+
+// channelBundle 加密前的渠道导出数据
+type channelBundle struct {
+	Version    int             `json:"version"`
+	ExportedAt int64           `json:"exported_at"`
+	Channels   []model.Channel `json:"channels"`
+}
+
+type ExportChannelsRequest struct {
+	Recipient string `json:"recipient" binding:"required"` // age 公钥或 PGP 公钥
+	Ids       []int  `json:"ids"`                          // 为空时导出全部渠道
+}
+
+type ImportChannelsRequest struct {
+	Bundle string `json:"bundle" binding:"required"` // 使用导入公钥加密的数据
+	KeyId  string `json:"key_id" binding:"required"`
+}
+
+// ExportChannels 使用管理员提供的公钥加密导出渠道（包含密钥），不提供明文导出
+func ExportChannels(c *gin.Context) {
+	var params ExportChannelsRequest
+	if err := c.ShouldBindJSON(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	format := keybundle.DetectFormat(params.Recipient)
+	if format == "" {
+		common.APIRespondWithError(c, http.StatusOK, keybundle.ErrUnknownRecipient)
+		return
+	}
+
+	channels, err := model.GetChannelsByIds(params.Ids)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	if len(channels) == 0 {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("没有可导出的渠道"))
+		return
+	}
+
+	bundle := channelBundle{
+		Version:    channelBundleVersion,
+		ExportedAt: utils.GetTimestamp(),
+		Channels:   make([]model.Channel, 0, len(channels)),
+	}
+	for _, channel := range channels {
+		bundle.Channels = append(bundle.Channels, *channel)
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	encrypted, err := keybundle.Encrypt(params.Recipient, plaintext)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	model.RecordLog(c.GetInt("id"), model.LogTypeManage, fmt.Sprintf("加密导出 %d 个渠道（%s）", len(channels), format))
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"format": format,
+			"count":  len(channels),
+			"bundle": encrypted,
+		},
+	})
+}
+
+// CreateChannelImportKey 生成一次性的 age 导入公钥，私钥只保存在服务端缓存中，
+// 在源实例用该公钥导出后即可导入，管理员的私钥不需要上传
+func CreateChannelImportKey(c *gin.Context) {
+	identity, recipient, err := keybundle.GenerateIdentity()
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	keyId := utils.GetUUID()
+	if err = cache.SetCache(channelImportKeyCachePrefix+keyId, identity, channelImportKeyDuration); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"key_id":     keyId,
+			"recipient":  recipient,
+			"expires_at": time.Now().Add(channelImportKeyDuration).Unix(),
+		},
+	})
+}
+
+// ImportChannels 解密导入使用导入公钥加密的渠道数据，导入的渠道会分配新的 ID
+func ImportChannels(c *gin.Context) {
+	var params ImportChannelsRequest
+	if err := c.ShouldBindJSON(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	identity, err := cache.GetCache[string](channelImportKeyCachePrefix + params.KeyId)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("导入公钥不存在或已过期，请重新生成"))
+		return
+	}
+
+	plaintext, err := keybundle.Decrypt(params.Bundle, identity)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	var bundle channelBundle
+	if err = json.Unmarshal(plaintext, &bundle); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("渠道数据格式错误"))
+		return
+	}
+	if bundle.Version != channelBundleVersion {
+		common.APIRespondWithError(c, http.StatusOK, fmt.Errorf("不支持的渠道数据版本: %d", bundle.Version))
+		return
+	}
+	if len(bundle.Channels) == 0 {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("没有可导入的渠道"))
+		return
+	}
+	if len(bundle.Channels) > maxBatchCreateChannels {
+		common.APIRespondWithError(c, http.StatusOK, fmt.Errorf("批量创建数量超过限制（最多 %d 个）", maxBatchCreateChannels))
+		return
+	}
+
+	now := utils.GetTimestamp()
+	for i := range bundle.Channels {
+		bundle.Channels[i].Id = 0
+		bundle.Channels[i].CreatedTime = now
+		bundle.Channels[i].CircuitBreaker = nil
+	}
+
+	if err = model.BatchInsertChannels(bundle.Channels); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	// 导入公钥只使用一次
+	cache.DeleteCache(channelImportKeyCachePrefix + params.KeyId)
+	model.RecordLog(c.GetInt("id"), model.LogTypeManage, fmt.Sprintf("解密导入 %d 个渠道", len(bundle.Channels)))
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"count": len(bundle.Channels),
+		},
+	})
+}
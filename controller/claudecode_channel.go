@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/requester"
+	"done-hub/cron"
+	"done-hub/model"
+	"done-hub/providers/claudecode"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetClaudeCodeChannelUsage 获取 Claude Code 渠道的订阅用量信息
+// GET /api/claudecode/channel/:id/usage
+func GetClaudeCodeChannelUsage(c *gin.Context) {
+	channelID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, fmt.Errorf("invalid channel id: %w", err))
+		return
+	}
+
+	ch, err := model.GetChannelById(channelID)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	if ch == nil {
+		c.JSON(http.StatusOK, gin.H{"success": false, "message": "channel not found"})
+		return
+	}
+	if ch.Type != config.ChannelTypeClaudeCode {
+		c.JSON(http.StatusOK, gin.H{"success": false, "message": "channel type is not ClaudeCode"})
+		return
+	}
+
+	rawKey := strings.TrimSpace(ch.Key)
+	if rawKey == "" {
+		c.JSON(http.StatusOK, gin.H{"success": false, "message": "channel key is empty"})
+		return
+	}
+
+	// 支持 JSON 凭证与 claude setup-token 生成的纯文本令牌
+	creds, parseErr := claudecode.FromJSON(rawKey)
+	if parseErr != nil {
+		creds = &claudecode.OAuth2Credentials{AccessToken: rawKey}
+	}
+
+	accessToken := strings.TrimSpace(creds.AccessToken)
+	if accessToken == "" {
+		c.JSON(http.StatusOK, gin.H{"success": false, "message": "access_token is required"})
+		return
+	}
+
+	proxyURL := ""
+	if ch.Proxy != nil && *ch.Proxy != "" {
+		proxyURL = *ch.Proxy
+	}
+
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
+
+	baseURL := "https://api.anthropic.com"
+	if ch.BaseURL != nil && *ch.BaseURL != "" {
+		baseURL = strings.TrimRight(*ch.BaseURL, "/")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	statusCode, body, fetchErr := fetchClaudeCodeUsage(ctx, client, baseURL, accessToken)
+	if fetchErr != nil {
+		logger.SysError(fmt.Sprintf("Failed to fetch claudecode usage: %s", fetchErr.Error()))
+		c.JSON(http.StatusOK, gin.H{"success": false, "message": "获取用量信息失败，请稍后重试"})
+		return
+	}
+
+	// 401/403 时尝试刷新凭证后重试
+	if (statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden) &&
+		strings.TrimSpace(creds.RefreshToken) != "" {
+
+		refreshCtx, refreshCancel := context.WithTimeout(c.Request.Context(), claudeCodeCredentialRefreshTimeout)
+		defer refreshCancel()
+
+		if refreshErr := cron.RefreshClaudeCodeChannelCredentialInternal(refreshCtx, ch, creds); refreshErr == nil {
+			ctx2, cancel2 := context.WithTimeout(c.Request.Context(), 15*time.Second)
+			defer cancel2()
+			statusCode, body, fetchErr = fetchClaudeCodeUsage(ctx2, client, baseURL, creds.AccessToken)
+			if fetchErr != nil {
+				logger.SysError(fmt.Sprintf("Failed to fetch claudecode usage after refresh: %s", fetchErr.Error()))
+				c.JSON(http.StatusOK, gin.H{"success": false, "message": "刷新凭证后获取用量信息仍然失败"})
+				return
+			}
+			model.ChannelGroup.Load()
+		}
+	}
+
+	var payload interface{}
+	if json.Unmarshal(body, &payload) != nil {
+		payload = string(body)
+	}
+
+	ok := statusCode >= 200 && statusCode < 300
+	resp := gin.H{
+		"success":         ok,
+		"message":         "",
+		"upstream_status": statusCode,
+		"data":            payload,
+	}
+	if !ok {
+		resp["message"] = fmt.Sprintf("upstream status: %d", statusCode)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefreshClaudeCodeChannelCredential 手动刷新 Claude Code 渠道凭证
+// POST /api/claudecode/channel/:id/refresh
+func RefreshClaudeCodeChannelCredential(c *gin.Context) {
+	channelID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, fmt.Errorf("invalid channel id: %w", err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	expiresAt, refreshErr := cron.RefreshClaudeCodeChannelCredentialByID(ctx, channelID)
+	if refreshErr != nil {
+		logger.SysError(fmt.Sprintf("Failed to refresh claudecode credential for channel %d: %s", channelID, refreshErr.Error()))
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "凭证刷新失败: " + refreshErr.Error(),
+		})
+		return
+	}
+
+	model.ChannelGroup.Load()
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "凭证刷新成功",
+		"data": gin.H{
+			"channel_id": channelID,
+			"expires_at": expiresAt,
+		},
+	})
+}
+
+// claudeCodeCredentialRefreshTimeout 凭证刷新超时时间（用于 Usage 中的自动刷新重试）
+const claudeCodeCredentialRefreshTimeout = 10 * time.Second
+
+// fetchClaudeCodeUsage 获取 Claude Code 订阅的 5 小时 / 7 天用量窗口
+func fetchClaudeCodeUsage(ctx context.Context, client *http.Client, baseURL string, accessToken string) (int, []byte, error) {
+	reqURL := strings.TrimRight(baseURL, "/") + "/api/oauth/usage"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("anthropic-beta", "oauth-2025-04-20")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
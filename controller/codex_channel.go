@@ -5,6 +5,7 @@ import (
 	"done-hub/common"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 	"done-hub/cron"
 	"done-hub/model"
 	"done-hub/providers/codex"
@@ -12,7 +13,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -73,7 +73,7 @@ func GetCodexChannelUsage(c *gin.Context) {
 	}
 
 	// 构建 HTTP 客户端
-	client := buildCodexHTTPClient(proxyURL)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	// 获取渠道 baseURL
 	baseURL := "https://chatgpt.com"
@@ -200,21 +200,3 @@ func fetchCodexWhamUsage(ctx context.Context, client *http.Client, baseURL strin
 
 	return resp.StatusCode, body, nil
 }
-
-// buildCodexHTTPClient 构建支持代理的 HTTP 客户端
-func buildCodexHTTPClient(proxyURL string) *http.Client {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	if proxyURL != "" {
-		proxyURLParsed, err := url.Parse(proxyURL)
-		if err == nil {
-			client.Transport = &http.Transport{
-				Proxy: http.ProxyURL(proxyURLParsed),
-			}
-		}
-	}
-
-	return client
-}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/stmp"
+	"done-hub/model"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetEmailLogsList(c *gin.Context) {
+	params := model.EmailLogSearchParams{}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	emailLogs, err := model.GetEmailLogsList(&params)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    emailLogs,
+	})
+}
+
+// SendTestEmail 使用当前邮件设置同步发送一封测试邮件，直接返回服务商的错误信息
+func SendTestEmail(c *gin.Context) {
+	email := c.Query("email")
+	if err := common.ValidateEmailStrict(email); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "邮箱格式不符合要求",
+		})
+		return
+	}
+
+	driver, err := stmp.GetSystemDriver()
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	subject := fmt.Sprintf("%s测试邮件", config.SystemName)
+	content := fmt.Sprintf("<p>这是一封来自 %s 的测试邮件，当前使用 %s 发送。</p>", config.SystemName, driver.Name())
+	if err := stmp.SendWithDriver(driver, email, subject, content); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"done-hub/common"
 	"done-hub/common/cache"
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 	"done-hub/providers/geminicli"
 	"encoding/base64"
 	"encoding/json"
@@ -885,7 +886,7 @@ func callLoadCodeAssistAPI(accessToken, proxyURL string) (*LoadCodeAssistRespons
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "GeminiCLI/0.1.5 (Windows; AMD64)")
 
-	client := createHTTPClient(proxyURL, 30*time.Second)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -964,7 +965,7 @@ func onboardUser(ctx context.Context, accessToken, proxyURL string) (string, err
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	client := createHTTPClient(proxyURL, 30*time.Second)
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 	// 像 demo 一样：每次轮询都调用 onboardUser，直到返回 done: true
 	maxAttempts := 5
@@ -1025,19 +1026,3 @@ func onboardUser(ctx context.Context, accessToken, proxyURL string) (string, err
 
 	return "", fmt.Errorf("onboardUser timeout after %d attempts", maxAttempts)
 }
-
-// createHTTPClient 创建 HTTP 客户端（支持代理）
-func createHTTPClient(proxyURL string, timeout time.Duration) *http.Client {
-	client := &http.Client{Timeout: timeout}
-
-	if proxyURL != "" {
-		proxyURLParsed, err := url.Parse(proxyURL)
-		if err == nil {
-			client.Transport = &http.Transport{
-				Proxy: http.ProxyURL(proxyURLParsed),
-			}
-		}
-	}
-
-	return client
-}
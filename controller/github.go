@@ -2,24 +2,20 @@ package controller
 
 import (
 	"bytes"
-	"context"
 	"done-hub/common"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 	"done-hub/common/utils"
 	"done-hub/model"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
-	"golang.org/x/net/proxy"
-
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -49,33 +45,13 @@ func configureClientWithGitHubProxy(client *http.Client) error {
 	if config.GitHubProxy == "" {
 		return nil
 	}
-	proxyURL, parseErr := url.Parse(config.GitHubProxy)
-	if parseErr != nil {
-		logger.SysError("GitHub proxy URL parse error: " + parseErr.Error())
-		return parseErr
-	}
 
-	switch proxyURL.Scheme {
-	case "http", "https":
-		client.Transport = &http.Transport{
-			Proxy: http.ProxyURL(proxyURL),
-		}
-	case "socks5":
-		dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
-		if err != nil {
-			logger.SysError("failed to create Github SOCKS5 dialer: " + err.Error())
-			return err
-		}
-		client.Transport = &http.Transport{
-			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-				return dialer.(proxy.ContextDialer).DialContext(ctx, network, addr)
-			},
-		}
-	default:
-		errMsg := "unsupported Github proxy scheme: " + proxyURL.Scheme
-		logger.SysError(errMsg)
-		return errors.New(errMsg)
+	trans, err := requester.GetTransport(config.GitHubProxy)
+	if err != nil {
+		logger.SysError("GitHub proxy error: " + err.Error())
+		return err
 	}
+	client.Transport = trans
 
 	return nil
 }
@@ -324,6 +300,7 @@ func GitHubOAuth(c *gin.Context) {
 			}
 
 			// 在事务中创建用户
+			user.TenantId = c.GetInt("request_tenant_id")
 			return user.InsertWithTx(tx, user.InviterId)
 		})
 
@@ -216,6 +216,7 @@ func LarkOAuth(c *gin.Context) {
 				}
 
 				// 在事务中创建用户
+				user.TenantId = c.GetInt("request_tenant_id")
 				return user.InsertWithTx(tx, 0)
 			})
 
@@ -309,6 +309,7 @@ func LinuxDoOAuth(c *gin.Context) {
 				}
 
 				// 在事务中创建用户
+				user.TenantId = c.GetInt("request_tenant_id")
 				return user.InsertWithTx(tx, user.InviterId)
 			})
 
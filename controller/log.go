@@ -73,7 +73,7 @@ func GetLogsList(c *gin.Context) {
 		return
 	}
 
-	logs, err := model.GetLogsList(&params)
+	logs, err := model.GetLogsList(&params, c.GetInt("tenant_scope"))
 	if err != nil {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
@@ -123,7 +123,7 @@ func GetLogsStat(c *gin.Context) {
 	username := c.Query("username")
 	modelName := c.Query("model_name")
 	channel, _ := strconv.Atoi(c.Query("channel"))
-	quotaNum := model.SumUsedQuota(startTimestamp, endTimestamp, modelName, username, tokenName, channel)
+	quotaNum := model.SumUsedQuota(startTimestamp, endTimestamp, modelName, username, tokenName, channel, c.GetInt("tenant_scope"))
 	//tokenNum := model.SumUsedToken(logType, startTimestamp, endTimestamp, modelName, username, "")
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -148,7 +148,7 @@ func GetLogsSelfStat(c *gin.Context) {
 	tokenName := c.Query("token_name")
 	modelName := c.Query("model_name")
 	channel, _ := strconv.Atoi(c.Query("channel"))
-	quotaNum := model.SumUsedQuota(startTimestamp, endTimestamp, modelName, username, tokenName, channel)
+	quotaNum := model.SumUsedQuota(startTimestamp, endTimestamp, modelName, username, tokenName, channel, -1)
 	//tokenNum := model.SumUsedToken(logType, startTimestamp, endTimestamp, modelName, username, tokenName)
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -184,6 +184,25 @@ func DeleteHistoryLogs(c *gin.Context) {
 	})
 }
 
+// GetLogExportBatches 最近导出到对象存储的日志批次
+func GetLogExportBatches(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	batches, err := model.GetLogExportBatches(limit)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    batches,
+	})
+}
+
 func ExportLogsList(c *gin.Context) {
 	var params model.LogsListParams
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -196,7 +215,7 @@ func ExportLogsList(c *gin.Context) {
 	}
 
 	// Get all matching records without pagination
-	logs, err := model.GetAllLogsList(&params)
+	logs, err := model.GetAllLogsList(&params, c.GetInt("tenant_scope"))
 	if err != nil {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
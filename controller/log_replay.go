@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/requester"
+	"done-hub/model"
+	"done-hub/providers"
+	providersBase "done-hub/providers/base"
+	"done-hub/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 单次回放最多对比的渠道数量
+const maxReplayChannels = 5
+
+type ReplayRequest struct {
+	LogId      int             `json:"log_id"`
+	ChannelIds []int           `json:"channel_ids"`
+	Path       string          `json:"path"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// ReplayReport 单个渠道的回放结果，exchanges 为实际发往上游的请求与响应
+type ReplayReport struct {
+	ChannelId   int                           `json:"channel_id"`
+	ChannelName string                        `json:"channel_name"`
+	ChannelType int                           `json:"channel_type"`
+	Model       string                        `json:"model"`
+	Success     bool                          `json:"success"`
+	StatusCode  int                           `json:"status_code"`
+	Error       string                        `json:"error,omitempty"`
+	Duration    int64                         `json:"duration"`
+	Response    string                        `json:"response"`
+	Exchanges   []*requester.CapturedExchange `json:"exchanges"`
+}
+
+// ReplayRequestDebug 将日志中记录的请求或直接提供的请求体重新发送到指定渠道，
+// 返回每个渠道实际发出的上游请求与响应，便于对比同一请求在不同渠道上的差异。回放不计费也不记录日志。
+// POST /api/log/replay
+func ReplayRequestDebug(c *gin.Context) {
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if req.LogId > 0 {
+		if err := fillReplayRequestFromLog(&req); err != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
+	if len(req.Body) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "请提供日志 ID 或请求体",
+		})
+		return
+	}
+	if len(req.ChannelIds) == 0 || len(req.ChannelIds) > maxReplayChannels {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": fmt.Sprintf("请选择 1 ~ %d 个渠道", maxReplayChannels),
+		})
+		return
+	}
+	if req.Path == "" {
+		req.Path = "/v1/chat/completions"
+	}
+
+	reports := make([]*ReplayReport, 0, len(req.ChannelIds))
+	for _, channelId := range req.ChannelIds {
+		channel, err := model.GetChannelById(channelId)
+		if err != nil {
+			reports = append(reports, &ReplayReport{ChannelId: channelId, Error: "渠道不存在"})
+			continue
+		}
+		reports = append(reports, replayOnChannel(channel, req.Path, req.Body))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    reports,
+	})
+}
+
+func fillReplayRequestFromLog(req *ReplayRequest) error {
+	log, err := model.GetLogById(req.LogId)
+	if err != nil {
+		return errors.New("日志不存在")
+	}
+
+	metadata := log.Metadata.Data()
+	body, _ := metadata["request_body"].(string)
+	if len(req.Body) == 0 {
+		if body == "" {
+			return errors.New("该日志没有记录请求体，请开启 LogRequestBodyEnabled 后重新请求，或直接提供请求体")
+		}
+		req.Body = json.RawMessage(body)
+	}
+	if req.Path == "" {
+		req.Path, _ = metadata["request_path"].(string)
+	}
+	if len(req.ChannelIds) == 0 && log.ChannelId > 0 {
+		req.ChannelIds = []int{log.ChannelId}
+	}
+
+	return nil
+}
+
+func replayOnChannel(channel *model.Channel, path string, body []byte) *ReplayReport {
+	report := &ReplayReport{
+		ChannelId:   channel.Id,
+		ChannelName: channel.Name,
+		ChannelType: channel.Type,
+	}
+
+	capture := &requester.Capture{}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := http.NewRequestWithContext(requester.WithCapture(context.Background(), capture), http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+	c.Set(config.GinRequestBodyKey, body)
+
+	channel.SetProxy()
+	provider := providers.GetProvider(channel, c)
+	if provider == nil {
+		report.Error = "channel not implemented"
+		return report
+	}
+	provider.SetUsage(&types.Usage{})
+
+	start := time.Now()
+	response, errWithCode := replayByPath(provider, path, body, report)
+	report.Duration = time.Since(start).Milliseconds()
+	report.Exchanges = capture.Exchanges()
+	report.Response = response
+
+	if errWithCode != nil {
+		report.StatusCode = errWithCode.StatusCode
+		report.Error = errWithCode.Message
+		return report
+	}
+
+	report.Success = true
+	report.StatusCode = http.StatusOK
+	return report
+}
+
+// replayByPath 按接口类型调用 provider，返回转换后（即客户端会收到）的响应
+func replayByPath(provider providersBase.ProviderInterface, path string, body []byte, report *ReplayReport) (string, *types.OpenAIErrorWithStatusCode) {
+	switch path {
+	case "/v1/chat/completions":
+		chatProvider, ok := provider.(providersBase.ChatInterface)
+		if !ok {
+			return "", replayError("channel not implemented")
+		}
+		var request types.ChatCompletionRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return "", replayError(err.Error())
+		}
+		if err := mapReplayModel(provider, &request.Model, report); err != nil {
+			return "", replayError(err.Error())
+		}
+		if request.Stream {
+			stream, errWithCode := chatProvider.CreateChatCompletionStream(&request)
+			if errWithCode != nil {
+				return "", errWithCode
+			}
+			return drainReplayStream(stream)
+		}
+		return marshalReplayResponse(chatProvider.CreateChatCompletion(&request))
+
+	case "/v1/embeddings":
+		embeddingsProvider, ok := provider.(providersBase.EmbeddingsInterface)
+		if !ok {
+			return "", replayError("channel not implemented")
+		}
+		var request types.EmbeddingRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return "", replayError(err.Error())
+		}
+		if err := mapReplayModel(provider, &request.Model, report); err != nil {
+			return "", replayError(err.Error())
+		}
+		return marshalReplayResponse(embeddingsProvider.CreateEmbeddings(&request))
+
+	case "/v1/responses":
+		responsesProvider, ok := provider.(providersBase.ResponsesInterface)
+		if !ok {
+			return "", replayError("channel not implemented")
+		}
+		var request types.OpenAIResponsesRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return "", replayError(err.Error())
+		}
+		if err := mapReplayModel(provider, &request.Model, report); err != nil {
+			return "", replayError(err.Error())
+		}
+		if request.Stream {
+			stream, errWithCode := responsesProvider.CreateResponsesStream(&request)
+			if errWithCode != nil {
+				return "", errWithCode
+			}
+			return drainReplayStream(stream)
+		}
+		return marshalReplayResponse(responsesProvider.CreateResponses(&request))
+	}
+
+	return "", replayError("暂不支持回放该接口：" + path)
+}
+
+func mapReplayModel(provider providersBase.ProviderInterface, modelName *string, report *ReplayReport) error {
+	provider.SetOriginalModel(*modelName)
+	newModelName, err := provider.ModelMappingHandler(*modelName)
+	if err != nil {
+		return err
+	}
+	*modelName = strings.TrimPrefix(newModelName, "+")
+	report.Model = *modelName
+	return nil
+}
+
+func marshalReplayResponse[T any](response T, errWithCode *types.OpenAIErrorWithStatusCode) (string, *types.OpenAIErrorWithStatusCode) {
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+	data, _ := json.MarshalIndent(response, "", "  ")
+	return string(data), nil
+}
+
+// drainReplayStream 读取完整的流式响应，原样拼接所有数据块
+func drainReplayStream(stream requester.StreamReaderInterface[string]) (string, *types.OpenAIErrorWithStatusCode) {
+	defer stream.Close()
+	dataChan, errChan := stream.Recv()
+
+	var sb strings.Builder
+	for {
+		select {
+		case data, ok := <-dataChan:
+			if !ok {
+				return sb.String(), nil
+			}
+			sb.WriteString(data)
+		case err := <-errChan:
+			if errors.Is(err, io.EOF) {
+				return sb.String(), nil
+			}
+			return sb.String(), replayError("stream error: " + err.Error())
+		}
+	}
+}
+
+func replayError(message string) *types.OpenAIErrorWithStatusCode {
+	return common.StringErrorWrapperLocal(message, "replay_error", http.StatusBadRequest)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/logstream"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	logStreamWriteTimeout = 10 * time.Second
+	logStreamPingInterval = 30 * time.Second
+)
+
+// 使用默认的同源检查，避免其他站点借用管理员的登录态建立连接
+var logStreamUpgrader = websocket.Upgrader{}
+
+// StreamLogs 通过 WebSocket 实时推送中继日志，可按用户、渠道、模型过滤或只查看错误
+func StreamLogs(c *gin.Context) {
+	var filter logstream.Filter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	filter.TenantScope = -1
+	if config.MultiTenantEnabled {
+		filter.TenantScope = c.GetInt("tenant_scope")
+	}
+
+	subscriber, err := logstream.Subscribe(filter)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	defer subscriber.Close()
+
+	conn, err := logStreamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// 读取客户端消息以处理 pong 与关闭连接
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(logStreamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(logStreamWriteTimeout)); err != nil {
+				return
+			}
+		case event := <-subscriber.Events():
+			if dropped := subscriber.TakeDropped(); dropped > 0 {
+				if err := writeLogStreamEvent(conn, &logstream.Event{Type: logstream.EventTypeDropped, Dropped: dropped}); err != nil {
+					return
+				}
+			}
+			if err := writeLogStreamEvent(conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeLogStreamEvent(conn *websocket.Conn, event *logstream.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(logStreamWriteTimeout))
+	return conn.WriteJSON(event)
+}
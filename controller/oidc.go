@@ -249,6 +249,7 @@ func OIDCAuth(c *gin.Context) {
 		}
 
 		// 在事务中创建用户
+		user.TenantId = c.GetInt("request_tenant_id")
 		return user.InsertWithTx(tx, user.InviterId)
 	})
 
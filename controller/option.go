@@ -2,7 +2,12 @@ package controller
 
 import (
 	"done-hub/common/config"
+	"done-hub/common/hook"
+	"done-hub/common/logexport"
+	"done-hub/common/pathrewrite"
+	"done-hub/common/stmp"
 	"done-hub/common/utils"
+	"done-hub/mcp/quota"
 	"done-hub/model"
 	"done-hub/safty"
 	"encoding/json"
@@ -133,6 +138,141 @@ func UpdateOption(c *gin.Context) {
 			})
 			return
 		}
+	case "UsageAnomalyRatio":
+		value, err := strconv.ParseFloat(option.Value, 64)
+		if err != nil || value <= 1 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "消费异常倍数必须大于 1",
+			})
+			return
+		}
+	case "UsageAnomalyMinQuota":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "消费异常最低额度必须为非负整数",
+			})
+			return
+		}
+	case "ChannelBalanceCheckInterval":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 10 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "余额检查间隔不能小于 10 分钟",
+			})
+			return
+		}
+	case "EmailProvider":
+		if !stmp.IsValidDriver(option.Value) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "不支持的邮件发送方式，可选值：smtp、sendgrid、mailgun、ses",
+			})
+			return
+		}
+	case "EmailRetryTimes":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 || value > 10 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "邮件重试次数必须为 0~10 之间的整数",
+			})
+			return
+		}
+	case "BillingWebhookRetryTimes":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 || value > 10 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "Webhook 重试次数必须为 0~10 之间的整数",
+			})
+			return
+		}
+	case "BillingWebhookURL":
+		if option.Value != "" && !strings.HasPrefix(option.Value, "http://") && !strings.HasPrefix(option.Value, "https://") {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "Webhook 地址必须以 http:// 或 https:// 开头",
+			})
+			return
+		}
+	case "BillingWebhookEnabled":
+		if option.Value == "true" && config.BillingWebhookURL == "" {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "无法启用计费 Webhook，请先填入 Webhook 地址！",
+			})
+			return
+		}
+	case "HookURL":
+		if option.Value != "" && !strings.HasPrefix(option.Value, "http://") && !strings.HasPrefix(option.Value, "https://") {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "钩子地址必须以 http:// 或 https:// 开头",
+			})
+			return
+		}
+	case "HookStages":
+		if !hook.IsValidStages(option.Value) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "不支持的钩子阶段，可选值：pre_auth、pre_upstream、post_upstream、post_billing",
+			})
+			return
+		}
+	case "HookTimeout":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 100 || value > 30000 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "钩子超时时间必须为 100~30000 毫秒之间的整数",
+			})
+			return
+		}
+	case "HookEnabled":
+		if option.Value == "true" && config.HookURL == "" {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "无法启用扩展钩子，请先填入钩子地址！",
+			})
+			return
+		}
+	case "LogExportFormat":
+		if !logexport.IsValidFormat(option.Value) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "不支持的日志导出格式，可选值：jsonl、parquet",
+			})
+			return
+		}
+	case "LogExportStorage":
+		if !logexport.IsValidStorage(option.Value) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "不支持的对象存储类型，可选值：s3、gcs",
+			})
+			return
+		}
+	case "LogExportInterval":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 5 || value > 1440 || 1440%value != 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "日志导出间隔必须在 5 ~ 1440 分钟之间，且能整除 1440",
+			})
+			return
+		}
+	case "LogExportEnabled":
+		if option.Value == "true" && (config.LogExportBucket == "" || config.LogExportAccessKeyId == "" || config.LogExportAccessKeySecret == "") {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "无法启用日志导出，请先填入 Bucket 以及访问密钥！",
+			})
+			return
+		}
 	case "QuotaForNewUser":
 		value, err := strconv.Atoi(option.Value)
 		if err != nil {
@@ -181,6 +321,65 @@ func UpdateOption(c *gin.Context) {
 			})
 			return
 		}
+	case "IdempotencyKeyTTL":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "幂等键缓存时间必须是非负整数",
+			})
+			return
+		}
+	case "StreamFirstByteTimeout":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "流式首字节超时时间必须是非负整数",
+			})
+			return
+		}
+	case "PathRewriteRules":
+		if _, err := pathrewrite.Parse(option.Value); err != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "路径重写规则无效：" + err.Error(),
+			})
+			return
+		}
+	case "MCPToolQuota":
+		if _, err := quota.Parse(option.Value); err != nil {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "MCP 工具额度配置无效：" + err.Error(),
+			})
+			return
+		}
+	case "GeoRestrictionAction":
+		if option.Value != "reject" && option.Value != "flag" {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "地区限制处理方式只能为 reject 或 flag",
+			})
+			return
+		}
+	case "RequestMaxBodySize", "RequestMaxMessages", "RequestMaxImageSize", "RequestMaxOutputTokens":
+		value, err := strconv.Atoi(option.Value)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "请求限制必须是非负整数",
+			})
+			return
+		}
+	case "InviteRewardTrigger":
+		if !model.IsValidInviteRewardTrigger(option.Value) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "邀请奖励发放时机只能为 signup 或 first_topup",
+			})
+			return
+		}
 	case "InviterRewardValue":
 		value, err := strconv.Atoi(option.Value)
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/spf13/viper"
 
@@ -73,6 +74,7 @@ func AddPrice(c *gin.Context) {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
 	}
+	model.RecordAppliedPriceVersion(&price, c.GetInt("id"))
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -99,6 +101,7 @@ func UpdatePrice(c *gin.Context) {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
 	}
+	model.RecordAppliedPriceVersion(&price, c.GetInt("id"))
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -205,3 +208,56 @@ func GetUpdatePriceService(c *gin.Context) {
 		"message": "",
 	})
 }
+
+func GetPriceVersions(c *gin.Context) {
+	versions, err := model.GetPriceVersions(c.Query("model"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    versions,
+	})
+}
+
+// AddPriceVersion 新增价格版本，effective_at 为空或已过去时立即生效，否则到期后由定时任务应用
+func AddPriceVersion(c *gin.Context) {
+	var version model.PriceVersion
+	if err := c.ShouldBindJSON(&version); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	version.CreatedBy = c.GetInt("id")
+
+	if err := model.CreatePriceVersion(&version); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    version,
+	})
+}
+
+func CancelPriceVersion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := model.CancelPriceVersion(id); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
@@ -2,14 +2,38 @@ package controller
 
 import (
 	"done-hub/common"
+	"done-hub/common/config"
 	"done-hub/common/utils"
 	"done-hub/model"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// 单个批次最多生成的兑换码数量
+	maxRedemptionBatchSize = 10000
+	// 兑换码前缀最大长度，兑换码总长度固定为 32
+	maxRedemptionPrefixLength = 12
+)
+
+var redemptionPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+type RedemptionBatchRequest struct {
+	Name        string `json:"name"`
+	Quota       int    `json:"quota"`
+	Count       int    `json:"count"`
+	Prefix      string `json:"prefix"`
+	ExpiredTime int64  `json:"expired_time"`
+	MaxUses     int    `json:"max_uses"`
+}
+
 func GetRedemptionsList(c *gin.Context) {
 	var params model.GenericParams
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -84,6 +108,16 @@ func AddRedemption(c *gin.Context) {
 		})
 		return
 	}
+	if redemption.MaxUses == 0 {
+		redemption.MaxUses = 1
+	}
+	if err := validateRedemptionLimits(redemption.ExpiredTime, redemption.MaxUses); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
 	var keys []string
 	for i := 0; i < redemption.Count; i++ {
 		key := utils.GetUUID()
@@ -93,6 +127,8 @@ func AddRedemption(c *gin.Context) {
 			Key:         key,
 			CreatedTime: utils.GetTimestamp(),
 			Quota:       redemption.Quota,
+			ExpiredTime: redemption.ExpiredTime,
+			MaxUses:     redemption.MaxUses,
 		}
 		err = cleanRedemption.Insert()
 		if err != nil {
@@ -153,6 +189,7 @@ func UpdateRedemption(c *gin.Context) {
 		// If you add more fields, please also update redemption.Update()
 		cleanRedemption.Name = redemption.Name
 		cleanRedemption.Quota = redemption.Quota
+		cleanRedemption.ExpiredTime = redemption.ExpiredTime
 	}
 	err = cleanRedemption.Update()
 	if err != nil {
@@ -168,3 +205,146 @@ func UpdateRedemption(c *gin.Context) {
 		"data":    cleanRedemption,
 	})
 }
+
+// AddRedemptionBatch 批量生成兑换码，同一批次共用批次 ID、前缀、过期时间与可兑换次数
+func AddRedemptionBatch(c *gin.Context) {
+	var req RedemptionBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if req.MaxUses == 0 {
+		req.MaxUses = 1
+	}
+	if err := validateRedemptionBatch(&req); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	batchId := utils.GetUUID()
+	now := utils.GetTimestamp()
+	redemptions := make([]*model.Redemption, 0, req.Count)
+	for i := 0; i < req.Count; i++ {
+		redemptions = append(redemptions, &model.Redemption{
+			UserId:      c.GetInt("id"),
+			Name:        req.Name,
+			Key:         req.Prefix + utils.GetUUID()[:32-len(req.Prefix)],
+			Quota:       req.Quota,
+			CreatedTime: now,
+			BatchId:     batchId,
+			ExpiredTime: req.ExpiredTime,
+			MaxUses:     req.MaxUses,
+		})
+	}
+
+	if err := model.InsertRedemptionBatch(redemptions); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"batch_id": batchId,
+			"count":    len(redemptions),
+		},
+	})
+}
+
+// ExportRedemptionBatch 以 CSV 格式下载批次中的所有兑换码
+func ExportRedemptionBatch(c *gin.Context) {
+	batchId := c.Param("batch_id")
+	redemptions, err := model.GetRedemptionsByBatchId(batchId)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	if len(redemptions) == 0 {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("批次不存在"))
+		return
+	}
+
+	filename := fmt.Sprintf("redemptions_%s_%s.csv", batchId, time.Now().Format("20060102_150405"))
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+
+	writer := csv.NewWriter(c.Writer)
+	defer writer.Flush()
+
+	headers := []string{"兑换码", "名称", "额度", "状态", "可兑换次数", "已兑换次数", "过期时间", "创建时间"}
+	if err := writer.Write(headers); err != nil {
+		return
+	}
+
+	for _, redemption := range redemptions {
+		expiredStr := "永不过期"
+		if redemption.ExpiredTime > 0 {
+			expiredStr = time.Unix(redemption.ExpiredTime, 0).Format("2006-01-02 15:04:05")
+		}
+		row := []string{
+			redemption.Key,
+			redemption.Name,
+			strconv.Itoa(redemption.Quota),
+			getRedemptionStatusText(redemption.Status),
+			strconv.Itoa(redemption.MaxUses),
+			strconv.Itoa(redemption.UsedCount),
+			expiredStr,
+			time.Unix(redemption.CreatedTime, 0).Format("2006-01-02 15:04:05"),
+		}
+		if err := writer.Write(row); err != nil {
+			return
+		}
+	}
+}
+
+// VoidRedemptionBatch 作废批次中尚未兑换完的兑换码，已兑换的额度不受影响
+func VoidRedemptionBatch(c *gin.Context) {
+	count, err := model.VoidRedemptionBatch(c.Param("batch_id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    count,
+	})
+}
+
+func validateRedemptionBatch(req *RedemptionBatchRequest) error {
+	if len(req.Name) == 0 || len(req.Name) > 20 {
+		return errors.New("兑换码名称长度必须在1-20之间")
+	}
+	if req.Count <= 0 || req.Count > maxRedemptionBatchSize {
+		return fmt.Errorf("兑换码个数必须在 1 ~ %d 之间", maxRedemptionBatchSize)
+	}
+	if len(req.Prefix) > maxRedemptionPrefixLength || !redemptionPrefixPattern.MatchString(req.Prefix) {
+		return fmt.Errorf("兑换码前缀最多 %d 个字符，只能包含字母、数字、下划线和短横线", maxRedemptionPrefixLength)
+	}
+	return validateRedemptionLimits(req.ExpiredTime, req.MaxUses)
+}
+
+func validateRedemptionLimits(expiredTime int64, maxUses int) error {
+	if expiredTime != 0 && expiredTime <= utils.GetTimestamp() {
+		return errors.New("过期时间必须晚于当前时间")
+	}
+	if maxUses < 1 {
+		return errors.New("可兑换次数必须大于 0")
+	}
+	return nil
+}
+
+func getRedemptionStatusText(status int) string {
+	switch status {
+	case config.RedemptionCodeStatusEnabled:
+		return "未使用"
+	case config.RedemptionCodeStatusDisabled:
+		return "已禁用"
+	case config.RedemptionCodeStatusUsed:
+		return "已使用"
+	}
+	return strconv.Itoa(status)
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetReferralReport 邀请转化报表，按邀请人汇总被邀请人数、首充转化人数、被邀请人充值额度与奖励
+// GET /api/user/referral/report?start_timestamp=&end_timestamp=&limit=
+func GetReferralReport(c *gin.Context) {
+	startTimestamp, _ := strconv.ParseInt(c.Query("start_timestamp"), 10, 64)
+	endTimestamp, _ := strconv.ParseInt(c.Query("end_timestamp"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	reports, err := model.GetReferralReport(startTimestamp, endTimestamp, limit)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    reports,
+	})
+}
+
+// GetSelfReferrals 当前用户的邀请记录
+func GetSelfReferrals(c *gin.Context) {
+	referrals, err := model.GetUserReferrals(c.GetInt("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    referrals,
+	})
+}
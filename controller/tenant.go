@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/model"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetTenants(c *gin.Context) {
+	var params model.SearchTenantParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	tenants, err := model.GetTenantsList(&params)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    tenants,
+	})
+}
+
+func GetTenantById(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	tenant, err := model.GetTenantById(id)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    tenant,
+	})
+}
+
+func AddTenant(c *gin.Context) {
+	tenant := model.Tenant{}
+	if err := c.ShouldBindJSON(&tenant); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := tenant.Insert(); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    tenant,
+	})
+}
+
+func UpdateTenant(c *gin.Context) {
+	tenant := model.Tenant{}
+	if err := c.ShouldBindJSON(&tenant); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if tenant.Id == 0 {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("租户 ID 不能为空"))
+		return
+	}
+
+	if err := tenant.Update(); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+func DeleteTenant(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	tenant, err := model.GetTenantById(id)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if err := tenant.Delete(); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+func GetTenantPrices(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	prices, err := model.GetTenantPrices(id)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    prices,
+	})
+}
+
+func SaveTenantPrice(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	price := model.TenantPrice{}
+	if err := c.ShouldBindJSON(&price); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	if _, err := model.GetTenantById(id); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	price.Id = 0
+	price.TenantId = id
+	if err := model.SaveTenantPrice(&price); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
+
+func DeleteTenantPrice(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if err := model.DeleteTenantPrice(id, c.Query("model")); err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+	})
+}
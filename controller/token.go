@@ -5,6 +5,7 @@ import (
 	"done-hub/common/config"
 	"done-hub/common/utils"
 	"done-hub/model"
+	"done-hub/relay/relay_util"
 	"errors"
 	"net/http"
 	"strconv"
@@ -310,5 +311,9 @@ func validateTokenSetting(setting *model.TokenSetting) error {
 		}
 	}
 
+	if !relay_util.IsValidReasoningFormat(setting.ReasoningFormat) {
+		return errors.New("reasoning format must be one of passthrough, strip, think")
+	}
+
 	return nil
 }
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SnippetLanguageCurl      = "curl"
+	SnippetLanguagePython    = "python"
+	SnippetLanguageNode      = "node"
+	SnippetLanguageLangChain = "langchain"
+)
+
+var snippetTemplates = map[string]string{
+	SnippetLanguageCurl: `curl %[1]s/chat/completions \
+  -H "Content-Type: application/json" \
+  -H "Authorization: Bearer %[2]s" \
+  -d '{
+    "model": "%[3]s",
+    "messages": [{"role": "user", "content": "Hello!"}]
+  }'
+`,
+	SnippetLanguagePython: `from openai import OpenAI
+
+client = OpenAI(
+    base_url="%[1]s",
+    api_key="%[2]s",
+)
+
+completion = client.chat.completions.create(
+    model="%[3]s",
+    messages=[{"role": "user", "content": "Hello!"}],
+)
+print(completion.choices[0].message.content)
+`,
+	SnippetLanguageNode: `import OpenAI from "openai";
+
+const client = new OpenAI({
+  baseURL: "%[1]s",
+  apiKey: "%[2]s",
+});
+
+const completion = await client.chat.completions.create({
+  model: "%[3]s",
+  messages: [{ role: "user", content: "Hello!" }],
+});
+console.log(completion.choices[0].message.content);
+`,
+	SnippetLanguageLangChain: `from langchain_openai import ChatOpenAI
+
+llm = ChatOpenAI(
+    base_url="%[1]s",
+    api_key="%[2]s",
+    model="%[3]s",
+)
+print(llm.invoke("Hello!").content)
+`,
+}
+
+// GetTokenSnippets 生成使用指定令牌调用接口的示例代码
+// 令牌默认脱敏显示，传入 reveal=true 时才填入完整令牌
+func GetTokenSnippets(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	userId := c.GetInt("id")
+
+	token, err := model.GetTokenByIds(id, userId)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+
+	models, err := getTokenAllowedModels(token)
+	if err != nil {
+		common.APIRespondWithError(c, http.StatusOK, err)
+		return
+	}
+	if len(models) == 0 {
+		common.APIRespondWithError(c, http.StatusOK, errors.New("该令牌没有可用的模型"))
+		return
+	}
+
+	modelName := c.Query("model")
+	if modelName == "" {
+		modelName = models[0]
+	} else if !slices.Contains(models, modelName) {
+		common.APIRespondWithError(c, http.StatusOK, fmt.Errorf("该令牌无权使用模型 %s", modelName))
+		return
+	}
+
+	reveal := c.Query("reveal") == "true"
+	apiKey := "sk-" + token.Key
+	if !reveal {
+		apiKey = maskTokenKey(apiKey)
+	}
+
+	baseURL := getSnippetBaseURL(token.UserId)
+	snippets := make(map[string]string, len(snippetTemplates))
+	for language, tmpl := range snippetTemplates {
+		snippets[language] = fmt.Sprintf(tmpl, baseURL, apiKey, modelName)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data": gin.H{
+			"base_url": baseURL,
+			"model":    modelName,
+			"models":   models,
+			"masked":   !reveal,
+			"snippets": snippets,
+		},
+	})
+}
+
+// getSnippetBaseURL 使用令牌所属租户的访问地址，统一返回带 /v1 的地址，避免客户端重复拼接出 /v1/v1
+func getSnippetBaseURL(userId int) string {
+	tenantId := model.DefaultTenantId
+	if config.MultiTenantEnabled {
+		tenantId, _ = model.CacheGetUserTenantId(userId)
+	}
+	baseURL := model.GlobalTenants.GetServerAddress(tenantId)
+	baseURL = strings.TrimSuffix(baseURL, "/v1")
+	return baseURL + "/v1"
+}
+
+// getTokenAllowedModels 令牌限制了模型时取限制列表与分组模型的交集，否则返回分组的全部模型
+func getTokenAllowedModels(token *model.Token) ([]string, error) {
+	group := token.Group
+	if group == "" {
+		userGroup, err := model.CacheGetUserGroup(token.UserId)
+		if err != nil {
+			return nil, err
+		}
+		group = userGroup
+	}
+
+	groupModels, err := model.ChannelGroup.GetGroupModels(group)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(groupModels)
+
+	limit := token.Setting.Data().Limits.LimitModelSetting
+	if !limit.Enabled || len(limit.Models) == 0 {
+		return groupModels, nil
+	}
+
+	models := make([]string, 0, len(limit.Models))
+	for _, modelName := range groupModels {
+		if slices.Contains(limit.Models, modelName) {
+			models = append(models, modelName)
+		}
+	}
+	return models, nil
+}
+
+func maskTokenKey(key string) string {
+	if len(key) <= 11 {
+		return "sk-****"
+	}
+	return key[:7] + strings.Repeat("*", 8) + key[len(key)-4:]
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGetTokenSnippets(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.User{}, &model.Token{}, &model.Tenant{}, &model.TenantPrice{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originRule := model.DB, model.ChannelGroup.Rule
+	originAddress, originMultiTenant := config.ServerAddress, config.MultiTenantEnabled
+	t.Cleanup(func() {
+		model.DB, model.ChannelGroup.Rule = originDB, originRule
+		config.ServerAddress, config.MultiTenantEnabled = originAddress, originMultiTenant
+		model.GlobalTenants.Load()
+	})
+	model.DB = db
+	model.ChannelGroup.Rule = map[string]map[string][][]int{
+		"default": {"gpt-4o": nil, "gpt-4o-mini": nil},
+	}
+	config.ServerAddress = "https://api.example.com/v1/"
+
+	session := db.Session(&gorm.Session{SkipHooks: true})
+	session.Create(&model.User{Id: 1, Username: "alice", AccessToken: "a", AffCode: "a", Group: "default", TenantId: 1})
+	session.Create(&model.Token{Id: 1, UserId: 1, Key: "abcdefghijklmnopqrstuvwxyz", Name: "default"})
+	session.Create(&model.Tenant{Id: 1, Code: "acme", Domains: "ai.acme.com, api.acme.com"})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/token/:id/snippets", func(c *gin.Context) {
+		c.Set("id", 1)
+		GetTokenSnippets(c)
+	})
+
+	type snippetResponse struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+		Data    struct {
+			BaseURL  string            `json:"base_url"`
+			Model    string            `json:"model"`
+			Masked   bool              `json:"masked"`
+			Snippets map[string]string `json:"snippets"`
+		} `json:"data"`
+	}
+	get := func(query string) snippetResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/token/1/snippets"+query, nil))
+		var resp snippetResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := get("")
+	if !resp.Success || resp.Data.BaseURL != "https://api.example.com/v1" || resp.Data.Model != "gpt-4o" || !resp.Data.Masked {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if strings.Contains(resp.Data.Snippets[SnippetLanguageCurl], "abcdefghijklmnop") {
+		t.Fatal("token key should be masked by default")
+	}
+
+	resp = get("?model=gpt-4o-mini&reveal=true")
+	if !resp.Success || resp.Data.Masked || !strings.Contains(resp.Data.Snippets[SnippetLanguagePython], "sk-abcdefghijklmnopqrstuvwxyz") {
+		t.Fatalf("token key should be revealed: %+v", resp)
+	}
+
+	if resp = get("?model=claude-3"); resp.Success {
+		t.Fatal("model outside the token group should be rejected")
+	}
+
+	// 开启多租户后使用租户绑定的域名
+	config.MultiTenantEnabled = true
+	model.GlobalTenants.Load()
+	if resp = get(""); resp.Data.BaseURL != "https://ai.acme.com/v1" {
+		t.Fatalf("expected tenant base url, got %s", resp.Data.BaseURL)
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"done-hub/common/utils"
+	"done-hub/model"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// usageMaxDays 单次查询的最大天数
+const usageMaxDays = 366
+
+// GetUserUsage 查询当前用户自己的用量，按天、令牌或模型分组，默认最近 7 天按天分组
+func GetUserUsage(c *gin.Context) {
+	params := model.UsageQueryParams{}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	// 使用 TZ 环境变量的时区，与 UpdateStatistics 保持一致
+	location := utils.GetLocalLocation()
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	if params.GroupBy == "" {
+		params.GroupBy = model.UsageGroupByDay
+	}
+	if params.EndDate == "" {
+		params.EndDate = today.Format("2006-01-02")
+	}
+	if params.StartDate == "" {
+		params.StartDate = today.AddDate(0, 0, -6).Format("2006-01-02")
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", params.StartDate, location)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "start_date 格式错误，应为 YYYY-MM-DD",
+		})
+		return
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", params.EndDate, location)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "end_date 格式错误，应为 YYYY-MM-DD",
+		})
+		return
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) >= usageMaxDays*24*time.Hour {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "查询范围不能超过 366 天，且结束日期不能早于开始日期",
+		})
+		return
+	}
+
+	usage, err := model.GetUserUsageSummary(c.GetInt("id"), &params)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "",
+		"data":    usage,
+	})
+}
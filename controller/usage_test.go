@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"done-hub/common"
+	"done-hub/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGetUserUsage(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.TokenStatistics{}, &model.Token{}); err != nil {
+		t.Fatal(err)
+	}
+	originDB, originSQLite := model.DB, common.UsingSQLite
+	t.Cleanup(func() { model.DB, common.UsingSQLite = originDB, originSQLite })
+	model.DB, common.UsingSQLite = db, true
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/usage", func(c *gin.Context) {
+		c.Set("id", 1)
+		GetUserUsage(c)
+	})
+
+	tests := []struct {
+		query   string
+		success bool
+	}{
+		{"", true},
+		{"?group_by=token&start_date=2026-01-01&end_date=2026-12-31", true},
+		{"?start_date=2026/01/01", false},
+		{"?end_date=bad", false},
+		{"?start_date=2026-02-01&end_date=2026-01-01", false},
+		{"?start_date=2025-01-01&end_date=2026-01-02", false},
+		{"?group_by=channel", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/usage"+tt.query, nil))
+
+		var resp struct {
+			Success bool   `json:"success"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Success != tt.success {
+			t.Errorf("query %q: success = %v, message = %s", tt.query, resp.Success, resp.Message)
+		}
+	}
+}
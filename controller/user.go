@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"math"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
@@ -114,6 +113,15 @@ func Login(c *gin.Context) {
 
 // setup session & cookies and then return user info
 func setupLogin(user *model.User, c *gin.Context) {
+	// 多租户模式下只能登录所属租户的站点，超级管理员除外
+	if config.MultiTenantEnabled && user.Role < config.RoleRootUser && user.TenantId != c.GetInt("request_tenant_id") {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "该账号不属于当前站点",
+			"success": false,
+		})
+		return
+	}
+
 	session := sessions.Default(c)
 	session.Set("id", user.Id)
 	session.Set("username", user.Username)
@@ -132,6 +140,11 @@ func setupLogin(user *model.User, c *gin.Context) {
 
 	user.Update(false)
 
+	// 长期未活跃被标记或暂停的账户登录后自动恢复
+	if user.InactiveFlaggedAt > 0 || user.InactivePausedAt > 0 {
+		model.ResumeInactiveUser(user.Id)
+	}
+
 	cleanUser := model.User{
 		Id:          user.Id,
 		AvatarUrl:   user.AvatarUrl,
@@ -300,6 +313,7 @@ func Register(c *gin.Context) {
 	// 使用事务确保用户创建和邀请码使用的原子性
 	err = model.DB.Transaction(func(tx *gorm.DB) error {
 		// 在事务中创建用户
+		cleanUser.TenantId = c.GetInt("request_tenant_id")
 		if err := cleanUser.InsertWithTx(tx, inviterId); err != nil {
 			return err
 		}
@@ -346,7 +360,7 @@ func GetUsersList(c *gin.Context) {
 		return
 	}
 
-	users, err := model.GetUsersList(&params)
+	users, err := model.GetUsersList(&params, c.GetInt("tenant_scope"))
 	if err != nil {
 		common.APIRespondWithError(c, http.StatusOK, err)
 		return
@@ -438,12 +452,7 @@ func GetUserDashboard(c *gin.Context) {
 	id := c.GetInt("id")
 
 	// 使用 TZ 环境变量的时区，与 UpdateStatistics 保持一致
-	location := time.Local
-	if tzEnv := os.Getenv("TZ"); tzEnv != "" {
-		if loc, err := time.LoadLocation(tzEnv); err == nil {
-			location = loc
-		}
-	}
+	location := utils.GetLocalLocation()
 	now := time.Now().In(location)
 	toDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
 	endOfDay := toDay.Add(-time.Second).Add(time.Hour * 24).Format("2006-01-02")
@@ -598,6 +607,15 @@ func UpdateUser(c *gin.Context) {
 		})
 		return
 	}
+	if !model.TenantAccessible(c.GetInt("tenant_scope"), originUser.TenantId) {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "无权操作其他租户的数据",
+		})
+		return
+	}
+	// 用户所属租户只在创建时指定
+	updatedUser.TenantId = originUser.TenantId
 	if myRole <= updatedUser.Role && myRole != config.RoleRootUser {
 		c.JSON(http.StatusOK, gin.H{
 			"success": false,
@@ -770,6 +788,11 @@ func CreateUser(c *gin.Context) {
 		Username:    user.Username,
 		Password:    user.Password,
 		DisplayName: user.DisplayName,
+		TenantId:    c.GetInt("tenant_id"),
+	}
+	// 超级管理员可以为任意租户创建用户
+	if myRole >= config.RoleRootUser {
+		cleanUser.TenantId = user.TenantId
 	}
 	if err := cleanUser.Insert(0); err != nil {
 		c.JSON(http.StatusOK, gin.H{
@@ -827,6 +850,13 @@ func ManageUser(c *gin.Context) {
 		})
 		return
 	}
+	if !model.TenantAccessible(c.GetInt("tenant_scope"), user.TenantId) {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "无权操作其他租户的数据",
+		})
+		return
+	}
 	switch req.Action {
 	case "disable":
 		user.Status = config.UserStatusDisabled
@@ -101,6 +101,7 @@ func WeChatAuth(c *gin.Context) {
 				}
 
 				// 在事务中创建用户
+				user.TenantId = c.GetInt("request_tenant_id")
 				return user.InsertWithTx(tx, 0)
 			})
 
@@ -0,0 +1,136 @@
+package cron
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/notify"
+	"done-hub/model"
+	"done-hub/providers"
+	providersBase "done-hub/providers/base"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	channelBalanceLastRun time.Time
+	// depletedChannels 已通知过余额耗尽的渠道，余额恢复后移除，避免重复通知
+	depletedChannels     = make(map[int]bool)
+	channelBalanceLocker sync.Mutex
+)
+
+// RunChannelBalanceCheck 定时查询已启用渠道的上游余额并记录历史，余额耗尽时发送通知，按配置自动禁用渠道
+func RunChannelBalanceCheck() {
+	if !config.ChannelBalanceCheckEnabled {
+		return
+	}
+
+	channelBalanceLocker.Lock()
+	if time.Since(channelBalanceLastRun) < time.Duration(config.ChannelBalanceCheckInterval)*time.Minute {
+		channelBalanceLocker.Unlock()
+		return
+	}
+	channelBalanceLastRun = time.Now()
+	channelBalanceLocker.Unlock()
+
+	channels, err := model.GetAllChannels()
+	if err != nil {
+		logger.SysError("Channel balance check failed: " + err.Error())
+		return
+	}
+
+	enabled := make(map[int]bool, len(channels))
+	for _, channel := range channels {
+		if channel.Status == config.ChannelStatusEnabled {
+			enabled[channel.Id] = true
+		}
+	}
+	// 禁用或删除的渠道不再查询，移除其耗尽记录，重新启用后余额仍然耗尽时会再次通知与禁用
+	pruneDepletedChannels(enabled)
+
+	var sb strings.Builder
+	checked := 0
+	for _, channel := range channels {
+		if !enabled[channel.Id] {
+			continue
+		}
+
+		notice, ok := checkChannelBalance(channel)
+		if !ok {
+			continue
+		}
+		checked++
+		sb.WriteString(notice)
+
+		time.Sleep(config.RequestInterval)
+	}
+
+	if err := model.CleanChannelBalanceHistory(); err != nil {
+		logger.SysError("failed to clean channel balance history: " + err.Error())
+	}
+
+	logger.SysLog(fmt.Sprintf("Channel balance check finished, %d channels checked", checked))
+	if sb.Len() == 0 {
+		return
+	}
+
+	logger.SysLog("Channel balance depleted:\n" + sb.String())
+	notify.Send("渠道余额耗尽提醒", fmt.Sprintf("以下渠道的上游余额已不高于 %.2f：\n\n%s", config.ChannelBalanceThreshold, sb.String()))
+}
+
+// checkChannelBalance 查询并记录单个渠道的余额，ok 为 false 表示渠道不支持或查询失败，余额刚耗尽时返回通知内容
+func checkChannelBalance(channel *model.Channel) (notice string, ok bool) {
+	detail, err := providers.QueryChannelBalance(channel)
+	if err != nil {
+		if !errors.Is(err, providersBase.ErrBalanceNotSupported) {
+			logger.SysError(fmt.Sprintf("Channel #%d balance check failed: %s", channel.Id, err.Error()))
+		}
+		return "", false
+	}
+
+	if err := model.RecordChannelBalance(channel.Id, detail.Currency, detail.Balance, detail.ChannelBalance); err != nil {
+		logger.SysError("failed to record channel balance: " + err.Error())
+	}
+
+	if !markChannelDepleted(channel.Id, detail.ChannelBalance <= config.ChannelBalanceThreshold) {
+		return "", true
+	}
+
+	action := "请及时充值"
+	if config.ChannelBalanceAutoDisable {
+		model.UpdateChannelStatusById(channel.Id, config.ChannelStatusAutoDisabled)
+		action = "已自动禁用"
+	}
+
+	return fmt.Sprintf("- 渠道 #%d %s：余额 %.4f %s，%s\n", channel.Id, channel.Name, detail.Balance, detail.Currency, action), true
+}
+
+// markChannelDepleted 更新渠道的耗尽状态，返回 true 表示渠道刚刚进入耗尽状态
+func markChannelDepleted(channelId int, depleted bool) bool {
+	channelBalanceLocker.Lock()
+	defer channelBalanceLocker.Unlock()
+
+	if !depleted {
+		delete(depletedChannels, channelId)
+		return false
+	}
+	if depletedChannels[channelId] {
+		return false
+	}
+	depletedChannels[channelId] = true
+	return true
+}
+
+// pruneDepletedChannels 移除不在 enabled 中的渠道的耗尽记录
+func pruneDepletedChannels(enabled map[int]bool) {
+	channelBalanceLocker.Lock()
+	defer channelBalanceLocker.Unlock()
+
+	for channelId := range depletedChannels {
+		if !enabled[channelId] {
+			delete(depletedChannels, channelId)
+		}
+	}
+}
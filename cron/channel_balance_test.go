@@ -0,0 +1,31 @@
+package cron
+
+import "testing"
+
+func TestMarkChannelDepleted(t *testing.T) {
+	t.Cleanup(func() { depletedChannels = make(map[int]bool) })
+
+	// 只在刚进入耗尽状态时通知一次
+	if !markChannelDepleted(1, true) {
+		t.Fatal("channel should be reported when it becomes depleted")
+	}
+	if markChannelDepleted(1, true) {
+		t.Fatal("depleted channel should be reported only once")
+	}
+
+	// 余额恢复后再次耗尽需要重新通知
+	markChannelDepleted(1, false)
+	if !markChannelDepleted(1, true) {
+		t.Fatal("channel should be reported again after recovering")
+	}
+
+	// 渠道被禁用后移除记录，重新启用时余额仍然耗尽需要再次通知
+	markChannelDepleted(2, true)
+	pruneDepletedChannels(map[int]bool{2: true})
+	if !markChannelDepleted(1, true) {
+		t.Fatal("disabled channel should be removed from depleted channels")
+	}
+	if markChannelDepleted(2, true) {
+		t.Fatal("enabled channel should keep its depleted state")
+	}
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"context"
+	"done-hub/common/cache"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"done-hub/providers/claudecode"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// claudeCodeCredentialRefreshThreshold Claude Code 的 access_token 有效期只有数小时，剩余不足 1 小时即刷新
+const claudeCodeCredentialRefreshThreshold = time.Hour
+
+var claudeCodeCredentialRefresher = &channelCredentialRefresher{
+	name:        "ClaudeCode",
+	channelType: config.ChannelTypeClaudeCode,
+	refresh:     refreshClaudeCodeChannelIfExpiring,
+	nonRetryable: func(err error) bool {
+		return strings.Contains(err.Error(), "non-retryable")
+	},
+	reloadChannels: true,
+}
+
+// RunClaudeCodeCredentialAutoRefresh 执行一次 Claude Code 凭证自动刷新检查
+// 扫描所有启用的 Claude Code 渠道，对即将过期的凭证自动刷新；直接填写 access_token（如 claude setup-token 生成的长期令牌）的渠道不参与
+func RunClaudeCodeCredentialAutoRefresh() {
+	claudeCodeCredentialRefresher.Run(context.Background())
+}
+
+func refreshClaudeCodeChannelIfExpiring(ctx context.Context, ch *model.Channel) (bool, error) {
+	creds, err := claudecode.FromJSON(strings.TrimSpace(ch.Key))
+	if err != nil || strings.TrimSpace(creds.RefreshToken) == "" {
+		return false, nil
+	}
+
+	if !creds.ExpiresAt.IsZero() && time.Until(creds.ExpiresAt) > claudeCodeCredentialRefreshThreshold {
+		return false, nil
+	}
+
+	if err := RefreshClaudeCodeChannelCredentialInternal(ctx, ch, creds); err != nil {
+		return false, err
+	}
+
+	logger.SysLog(fmt.Sprintf("[ClaudeCode] Credential auto-refresh: channel_id=%d name=%s refreshed, expires_at=%s",
+		ch.Id, ch.Name, creds.ExpiresAt.Format(time.RFC3339)))
+	return true, nil
+}
+
+// RefreshClaudeCodeChannelCredentialInternal 刷新单个渠道的 Claude Code 凭证（内部方法）
+func RefreshClaudeCodeChannelCredentialInternal(ctx context.Context, ch *model.Channel, creds *claudecode.OAuth2Credentials) error {
+	proxyURL := ""
+	if ch.Proxy != nil && *ch.Proxy != "" {
+		proxyURL = *ch.Proxy
+	}
+
+	if creds.ClientID == "" {
+		creds.ClientID = claudecode.DefaultClientID
+	}
+
+	if err := creds.Refresh(ctx, proxyURL, 3); err != nil {
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+
+	credentialsJSON, err := creds.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to serialize credentials: %w", err)
+	}
+
+	if err := model.UpdateChannelKey(ch.Id, credentialsJSON); err != nil {
+		return fmt.Errorf("failed to update channel key: %w", err)
+	}
+
+	// 清除中继时缓存的旧 token
+	_ = cache.DeleteCache(fmt.Sprintf("%s:%d", claudecode.TokenCacheKey, ch.Id))
+
+	return nil
+}
+
+// RefreshClaudeCodeChannelCredentialByID 手动刷新指定渠道的 Claude Code 凭证（供控制器调用）
+func RefreshClaudeCodeChannelCredentialByID(ctx context.Context, channelID int) (expiresAt string, err error) {
+	ch, dbErr := model.GetChannelById(channelID)
+	if dbErr != nil {
+		err = dbErr
+		return
+	}
+	if ch == nil {
+		err = fmt.Errorf("channel not found")
+		return
+	}
+	if ch.Type != config.ChannelTypeClaudeCode {
+		err = fmt.Errorf("channel type is not ClaudeCode")
+		return
+	}
+
+	creds, parseErr := claudecode.FromJSON(strings.TrimSpace(ch.Key))
+	if parseErr != nil || strings.TrimSpace(creds.RefreshToken) == "" {
+		err = fmt.Errorf("refresh_token is required to refresh credential")
+		return
+	}
+
+	if refreshErr := RefreshClaudeCodeChannelCredentialInternal(ctx, ch, creds); refreshErr != nil {
+		err = refreshErr
+		return
+	}
+
+	expiresAt = creds.ExpiresAt.Format(time.RFC3339)
+	return
+}
@@ -8,129 +8,54 @@ import (
 	"done-hub/providers/codex"
 	"fmt"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-const (
-	// codexCredentialRefreshThreshold 凭证过期时间不足此阈值时触发刷新
-	codexCredentialRefreshThreshold = 24 * time.Hour
-	// codexCredentialRefreshBatchSize 每批查询的渠道数量
-	codexCredentialRefreshBatchSize = 200
-	// codexCredentialRefreshTimeout 每次刷新操作的超时时间
-	codexCredentialRefreshTimeout = 15 * time.Second
-	// 不可重试的凭证错误只做运行时熔断，不再自动改写渠道状态
-	codexCredentialFailureCircuitBreakSeconds int64 = 3600
-)
-
-var codexCredentialRefreshRunning atomic.Bool
+// codexCredentialRefreshThreshold 凭证过期时间不足此阈值时触发刷新
+const codexCredentialRefreshThreshold = 24 * time.Hour
+
+var codexCredentialRefresher = &channelCredentialRefresher{
+	name:        "Codex",
+	channelType: config.ChannelTypeCodex,
+	refresh:     refreshCodexChannelIfExpiring,
+	nonRetryable: func(err error) bool {
+		return strings.Contains(err.Error(), "non-retryable")
+	},
+	reloadChannels: true,
+}
 
 // RunCodexCredentialAutoRefresh 执行一次 Codex 凭证自动刷新检查
 // 扫描所有启用的 Codex 渠道，对即将过期的凭证自动刷新
 func RunCodexCredentialAutoRefresh() {
-	if !codexCredentialRefreshRunning.CompareAndSwap(false, true) {
-		logger.SysLog("[Codex] Credential auto-refresh already running, skipping")
-		return
+	codexCredentialRefresher.Run(context.Background())
+}
+
+func refreshCodexChannelIfExpiring(ctx context.Context, ch *model.Channel) (bool, error) {
+	rawKey := strings.TrimSpace(ch.Key)
+	if rawKey == "" {
+		return false, nil
+	}
+
+	// 不是 JSON 凭证或没有 refresh_token 的不参与自动刷新
+	creds, err := codex.FromJSON(rawKey)
+	if err != nil || strings.TrimSpace(creds.RefreshToken) == "" {
+		return false, nil
 	}
-	defer codexCredentialRefreshRunning.Store(false)
-
-	ctx := context.Background()
-
-	var refreshed int
-	var scanned int
-	var failed int
-
-	offset := 0
-	for {
-		var channels []*model.Channel
-		err := model.DB.
-			Select("id", "name", "key", "status", "proxy").
-			Where("type = ? AND status = 1", config.ChannelTypeCodex).
-			Order("id asc").
-			Limit(codexCredentialRefreshBatchSize).
-			Offset(offset).
-			Find(&channels).Error
-		if err != nil {
-			logger.SysError(fmt.Sprintf("[Codex] Credential auto-refresh: query channels failed: %v", err))
-			return
-		}
-		if len(channels) == 0 {
-			break
-		}
-		offset += codexCredentialRefreshBatchSize
-
-		for _, ch := range channels {
-			if ch == nil {
-				continue
-			}
-			scanned++
-
-			rawKey := strings.TrimSpace(ch.Key)
-			if rawKey == "" {
-				continue
-			}
-
-			// 尝试解析为 JSON 凭证
-			creds, err := codex.FromJSON(rawKey)
-			if err != nil {
-				continue
-			}
-
-			// 没有 refresh_token 的不参与自动刷新
-			if strings.TrimSpace(creds.RefreshToken) == "" {
-				continue
-			}
-
-			// 检查是否需要刷新: 过期时间不足阈值
-			if !creds.ExpiresAt.IsZero() && time.Until(creds.ExpiresAt) > codexCredentialRefreshThreshold {
-				continue
-			}
-
-			// 执行刷新
-			refreshCtx, cancel := context.WithTimeout(ctx, codexCredentialRefreshTimeout)
-			err = RefreshCodexChannelCredentialInternal(refreshCtx, ch, creds)
-			cancel()
-
-			if err != nil {
-				failed++
-				logger.SysError(fmt.Sprintf("[Codex] Credential auto-refresh: channel_id=%d name=%s refresh failed: %v",
-					ch.Id, ch.Name, err))
-
-				// 如果是不可重试的错误（如 refresh_token 过期），临时熔断渠道而不是自动禁用
-				if strings.Contains(err.Error(), "non-retryable") {
-					if !model.ChannelGroup.IsChannelInCooldown(ch.Id) {
-						model.ChannelGroup.SetChannelCooldownsWithDuration(ch.Id, codexCredentialFailureCircuitBreakSeconds)
-					}
-					logger.SysError(fmt.Sprintf("[Codex] Credential auto-refresh: channel_id=%d name=%s has non-retryable error, circuit-breaking for %ds instead of disabling",
-						ch.Id, ch.Name, codexCredentialFailureCircuitBreakSeconds))
-				}
-				continue
-			}
-
-			refreshed++
-			logger.SysLog(fmt.Sprintf("[Codex] Credential auto-refresh: channel_id=%d name=%s refreshed, expires_at=%s",
-				ch.Id, ch.Name, creds.ExpiresAt.Format(time.RFC3339)))
-		}
-	}
-
-	// 如果有刷新成功的，重新加载渠道缓存
-	if refreshed > 0 {
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					logger.SysError(fmt.Sprintf("[Codex] Credential auto-refresh: ChannelGroup.Load panic: %v", r))
-				}
-			}()
-			model.ChannelGroup.Load()
-		}()
-	}
-
-	if scanned > 0 || refreshed > 0 || failed > 0 {
-		logger.SysLog(fmt.Sprintf("[Codex] Credential auto-refresh completed: scanned=%d refreshed=%d failed=%d",
-			scanned, refreshed, failed))
+
+	// 检查是否需要刷新: 过期时间不足阈值
+	if !creds.ExpiresAt.IsZero() && time.Until(creds.ExpiresAt) > codexCredentialRefreshThreshold {
+		return false, nil
 	}
+
+	if err := RefreshCodexChannelCredentialInternal(ctx, ch, creds); err != nil {
+		return false, err
+	}
+
+	logger.SysLog(fmt.Sprintf("[Codex] Credential auto-refresh: channel_id=%d name=%s refreshed, expires_at=%s",
+		ch.Id, ch.Name, creds.ExpiresAt.Format(time.RFC3339)))
+	return true, nil
 }
 
 // RefreshCodexChannelCredentialInternal 刷新单个渠道的 Codex 凭证（内部方法）
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/model"
+	"done-hub/providers/copilot"
+	"errors"
+	"strings"
+	"time"
+)
+
+// copilotTokenRefreshThreshold Copilot token 有效期约 30 分钟，剩余不足此阈值时提前换取
+const copilotTokenRefreshThreshold = 15 * time.Minute
+
+var copilotTokenRefresher = &channelCredentialRefresher{
+	name:        "Copilot",
+	channelType: config.ChannelTypeCopilot,
+	refresh:     refreshCopilotTokenIfExpiring,
+	// GitHub token 失效或没有 Copilot 订阅
+	nonRetryable: func(err error) bool {
+		var tokenErr *copilot.TokenError
+		return errors.As(err, &tokenErr) && tokenErr.Unauthorized
+	},
+}
+
+// RunCopilotTokenRefresh 执行一次 Copilot token 换取检查
+// 扫描所有启用的 Copilot 渠道，提前换取即将过期的 Copilot token，避免请求时同步等待
+func RunCopilotTokenRefresh() {
+	copilotTokenRefresher.Run(context.Background())
+}
+
+func refreshCopilotTokenIfExpiring(ctx context.Context, ch *model.Channel) (bool, error) {
+	if strings.TrimSpace(ch.Key) == "" {
+		return false, nil
+	}
+
+	if token := copilot.GetCachedToken(ch.Id); token != nil && !token.ExpiresWithin(copilotTokenRefreshThreshold) {
+		return false, nil
+	}
+
+	proxyURL := ""
+	if ch.Proxy != nil && *ch.Proxy != "" {
+		proxyURL = *ch.Proxy
+	}
+
+	if _, err := copilot.RefreshToken(ctx, ch.Id, ch.Key, proxyURL); err != nil {
+		return false, err
+	}
+	return true, nil
+}
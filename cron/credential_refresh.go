@@ -0,0 +1,113 @@
+package cron
+
+import (
+	"context"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// credentialRefreshBatchSize 每批查询的渠道数量
+	credentialRefreshBatchSize = 200
+	// credentialRefreshTimeout 每次刷新操作的超时时间
+	credentialRefreshTimeout = 15 * time.Second
+	// 不可重试的凭证错误只做运行时熔断，不再自动改写渠道状态
+	credentialFailureCircuitBreakSeconds int64 = 3600
+)
+
+// channelCredentialRefresher 定时刷新某一类渠道的凭证，扫描、超时、熔断与统计由此统一处理，
+// 各渠道类型只需提供单个渠道的刷新方法
+type channelCredentialRefresher struct {
+	// name 日志前缀，如 Codex、ClaudeCode
+	name        string
+	channelType int
+	running     atomic.Bool
+	// refresh 刷新单个渠道的凭证，凭证无需刷新或不支持刷新时返回 false
+	refresh func(ctx context.Context, ch *model.Channel) (bool, error)
+	// nonRetryable 判断刷新错误是否不可重试（如 refresh_token 失效），不可重试时临时熔断渠道
+	nonRetryable func(err error) bool
+	// reloadChannels 刷新成功后是否重新加载渠道缓存，凭证保存在渠道 key 中时需要
+	reloadChannels bool
+}
+
+// Run 扫描所有启用的该类型渠道并刷新凭证，上一次执行未结束时跳过
+func (r *channelCredentialRefresher) Run(ctx context.Context) {
+	if !r.running.CompareAndSwap(false, true) {
+		logger.SysLog(fmt.Sprintf("[%s] Credential refresh already running, skipping", r.name))
+		return
+	}
+	defer r.running.Store(false)
+
+	var refreshed int
+	var scanned int
+	var failed int
+
+	offset := 0
+	for ctx.Err() == nil {
+		var channels []*model.Channel
+		err := model.DB.
+			Select("id", "name", "key", "status", "proxy").
+			Where("type = ? AND status = 1", r.channelType).
+			Order("id asc").
+			Limit(credentialRefreshBatchSize).
+			Offset(offset).
+			Find(&channels).Error
+		if err != nil {
+			logger.SysError(fmt.Sprintf("[%s] Credential refresh: query channels failed: %v", r.name, err))
+			return
+		}
+		if len(channels) == 0 {
+			break
+		}
+		offset += credentialRefreshBatchSize
+
+		for _, ch := range channels {
+			if ch == nil {
+				continue
+			}
+			scanned++
+
+			refreshCtx, cancel := context.WithTimeout(ctx, credentialRefreshTimeout)
+			ok, err := r.refresh(refreshCtx, ch)
+			cancel()
+
+			if err != nil {
+				failed++
+				logger.SysError(fmt.Sprintf("[%s] Credential refresh: channel_id=%d name=%s refresh failed: %v",
+					r.name, ch.Id, ch.Name, err))
+
+				if r.nonRetryable != nil && r.nonRetryable(err) {
+					if !model.ChannelGroup.IsChannelInCooldown(ch.Id) {
+						model.ChannelGroup.SetChannelCooldownsWithDuration(ch.Id, credentialFailureCircuitBreakSeconds)
+					}
+					logger.SysError(fmt.Sprintf("[%s] Credential refresh: channel_id=%d name=%s has non-retryable error, circuit-breaking for %ds instead of disabling",
+						r.name, ch.Id, ch.Name, credentialFailureCircuitBreakSeconds))
+				}
+				continue
+			}
+
+			if ok {
+				refreshed++
+			}
+		}
+	}
+
+	if refreshed > 0 && r.reloadChannels {
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					logger.SysError(fmt.Sprintf("[%s] Credential refresh: ChannelGroup.Load panic: %v", r.name, p))
+				}
+			}()
+			model.ChannelGroup.Load()
+		}()
+	}
+
+	if refreshed > 0 || failed > 0 {
+		logger.SysLog(fmt.Sprintf("[%s] Credential refresh completed: scanned=%d refreshed=%d failed=%d",
+			r.name, scanned, refreshed, failed))
+	}
+}
@@ -0,0 +1,37 @@
+package cron
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/notify"
+	"done-hub/model"
+	"fmt"
+	"sync/atomic"
+)
+
+var inactiveUserPauseRunning atomic.Bool
+
+// RunInactiveUserPause 执行一次不活跃账户检查
+func RunInactiveUserPause() {
+	if !config.InactiveUserPauseEnabled {
+		return
+	}
+	if !inactiveUserPauseRunning.CompareAndSwap(false, true) {
+		logger.SysLog("Inactive user pause already running, skipping")
+		return
+	}
+	defer inactiveUserPauseRunning.Store(false)
+
+	result, err := model.RunInactiveUserPolicy()
+	if err != nil {
+		logger.SysError("Inactive user pause failed: " + err.Error())
+	}
+
+	message := fmt.Sprintf("标记不活跃账户 %d 个，暂停账户 %d 个，恢复活跃账户 %d 个，回收赠送额度 %d",
+		result.Flagged, result.Paused, result.Reactivated, result.ReclaimedQuota)
+	logger.SysLog("Inactive user pause done: " + message)
+
+	if result.Flagged > 0 || result.Paused > 0 {
+		notify.Send("不活跃账户处理", message)
+	}
+}
@@ -0,0 +1,185 @@
+package cron
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logexport"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// logExportDelay 批次结束后等待的时间，避免遗漏异步写入的日志
+	logExportDelay = 5 * time.Minute
+	// logExportPageSize 每次从数据库读取的日志条数
+	logExportPageSize = 5000
+	// logExportMaxBatches 单次任务最多导出的批次数，积压较多时分多次追赶，避免长时间占用数据库
+	logExportMaxBatches = 24
+)
+
+var logExportLocker sync.Mutex
+
+// RunLogExport 将已结束批次的日志导出到对象存储。批次按 LogExportInterval 对齐（UTC），
+// 首次启用时从当天零点（UTC）开始导出，之后从最后一个已导出批次的结束时间继续
+func RunLogExport() {
+	if !config.LogExportEnabled {
+		return
+	}
+
+	// 单个批次数据量较大时任务可能超过调度间隔，跳过重叠的执行
+	if !logExportLocker.TryLock() {
+		return
+	}
+	defer logExportLocker.Unlock()
+
+	interval := int64(config.LogExportInterval) * 60
+	if interval <= 0 {
+		return
+	}
+
+	start, err := nextLogExportStart(interval)
+	if err != nil {
+		logger.SysError("Log export error: " + err.Error())
+		return
+	}
+
+	deadline := time.Now().Add(-logExportDelay).Unix()
+	for i := 0; i < logExportMaxBatches && start+interval <= deadline; i++ {
+		if err := exportLogBatch(start, start+interval); err != nil {
+			logger.SysError(fmt.Sprintf("Log export batch %d error: %s", start, err.Error()))
+			return
+		}
+		start += interval
+	}
+}
+
+func nextLogExportStart(interval int64) (int64, error) {
+	last, err := model.GetLastLogExportBatch()
+	if err != nil {
+		return 0, err
+	}
+	if last != nil {
+		// 调整导出间隔后，从上一批次结束后的第一个对齐时间点继续
+		return (last.EndTime + interval - 1) / interval * interval, nil
+	}
+
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Unix(), nil
+}
+
+// exportLogBatch 分页读取批次内的日志，边读边编码写入临时文件，完成后再上传
+func exportLogBatch(start, end int64) error {
+	file, err := os.CreateTemp("", "log-export-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	encoder, ext, contentType, err := logexport.NewEncoder(config.LogExportFormat, file)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	afterId := 0
+	for {
+		logs, err := model.GetLogsForExport(start, end, afterId, logExportPageSize)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if err := encoder.Write(toExportRecord(log)); err != nil {
+				return err
+			}
+		}
+		count += len(logs)
+		if len(logs) < logExportPageSize {
+			break
+		}
+		afterId = logs[len(logs)-1].Id
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	batch := &model.LogExportBatch{
+		StartTime: start,
+		EndTime:   end,
+		Records:   count,
+		Format:    config.LogExportFormat,
+	}
+
+	// 没有日志的批次只记录进度，不上传空文件
+	if count > 0 {
+		size, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		key := logexport.ObjectKey(config.LogExportPrefix, start, end, ext)
+		err = logexport.Upload(&logexport.StorageConfig{
+			Storage:         config.LogExportStorage,
+			Endpoint:        config.LogExportEndpoint,
+			Region:          config.LogExportRegion,
+			Bucket:          config.LogExportBucket,
+			AccessKeyId:     config.LogExportAccessKeyId,
+			AccessKeySecret: config.LogExportAccessKeySecret,
+		}, key, file, contentType)
+		if err != nil {
+			return err
+		}
+
+		batch.ObjectKey = key
+		batch.Size = size
+		logger.SysLog(fmt.Sprintf("Exported %d logs to %s", count, key))
+	}
+
+	return model.SaveLogExportBatch(batch)
+}
+
+func toExportRecord(log *model.Log) *logexport.Record {
+	record := &logexport.Record{
+		Id:               int64(log.Id),
+		CreatedAt:        log.CreatedAt,
+		Type:             int64(log.Type),
+		UserId:           int64(log.UserId),
+		Username:         log.Username,
+		TokenName:        log.TokenName,
+		ModelName:        log.ModelName,
+		ChannelId:        int64(log.ChannelId),
+		Quota:            int64(log.Quota),
+		PromptTokens:     int64(log.PromptTokens),
+		CompletionTokens: int64(log.CompletionTokens),
+		RequestTime:      int64(log.RequestTime),
+		FirstTokenTime:   int64(log.FirstTokenTime),
+		UpstreamTime:     int64(log.UpstreamTime),
+		RetryCount:       int64(log.RetryCount),
+		IsStream:         log.IsStream,
+		SourceIp:         log.SourceIp,
+		RequestId:        log.RequestId,
+		Content:          log.Content,
+	}
+
+	metadata := log.Metadata.Data()
+	if len(metadata) > 0 {
+		// 原始请求体只用于回放调试，体积大且可能包含敏感内容，不参与导出
+		delete(metadata, "request_body")
+		if data, err := json.Marshal(metadata); err == nil {
+			record.Metadata = string(data)
+		}
+	}
+
+	return record
+}
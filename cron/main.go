@@ -5,6 +5,7 @@ import (
 	"done-hub/common/logger"
 	"done-hub/common/scheduler"
 	"done-hub/model"
+	"fmt"
 	"github.com/spf13/viper"
 	"time"
 
@@ -12,6 +13,11 @@ import (
 )
 
 func InitCron() {
+	// 未开启 Redis 时 Copilot token 缓存在各节点内存中，从节点也需要提前换取
+	if config.IsMasterNode || !config.RedisEnabled {
+		registerCopilotTokenRefresh()
+	}
+
 	if !config.IsMasterNode {
 		logger.SysLog("Cron is disabled on slave node")
 		return
@@ -106,4 +112,101 @@ func InitCron() {
 	} else {
 		logger.SysLog("Codex credential auto-refresh task registered (every 6 hours)")
 	}
+
+	// Claude Code 凭证自动刷新任务：access_token 有效期较短，每 30 分钟检查一次
+	err = scheduler.Manager.AddJob(
+		"claudecode_credential_auto_refresh",
+		gocron.DurationJob(30*time.Minute),
+		gocron.NewTask(func() {
+			RunClaudeCodeCredentialAutoRefresh()
+		}),
+	)
+	if err != nil {
+		logger.SysError("ClaudeCode credential auto-refresh cron job error: " + err.Error())
+	} else {
+		logger.SysLog("ClaudeCode credential auto-refresh task registered (every 30 minutes)")
+	}
+
+	// 不活跃账户检查：每天凌晨三点执行，是否生效由 InactiveUserPauseEnabled 控制
+	err = scheduler.Manager.AddJob(
+		"inactive_user_pause",
+		gocron.DailyJob(1, gocron.NewAtTimes(gocron.NewAtTime(3, 0, 0))),
+		gocron.NewTask(func() {
+			RunInactiveUserPause()
+		}),
+	)
+	if err != nil {
+		logger.SysError("Inactive user pause cron job error: " + err.Error())
+	}
+
+	// 消费异常检测：每 30 分钟对比一次当日与前一日的统计数据，是否生效由 UsageAnomalyNotifyEnabled 控制
+	err = scheduler.Manager.AddJob(
+		"usage_anomaly_check",
+		gocron.DurationJob(30*time.Minute),
+		gocron.NewTask(func() {
+			RunUsageAnomalyCheck()
+		}),
+	)
+	if err != nil {
+		logger.SysError("Usage anomaly check cron job error: " + err.Error())
+	}
+
+	// 上游余额检查：每 10 分钟触发一次，实际间隔由 ChannelBalanceCheckInterval 控制
+	err = scheduler.Manager.AddJob(
+		"channel_balance_check",
+		gocron.DurationJob(10*time.Minute),
+		gocron.NewTask(func() {
+			RunChannelBalanceCheck()
+		}),
+	)
+	if err != nil {
+		logger.SysError("Channel balance check cron job error: " + err.Error())
+	}
+
+	// 日志导出：每 5 分钟检查一次已结束的批次，是否生效由 LogExportEnabled 控制
+	err = scheduler.Manager.AddJob(
+		"log_export",
+		gocron.DurationJob(5*time.Minute),
+		gocron.NewTask(func() {
+			RunLogExport()
+		}),
+	)
+	if err != nil {
+		logger.SysError("Log export cron job error: " + err.Error())
+	}
+
+	// 每分钟检查一次到期的价格版本
+	err = scheduler.Manager.AddJob(
+		"apply_price_versions",
+		gocron.DurationJob(time.Minute),
+		gocron.NewTask(func() {
+			applied, err := model.ApplyDuePriceVersions()
+			if err != nil {
+				logger.SysError("Apply price versions error: " + err.Error())
+				return
+			}
+			if applied > 0 {
+				logger.SysLog(fmt.Sprintf("Applied %d price versions", applied))
+			}
+		}),
+	)
+	if err != nil {
+		logger.SysError("Apply price versions cron job error: " + err.Error())
+	}
+}
+
+func registerCopilotTokenRefresh() {
+	// Copilot token 换取任务：Copilot token 有效期约 30 分钟，每 10 分钟提前换取
+	err := scheduler.Manager.AddJob(
+		"copilot_token_refresh",
+		gocron.DurationJob(10*time.Minute),
+		gocron.NewTask(func() {
+			RunCopilotTokenRefresh()
+		}),
+	)
+	if err != nil {
+		logger.SysError("Copilot token refresh cron job error: " + err.Error())
+	} else {
+		logger.SysLog("Copilot token refresh task registered (every 10 minutes)")
+	}
 }
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/notify"
+	"done-hub/common/utils"
+	"done-hub/model"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// notifiedUsageAnomalies 已通知过的异常，同一对象每天只通知一次
+var (
+	notifiedUsageAnomalies     = make(map[string]string)
+	notifiedUsageAnomaliesLock sync.Mutex
+)
+
+// RunUsageAnomalyCheck 检查当日消费突增的用户、令牌和渠道，发现新的异常时发送通知
+func RunUsageAnomalyCheck() {
+	if !config.UsageAnomalyNotifyEnabled {
+		return
+	}
+
+	location := utils.GetLocalLocation()
+	today := time.Now().In(location).Format("2006-01-02")
+
+	var sb strings.Builder
+	for _, dimension := range []string{model.ConsumerDimensionUser, model.ConsumerDimensionToken, model.ConsumerDimensionChannel} {
+		anomalies, err := model.GetUsageAnomalies(&model.UsageAnomalyParams{Date: today, Dimension: dimension}, -1)
+		if err != nil {
+			logger.SysError("Usage anomaly check failed: " + err.Error())
+			return
+		}
+
+		for _, anomaly := range anomalies {
+			key, name := usageAnomalyKey(dimension, anomaly)
+			if !markUsageAnomalyNotified(key, today) {
+				continue
+			}
+
+			previous := "前一日无消费"
+			if anomaly.PreviousQuota > 0 {
+				previous = fmt.Sprintf("前一日 $%.4f，%.1f 倍", float64(anomaly.PreviousQuota)/config.QuotaPerUnit, anomaly.Ratio)
+			}
+			sb.WriteString(fmt.Sprintf("- %s：当日 $%.4f（%d 次请求），%s\n", name, float64(anomaly.CurrentQuota)/config.QuotaPerUnit, anomaly.CurrentRequests, previous))
+		}
+	}
+
+	if sb.Len() == 0 {
+		return
+	}
+
+	logger.SysLog("Usage anomalies detected:\n" + sb.String())
+	notify.Send("消费异常提醒", fmt.Sprintf("以下对象 %s 的消费较前一日突增：\n\n%s", today, sb.String()))
+}
+
+func usageAnomalyKey(dimension string, anomaly *model.UsageAnomaly) (key, name string) {
+	switch dimension {
+	case model.ConsumerDimensionToken:
+		return fmt.Sprintf("token:%d:%d", anomaly.UserId, anomaly.TokenId), fmt.Sprintf("用户 %s 的令牌 #%d %s", anomaly.Username, anomaly.TokenId, anomaly.TokenName)
+	case model.ConsumerDimensionChannel:
+		return fmt.Sprintf("channel:%d", anomaly.ChannelId), fmt.Sprintf("渠道 #%d %s", anomaly.ChannelId, anomaly.ChannelName)
+	default:
+		return fmt.Sprintf("user:%d", anomaly.UserId), fmt.Sprintf("用户 #%d %s", anomaly.UserId, anomaly.Username)
+	}
+}
+
+// markUsageAnomalyNotified 记录当日已通知的对象，返回 false 表示今天已经通知过
+func markUsageAnomalyNotified(key, date string) bool {
+	notifiedUsageAnomaliesLock.Lock()
+	defer notifiedUsageAnomaliesLock.Unlock()
+
+	for k, d := range notifiedUsageAnomalies {
+		if d != date {
+			delete(notifiedUsageAnomalies, k)
+		}
+	}
+
+	if notifiedUsageAnomalies[key] == date {
+		return false
+	}
+	notifiedUsageAnomalies[key] = date
+	return true
+}
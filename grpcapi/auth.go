@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// authInterceptor 使用超级管理员的系统访问令牌鉴权，通过 metadata 的 authorization 传递
+func authInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, status.Error(codes.Unauthenticated, "未提供访问令牌")
+	}
+
+	user := model.ValidateAccessToken(strings.TrimSpace(values[0]))
+	if user == nil || user.Username == "" {
+		return nil, status.Error(codes.Unauthenticated, "无效的访问令牌")
+	}
+	if user.Status != config.UserStatusEnabled {
+		return nil, status.Error(codes.PermissionDenied, "用户已被封禁")
+	}
+	if user.Role < config.RoleRootUser {
+		return nil, status.Error(codes.PermissionDenied, "仅超级管理员可以使用 gRPC 管理接口")
+	}
+
+	return handler(ctx, req)
+}
+
+func recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.SysError(fmt.Sprintf("gRPC panic in %s: %v\n%s", info.FullMethod, r, debug.Stack()))
+			err = status.Error(codes.Internal, "服务器内部错误")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// toStatusError 将业务错误转换为 gRPC 状态码
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "记录不存在")
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func invalidArgument(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/utils"
+	"done-hub/grpcapi/adminpb"
+	"done-hub/model"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type channelService struct {
+	adminpb.UnimplementedChannelServiceServer
+}
+
+func (s *channelService) ListChannels(ctx context.Context, req *adminpb.ListChannelsRequest) (*adminpb.ListChannelsResponse, error) {
+	params := &model.SearchChannelsParams{
+		PaginationParams: toPaginationParams(req.GetPage()),
+		FilterTag:        model.ChannelFilterTagAll,
+	}
+	params.Name = req.GetName()
+	params.Type = int(req.GetType())
+	params.Status = int(req.GetStatus())
+	params.Group = req.GetGroup()
+	params.Tag = req.GetTag()
+	params.Models = req.GetModels()
+
+	result, err := model.GetChannelsList(params, -1)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &adminpb.ListChannelsResponse{Total: result.TotalCount}
+	for _, channel := range *result.Data {
+		resp.Channels = append(resp.Channels, toChannelPb(channel))
+	}
+	return resp, nil
+}
+
+func (s *channelService) GetChannel(ctx context.Context, req *adminpb.GetChannelRequest) (*adminpb.Channel, error) {
+	channel, err := model.GetChannelById(int(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toChannelPb(channel), nil
+}
+
+func (s *channelService) CreateChannel(ctx context.Context, req *adminpb.CreateChannelRequest) (*adminpb.Channel, error) {
+	if req.GetChannel() == nil {
+		return nil, invalidArgument("渠道信息不能为空")
+	}
+	channel := fromChannelPb(req.GetChannel())
+	if channel.Key == "" {
+		return nil, invalidArgument("渠道密钥不能为空")
+	}
+	if channel.Name == "" {
+		return nil, invalidArgument("渠道名称不能为空")
+	}
+	if channel.Group == "" {
+		channel.Group = "default"
+	}
+	channel.Id = 0
+	channel.CreatedTime = utils.GetTimestamp()
+
+	if err := channel.Insert(); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toChannelPb(channel), nil
+}
+
+func (s *channelService) UpdateChannel(ctx context.Context, req *adminpb.UpdateChannelRequest) (*adminpb.Channel, error) {
+	if req.GetChannel().GetId() == 0 {
+		return nil, invalidArgument("渠道 ID 不能为空")
+	}
+	if _, err := model.GetChannelById(int(req.GetChannel().GetId())); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	channel := fromChannelPb(req.GetChannel())
+	// 所属租户和状态通过专门的接口修改
+	channel.TenantId = 0
+	channel.Status = 0
+	if err := channel.Update(false); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toChannelPb(channel), nil
+}
+
+func (s *channelService) SetChannelStatus(ctx context.Context, req *adminpb.SetChannelStatusRequest) (*emptypb.Empty, error) {
+	switch int(req.GetStatus()) {
+	case config.ChannelStatusEnabled, config.ChannelStatusManuallyDisabled:
+	default:
+		return nil, invalidArgument("不支持的渠道状态")
+	}
+	if _, err := model.GetChannelById(int(req.GetId())); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	model.UpdateChannelStatusById(int(req.GetId()), int(req.GetStatus()))
+	return &emptypb.Empty{}, nil
+}
+
+func (s *channelService) DeleteChannel(ctx context.Context, req *adminpb.DeleteChannelRequest) (*emptypb.Empty, error) {
+	channel, err := model.GetChannelById(int(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := channel.Delete(); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
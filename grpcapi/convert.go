@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"done-hub/grpcapi/adminpb"
+	"done-hub/model"
+)
+
+func toPaginationParams(page *adminpb.PageRequest) model.PaginationParams {
+	return model.PaginationParams{
+		Page:  int(page.GetPage()),
+		Size:  int(page.GetSize()),
+		Order: page.GetOrder(),
+	}
+}
+
+func toChannelPb(channel *model.Channel) *adminpb.Channel {
+	pb := &adminpb.Channel{
+		Id:           int64(channel.Id),
+		Type:         int32(channel.Type),
+		Name:         channel.Name,
+		Key:          channel.Key,
+		Status:       int32(channel.Status),
+		BaseUrl:      channel.GetBaseURL(),
+		Models:       channel.Models,
+		Group:        channel.Group,
+		Tag:          channel.Tag,
+		Priority:     channel.GetPriority(),
+		ModelMapping: channel.GetModelMapping(),
+		Other:        channel.Other,
+		TestModel:    channel.TestModel,
+		TenantId:     int64(channel.TenantId),
+		UsedQuota:    channel.UsedQuota,
+		Balance:      channel.Balance,
+		ResponseTime: int64(channel.ResponseTime),
+		CreatedTime:  channel.CreatedTime,
+	}
+	if channel.Weight != nil {
+		pb.Weight = uint32(*channel.Weight)
+	}
+	if channel.Proxy != nil {
+		pb.Proxy = *channel.Proxy
+	}
+	return pb
+}
+
+// fromChannelPb 转换为渠道模型，指针字段只在有值时设置，更新时不会覆盖为空
+func fromChannelPb(pb *adminpb.Channel) *model.Channel {
+	channel := &model.Channel{
+		Id:        int(pb.GetId()),
+		Type:      int(pb.GetType()),
+		Name:      pb.GetName(),
+		Key:       pb.GetKey(),
+		Status:    int(pb.GetStatus()),
+		Models:    pb.GetModels(),
+		Group:     pb.GetGroup(),
+		Tag:       pb.GetTag(),
+		Other:     pb.GetOther(),
+		TestModel: pb.GetTestModel(),
+		TenantId:  int(pb.GetTenantId()),
+	}
+	if baseURL := pb.GetBaseUrl(); baseURL != "" {
+		channel.BaseURL = &baseURL
+	}
+	if priority := pb.GetPriority(); priority != 0 {
+		channel.Priority = &priority
+	}
+	if weight := uint(pb.GetWeight()); weight != 0 {
+		channel.Weight = &weight
+	}
+	if modelMapping := pb.GetModelMapping(); modelMapping != "" {
+		channel.ModelMapping = &modelMapping
+	}
+	if proxy := pb.GetProxy(); proxy != "" {
+		channel.Proxy = &proxy
+	}
+	return channel
+}
+
+func toTokenPb(token *model.Token) *adminpb.Token {
+	return &adminpb.Token{
+		Id:             int64(token.Id),
+		UserId:         int64(token.UserId),
+		Name:           token.Name,
+		Key:            token.Key,
+		Status:         int32(token.Status),
+		RemainQuota:    int64(token.RemainQuota),
+		UnlimitedQuota: token.UnlimitedQuota,
+		UsedQuota:      int64(token.UsedQuota),
+		ExpiredTime:    token.ExpiredTime,
+		Group:          token.Group,
+		BackupGroup:    token.BackupGroup,
+		CreatedTime:    token.CreatedTime,
+		AccessedTime:   token.AccessedTime,
+	}
+}
+
+func toUserPb(user *model.User) *adminpb.User {
+	return &adminpb.User{
+		Id:            int64(user.Id),
+		Username:      user.Username,
+		DisplayName:   user.DisplayName,
+		Role:          int32(user.Role),
+		Status:        int32(user.Status),
+		Email:         user.Email,
+		Quota:         int64(user.Quota),
+		UsedQuota:     int64(user.UsedQuota),
+		RequestCount:  int64(user.RequestCount),
+		Group:         user.Group,
+		TenantId:      int64(user.TenantId),
+		CreatedTime:   user.CreatedTime,
+		LastLoginTime: user.LastLoginTime,
+	}
+}
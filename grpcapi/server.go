@@ -0,0 +1,129 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"done-hub/common/logger"
+	"done-hub/grpcapi/adminpb"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var grpcServer *grpc.Server
+
+// InitGrpcServer 启动 gRPC 管理接口，与 HTTP 管理接口共用同一套数据
+func InitGrpcServer() {
+	if !viper.GetBool("grpc.enable") {
+		return
+	}
+
+	server, err := newServer()
+	if err != nil {
+		logger.FatalLog("failed to create gRPC server: " + err.Error())
+	}
+
+	listener, err := net.Listen("tcp", ":"+viper.GetString("grpc.port"))
+	if err != nil {
+		logger.FatalLog("failed to listen gRPC port: " + err.Error())
+	}
+
+	grpcServer = server
+	logger.SysLog("gRPC admin server started on " + listener.Addr().String())
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.SysError("gRPC server stopped: " + err.Error())
+		}
+	}()
+}
+
+// StopGrpcServer 停止接收新请求并等待进行中的调用结束，超时后强制关闭
+func StopGrpcServer(ctx context.Context) {
+	if grpcServer == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
+
+func newServer() (*grpc.Server, error) {
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoveryInterceptor, authInterceptor),
+	}
+
+	creds, err := loadTLSCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		options = append(options, grpc.Creds(creds))
+	} else {
+		logger.SysError("gRPC admin server is running without TLS (grpc.insecure), please only expose it to trusted networks")
+	}
+
+	server := grpc.NewServer(options...)
+	adminpb.RegisterChannelServiceServer(server, &channelService{})
+	adminpb.RegisterTokenServiceServer(server, &tokenService{})
+	adminpb.RegisterUserServiceServer(server, &userService{})
+	adminpb.RegisterUsageServiceServer(server, &usageService{})
+
+	return server, nil
+}
+
+// loadTLSCredentials 加载服务端证书，配置了客户端 CA 时要求客户端证书（mTLS）。
+// 默认必须配置证书，只有显式开启 grpc.insecure 时才允许明文传输，此时返回 nil
+func loadTLSCredentials() (credentials.TransportCredentials, error) {
+	certFile := viper.GetString("grpc.tls.cert_file")
+	keyFile := viper.GetString("grpc.tls.key_file")
+	clientCAFile := viper.GetString("grpc.tls.client_ca_file")
+
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("grpc.tls.client_ca_file requires grpc.tls.cert_file and grpc.tls.key_file")
+		}
+		if viper.GetBool("grpc.insecure") {
+			return nil, nil
+		}
+		return nil, errors.New("grpc.tls.cert_file and grpc.tls.key_file are required, set grpc.insecure to serve without TLS")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		caPem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, errors.New("failed to parse grpc.tls.client_ca_file")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,188 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// TestToStatusError 测试业务错误到 gRPC 状态码的转换
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{nil, codes.OK},
+		{gorm.ErrRecordNotFound, codes.NotFound},
+		{errors.New("用户名已存在！"), codes.InvalidArgument},
+		{status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(toStatusError(tt.err)); got != tt.want {
+			t.Errorf("toStatusError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestAuthInterceptorWithoutToken 未携带访问令牌时不调用处理函数
+func TestAuthInterceptorWithoutToken(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	_, err := authInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", err)
+	}
+	if called {
+		t.Error("handler should not be called without token")
+	}
+}
+
+// TestLoadTLSCredentials 测试证书配置的校验，默认要求 TLS
+func TestLoadTLSCredentials(t *testing.T) {
+	defer viper.Reset()
+
+	if _, err := loadTLSCredentials(); err == nil {
+		t.Error("expected error without cert when insecure is not set")
+	}
+
+	viper.Set("grpc.insecure", true)
+	creds, err := loadTLSCredentials()
+	if err != nil || creds != nil {
+		t.Errorf("expected no TLS when insecure is set, got %v, %v", creds, err)
+	}
+
+	viper.Set("grpc.tls.client_ca_file", "ca.pem")
+	if _, err := loadTLSCredentials(); err == nil {
+		t.Error("expected error when client CA is set without server cert")
+	}
+}
+
+// TestMutualTLS 配置客户端 CA 后，没有客户端证书的连接会被拒绝
+func TestMutualTLS(t *testing.T) {
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	ca, caKey := newTestCertificate(t, nil, nil, "test-ca")
+	serverCert, serverKey := newTestCertificate(t, ca, caKey, "localhost")
+	clientCert, clientKey := newTestCertificate(t, ca, caKey, "client")
+
+	caFile := writeTestPEM(t, dir, "ca.pem", "CERTIFICATE", ca.Raw)
+	viper.Set("grpc.tls.cert_file", writeTestPEM(t, dir, "server.pem", "CERTIFICATE", serverCert.Raw))
+	viper.Set("grpc.tls.key_file", writeTestPEM(t, dir, "server.key", "EC PRIVATE KEY", marshalTestKey(t, serverKey)))
+	viper.Set("grpc.tls.client_ca_file", caFile)
+
+	creds, err := loadTLSCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	check := func(certificates []tls.Certificate) error {
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:      pool,
+			ServerName:   "localhost",
+			Certificates: certificates,
+		})))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	if err := check(nil); err == nil {
+		t.Error("connection without client certificate should be rejected")
+	}
+	if err := check([]tls.Certificate{{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey}}); err != nil {
+		t.Errorf("connection with client certificate should succeed: %v", err)
+	}
+}
+
+// newTestCertificate 生成测试证书，parent 为空时生成自签名的 CA 证书
+func newTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, commonName string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{commonName},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func marshalTestKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func writeTestPEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
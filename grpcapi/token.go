@@ -0,0 +1,146 @@
+package grpcapi
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/utils"
+	"done-hub/grpcapi/adminpb"
+	"done-hub/model"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type tokenService struct {
+	adminpb.UnimplementedTokenServiceServer
+}
+
+func (s *tokenService) ListTokens(ctx context.Context, req *adminpb.ListTokensRequest) (*adminpb.ListTokensResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, invalidArgument("用户 ID 不能为空")
+	}
+
+	params := &model.GenericParams{
+		PaginationParams: toPaginationParams(req.GetPage()),
+		Keyword:          req.GetKeyword(),
+	}
+	result, err := model.GetUserTokensList(int(req.GetUserId()), params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &adminpb.ListTokensResponse{Total: result.TotalCount}
+	for _, token := range *result.Data {
+		resp.Tokens = append(resp.Tokens, toTokenPb(token))
+	}
+	return resp, nil
+}
+
+func (s *tokenService) GetToken(ctx context.Context, req *adminpb.GetTokenRequest) (*adminpb.Token, error) {
+	token, err := model.GetTokenById(int(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toTokenPb(token), nil
+}
+
+func (s *tokenService) CreateToken(ctx context.Context, req *adminpb.CreateTokenRequest) (*adminpb.Token, error) {
+	if _, err := model.GetUserById(int(req.GetUserId()), false); err != nil {
+		return nil, toStatusError(err)
+	}
+	if req.GetName() == "" || len(req.GetName()) > 30 {
+		return nil, invalidArgument("令牌名称不能为空且不能超过 30 个字符")
+	}
+	if err := validateGroups(req.GetGroup(), req.GetBackupGroup()); err != nil {
+		return nil, err
+	}
+
+	expiredTime := req.GetExpiredTime()
+	if expiredTime == 0 {
+		expiredTime = -1
+	}
+
+	token := &model.Token{
+		UserId:         int(req.GetUserId()),
+		Name:           req.GetName(),
+		CreatedTime:    utils.GetTimestamp(),
+		AccessedTime:   utils.GetTimestamp(),
+		ExpiredTime:    expiredTime,
+		RemainQuota:    int(req.GetRemainQuota()),
+		UnlimitedQuota: req.GetUnlimitedQuota(),
+		Group:          req.GetGroup(),
+		BackupGroup:    req.GetBackupGroup(),
+	}
+	if err := token.Insert(); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toTokenPb(token), nil
+}
+
+func (s *tokenService) UpdateToken(ctx context.Context, req *adminpb.UpdateTokenRequest) (*adminpb.Token, error) {
+	update := req.GetToken()
+	token, err := model.GetTokenById(int(update.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := validateGroups(update.GetGroup(), update.GetBackupGroup()); err != nil {
+		return nil, err
+	}
+
+	if update.GetName() != "" {
+		if len(update.GetName()) > 30 {
+			return nil, invalidArgument("令牌名称过长")
+		}
+		token.Name = update.GetName()
+	}
+	if update.GetStatus() != 0 {
+		token.Status = int(update.GetStatus())
+	}
+	if update.GetRemainQuota() != 0 {
+		token.RemainQuota = int(update.GetRemainQuota())
+	}
+	if update.GetExpiredTime() != 0 {
+		token.ExpiredTime = update.GetExpiredTime()
+	}
+	if update.GetGroup() != "" {
+		token.Group = update.GetGroup()
+	}
+	if update.GetBackupGroup() != "" {
+		token.BackupGroup = update.GetBackupGroup()
+	}
+	token.UnlimitedQuota = update.GetUnlimitedQuota()
+
+	if token.Status == config.TokenStatusEnabled {
+		if token.ExpiredTime != -1 && token.ExpiredTime <= utils.GetTimestamp() {
+			return nil, invalidArgument("令牌已过期，无法启用")
+		}
+		if token.RemainQuota <= 0 && !token.UnlimitedQuota {
+			return nil, invalidArgument("令牌可用额度已用尽，无法启用")
+		}
+	}
+
+	if err := token.Update(); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toTokenPb(token), nil
+}
+
+func (s *tokenService) DeleteToken(ctx context.Context, req *adminpb.DeleteTokenRequest) (*emptypb.Empty, error) {
+	token, err := model.GetTokenById(int(req.GetId()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := model.DeleteTokenById(token.Id, token.UserId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// validateGroups 校验分组是否存在，超级管理员可以为令牌指定任意分组
+func validateGroups(groups ...string) error {
+	for _, group := range groups {
+		if group != "" && model.GlobalUserGroupRatio.GetBySymbol(group) == nil {
+			return invalidArgument("无效的用户组：" + group)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package grpcapi
+
+import (
+	"context"
+	"done-hub/grpcapi/adminpb"
+	"done-hub/model"
+	"time"
+)
+
+type usageService struct {
+	adminpb.UnimplementedUsageServiceServer
+}
+
+func (s *usageService) GetUserUsage(ctx context.Context, req *adminpb.GetUserUsageRequest) (*adminpb.GetUserUsageResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, invalidArgument("用户 ID 不能为空")
+	}
+	startDate, err := time.Parse("2006-01-02", req.GetStartDate())
+	if err != nil {
+		return nil, invalidArgument("开始日期格式错误，应为 YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", req.GetEndDate())
+	if err != nil {
+		return nil, invalidArgument("结束日期格式错误，应为 YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, invalidArgument("结束日期不能早于开始日期")
+	}
+
+	statistics, err := model.GetUserModelStatisticsByPeriod(int(req.GetUserId()), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &adminpb.GetUserUsageResponse{}
+	for _, statistic := range statistics {
+		resp.Usages = append(resp.Usages, &adminpb.ModelUsage{
+			Date:             statistic.Date,
+			ModelName:        statistic.ModelName,
+			RequestCount:     statistic.RequestCount,
+			Quota:            statistic.Quota,
+			PromptTokens:     statistic.PromptTokens,
+			CompletionTokens: statistic.CompletionTokens,
+			RequestTime:      statistic.RequestTime,
+		})
+	}
+	return resp, nil
+}
+
+func (s *usageService) SumQuota(ctx context.Context, req *adminpb.SumQuotaRequest) (*adminpb.SumQuotaResponse, error) {
+	quota := model.SumUsedQuota(
+		req.GetStartTimestamp(),
+		req.GetEndTimestamp(),
+		req.GetModelName(),
+		req.GetUsername(),
+		req.GetTokenName(),
+		int(req.GetChannelId()),
+		-1,
+	)
+	return &adminpb.SumQuotaResponse{Quota: int64(quota)}, nil
+}
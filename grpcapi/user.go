@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"context"
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/grpcapi/adminpb"
+	"done-hub/model"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type userService struct {
+	adminpb.UnimplementedUserServiceServer
+}
+
+func (s *userService) ListUsers(ctx context.Context, req *adminpb.ListUsersRequest) (*adminpb.ListUsersResponse, error) {
+	params := &model.GenericParams{
+		PaginationParams: toPaginationParams(req.GetPage()),
+		Keyword:          req.GetKeyword(),
+	}
+	result, err := model.GetUsersList(params, -1)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &adminpb.ListUsersResponse{Total: result.TotalCount}
+	for _, user := range *result.Data {
+		resp.Users = append(resp.Users, toUserPb(user))
+	}
+	return resp, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, req *adminpb.GetUserRequest) (*adminpb.User, error) {
+	user, err := model.GetUserById(int(req.GetId()), false)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toUserPb(user), nil
+}
+
+func (s *userService) CreateUser(ctx context.Context, req *adminpb.CreateUserRequest) (*adminpb.User, error) {
+	if strings.TrimSpace(req.GetUsername()) == "" {
+		return nil, invalidArgument("用户名不能为空")
+	}
+	if strings.TrimSpace(req.GetPassword()) == "" {
+		return nil, invalidArgument("密码不能为空")
+	}
+	if err := validateGroups(req.GetGroup()); err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		Username:    req.GetUsername(),
+		Password:    req.GetPassword(),
+		DisplayName: req.GetDisplayName(),
+		Email:       req.GetEmail(),
+		Group:       req.GetGroup(),
+		TenantId:    int(req.GetTenantId()),
+	}
+	if user.DisplayName == "" {
+		user.DisplayName = user.Username
+	}
+	if err := common.Validate.Struct(user); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+
+	if err := user.Insert(0); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if req.GetQuota() > 0 {
+		if err := changeUserQuota(ctx, user.Id, int(req.GetQuota()), ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, &adminpb.GetUserRequest{Id: int64(user.Id)})
+}
+
+func (s *userService) UpdateUser(ctx context.Context, req *adminpb.UpdateUserRequest) (*adminpb.User, error) {
+	update := req.GetUser()
+	user, err := model.GetUserById(int(update.GetId()), false)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := validateGroups(update.GetGroup()); err != nil {
+		return nil, err
+	}
+
+	if update.GetDisplayName() != "" {
+		user.DisplayName = update.GetDisplayName()
+	}
+	if update.GetEmail() != "" {
+		if err := common.ValidateEmailStrict(update.GetEmail()); err != nil {
+			return nil, invalidArgument("邮箱格式不符合要求")
+		}
+		user.Email = update.GetEmail()
+	}
+	if update.GetGroup() != "" {
+		user.Group = update.GetGroup()
+	}
+	if update.GetStatus() != 0 {
+		if user.Role >= config.RoleRootUser && int(update.GetStatus()) != config.UserStatusEnabled {
+			return nil, status.Error(codes.PermissionDenied, "无法禁用超级管理员用户")
+		}
+		user.Status = int(update.GetStatus())
+	}
+
+	if err := user.Update(false); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toUserPb(user), nil
+}
+
+func (s *userService) AdjustUserQuota(ctx context.Context, req *adminpb.AdjustUserQuotaRequest) (*adminpb.User, error) {
+	if req.GetQuota() == 0 {
+		return nil, invalidArgument("额度变化不能为 0")
+	}
+	if _, err := model.GetUserById(int(req.GetId()), false); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := changeUserQuota(ctx, int(req.GetId()), int(req.GetQuota()), req.GetRemark()); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, &adminpb.GetUserRequest{Id: req.GetId()})
+}
+
+func (s *userService) DeleteUser(ctx context.Context, req *adminpb.DeleteUserRequest) (*emptypb.Empty, error) {
+	user, err := model.GetUserById(int(req.GetId()), false)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if user.Role >= config.RoleRootUser {
+		return nil, status.Error(codes.PermissionDenied, "无法删除超级管理员用户")
+	}
+
+	if err := user.Delete(); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// changeUserQuota 调整用户额度并记录管理日志
+func changeUserQuota(ctx context.Context, userId int, quota int, remark string) error {
+	if err := model.ChangeUserQuota(userId, quota, false); err != nil {
+		return toStatusError(err)
+	}
+
+	content := fmt.Sprintf("管理员通过 gRPC 接口增减用户额度 %s", common.LogQuota(quota))
+	if remark != "" {
+		content = fmt.Sprintf("%s, 备注: %s", content, remark)
+	}
+	model.RecordQuotaLog(userId, model.LogTypeManage, quota, peerIP(ctx), content)
+	return nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -1,30 +1,42 @@
 package main
 
 import (
+	"context"
 	"done-hub/cli"
 	"done-hub/common"
 	"done-hub/common/cache"
 	"done-hub/common/config"
+	"done-hub/common/geoip"
+	"done-hub/common/graceful"
 	"done-hub/common/logger"
+	"done-hub/common/logstream"
 	"done-hub/common/notify"
 	"done-hub/common/oidc"
 	"done-hub/common/redis"
 	"done-hub/common/requester"
+	"done-hub/common/scheduler"
 	"done-hub/common/search"
+	"done-hub/common/stmp"
 	"done-hub/common/storage"
 	"done-hub/common/telegram"
+	"done-hub/common/webhook"
 	"done-hub/controller"
 	"done-hub/cron"
+	"done-hub/grpcapi"
 	"done-hub/middleware"
 	"done-hub/model"
 	"done-hub/relay/task"
 	"done-hub/router"
 	"done-hub/safty"
 	"embed"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/sessions"
@@ -75,6 +87,10 @@ func main() {
 	model.InitInviteCodeLock()
 	// Initialize options
 	model.InitOptionMap()
+	// Initialize cross-node cache invalidation
+	model.InitCacheInvalidation()
+	// Initialize cross-node live log stream
+	logstream.Init()
 	// Initialize oidc
 	oidc.InitOIDCConfig()
 	model.NewPricing()
@@ -95,6 +111,7 @@ func main() {
 	cron.InitCron()
 	storage.InitStorage()
 	search.InitSearcher()
+	geoip.InitGeoIP()
 	// 初始化安全检查器
 	safty.InitSaftyTools()
 	// 初始化账单数据
@@ -102,6 +119,7 @@ func main() {
 		logger.SysLog("Enable User Invoice Monthly Data")
 		go model.InsertStatisticsMonth()
 	}
+	grpcapi.InitGrpcServer()
 	initHttpServer()
 }
 
@@ -143,7 +161,15 @@ func initHttpServer() {
 		server.TrustedPlatform = trustedHeader
 	}
 
-	store := cookie.NewStore([]byte(config.SessionSecret))
+	// 仅信任指定代理转发的客户端 IP，避免伪造 X-Forwarded-For 绕过按 IP 限流
+	if err := middleware.SetTrustedProxies(server, viper.GetString("trusted_proxies")); err != nil {
+		logger.FatalLog("failed to set trusted proxies: " + err.Error())
+	}
+	if !middleware.TrustedProxiesConfigured() {
+		logger.SysError("TRUSTED_PROXIES is not set, X-Forwarded-For and X-Real-IP from any client are trusted and can be spoofed, set it to your reverse proxy addresses, or to none when not behind a proxy")
+	}
+
+	store := newSessionStore()
 
 	// 检测是否在 HTTPS 环境下运行
 	isHTTPS := viper.GetBool("https") || viper.GetString("trusted_header") == "CF-Connecting-IP"
@@ -161,10 +187,86 @@ func initHttpServer() {
 	router.SetRouter(server, buildFS, indexPage)
 	port := viper.GetString("port")
 
-	err := server.Run(":" + port)
-	if err != nil {
-		logger.FatalLog("failed to start HTTP server: " + err.Error())
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: server,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.SysLog("HTTP server started on " + srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.FatalLog("failed to start HTTP server: " + err.Error())
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	gracefulShutdown(srv)
+}
+
+// newSessionStore 根据 SESSION_STORE 选择会话存储，auto 时启用了 Redis 则使用 Redis，否则使用 Cookie
+func newSessionStore() sessions.Store {
+	storeType := strings.ToLower(viper.GetString("session_store"))
+	if storeType == "redis" && !config.RedisEnabled {
+		logger.SysError("SESSION_STORE is redis but Redis is not enabled, fallback to cookie store")
+		storeType = "cookie"
+	}
+
+	if storeType != "cookie" && config.RedisEnabled {
+		// 未设置 SESSION_SECRET 时每个节点使用随机密钥，其他节点或重启后无法识别已登录的会话
+		if viper.GetString("session_secret") == "" {
+			if storeType == "redis" {
+				logger.FatalLog("SESSION_STORE is redis but SESSION_SECRET is not set, all nodes must share the same SESSION_SECRET")
+			}
+			logger.SysError("session store is redis but SESSION_SECRET is not set, sessions will not be shared between nodes or survive restarts")
+		}
+		logger.SysLog("session store: redis")
+		return redis.NewSessionStore([]byte(config.SessionSecret))
 	}
+
+	return cookie.NewStore([]byte(config.SessionSecret))
+}
+
+// gracefulShutdown 停止接收新请求，等待进行中的请求（包括流式响应）结束，
+// 再写入尚未落库的扣费与日志、推送剩余的计费事件并发送队列中的邮件，最后停止定时任务
+func gracefulShutdown(srv *http.Server) {
+	timeout := time.Duration(viper.GetInt("shutdown_timeout")) * time.Second
+	logger.SysLog(fmt.Sprintf("shutting down, waiting up to %s for in-flight requests", timeout))
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(drainCtx); err != nil {
+		logger.SysError("failed to drain HTTP server: " + err.Error())
+	}
+	grpcapi.StopGrpcServer(drainCtx)
+
+	// 请求结束后仍可能有异步扣费在执行，单独给一段等待时间
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), timeout)
+	defer flushCancel()
+
+	if err := graceful.WaitBackground(flushCtx); err != nil {
+		logger.SysError("failed to wait background tasks: " + err.Error())
+	}
+	model.FlushBatchUpdate()
+	// 扣费完成后才会产生计费事件，最后推送剩余的 Webhook
+	if err := webhook.DrainBilling(flushCtx); err != nil {
+		logger.SysError("failed to drain billing webhooks: " + err.Error())
+	}
+	if err := stmp.DrainEmail(flushCtx); err != nil {
+		logger.SysError("failed to drain email queue: " + err.Error())
+	}
+
+	if scheduler.Manager != nil {
+		if err := scheduler.Manager.Stop(); err != nil {
+			logger.SysError("failed to stop scheduler: " + err.Error())
+		}
+	}
+
+	logger.SysLog("shutdown completed")
 }
 
 func SyncChannelCache(frequency int) {
@@ -176,10 +278,11 @@ func SyncChannelCache(frequency int) {
 	for {
 		time.Sleep(time.Duration(frequency) * time.Second)
 		logger.SysLog("syncing channels from database")
-		model.ChannelGroup.Load()
+		model.ChannelGroup.LoadLocal()
 		model.PricingInstance.Init()
 		model.ModelOwnedBysInstance.Load()
 		model.GlobalUserGroupRatio.Load()
+		model.GlobalTenants.Load()
 	}
 }
 
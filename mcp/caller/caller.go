@@ -0,0 +1,68 @@
+package caller
+
+import (
+	"context"
+	"done-hub/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type callerKey struct{}
+
+// Caller 发起 MCP 调用的令牌信息
+type Caller struct {
+	UserId     int
+	TokenId    int
+	TokenName  string
+	TokenGroup string
+	ClientIP   string
+	Setting    *model.TokenSetting
+	// Tools 令牌允许调用的工具，为 nil 时不限制
+	Tools []string
+}
+
+// Setup 将令牌信息写入请求上下文，需放在令牌鉴权之后
+func Setup() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller := &Caller{
+			UserId:     c.GetInt("id"),
+			TokenId:    c.GetInt("token_id"),
+			TokenName:  c.GetString("token_name"),
+			TokenGroup: c.GetString("token_group"),
+			ClientIP:   c.ClientIP(),
+		}
+		if setting, ok := c.Value("token_setting").(*model.TokenSetting); ok && setting != nil {
+			caller.Setting = setting
+			if limit := setting.Limits.LimitMCPToolSetting; limit.Enabled {
+				caller.Tools = append([]string{}, limit.Tools...)
+			}
+		}
+
+		c.Request = c.Request.WithContext(WithCaller(c.Request.Context(), caller))
+		c.Next()
+	}
+}
+
+// WithCaller 返回携带调用方信息的上下文
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// FromContext 获取调用方信息，未经过令牌鉴权时返回 nil
+func FromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
+
+// AllowModel 令牌是否可以使用指定模型
+func (c *Caller) AllowModel(modelName string) bool {
+	if c.Setting == nil || !c.Setting.Limits.LimitModelSetting.Enabled {
+		return true
+	}
+	for _, allowed := range c.Setting.Limits.LimitModelSetting.Models {
+		if allowed == modelName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/hook"
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"done-hub/common/webhook"
+	"done-hub/mcp/caller"
+	"done-hub/mcp/quota"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+	"github.com/ThinkInAIXYZ/go-mcp/server"
+)
+
+// withToolGuard 校验令牌的工具权限，按配置扣除额度并记录调用日志
+func withToolGuard(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+		current := caller.FromContext(ctx)
+		if current == nil || current.TokenId == 0 {
+			return nil, errors.New("未通过令牌鉴权")
+		}
+		if current.Tools != nil && !slices.Contains(current.Tools, name) {
+			return nil, fmt.Errorf("当前令牌无权调用工具 %s", name)
+		}
+
+		toolQuota := quota.ToolQuota(name)
+		if toolQuota > 0 {
+			if err := model.PreConsumeTokenQuota(current.TokenId, toolQuota); err != nil {
+				return nil, err
+			}
+		}
+
+		startTime := time.Now()
+		result, err := handler(ctx, req)
+		requestTime := int(time.Since(startTime).Milliseconds())
+
+		// 返回错误或工具结果标记为 IsError 时视为调用失败，退还额度且不计费
+		failed := err != nil || (result != nil && result.IsError)
+		if failed && toolQuota > 0 {
+			if refundErr := model.PostConsumeTokenQuota(current.TokenId, -toolQuota); refundErr != nil {
+				logger.SysError("failed to refund mcp tool quota: " + refundErr.Error())
+			}
+		}
+
+		consumed := toolQuota
+		content := "MCP 工具调用"
+		if failed {
+			consumed = 0
+			content = "MCP 工具调用失败"
+			if err != nil {
+				content += "：" + err.Error()
+			}
+		} else {
+			if consumed > 0 {
+				model.UpdateUserUsedQuotaAndRequestCount(current.UserId, consumed)
+			}
+			runPostBillingHook(ctx, current, name, consumed)
+			emitBillingWebhook(ctx, current, name, consumed)
+		}
+
+		model.RecordConsumeLog(ctx, current.UserId, 0, 0, 0, "mcp:"+name, current.TokenId, current.TokenName, consumed, content, requestTime, false, nil, map[string]any{
+			"mcp_tool": name,
+			"success":  !failed,
+		}, current.ClientIP)
+
+		return result, err
+	}
+}
+
+// runPostBillingHook 工具调用计费完成后执行 post_billing 阶段的扩展钩子，在后台执行，不影响调用耗时
+func runPostBillingHook(ctx context.Context, current *caller.Caller, name string, quota int) {
+	if !hook.Enabled(hook.StagePostBilling) {
+		return
+	}
+
+	event := &hook.Event{
+		Stage:     hook.StagePostBilling,
+		RequestId: logger.GetRequestId(ctx),
+		CreatedAt: utils.GetTimestamp(),
+		UserId:    current.UserId,
+		TokenId:   current.TokenId,
+		TokenName: current.TokenName,
+		Group:     current.TokenGroup,
+		Model:     "mcp:" + name,
+		Quota:     quota,
+	}
+	go hook.Run(context.WithoutCancel(ctx), event)
+}
+
+// emitBillingWebhook 推送工具调用的计费事件，与模型请求的事件格式相同
+func emitBillingWebhook(ctx context.Context, current *caller.Caller, name string, quota int) {
+	if !config.BillingWebhookEnabled {
+		return
+	}
+
+	username, _ := model.CacheGetUsername(current.UserId)
+	webhook.EmitBilling(&webhook.BillingEvent{
+		RequestId: logger.GetRequestId(ctx),
+		UserId:    current.UserId,
+		Username:  username,
+		TokenId:   current.TokenId,
+		TokenName: current.TokenName,
+		Model:     "mcp:" + name,
+		Group:     current.TokenGroup,
+		Quota:     quota,
+	})
+}
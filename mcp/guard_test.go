@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/webhook"
+	"done-hub/mcp/caller"
+	"done-hub/mcp/quota"
+	"done-hub/model"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func setupGuardTest(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.User{}, &model.Token{}, &model.Log{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB := model.DB
+	t.Cleanup(func() {
+		model.DB = originDB
+		quota.SetToolQuota("")
+	})
+	model.DB = db
+
+	db.Create(&model.User{Id: 1, Username: "alice", AccessToken: "a", AffCode: "a", Quota: 100000})
+	// 跳过生成令牌密钥的钩子
+	db.Session(&gorm.Session{SkipHooks: true}).Create(&model.Token{Id: 1, UserId: 1, Key: "k", Name: "mcp", RemainQuota: 100000})
+	if err = quota.SetToolQuota(`{"*": 10}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func guardContext(tools []string) context.Context {
+	return caller.WithCaller(context.Background(), &caller.Caller{UserId: 1, TokenId: 1, TokenName: "mcp", Tools: tools})
+}
+
+func userAndTokenQuota(t *testing.T) (int, int) {
+	user, err := model.GetUserById(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := model.GetTokenById(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user.Quota, token.RemainQuota
+}
+
+func TestToolGuardPermission(t *testing.T) {
+	setupGuardTest(t)
+
+	called := false
+	handler := withToolGuard("calculator", func(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+		called = true
+		return &protocol.CallToolResult{}, nil
+	})
+
+	if _, err := handler(context.Background(), &protocol.CallToolRequest{}); err == nil {
+		t.Fatal("call without token should be rejected")
+	}
+	if _, err := handler(guardContext([]string{"current_time"}), &protocol.CallToolRequest{}); err == nil {
+		t.Fatal("tool not allowed by the token should be rejected")
+	}
+	if called {
+		t.Fatal("handler should not be called when rejected")
+	}
+	if userQuota, tokenQuota := userAndTokenQuota(t); userQuota != 100000 || tokenQuota != 100000 {
+		t.Fatalf("rejected calls should not charge quota: %d, %d", userQuota, tokenQuota)
+	}
+}
+
+func TestToolGuardBilling(t *testing.T) {
+	setupGuardTest(t)
+
+	tests := []struct {
+		name   string
+		result *protocol.CallToolResult
+		err    error
+		charge bool
+	}{
+		{"success", &protocol.CallToolResult{}, nil, true},
+		{"error result", &protocol.CallToolResult{IsError: true}, nil, false},
+		{"handler error", nil, errors.New("failed"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beforeUser, beforeToken := userAndTokenQuota(t)
+			handler := withToolGuard("calculator", func(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+				return tt.result, tt.err
+			})
+			handler(guardContext(nil), &protocol.CallToolRequest{})
+
+			want := 0
+			if tt.charge {
+				want = 10
+			}
+			afterUser, afterToken := userAndTokenQuota(t)
+			if beforeUser-afterUser != want || beforeToken-afterToken != want {
+				t.Fatalf("expected charge %d, got user %d token %d", want, beforeUser-afterUser, beforeToken-afterToken)
+			}
+
+			var log model.Log
+			if err := model.DB.Last(&log).Error; err != nil {
+				t.Fatal(err)
+			}
+			if log.Quota != want || log.ModelName != "mcp:calculator" {
+				t.Fatalf("unexpected log: %+v", log)
+			}
+		})
+	}
+}
+
+func TestToolGuardBillingWebhook(t *testing.T) {
+	setupGuardTest(t)
+
+	events := make(chan webhook.BillingEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event webhook.BillingEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		events <- event
+	}))
+	defer server.Close()
+
+	config.BillingWebhookEnabled = true
+	config.BillingWebhookURL = server.URL
+	t.Cleanup(func() {
+		config.BillingWebhookEnabled = false
+		config.BillingWebhookURL = ""
+	})
+
+	handler := withToolGuard("calculator", func(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+		return &protocol.CallToolResult{}, nil
+	})
+	handler(guardContext(nil), &protocol.CallToolRequest{})
+
+	select {
+	case event := <-events:
+		if event.Model != "mcp:calculator" || event.Quota != 10 || event.TokenId != 1 || event.Username != "alice" {
+			t.Fatalf("unexpected billing event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("billing webhook should be sent for tool calls")
+	}
+}
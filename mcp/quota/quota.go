@@ -0,0 +1,67 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultKey 未单独配置的工具使用的额度
+const DefaultKey = "*"
+
+var toolQuota = struct {
+	sync.RWMutex
+	raw    string
+	quotas map[string]int
+}{quotas: map[string]int{}}
+
+// Parse 解析 MCP 工具每次调用扣除的额度，格式为 {"工具名": 额度}，* 为默认额度
+func Parse(raw string) (map[string]int, error) {
+	quotas := make(map[string]int)
+	if strings.TrimSpace(raw) == "" {
+		return quotas, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &quotas); err != nil {
+		return nil, fmt.Errorf("格式错误，应为 {\"工具名\": 额度}：%s", err.Error())
+	}
+	for name, quota := range quotas {
+		if quota < 0 {
+			return nil, fmt.Errorf("工具 %s 的额度不能为负数", name)
+		}
+	}
+	return quotas, nil
+}
+
+// SetToolQuota 更新工具额度配置
+func SetToolQuota(raw string) error {
+	quotas, err := Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	toolQuota.Lock()
+	defer toolQuota.Unlock()
+	toolQuota.raw = raw
+	toolQuota.quotas = quotas
+	return nil
+}
+
+// GetToolQuota 返回工具额度的原始配置
+func GetToolQuota() string {
+	toolQuota.RLock()
+	defer toolQuota.RUnlock()
+	return toolQuota.raw
+}
+
+// ToolQuota 返回工具每次调用扣除的额度
+func ToolQuota(name string) int {
+	toolQuota.RLock()
+	defer toolQuota.RUnlock()
+
+	if quota, ok := toolQuota.quotas[name]; ok {
+		return quota
+	}
+	return toolQuota.quotas[DefaultKey]
+}
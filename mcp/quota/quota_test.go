@@ -0,0 +1,27 @@
+package quota
+
+import "testing"
+
+// TestToolQuota 测试工具额度的解析与默认值
+func TestToolQuota(t *testing.T) {
+	defer SetToolQuota("")
+
+	if err := SetToolQuota(`{"dashboard": 100, "*": 10}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ToolQuota("dashboard"); got != 100 {
+		t.Errorf("ToolQuota(dashboard) = %d, want 100", got)
+	}
+	if got := ToolQuota("calculator"); got != 10 {
+		t.Errorf("ToolQuota(calculator) = %d, want 10", got)
+	}
+
+	for _, raw := range []string{`{"dashboard": -1}`, `not json`} {
+		if err := SetToolQuota(raw); err == nil {
+			t.Errorf("SetToolQuota(%s) should fail", raw)
+		}
+	}
+	if got := ToolQuota("dashboard"); got != 100 {
+		t.Errorf("invalid config should not replace current one, got %d", got)
+	}
+}
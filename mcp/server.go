@@ -54,9 +54,10 @@ func NewMcpServer() *Server {
 // RegisterTools 注册所有MCP工具到服务器
 func (mcp *Server) RegisterTools() {
 	// 遍历注册所有工具
-	for _, tool := range tools.McpTools {
-		mcp.SSEServer.RegisterTool(tool.GetTool(), tool.HandleRequest)
-		mcp.StreamableServer.RegisterTool(tool.GetTool(), tool.HandleRequest)
+	for name, tool := range tools.McpTools {
+		handler := withToolGuard(name, tool.HandleRequest)
+		mcp.SSEServer.RegisterTool(tool.GetTool(), handler)
+		mcp.StreamableServer.RegisterTool(tool.GetTool(), handler)
 	}
 	logger.SysLog("All MCP tools registered")
 }
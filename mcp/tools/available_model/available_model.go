@@ -3,6 +3,7 @@ package available_model
 import (
 	"context"
 	"done-hub/common/logger"
+	"done-hub/mcp/caller"
 	"done-hub/model"
 	"done-hub/relay"
 	"errors"
@@ -49,13 +50,21 @@ func (c *AvailableModel) HandleRequest(ctx context.Context, req *protocol.CallTo
 		logger.SysLog(fmt.Sprintf("错误：%s", err.Error()))
 		return nil, err
 	}
+	current := caller.FromContext(ctx)
 	if query.GroupName == "" {
 		query.GroupName = user.Group
+		if current != nil && current.TokenGroup != "" {
+			query.GroupName = current.TokenGroup
+		}
 	}
 	models := relay.GetAvailableModels(query.GroupName)
 	// 转成字符串
 	modelsStr := fmt.Sprintf("分组[%s]模型列表\n", query.GroupName)
 	for _, m := range models {
+		// 令牌限制了可用模型时只返回允许的模型
+		if current != nil && !current.AllowModel(m.Price.Model) {
+			continue
+		}
 		modelsStr += fmt.Sprintf("供应商:%s 名称:%s 输入价格:$%f/1K 输出价格:$%f/1K \n", m.OwnedBy, m.Price.Model, m.Price.Input*0.002, m.Price.Output*0.002)
 	}
 	// 返回查询结果
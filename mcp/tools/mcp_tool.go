@@ -6,6 +6,8 @@ import (
 	"done-hub/mcp/tools/calculator"
 	"done-hub/mcp/tools/current_time"
 	"done-hub/mcp/tools/dashboard"
+	"done-hub/mcp/tools/recent_usage"
+	"done-hub/mcp/tools/remaining_quota"
 	"github.com/ThinkInAIXYZ/go-mcp/protocol"
 )
 
@@ -21,4 +23,6 @@ func init() {
 	McpTools[available_model.NAME] = &available_model.AvailableModel{}
 	McpTools[dashboard.NAME] = &dashboard.Dashboard{}
 	McpTools[current_time.NAME] = &current_time.CurrentTime{}
+	McpTools[remaining_quota.NAME] = &remaining_quota.RemainingQuota{}
+	McpTools[recent_usage.NAME] = &recent_usage.RecentUsage{}
 }
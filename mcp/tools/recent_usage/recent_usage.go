@@ -0,0 +1,87 @@
+package recent_usage
+
+import (
+	"context"
+	"done-hub/common"
+	"done-hub/mcp/caller"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+)
+
+const NAME = "recent_usage"
+
+const maxLimit = 50
+
+// RecentUsage 查询最近的消费记录
+type RecentUsage struct{}
+
+type usageQueryParam struct {
+	Limit     int  `json:"limit" description:"返回的记录条数，默认10，最多50" default:"10" required:"false"`
+	TokenOnly bool `json:"tokenOnly" description:"是否只查询当前令牌的记录，默认false" default:"false" required:"false"`
+}
+
+// GetTool 返回消费记录查询工具的定义
+func (c *RecentUsage) GetTool() *protocol.Tool {
+	usageTool, _ := protocol.NewTool(
+		NAME,
+		"查询最近的消费记录，包括时间、模型、令牌、输入输出 Token 数和消耗的额度",
+		usageQueryParam{},
+	)
+	return usageTool
+}
+
+func (c *RecentUsage) HandleRequest(ctx context.Context, req *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+	current := caller.FromContext(ctx)
+	if current == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	query := usageQueryParam{}
+	if err := protocol.VerifyAndUnmarshal(req.RawArguments, &query); err != nil {
+		return nil, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	if query.Limit > maxLimit {
+		query.Limit = maxLimit
+	}
+
+	params := &model.LogsListParams{
+		PaginationParams: model.PaginationParams{
+			Page:  1,
+			Size:  query.Limit,
+			Order: "-created_at",
+		},
+		LogType: model.LogTypeConsume,
+	}
+	if query.TokenOnly {
+		params.TokenName = current.TokenName
+	}
+
+	logs, err := model.GetUserLogsList(current.UserId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("最近%d条消费记录\n", len(*logs.Data)))
+	for _, log := range *logs.Data {
+		result.WriteString(fmt.Sprintf("Time:%s Model:%s Token:%s InputToken:%d OutputToken:%d Quota:%s RequestTime(ms):%d\n",
+			time.Unix(log.CreatedAt, 0).Format("2006-01-02 15:04:05"), log.ModelName, log.TokenName, log.PromptTokens, log.CompletionTokens, common.LogQuota(log.Quota), log.RequestTime))
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			&protocol.TextContent{
+				Type: "text",
+				Text: result.String(),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package recent_usage
+
+import (
+	"context"
+	"done-hub/mcp/caller"
+	"done-hub/model"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestHandleRequest(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.Log{}); err != nil {
+		t.Fatal(err)
+	}
+	originDB := model.DB
+	t.Cleanup(func() { model.DB = originDB })
+	model.DB = db
+
+	logs := []model.Log{
+		{UserId: 1, CreatedAt: 100, Type: model.LogTypeConsume, TokenName: "mcp", ModelName: "gpt-4o"},
+		{UserId: 1, CreatedAt: 200, Type: model.LogTypeConsume, TokenName: "other", ModelName: "gpt-4o-mini"},
+		{UserId: 1, CreatedAt: 300, Type: model.LogTypeTopup, TokenName: "mcp", ModelName: "topup"},
+		{UserId: 2, CreatedAt: 400, Type: model.LogTypeConsume, TokenName: "mcp", ModelName: "claude"},
+	}
+	if err = db.Create(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tool := &RecentUsage{}
+	// 注册工具时才会生成参数校验所需的 schema
+	tool.GetTool()
+	ctx := caller.WithCaller(context.Background(), &caller.Caller{UserId: 1, TokenId: 1, TokenName: "mcp"})
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+	}{
+		{"all tokens", map[string]any{}, []string{"最近2条", "gpt-4o-mini", "Model:gpt-4o "}, []string{"topup", "claude"}},
+		{"limit", map[string]any{"limit": 1}, []string{"最近1条", "gpt-4o-mini"}, []string{"Model:gpt-4o "}},
+		{"token only", map[string]any{"tokenOnly": true}, []string{"最近1条", "Model:gpt-4o "}, []string{"gpt-4o-mini"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, _ := json.Marshal(tt.args)
+			result, err := tool.HandleRequest(ctx, &protocol.CallToolRequest{RawArguments: args})
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := result.Content[0].(*protocol.TextContent).Text
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("result %q should contain %q", text, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("result %q should not contain %q", text, notWant)
+				}
+			}
+		})
+	}
+
+	if _, err = tool.HandleRequest(context.Background(), &protocol.CallToolRequest{}); err == nil {
+		t.Fatal("call without caller should fail")
+	}
+}
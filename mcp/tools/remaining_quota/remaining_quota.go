@@ -0,0 +1,70 @@
+package remaining_quota
+
+import (
+	"context"
+	"done-hub/common"
+	"done-hub/mcp/caller"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+)
+
+const NAME = "remaining_quota"
+
+// RemainingQuota 查询当前用户和令牌的剩余额度
+type RemainingQuota struct{}
+
+type quotaQueryParam struct {
+}
+
+// GetTool 返回额度查询工具的定义
+func (c *RemainingQuota) GetTool() *protocol.Tool {
+	quotaTool, _ := protocol.NewTool(
+		NAME,
+		"查询当前账户余额、已用额度，以及当前令牌的剩余额度和过期时间",
+		quotaQueryParam{},
+	)
+	return quotaTool
+}
+
+func (c *RemainingQuota) HandleRequest(ctx context.Context, _ *protocol.CallToolRequest) (*protocol.CallToolResult, error) {
+	current := caller.FromContext(ctx)
+	if current == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	user, err := model.GetUserById(current.UserId, false)
+	if err != nil {
+		return nil, errors.New("用户不存在")
+	}
+	token, err := model.GetTokenById(current.TokenId)
+	if err != nil {
+		return nil, errors.New("令牌不存在")
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("账户余额:%s 已用:%s 请求次数:%d 分组:%s\n", common.LogQuota(user.Quota), common.LogQuota(user.UsedQuota), user.RequestCount, user.Group))
+
+	remain := "无限制"
+	if !token.UnlimitedQuota {
+		remain = common.LogQuota(token.RemainQuota)
+	}
+	expired := "永不过期"
+	if token.ExpiredTime != -1 {
+		expired = time.Unix(token.ExpiredTime, 0).Format("2006-01-02 15:04:05")
+	}
+	result.WriteString(fmt.Sprintf("令牌:%s 剩余额度:%s 已用:%s 过期时间:%s\n", token.Name, remain, common.LogQuota(token.UsedQuota), expired))
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			&protocol.TextContent{
+				Type: "text",
+				Text: result.String(),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,53 @@
+package remaining_quota
+
+import (
+	"context"
+	"done-hub/mcp/caller"
+	"done-hub/model"
+	"strings"
+	"testing"
+
+	"github.com/ThinkInAIXYZ/go-mcp/protocol"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestHandleRequest(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.User{}, &model.Token{}); err != nil {
+		t.Fatal(err)
+	}
+	originDB := model.DB
+	t.Cleanup(func() { model.DB = originDB })
+	model.DB = db
+
+	db.Create(&model.User{Id: 1, Username: "alice", AccessToken: "a", AffCode: "a", Group: "vip"})
+	// 跳过生成令牌密钥的钩子
+	db.Session(&gorm.Session{SkipHooks: true}).Create(&model.Token{Id: 1, UserId: 1, Key: "k", Name: "mcp-token", ExpiredTime: -1, UnlimitedQuota: true})
+
+	tool := &RemainingQuota{}
+	if _, err = tool.HandleRequest(context.Background(), &protocol.CallToolRequest{}); err == nil {
+		t.Fatal("call without caller should fail")
+	}
+
+	ctx := caller.WithCaller(context.Background(), &caller.Caller{UserId: 1, TokenId: 1, TokenName: "mcp-token"})
+	result, err := tool.HandleRequest(ctx, &protocol.CallToolRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := result.Content[0].(*protocol.TextContent).Text
+	for _, want := range []string{"分组:vip", "令牌:mcp-token", "剩余额度:无限制", "永不过期"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result %q should contain %q", text, want)
+		}
+	}
+
+	ctx = caller.WithCaller(context.Background(), &caller.Caller{UserId: 1, TokenId: 2})
+	if _, err = tool.HandleRequest(ctx, &protocol.CallToolRequest{}); err == nil {
+		t.Fatal("missing token should fail")
+	}
+}
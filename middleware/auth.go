@@ -68,6 +68,9 @@ func authHelper(c *gin.Context, minRole int) {
 	c.Set("username", username)
 	c.Set("role", role)
 	c.Set("id", id)
+	if !setupTenantScope(c, id.(int), role.(int)) {
+		return
+	}
 	c.Next()
 }
 
@@ -252,6 +255,17 @@ func GeminiAuth() func(c *gin.Context) {
 	}
 }
 
+// MCPAuth MCP 连接使用 API 令牌鉴权，无法设置请求头的客户端可以将令牌放在路径中
+func MCPAuth() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		key := c.Request.Header.Get("Authorization")
+		if key == "" {
+			key = c.Param("token")
+		}
+		tokenAuth(c, key)
+	}
+}
+
 func MjAuth() func(c *gin.Context) {
 	return func(c *gin.Context) {
 		// 判断path :mode
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"bytes"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"done-hub/safty"
+	saftyTypes "done-hub/safty/types"
+	"done-hub/types"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 需要审查的文本字段，覆盖 OpenAI、Claude、Gemini 等格式的请求
+var safetyTextKeys = map[string]bool{
+	"content":      true,
+	"text":         true,
+	"prompt":       true,
+	"input":        true,
+	"instructions": true,
+	"system":       true,
+}
+
+type safetyText struct {
+	path string
+	text string
+}
+
+// ContentSafety 请求转发到上游前的内容安全审查
+// 根据配置对命中的请求进行拦截、记录或替换命中内容
+func ContentSafety() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.EnableSafe || !contentSafetyEnabled(c) || !strings.Contains(c.ContentType(), "json") {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body.Close()
+		if err != nil {
+			abortWithMessage(c, http.StatusBadRequest, "读取请求失败")
+			return
+		}
+
+		body, ok := screenRequestBody(c, body)
+		if !ok {
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Request.ContentLength = int64(len(body))
+		c.Next()
+	}
+}
+
+// contentSafetyEnabled 审查分组为空时审查所有请求，令牌可单独开启审查
+func contentSafetyEnabled(c *gin.Context) bool {
+	if setting, exists := c.Get("token_setting"); exists {
+		if tokenSetting, ok := setting.(*model.TokenSetting); ok && tokenSetting != nil && tokenSetting.ContentSafety {
+			return true
+		}
+	}
+
+	if strings.TrimSpace(config.SafeGroups) == "" {
+		return true
+	}
+
+	group := c.GetString("token_group")
+	if group == "" {
+		group = c.GetString("group")
+	}
+	for _, item := range strings.Split(config.SafeGroups, ",") {
+		if strings.TrimSpace(item) == group {
+			return true
+		}
+	}
+	return false
+}
+
+// screenRequestBody 审查请求中的文本，返回处理后的请求体；请求被拦截时返回 false
+func screenRequestBody(c *gin.Context, body []byte) ([]byte, bool) {
+	if !gjson.ValidBytes(body) {
+		return body, true
+	}
+
+	texts := make([]safetyText, 0)
+	collectSafetyTexts(gjson.ParseBytes(body), "", "", &texts)
+	if len(texts) == 0 {
+		return body, true
+	}
+
+	// 只有替换处理需要逐段审查，其余情况合并后审查一次，减少外部审查模型的调用
+	if config.SafeAction != saftyTypes.SafeActionRedact {
+		merged := make([]string, 0, len(texts))
+		for _, item := range texts {
+			merged = append(merged, item.text)
+		}
+		texts = []safetyText{{text: strings.Join(merged, "\n")}}
+	}
+
+	for _, item := range texts {
+		result, _ := safty.CheckContent(item.text)
+		if result.IsSafe {
+			continue
+		}
+
+		switch config.SafeAction {
+		case saftyTypes.SafeActionFlag:
+			recordContentSafetyHit(c, result, "已放行")
+			continue
+		case saftyTypes.SafeActionRedact:
+			if len(result.Matches) > 0 && item.path != "" {
+				redacted, err := sjson.SetBytes(body, item.path, safty.Redact(item.text, result.Matches))
+				if err == nil {
+					body = redacted
+					recordContentSafetyHit(c, result, "已替换命中内容")
+					continue
+				}
+			}
+		}
+
+		recordContentSafetyHit(c, result, "已拦截")
+		abortWithRelayError(c, &types.OpenAIErrorWithStatusCode{
+			OpenAIError: types.OpenAIError{
+				Message: result.Reason,
+				Type:    "one_hub_error",
+				Code:    result.Code,
+			},
+			StatusCode: http.StatusBadRequest,
+			LocalError: true,
+		})
+		return nil, false
+	}
+
+	return body, true
+}
+
+// collectSafetyTexts 递归收集请求中需要审查的文本及其路径
+func collectSafetyTexts(value gjson.Result, path, key string, texts *[]safetyText) {
+	switch {
+	case value.IsObject():
+		value.ForEach(func(k, v gjson.Result) bool {
+			collectSafetyTexts(v, joinSafetyPath(path, escapeSafetyPathKey(k.String())), k.String(), texts)
+			return true
+		})
+	case value.IsArray():
+		index := 0
+		value.ForEach(func(_, v gjson.Result) bool {
+			// 数组元素沿用父级的字段名，如 prompt: ["a", "b"]
+			collectSafetyTexts(v, joinSafetyPath(path, strconv.Itoa(index)), key, texts)
+			index++
+			return true
+		})
+	case value.Type == gjson.String && safetyTextKeys[key] && value.Str != "":
+		*texts = append(*texts, safetyText{path: path, text: value.Str})
+	}
+}
+
+func joinSafetyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func escapeSafetyPathKey(key string) string {
+	replacer := strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`, "|", `\|`, "#", `\#`, "@", `\@`)
+	return replacer.Replace(key)
+}
+
+func recordContentSafetyHit(c *gin.Context, result saftyTypes.CheckResult, action string) {
+	content := fmt.Sprintf("内容安全审查命中（%s）：%s", action, result.Reason)
+	if len(result.Details) > 0 {
+		content += "，" + strings.Join(result.Details, ", ")
+	}
+	logger.LogWarn(c.Request.Context(), fmt.Sprintf("user %d token %d %s", c.GetInt("id"), c.GetInt("token_id"), content))
+
+	if userId := c.GetInt("id"); userId > 0 {
+		model.RecordLog(userId, model.LogTypeSystem, content)
+	}
+}
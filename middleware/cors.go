@@ -1,17 +1,91 @@
 package middleware
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 )
 
+// 管理后台中允许任意来源访问的公开接口，使用中继接口的跨域策略
+var publicApiPaths = map[string]bool{
+	"/api/prices":          true,
+	"/api/available_model": true,
+}
+
+var (
+	corsOnce    sync.Once
+	relayCORS   gin.HandlerFunc
+	apiCORS     gin.HandlerFunc
+	noopHandler = func(c *gin.Context) {}
+)
+
+// CORS 按路由分组应用跨域策略
+// /api 下的管理后台接口使用 cors.api 配置，其余中继接口使用 cors.relay 配置
 func CORS() gin.HandlerFunc {
+	corsOnce.Do(func() {
+		relayCORS = newCORS("cors.relay")
+		apiCORS = newCORS("cors.api")
+	})
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if strings.HasPrefix(path, "/api/") && !publicApiPaths[path] {
+			apiCORS(c)
+			return
+		}
+		relayCORS(c)
+	}
+}
+
+// newCORS 根据配置创建跨域中间件，未配置允许的来源时不处理跨域请求
+func newCORS(prefix string) gin.HandlerFunc {
+	origins := corsStringSlice(prefix + ".allow_origins")
+	if len(origins) == 0 {
+		return noopHandler
+	}
+
 	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowCredentials = true
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"*"}
-	// 添加 Vary 头部以防止 CDN 缓存问题
-	config.ExposeHeaders = []string{"Vary", "Cache-Control"}
+	for _, origin := range origins {
+		if origin == "*" {
+			config.AllowAllOrigins = true
+			break
+		}
+		if strings.Contains(origin, "*") {
+			config.AllowWildcard = true
+		}
+	}
+	if !config.AllowAllOrigins {
+		config.AllowOrigins = origins
+	}
+
+	config.AllowCredentials = viper.GetBool(prefix + ".allow_credentials")
+	if methods := corsStringSlice(prefix + ".allow_methods"); len(methods) > 0 {
+		config.AllowMethods = methods
+	}
+	if headers := corsStringSlice(prefix + ".allow_headers"); len(headers) > 0 {
+		config.AllowHeaders = headers
+	}
+	config.ExposeHeaders = corsStringSlice(prefix + ".expose_headers")
+	if maxAge := viper.GetInt(prefix + ".max_age"); maxAge > 0 {
+		config.MaxAge = time.Duration(maxAge) * time.Second
+	}
+
 	return cors.New(config)
 }
+
+// corsStringSlice 兼容配置文件中的列表和环境变量中逗号分隔的字符串
+func corsStringSlice(key string) []string {
+	result := make([]string, 0)
+	for _, value := range viper.GetStringSlice(key) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
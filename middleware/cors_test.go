@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// TestCORS 测试中继接口与管理后台接口使用不同的跨域策略
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	viper.Set("cors.relay.allow_origins", "*")
+	viper.Set("cors.api.allow_origins", "https://admin.example.com")
+	defer viper.Reset()
+
+	router := gin.New()
+	router.Use(CORS())
+	router.GET("/v1/models", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/user/self", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/prices", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{"中继接口允许任意来源", http.MethodGet, "/v1/models", "https://app.example.com", http.StatusOK, "*"},
+		{"中继接口预检请求", http.MethodOptions, "/v1/chat/completions", "https://app.example.com", http.StatusNoContent, "*"},
+		{"后台接口允许的来源", http.MethodGet, "/api/user/self", "https://admin.example.com", http.StatusOK, "https://admin.example.com"},
+		{"后台接口拒绝其他来源", http.MethodGet, "/api/user/self", "https://app.example.com", http.StatusForbidden, ""},
+		{"公开接口使用中继策略", http.MethodGet, "/api/prices", "https://app.example.com", http.StatusOK, "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"done-hub/common/config"
 	"done-hub/model"
 	"fmt"
 	"net/http"
@@ -24,6 +25,16 @@ func (gd *GroupDistributor) SetupGroups() error {
 	userGroup, _ := model.CacheGetUserGroup(userId)
 	gd.context.Set("group", userGroup)
 
+	// 多租户模式下令牌只能使用所属租户的渠道
+	if config.MultiTenantEnabled {
+		tenantId, err := model.CacheGetUserTenantId(userId)
+		if err != nil {
+			abortWithMessage(gd.context, http.StatusForbidden, "无法获取用户所属租户")
+			return err
+		}
+		gd.context.Set("tenant_id", tenantId)
+	}
+
 	tokenGroup := gd.context.GetString("token_group")
 	backupGroup := gd.context.GetString("token_backup_group")
 
@@ -42,6 +53,13 @@ func (gd *GroupDistributor) SetupGroups() error {
 	// 初始设置分组比例（使用第一优先级分组）
 	// 注意：实际使用的分组可能会在relay层根据渠道可用性动态调整
 	initialGroup := gd.getInitialGroup(tokenGroup, backupGroup, userGroup)
+
+	// 地区限制按令牌设置与初始分组检查
+	if err := checkGeoRestriction(gd.context, initialGroup); err != nil {
+		abortWithMessage(gd.context, http.StatusForbidden, err.Error())
+		return err
+	}
+
 	return gd.setGroupRatio(initialGroup)
 }
 
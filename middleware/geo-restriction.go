@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/geoip"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"fmt"
+	"net"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkGeoRestriction 解析客户端 IP 的地理位置并检查令牌与分组的地区限制，
+// 未加载 GeoIP 数据库时不做限制，能确认来源的内网地址视为允许
+func checkGeoRestriction(c *gin.Context, group string) error {
+	if !geoip.IsEnabled() {
+		return nil
+	}
+
+	ip := c.ClientIP()
+	location := geoip.Lookup(ip)
+	if location != nil {
+		c.Set(geoip.ContextKey, location)
+	}
+
+	rules := make([][]string, 0, 2)
+	if setting, ok := c.Get("token_setting"); ok {
+		if tokenSetting, ok := setting.(*model.TokenSetting); ok && tokenSetting != nil {
+			regionSetting := tokenSetting.Limits.LimitsRegionSetting
+			if regionSetting.Enabled && len(regionSetting.Regions) > 0 {
+				rules = append(rules, regionSetting.Regions)
+			}
+		}
+	}
+	if userGroup := model.GlobalUserGroupRatio.GetBySymbol(group); userGroup != nil && userGroup.AllowedRegions != "" {
+		rules = append(rules, geoip.ParseRegions(userGroup.AllowedRegions))
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	if isInternalIP(ip) {
+		if internalIPExempt(c, ip) {
+			return nil
+		}
+		// 无法确认真实来源时按未知地区处理，由 GeoRestrictionAction 决定拒绝还是标记
+		logger.LogError(c.Request.Context(), fmt.Sprintf("cannot verify the region of forwarded request from internal ip %s (remote: %s), set TRUSTED_PROXIES to the reverse proxy addresses", ip, c.RemoteIP()))
+	}
+
+	for _, allowed := range rules {
+		if geoip.Match(location, allowed) {
+			continue
+		}
+
+		region := "unknown"
+		if location != nil {
+			region = location.Country
+			if location.Region != "" {
+				region = location.Region
+			}
+		}
+
+		if config.GeoRestrictionAction == "flag" {
+			c.Set(geoip.FlaggedContextKey, true)
+			logger.LogWarn(c.Request.Context(), fmt.Sprintf("request from restricted region %s (ip: %s, token: %d)", region, ip, c.GetInt("token_id")))
+			return nil
+		}
+		return fmt.Errorf("当前地区（%s）不允许访问", region)
+	}
+
+	return nil
+}
+
+// isInternalIP 判断是否为内网地址，无法解析的地址不视为内网
+func isInternalIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && (parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsUnspecified())
+}
+
+// internalIPExempt 内网地址只有在能确认是真实来源时才免于地区限制：
+// 未配置可信代理时内网地址可能来自伪造的转发请求头；请求带有转发请求头但客户端 IP 仍是对端地址时，
+// 说明请求经过了未被信任的反向代理，内网地址只是代理自身的地址
+func internalIPExempt(c *gin.Context, ip string) bool {
+	if !clientIPVerified(c) {
+		return false
+	}
+	if ip == c.RemoteIP() && (c.GetHeader("X-Forwarded-For") != "" || c.GetHeader("X-Real-IP") != "") {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestGeoInternalIPIgnoresSpoofedHeader 测试伪造的 X-Forwarded-For 不能让外网请求被视为内网地址
+func TestGeoInternalIPIgnoresSpoofedHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer func() { trustedProxiesConfigured = false }()
+
+	tests := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		want           bool
+	}{
+		{"spoofed private", "none", "1.2.3.4:1234", "192.168.1.1", false},
+		{"direct private", "", "192.168.1.1:1234", "", true},
+		{"trusted proxy", "10.0.0.0/8", "10.0.0.1:1234", "8.8.8.8", false},
+		{"trusted proxy private client", "10.0.0.0/8", "10.0.0.1:1234", "192.168.1.1", true},
+		{"invalid remote", "", "invalid", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			server := gin.New()
+			if err := SetTrustedProxies(server, tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+			server.GET("/", func(c *gin.Context) {
+				got = isInternalIP(c.ClientIP())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			server.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Fatalf("isInternalIP = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGeoInternalIPExempt 测试只有能确认真实来源的内网地址才免于地区限制
+func TestGeoInternalIPExempt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer func() { trustedProxiesConfigured = false }()
+
+	tests := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		want           bool
+	}{
+		{"direct private", "", "192.168.1.1:1234", "", true},
+		{"unverified forwarded private", "", "1.2.3.4:1234", "192.168.1.1", false},
+		{"untrusted reverse proxy", "none", "10.0.0.1:1234", "8.8.8.8", false},
+		{"trusted proxy private client", "10.0.0.0/8", "10.0.0.1:1234", "192.168.1.1", true},
+		{"proxy outside trusted list", "10.0.0.0/8", "172.16.0.1:1234", "8.8.8.8", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			server := gin.New()
+			if err := SetTrustedProxies(server, tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+			server.GET("/", func(c *gin.Context) {
+				ip := c.ClientIP()
+				got = isInternalIP(ip) && internalIPExempt(c, ip)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			server.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Fatalf("internal ip exempt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
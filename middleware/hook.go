@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"done-hub/common/hook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreAuthHook 在鉴权之前执行 pre_auth 阶段的扩展钩子
+func PreAuthHook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hook.Enabled(hook.StagePreAuth) {
+			c.Next()
+			return
+		}
+
+		result := hook.RunWithContext(c, hook.NewEvent(c, hook.StagePreAuth))
+		if result.Reject {
+			abortWithMessage(c, result.StatusCode, result.Message)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"done-hub/common/config"
+	"done-hub/common/idempotency"
+	"done-hub/common/logger"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyKeyMaxLength = 255
+	// 超过该大小的响应不缓存，重试时会重新请求
+	idempotencyMaxBodySize = 4 << 20
+	// 未设置请求体大小限制时，计算请求摘要最多读取的请求体大小
+	idempotencyMaxRequestSize = 32 << 20
+	// 处理中标记的有效期，防止进程异常退出后 key 一直不可用
+	idempotencyLockTTL = 10 * time.Minute
+)
+
+// idempotencyWriter 在返回给客户端的同时记录响应内容，包括流式响应
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *idempotencyWriter) capture(data []byte) {
+	// 心跳与保活内容不缓存，重放时不需要
+	if w.overflow || isHeartbeatWrite(data) {
+		return
+	}
+	if w.body.Len()+len(data) > idempotencyMaxBodySize {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
+
+// Idempotency 支持 Idempotency-Key 请求头，需放在令牌鉴权之后
+// 相同令牌、相同 key 的请求在缓存时间内直接返回首次成功的响应，不会重复请求上游和扣费
+func Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost || config.IdempotencyKeyTTL <= 0 {
+			c.Next()
+			return
+		}
+
+		if len(key) > idempotencyKeyMaxLength {
+			abortWithMessage(c, http.StatusBadRequest, fmt.Sprintf("Idempotency-Key 长度不能超过 %d", idempotencyKeyMaxLength))
+			return
+		}
+
+		fingerprint, err := requestFingerprint(c)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				abortWithMessage(c, http.StatusRequestEntityTooLarge, requestBodyTooLargeMessage(maxBytesErr.Limit))
+				return
+			}
+			abortWithMessage(c, http.StatusBadRequest, "读取请求体失败")
+			return
+		}
+
+		storeKey := fmt.Sprintf("%d:%s", c.GetInt("token_id"), hashString(key))
+		if replayIdempotentResponse(c, storeKey, fingerprint) {
+			return
+		}
+
+		acquired, err := idempotency.Acquire(storeKey, idempotencyLockTTL)
+		if err != nil {
+			logger.LogError(c.Request.Context(), "idempotency acquire failed: "+err.Error())
+			c.Next()
+			return
+		}
+		if !acquired {
+			abortWithMessage(c, http.StatusConflict, "相同 Idempotency-Key 的请求正在处理中，请稍后重试")
+			return
+		}
+		defer idempotency.Release(storeKey)
+
+		// 获取标记前可能刚好有请求完成
+		if replayIdempotentResponse(c, storeKey, fingerprint) {
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// 仅缓存完整返回的成功响应，失败时允许客户端使用相同的 key 重试；
+		// 流式响应开始输出后中继失败或客户端断开时状态码仍为 200，同样不缓存
+		status := writer.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices || writer.overflow {
+			return
+		}
+		if c.GetBool(config.GinRelayFailedKey) || c.Request.Context().Err() != nil {
+			return
+		}
+
+		record := &idempotency.Record{
+			Fingerprint: fingerprint,
+			StatusCode:  status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := idempotency.Save(storeKey, record, time.Duration(config.IdempotencyKeyTTL)*time.Second); err != nil {
+			logger.LogError(c.Request.Context(), "idempotency save failed: "+err.Error())
+		}
+	}
+}
+
+// replayIdempotentResponse 存在缓存的响应时直接返回，请求内容不一致时拒绝
+func replayIdempotentResponse(c *gin.Context, storeKey, fingerprint string) bool {
+	record, err := idempotency.Get(storeKey)
+	if err != nil {
+		logger.LogError(c.Request.Context(), "idempotency get failed: "+err.Error())
+		return false
+	}
+	if record == nil {
+		return false
+	}
+
+	if record.Fingerprint != fingerprint {
+		abortWithMessage(c, http.StatusUnprocessableEntity, "Idempotency-Key 已用于其他请求，请更换后重试")
+		return true
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+	c.Abort()
+	return true
+}
+
+// requestFingerprint 根据请求路径和请求体计算摘要，读取后恢复请求体供后续处理
+// 请求体按大小限制读取，未设置限制时最多读取 idempotencyMaxRequestSize
+func requestFingerprint(c *gin.Context) (string, error) {
+	maxSize := getRequestLimits(c).maxBodySize
+	if maxSize <= 0 {
+		maxSize = idempotencyMaxRequestSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSize))
+	if err != nil {
+		return "", err
+	}
+	c.Request.Body.Close()
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isHeartbeatWrite 判断写入的内容是否为心跳：SSE 注释行或非流式响应前的空白
+func isHeartbeatWrite(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || trimmed[0] == ':'
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
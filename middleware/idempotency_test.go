@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.Logger = zap.NewNop()
+
+	calls := 0
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("token_id", 1)
+	}, Idempotency())
+	router.POST("/v1/chat/completions", func(c *gin.Context) {
+		calls++
+		if strings.Contains(c.GetHeader("X-Fail"), "1") {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "upstream"})
+			return
+		}
+		if c.GetHeader("X-Stream") != "" {
+			// 保活内容不缓存，流式输出开始后失败的响应不缓存
+			c.Writer.WriteString(": ping\n\n")
+			c.Writer.WriteString("data: {\"calls\":1}\n\n")
+			c.Set(config.GinRelayFailedKey, c.GetHeader("X-Stream") == "fail")
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+
+	send := func(key, body, fail string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		req.Header.Set("X-Fail", fail)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := send("key-1", `{"model":"gpt"}`, "")
+	second := send("key-1", `{"model":"gpt"}`, "")
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, got %d", calls)
+	}
+	if second.Header().Get(IdempotencyReplayedHeader) != "true" || second.Body.String() != first.Body.String() || second.Code != first.Code {
+		t.Fatalf("expected replayed response, got %d %q", second.Code, second.Body.String())
+	}
+
+	if w := send("key-1", `{"model":"other"}`, ""); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for reused key, got %d", w.Code)
+	}
+
+	send("key-2", `{}`, "1")
+	if w := send("key-2", `{}`, ""); w.Code != http.StatusOK || w.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Fatalf("failed response should not be cached, got %d", w.Code)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 handler calls, got %d", calls)
+	}
+
+	send("key-3", `{}`, "", "X-Stream", "fail")
+	send("key-3", `{}`, "", "X-Stream", "ok")
+	if calls != 5 {
+		t.Fatalf("stream failed after output should not be cached, got %d calls", calls)
+	}
+	if w := send("key-3", `{}`, "", "X-Stream", "ok"); w.Header().Get(IdempotencyReplayedHeader) != "true" || w.Body.String() != "data: {\"calls\":1}\n\n" {
+		t.Fatalf("expected replayed stream without heartbeats, got %q", w.Body.String())
+	}
+
+	// 请求体超过大小限制时不读取完整请求体
+	config.RequestMaxBodySize = 1
+	defer func() { config.RequestMaxBodySize = 0 }()
+	if w := send("key-4", `{"input":"`+strings.Repeat("a", 2<<20)+`"}`, ""); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for oversized body, got %d", w.Code)
+	}
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/limit"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IP_LIMIT_KEY               = "ip-limiter:%s:%s"
+	IP_RATE_LIMIT_EXCEEDED_MSG = "当前 IP 请求过于频繁，请稍后再试。"
+)
+
+// ipRateLimiter 按客户端 IP 限流，设置变更后重新创建限流器
+// 启用 Redis 时计数保存在 Redis 中，多节点部署共享同一限额
+type ipRateLimiter struct {
+	sync.Mutex
+	rpm     int
+	limiter limit.RateLimiter
+}
+
+func (l *ipRateLimiter) get(rpm int) limit.RateLimiter {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.limiter == nil || l.rpm != rpm {
+		if stopper, ok := l.limiter.(interface{ Stop() }); ok {
+			stopper.Stop()
+		}
+		l.limiter = limit.NewAPILimiter(rpm)
+		l.rpm = rpm
+	}
+	return l.limiter
+}
+
+var (
+	relayIPRateLimiter = &ipRateLimiter{}
+	authIPRateLimiter  = &ipRateLimiter{}
+)
+
+// ipWhitelist 缓存解析后的白名单，设置未变化时不重复解析
+var ipWhitelist = struct {
+	sync.RWMutex
+	raw  string
+	nets []*net.IPNet
+}{}
+
+// RelayIPRateLimit 中继接口的 IP 限流，需放在鉴权之前，避免无效令牌的暴力尝试
+func RelayIPRateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !allowIPRequest(c, relayIPRateLimiter, config.IPRelayRateLimit, "relay") {
+			c.Header("Retry-After", "60")
+			abortWithMessage(c, http.StatusTooManyRequests, IP_RATE_LIMIT_EXCEEDED_MSG)
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuthIPRateLimit 登录、注册等认证接口的 IP 限流
+func AuthIPRateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !allowIPRequest(c, authIPRateLimiter, config.IPAuthRateLimit, "auth") {
+			c.Header("Retry-After", "60")
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"message": IP_RATE_LIMIT_EXCEEDED_MSG,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// trustedProxiesConfigured 是否通过 TRUSTED_PROXIES 明确配置了可信代理
+var trustedProxiesConfigured bool
+
+// SetTrustedProxies 设置可信的反向代理，多个用逗号分隔。未设置时保持原有行为信任所有代理转发的
+// X-Forwarded-For、X-Real-IP，兼容已部署在反向代理之后的站点；设置为 none 时不信任任何代理，
+// 客户端 IP 取连接的对端地址
+func SetTrustedProxies(server *gin.Engine, trustedProxies string) error {
+	trustedProxies = strings.TrimSpace(trustedProxies)
+	trustedProxiesConfigured = trustedProxies != ""
+	if trustedProxies == "" {
+		return nil
+	}
+	if strings.EqualFold(trustedProxies, "none") {
+		return server.SetTrustedProxies(nil)
+	}
+
+	var proxies []string
+	for _, proxy := range strings.Split(trustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return server.SetTrustedProxies(proxies)
+}
+
+// TrustedProxiesConfigured 是否明确配置了可信代理
+func TrustedProxiesConfigured() bool {
+	return trustedProxiesConfigured
+}
+
+// clientIPVerified 客户端 IP 是否可信：明确配置了可信代理，或 IP 就是连接的对端地址。
+// 未配置可信代理时转发请求头可以被伪造，不能据此豁免限流或地区限制
+func clientIPVerified(c *gin.Context) bool {
+	return trustedProxiesConfigured || c.ClientIP() == c.RemoteIP()
+}
+
+func allowIPRequest(c *gin.Context, ipLimiter *ipRateLimiter, rpm int, scope string) bool {
+	if !config.IPRateLimitEnabled || rpm <= 0 {
+		return true
+	}
+
+	ip := c.ClientIP()
+	if (isLoopbackIP(ip) || isWhitelistedIP(ip)) && clientIPVerified(c) {
+		return true
+	}
+
+	return ipLimiter.get(rpm).Allow(fmt.Sprintf(IP_LIMIT_KEY, scope, ip))
+}
+
+func isWhitelistedIP(remoteIP string) bool {
+	ip := net.ParseIP(remoteIP)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range getIPWhitelist() {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func getIPWhitelist() []*net.IPNet {
+	raw := config.IPRateLimitWhitelist
+
+	ipWhitelist.RLock()
+	if ipWhitelist.raw == raw {
+		nets := ipWhitelist.nets
+		ipWhitelist.RUnlock()
+		return nets
+	}
+	ipWhitelist.RUnlock()
+
+	nets := parseIPNets(raw)
+
+	ipWhitelist.Lock()
+	ipWhitelist.raw = raw
+	ipWhitelist.nets = nets
+	ipWhitelist.Unlock()
+
+	return nets
+}
+
+// parseIPNets 解析逗号分隔的 IP 或 CIDR 网段，忽略无效的条目
+func parseIPNets(raw string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				continue
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		if _, ipNet, err := net.ParseCIDR(item); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
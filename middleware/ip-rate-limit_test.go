@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestIsWhitelistedIP 测试 IP 白名单解析与匹配
+func TestIsWhitelistedIP(t *testing.T) {
+	config.IPRateLimitWhitelist = "1.2.3.4, 10.0.0.0/8, invalid, 2001:db8::/32"
+	defer func() { config.IPRateLimitWhitelist = "" }()
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"1.2.3.4", true},
+		{"1.2.3.5", false},
+		{"10.20.30.40", true},
+		{"2001:db8::1", true},
+		{"2001:db9::1", false},
+		{"not-an-ip", false},
+	}
+
+	for _, tt := range tests {
+		if got := isWhitelistedIP(tt.ip); got != tt.want {
+			t.Errorf("isWhitelistedIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+// TestSetTrustedProxies 测试未设置可信代理时保持信任转发请求头，设置为 none 时只取对端地址
+func TestSetTrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer func() { trustedProxiesConfigured = false }()
+
+	tests := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		want           string
+	}{
+		{"default", "", "10.0.0.1:1234", "1.2.3.4"},
+		{"none", "none", "10.0.0.1:1234", "10.0.0.1"},
+		{"trusted proxy", "10.0.0.0/8", "10.0.0.1:1234", "1.2.3.4"},
+		{"untrusted proxy", "10.0.0.0/8", "172.16.0.1:1234", "172.16.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := gin.New()
+			if err := SetTrustedProxies(server, tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+			server.GET("/", func(c *gin.Context) {
+				got = c.ClientIP()
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "1.2.3.4")
+			server.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Fatalf("ClientIP = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAllowIPRequestIgnoresSpoofedHeader 测试伪造的 X-Forwarded-For 不能借回环地址与白名单绕过限流
+func TestAllowIPRequestIgnoresSpoofedHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.IPRateLimitEnabled = true
+	config.IPRateLimitWhitelist = "5.6.7.8"
+	defer func() {
+		config.IPRateLimitEnabled = false
+		config.IPRateLimitWhitelist = ""
+		trustedProxiesConfigured = false
+	}()
+
+	tests := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		wantSecond     bool
+	}{
+		{"spoofed loopback", "", "1.2.3.4:1234", "127.0.0.1", false},
+		{"spoofed whitelist", "", "1.2.3.4:1234", "5.6.7.8", false},
+		{"spoofed loopback without proxies", "none", "1.2.3.4:1234", "127.0.0.1", false},
+		{"direct loopback", "", "127.0.0.1:1234", "", true},
+		{"direct whitelist", "", "5.6.7.8:1234", "", true},
+		{"trusted proxy", "10.0.0.0/8", "10.0.0.1:1234", "127.0.0.1", true},
+		{"untrusted proxy", "10.0.0.0/8", "1.2.3.5:1234", "127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &ipRateLimiter{}
+			allowed := make([]bool, 0, 2)
+
+			server := gin.New()
+			if err := SetTrustedProxies(server, tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+			server.GET("/", func(c *gin.Context) {
+				allowed = append(allowed, allowIPRequest(c, limiter, 1, "test"))
+			})
+
+			for i := 0; i < 2; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = tt.remoteAddr
+				if tt.forwardedFor != "" {
+					req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+				}
+				server.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			if !allowed[0] || allowed[1] != tt.wantSecond {
+				t.Fatalf("allowed = %v, want second request allowed = %v", allowed, tt.wantSecond)
+			}
+		})
+	}
+}
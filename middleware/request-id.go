@@ -4,19 +4,27 @@ import (
 	"context"
 	"done-hub/common/logger"
 	"done-hub/common/utils"
+	"regexp"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// 调用方传入的请求 ID 仅允许常见字符，避免写入日志和响应头时被注入
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
 func RequestId() func(c *gin.Context) {
 	return func(c *gin.Context) {
-		id := utils.GetTimeString() + utils.GetRandomString(8)
+		id := c.GetHeader(logger.RequestIdHeader)
+		if !requestIdPattern.MatchString(id) {
+			id = utils.GetTimeString() + utils.GetRandomString(8)
+		}
 		c.Set(logger.RequestIdKey, id)
 		c.Set("requestStartTime", time.Now())
 		ctx := context.WithValue(c.Request.Context(), logger.RequestIdKey, id)
 		c.Request = c.Request.WithContext(ctx)
 		c.Header(logger.RequestIdKey, id)
+		c.Header(logger.RequestIdHeader, id)
 		c.Next()
 	}
 }
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"done-hub/common/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestId())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, logger.GetRequestId(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"generate", "", false},
+		{"honor incoming", "req-123_abc.4:5", true},
+		{"reject invalid", "bad id\r\nX-Injected: 1", false},
+		{"reject too long", strings.Repeat("a", 65), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(logger.RequestIdHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(logger.RequestIdHeader)
+			if id == "" || id != w.Header().Get(logger.RequestIdKey) || id != w.Body.String() {
+				t.Fatalf("inconsistent request id: header=%q legacy=%q body=%q", id, w.Header().Get(logger.RequestIdKey), w.Body.String())
+			}
+			if tt.keep && id != tt.incoming {
+				t.Fatalf("expected incoming id %q, got %q", tt.incoming, id)
+			}
+			if !tt.keep && id == tt.incoming {
+				t.Fatalf("expected generated id, got %q", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"bytes"
+	"done-hub/common/config"
+	"done-hub/model"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// requestLimits 当前请求生效的大小限制，0 为不限制
+type requestLimits struct {
+	maxBodySize     int64
+	maxMessages     int
+	maxImageSize    int64
+	maxOutputTokens int
+}
+
+// 各接口格式中表示消息列表的字段，input 只在元素为对象时（Responses 接口）计为消息，不包括 embeddings 的文本列表
+var requestMessageKeys = []string{"messages", "contents", "input"}
+
+// 各接口格式中表示最大输出 token 数的字段
+var requestOutputTokenKeys = []string{
+	"max_tokens",
+	"max_completion_tokens",
+	"max_output_tokens",
+	"generationConfig.maxOutputTokens",
+	"generation_config.max_output_tokens",
+}
+
+// 与 base64 数据同级、用于标识内嵌文件的字段，覆盖 Claude 的 source 与 Gemini 的 inline_data
+var inlineDataMarkerKeys = []string{"media_type", "mime_type", "mimeType"}
+
+// RequestLimit 检查请求体大小、消息数、内嵌图片大小与最大输出 token 数，
+// 全局、分组与令牌的限制同时生效，取最严格的一个
+func RequestLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limits := getRequestLimits(c)
+		if *limits == (requestLimits{}) {
+			c.Next()
+			return
+		}
+
+		if limits.maxBodySize > 0 && c.Request.ContentLength > limits.maxBodySize {
+			abortWithMessage(c, http.StatusRequestEntityTooLarge, requestBodyTooLargeMessage(limits.maxBodySize))
+			return
+		}
+
+		isJSON := strings.Contains(c.ContentType(), "json")
+		if !isJSON && limits.maxBodySize == 0 {
+			c.Next()
+			return
+		}
+
+		reader := c.Request.Body
+		if limits.maxBodySize > 0 {
+			// 未声明 Content-Length（分块传输）时在读取过程中限制大小
+			reader = http.MaxBytesReader(c.Writer, c.Request.Body, limits.maxBodySize)
+		}
+		body, err := io.ReadAll(reader)
+		c.Request.Body.Close()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				abortWithMessage(c, http.StatusRequestEntityTooLarge, requestBodyTooLargeMessage(limits.maxBodySize))
+				return
+			}
+			abortWithMessage(c, http.StatusBadRequest, "读取请求失败")
+			return
+		}
+
+		if isJSON && gjson.ValidBytes(body) {
+			if err := checkRequestBody(gjson.ParseBytes(body), limits); err != nil {
+				abortWithMessage(c, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Request.ContentLength = int64(len(body))
+		c.Next()
+	}
+}
+
+// getRequestLimits 合并全局、分组与令牌的限制
+func getRequestLimits(c *gin.Context) *requestLimits {
+	limits := &requestLimits{
+		maxBodySize:     int64(config.RequestMaxBodySize) << 20,
+		maxMessages:     config.RequestMaxMessages,
+		maxImageSize:    int64(config.RequestMaxImageSize) << 20,
+		maxOutputTokens: config.RequestMaxOutputTokens,
+	}
+
+	// 本中间件在 Distribute 之前执行，令牌未指定分组时需自行读取用户分组
+	group := c.GetString("token_group")
+	if group == "" {
+		group = c.GetString("group")
+	}
+	if userId := c.GetInt("id"); group == "" && userId > 0 {
+		group, _ = model.CacheGetUserGroup(userId)
+	}
+	if userGroup := model.GlobalUserGroupRatio.GetBySymbol(group); userGroup != nil {
+		limits.merge(userGroup.MaxBodySize, userGroup.MaxMessages, userGroup.MaxImageSize, userGroup.MaxOutputTokens)
+	}
+
+	if setting, ok := c.Get("token_setting"); ok {
+		if tokenSetting, ok := setting.(*model.TokenSetting); ok && tokenSetting != nil {
+			requestSetting := tokenSetting.Limits.LimitsRequestSetting
+			if requestSetting.Enabled {
+				limits.merge(requestSetting.MaxBodySize, requestSetting.MaxMessages, requestSetting.MaxImageSize, requestSetting.MaxOutputTokens)
+			}
+		}
+	}
+
+	return limits
+}
+
+// merge 合并一组限制（大小单位为 MB），保留更严格的值
+func (l *requestLimits) merge(maxBodySize, maxMessages, maxImageSize, maxOutputTokens int) {
+	l.maxBodySize = stricterLimit(l.maxBodySize, int64(maxBodySize)<<20)
+	l.maxMessages = int(stricterLimit(int64(l.maxMessages), int64(maxMessages)))
+	l.maxImageSize = stricterLimit(l.maxImageSize, int64(maxImageSize)<<20)
+	l.maxOutputTokens = int(stricterLimit(int64(l.maxOutputTokens), int64(maxOutputTokens)))
+}
+
+func stricterLimit(current, limit int64) int64 {
+	if limit <= 0 {
+		return current
+	}
+	if current <= 0 || limit < current {
+		return limit
+	}
+	return current
+}
+
+// checkRequestBody 检查 JSON 请求体的消息数、内嵌图片大小与最大输出 token 数
+func checkRequestBody(body gjson.Result, limits *requestLimits) error {
+	if limits.maxMessages > 0 {
+		for _, key := range requestMessageKeys {
+			messages := body.Get(key)
+			if !messages.IsArray() || (key == "input" && !messages.Get("0").IsObject()) {
+				continue
+			}
+			if len(messages.Array()) > limits.maxMessages {
+				return fmt.Errorf("消息数量超过限制，最多 %d 条", limits.maxMessages)
+			}
+		}
+	}
+
+	if limits.maxOutputTokens > 0 {
+		for _, key := range requestOutputTokenKeys {
+			if value := body.Get(key); value.Exists() && value.Int() > int64(limits.maxOutputTokens) {
+				return fmt.Errorf("%s 超过限制，最大为 %d", key, limits.maxOutputTokens)
+			}
+		}
+	}
+
+	if limits.maxImageSize > 0 && inlineDataSize(body) > limits.maxImageSize {
+		return fmt.Errorf("请求中的图片总大小超过限制，最大为 %dMB", limits.maxImageSize>>20)
+	}
+
+	return nil
+}
+
+// inlineDataSize 估算请求中内嵌的 base64 数据解码后的总字节数，
+// 包括 data URL（OpenAI）以及带媒体类型字段的 data（Claude、Gemini）
+func inlineDataSize(value gjson.Result) int64 {
+	var size int64
+	switch {
+	case value.IsObject():
+		isInlineData := false
+		for _, key := range inlineDataMarkerKeys {
+			if value.Get(key).Exists() {
+				isInlineData = true
+				break
+			}
+		}
+		value.ForEach(func(key, item gjson.Result) bool {
+			if isInlineData && key.String() == "data" && item.Type == gjson.String {
+				size += base64DecodedSize(len(item.Str))
+			} else {
+				size += inlineDataSize(item)
+			}
+			return true
+		})
+	case value.IsArray():
+		value.ForEach(func(_, item gjson.Result) bool {
+			size += inlineDataSize(item)
+			return true
+		})
+	case value.Type == gjson.String:
+		if strings.HasPrefix(value.Str, "data:") {
+			if index := strings.Index(value.Str, ";base64,"); index > 0 {
+				size += base64DecodedSize(len(value.Str) - index - len(";base64,"))
+			}
+		}
+	}
+	return size
+}
+
+func base64DecodedSize(length int) int64 {
+	return int64(length) * 3 / 4
+}
+
+func requestBodyTooLargeMessage(maxBodySize int64) string {
+	return fmt.Sprintf("请求体大小超过限制，最大为 %dMB", maxBodySize>>20)
+}
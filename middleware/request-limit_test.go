@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestCheckRequestBody 测试消息数、最大输出 token 数与内嵌图片大小的检查
+func TestCheckRequestBody(t *testing.T) {
+	limits := &requestLimits{maxMessages: 2, maxOutputTokens: 1000, maxImageSize: 1 << 20}
+	image := strings.Repeat("A", 2<<20)
+
+	tests := []struct {
+		name string
+		body string
+		ok   bool
+	}{
+		{"within limits", `{"messages":[{"role":"user","content":"hi"}],"max_tokens":1000}`, true},
+		{"too many messages", `{"messages":[{},{},{}]}`, false},
+		{"too many gemini contents", `{"contents":[{},{},{}]}`, false},
+		{"embedding input", `{"input":["a","b","c"]}`, true},
+		{"too many responses input", `{"input":[{},{},{}]}`, false},
+		{"max tokens", `{"max_completion_tokens":1001}`, false},
+		{"gemini max tokens", `{"generationConfig":{"maxOutputTokens":4096}}`, false},
+		{"openai image", `{"messages":[{"content":[{"type":"image_url","image_url":{"url":"data:image/png;base64,` + image + `"}}]}]}`, false},
+		{"claude image", `{"messages":[{"content":[{"type":"image","source":{"type":"base64","media_type":"image/png","data":"` + image + `"}}]}]}`, false},
+		{"gemini image", `{"contents":[{"parts":[{"inline_data":{"mime_type":"image/png","data":"` + image + `"}}]}]}`, false},
+		{"remote image", `{"messages":[{"content":[{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}]}]}`, true},
+	}
+
+	for _, tt := range tests {
+		err := checkRequestBody(gjson.Parse(tt.body), limits)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected result %v", tt.name, err)
+		}
+	}
+}
+
+// TestRequestLimitBodySize 测试请求体超过大小限制时返回 413
+func TestRequestLimitBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.RequestMaxBodySize = 1
+	defer func() { config.RequestMaxBodySize = 0 }()
+
+	router := gin.New()
+	router.Use(RequestLimit())
+	router.POST("/v1/chat/completions", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		size          int
+		contentLength bool
+		want          int
+	}{
+		{1 << 10, true, http.StatusOK},
+		{2 << 20, true, http.StatusRequestEntityTooLarge},
+		{2 << 20, false, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		body := `{"input":"` + strings.Repeat("a", tt.size) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if !tt.contentLength {
+			req.ContentLength = -1
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("size %d: got status %d, want %d", tt.size, w.Code, tt.want)
+		}
+	}
+}
+
+// TestRequestLimitUserGroup 测试令牌未指定分组时应用用户分组的限制
+func TestRequestLimitUserGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.User{}); err != nil {
+		t.Fatal(err)
+	}
+	user := &model.User{Id: 1, Username: "user1", AccessToken: "access1", AffCode: "aff1", Group: "limited"}
+	if err = db.Session(&gorm.Session{SkipHooks: true}).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originGroups := model.DB, model.GlobalUserGroupRatio.UserGroup
+	t.Cleanup(func() {
+		model.DB = originDB
+		model.GlobalUserGroupRatio.UserGroup = originGroups
+	})
+	model.DB = db
+	model.GlobalUserGroupRatio.UserGroup = map[string]*model.UserGroup{
+		"limited": {Symbol: "limited", MaxMessages: 1},
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("id", 1)
+		c.Next()
+	}, RequestLimit())
+	router.POST("/v1/chat/completions", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"messages":[{"role":"user","content":"hi"}]}`, http.StatusOK},
+		{`{"messages":[{"role":"user","content":"hi"},{"role":"user","content":"hi"}]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("body %s: got status %d, want %d", tt.body, w.Code, tt.want)
+		}
+	}
+}
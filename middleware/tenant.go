@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const TenantHeader = "X-Tenant"
+
+// Tenant 根据 X-Tenant 请求头或域名解析请求所属的租户
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.MultiTenantEnabled {
+			c.Next()
+			return
+		}
+
+		var tenant *model.Tenant
+		if code := strings.TrimSpace(c.GetHeader(TenantHeader)); code != "" {
+			tenant = model.GlobalTenants.GetByCode(code)
+			if tenant == nil {
+				abortWithTenantMessage(c, http.StatusNotFound, "租户不存在")
+				return
+			}
+		} else {
+			tenant = model.GlobalTenants.GetByHost(c.Request.Host)
+		}
+
+		tenantId := model.DefaultTenantId
+		if tenant != nil {
+			if tenant.Status != model.TenantStatusEnabled {
+				abortWithTenantMessage(c, http.StatusForbidden, "租户已停用")
+				return
+			}
+			tenantId = tenant.Id
+		}
+
+		c.Set("request_tenant_id", tenantId)
+		c.Next()
+	}
+}
+
+// setupTenantScope 设置登录用户的租户，非超级管理员只能访问所属租户的站点
+// tenant_scope 为管理接口可访问的租户，超级管理员为 -1 表示全部租户，可通过 tenant_id 参数筛选
+func setupTenantScope(c *gin.Context, userId, role int) bool {
+	if !config.MultiTenantEnabled {
+		return true
+	}
+
+	tenantId, err := model.CacheGetUserTenantId(userId)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "无法获取用户所属租户",
+		})
+		c.Abort()
+		return false
+	}
+
+	scope := tenantId
+	if role >= config.RoleRootUser {
+		scope = -1
+		if queryTenant, err := strconv.Atoi(c.Query("tenant_id")); err == nil {
+			scope = queryTenant
+		}
+	} else if tenantId != c.GetInt("request_tenant_id") {
+		c.JSON(http.StatusOK, gin.H{
+			"success": false,
+			"message": "当前账号不属于该站点",
+		})
+		c.Abort()
+		return false
+	}
+
+	c.Set("tenant_id", tenantId)
+	c.Set("tenant_scope", scope)
+	return true
+}
+
+// TenantChannelGuard 校验管理员是否可以操作路径中指定的渠道
+func TenantChannelGuard() gin.HandlerFunc {
+	return tenantResourceGuard(func(id int) (int, error) {
+		channel, err := model.GetChannelById(id)
+		if err != nil {
+			return 0, err
+		}
+		return channel.TenantId, nil
+	})
+}
+
+// TenantUserGuard 校验管理员是否可以操作路径中指定的用户
+func TenantUserGuard() gin.HandlerFunc {
+	return tenantResourceGuard(model.GetUserTenantId)
+}
+
+func tenantResourceGuard(getTenantId func(id int) (int, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if !config.MultiTenantEnabled || err != nil {
+			c.Next()
+			return
+		}
+
+		tenantId, err := getTenantId(id)
+		if err != nil || !model.TenantAccessible(c.GetInt("tenant_scope"), tenantId) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "无权操作其他租户的数据",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// TenantRootOnly 开启多租户后，跨租户的批量操作仅超级管理员可用
+func TenantRootOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.MultiTenantEnabled && c.GetInt("role") < config.RoleRootUser {
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"message": "多租户模式下该操作仅超级管理员可用",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortWithTenantMessage(c *gin.Context, statusCode int, message string) {
+	if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+		c.JSON(statusCode, gin.H{
+			"success": false,
+			"message": message,
+		})
+		c.Abort()
+		return
+	}
+	abortWithMessage(c, statusCode, message)
+}
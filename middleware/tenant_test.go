@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"done-hub/common/config"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTenantResourceGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originEnabled := config.MultiTenantEnabled
+	defer func() { config.MultiTenantEnabled = originEnabled }()
+	config.MultiTenantEnabled = true
+
+	// 资源 1 属于租户 1，资源 2 属于租户 2，资源 3 不存在
+	guard := tenantResourceGuard(func(id int) (int, error) {
+		if id == 3 {
+			return 0, errors.New("record not found")
+		}
+		return id, nil
+	})
+
+	tests := []struct {
+		name  string
+		scope int
+		path  string
+		want  bool
+	}{
+		{"same tenant", 1, "/1", true},
+		{"other tenant", 1, "/2", false},
+		{"root scope", -1, "/2", true},
+		{"missing resource", 1, "/3", false},
+		{"non numeric id", 1, "/abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed := false
+			server := gin.New()
+			server.GET("/:id", func(c *gin.Context) {
+				c.Set("tenant_scope", tt.scope)
+				c.Next()
+			}, guard, func(c *gin.Context) {
+				passed = true
+			})
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if passed != tt.want {
+				t.Fatalf("expected passed %v, got %v: %s", tt.want, passed, w.Body.String())
+			}
+			if !tt.want {
+				var resp struct {
+					Success bool `json:"success"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Success {
+					t.Fatalf("unexpected response: %s", w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestTenantRootOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originEnabled := config.MultiTenantEnabled
+	defer func() { config.MultiTenantEnabled = originEnabled }()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		role    int
+		want    bool
+	}{
+		{"admin with multi tenant", true, config.RoleAdminUser, false},
+		{"root with multi tenant", true, config.RoleRootUser, true},
+		{"admin without multi tenant", false, config.RoleAdminUser, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.MultiTenantEnabled = tt.enabled
+			passed := false
+			server := gin.New()
+			server.GET("/", func(c *gin.Context) {
+				c.Set("role", tt.role)
+				c.Next()
+			}, TenantRootOnly(), func(c *gin.Context) {
+				passed = true
+			})
+
+			server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			if passed != tt.want {
+				t.Fatalf("expected passed %v, got %v", tt.want, passed)
+			}
+		})
+	}
+}
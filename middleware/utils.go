@@ -3,7 +3,11 @@ package middleware
 import (
 	"done-hub/common/logger"
 	"done-hub/common/utils"
+	"done-hub/providers/claude"
+	"done-hub/providers/gemini"
+	"done-hub/types"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,3 +33,17 @@ func midjourneyAbortWithMessage(c *gin.Context, code int, description string) {
 	c.Abort()
 	logger.LogError(c.Request.Context(), description)
 }
+
+// abortWithRelayError 按请求的接口返回对应格式的错误，Claude、Gemini 接口使用各自的错误结构，其他接口使用 OpenAI 格式
+func abortWithRelayError(c *gin.Context, err *types.OpenAIErrorWithStatusCode) {
+	path := c.Request.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/claude/"):
+		c.JSON(err.StatusCode, claude.OpenaiErrToClaudeErr(err).ClaudeError)
+	case strings.HasPrefix(path, "/gemini/"):
+		c.JSON(err.StatusCode, gemini.OpenaiErrToGeminiErr(err).GeminiErrorResponse)
+	default:
+		c.JSON(err.StatusCode, gin.H{"error": err.OpenAIError})
+	}
+	c.Abort()
+}
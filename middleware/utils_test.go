@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"done-hub/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+)
+
+// TestAbortWithRelayError 测试按接口返回对应格式的错误
+func TestAbortWithRelayError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		abortWithRelayError(c, &types.OpenAIErrorWithStatusCode{
+			OpenAIError: types.OpenAIError{Message: "blocked", Type: "one_hub_error", Code: "content_security_policy_blocking"},
+			StatusCode:  http.StatusBadRequest,
+			LocalError:  true,
+		})
+	})
+	router.POST("/*path", func(c *gin.Context) {})
+
+	tests := []struct {
+		path    string
+		field   string
+		message string
+	}{
+		{"/v1/chat/completions", "error.code", "error.message"},
+		{"/claude/v1/messages", "error.type", "error.message"},
+		{"/gemini/v1beta/models/gemini-pro:generateContent", "error.status", "error.message"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: got status %d", tt.path, w.Code)
+		}
+		body := gjson.ParseBytes(w.Body.Bytes())
+		if !body.Get(tt.field).Exists() || body.Get(tt.message).String() != "blocked" {
+			t.Errorf("%s: unexpected body %s", tt.path, w.Body.String())
+		}
+	}
+}
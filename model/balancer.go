@@ -51,6 +51,7 @@ type ChannelsChooser struct {
 	Cooldowns sync.Map
 
 	ModelGroup map[string]map[string]bool
+	Canary     map[string]*CanaryRule // model -> 灰度分流规则
 }
 
 type ChannelsFilterFunc func(channelId int, choice *ChannelChoice) bool
@@ -270,8 +271,8 @@ func (cc *ChannelsChooser) checkStickySession(channelIds []int, filters []Channe
 		return nil
 	}
 
-	// 检查渠道是否在冷却中
-	if cc.IsInCooldown(mappedChannelID, modelName) {
+	// 检查渠道是否在冷却中或已熔断
+	if cc.IsInCooldown(mappedChannelID, modelName) || !ChannelCircuitBreaker.Allow(mappedChannelID) {
 		// 渠道在冷却中，删除映射
 		redis.DeleteStickySessionMapping(sessionHash, channelType)
 		return nil
@@ -625,6 +626,10 @@ func (cc *ChannelsChooser) balancer(channelIds []int, filters []ChannelsFilterFu
 			continue
 		}
 
+		if !ChannelCircuitBreaker.Allow(channelId) {
+			continue
+		}
+
 		isSkip := false
 		for _, filter := range filters {
 			if filter(channelId, choice) {
@@ -751,11 +756,8 @@ func (cc *ChannelsChooser) Next(group, modelName string, filters ...ChannelsFilt
 		return nil, errors.New(ErrChannelNotFound)
 	}
 
-	for _, priority := range channelsPriority {
-		channel := cc.balancer(priority, filters, modelName, nil)
-		if channel != nil {
-			return channel, nil
-		}
+	if channel := cc.nextWithCanary(channelsPriority, filters, modelName, nil); channel != nil {
+		return channel, nil
 	}
 
 	return nil, errors.New(ErrChannelNotFound)
@@ -780,14 +782,53 @@ func (cc *ChannelsChooser) NextByValidatedModel(group, validatedModelName string
 		return nil, errors.New(ErrNoChannelsAvailable)
 	}
 
+	if channel := cc.nextWithCanary(channelsPriority, filters, validatedModelName, ginContext); channel != nil {
+		return channel, nil
+	}
+
+	return nil, errors.New(ErrNoAvailableChannelsAfterFiltering)
+}
+
+// nextWithCanary 先按灰度规则分流，未命中时从灰度渠道以外的渠道中按优先级选择，
+// 灰度渠道以外没有可用渠道时再退回到全部渠道，保证可用性
+func (cc *ChannelsChooser) nextWithCanary(channelsPriority [][]int, filters []ChannelsFilterFunc, modelName string, ginContext interface{}) *Channel {
+	if rule, ok := cc.Canary[modelName]; ok {
+		canaryIds := make([]int, 0, len(rule.Targets))
+		for _, target := range rule.Targets {
+			canaryIds = append(canaryIds, target.ChannelId)
+		}
+
+		targetId := pickCanaryTarget(rule, canaryRoll(rule, ginContext))
+		if targetId > 0 && containsChannelId(channelsPriority, targetId) {
+			if channel := cc.balancer([]int{targetId}, filters, modelName, nil); channel != nil {
+				return channel
+			}
+		}
+
+		canaryFilters := append(filters[:len(filters):len(filters)], FilterChannelId(canaryIds))
+		for _, priority := range channelsPriority {
+			if channel := cc.balancer(priority, canaryFilters, modelName, ginContext); channel != nil {
+				return channel
+			}
+		}
+	}
+
 	for _, priority := range channelsPriority {
-		channel := cc.balancer(priority, filters, validatedModelName, ginContext)
-		if channel != nil {
-			return channel, nil
+		if channel := cc.balancer(priority, filters, modelName, ginContext); channel != nil {
+			return channel
 		}
 	}
 
-	return nil, errors.New(ErrNoAvailableChannelsAfterFiltering)
+	return nil
+}
+
+func containsChannelId(channelsPriority [][]int, channelId int) bool {
+	for _, priority := range channelsPriority {
+		if utils.Contains(channelId, priority) {
+			return true
+		}
+	}
+	return false
 }
 
 func (cc *ChannelsChooser) GetGroupModels(group string) ([]string, error) {
@@ -864,6 +905,10 @@ func (cc *ChannelsChooser) countValidChannels(channelIds []int, filters []Channe
 			continue
 		}
 
+		if !ChannelCircuitBreaker.Allow(channelId) {
+			continue
+		}
+
 		isSkip := false
 		for _, filter := range filters {
 			if filter(channelId, choice) {
@@ -882,7 +927,14 @@ func (cc *ChannelsChooser) countValidChannels(channelIds []int, filters []Channe
 
 var ChannelGroup = ChannelsChooser{}
 
+// Load 重新加载渠道缓存，并通知其他节点同步刷新
 func (cc *ChannelsChooser) Load() {
+	cc.LoadLocal()
+	PublishCacheInvalidation(CacheInvalidationChannels)
+}
+
+// LoadLocal 只重新加载当前节点的渠道缓存
+func (cc *ChannelsChooser) LoadLocal() {
 	var channels []*Channel
 	DB.Where("status = ?", config.ChannelStatusEnabled).Find(&channels)
 
@@ -979,12 +1031,15 @@ func (cc *ChannelsChooser) Load() {
 		newMatchList = append(newMatchList, match)
 	}
 
+	newCanary := loadCanaryRules()
+
 	// 更新ChannelsChooser
 	cc.Lock()
 	cc.Rule = newGroup
 	cc.Channels = newChannels
 	cc.Match = newMatchList
 	cc.ModelGroup = newModelGroup
+	cc.Canary = newCanary
 	cc.Unlock()
 	logger.SysLog("channels Load success")
 }
@@ -14,6 +14,7 @@ import (
 var (
 	TokenCacheSeconds           = 0
 	UserGroupCacheKey           = "user_group:%d"
+	UserTenantCacheKey          = "user_tenant:%d"
 	UserTokensKey               = "token:%s"
 	UsernameCacheKey            = "user_name:%d"
 	UserQuotaCacheKey           = "user_quota:%d"
@@ -60,6 +61,20 @@ func CacheGetUserGroup(id int) (group string, err error) {
 	return group, err
 }
 
+func CacheGetUserTenantId(id int) (tenantId int, err error) {
+	if !config.RedisEnabled {
+		return GetUserTenantId(id)
+	}
+
+	return cache.GetOrSetCache(
+		fmt.Sprintf(UserTenantCacheKey, id),
+		time.Duration(TokenCacheSeconds)*time.Second,
+		func() (int, error) {
+			return GetUserTenantId(id)
+		},
+		cache.CacheTimeout)
+}
+
 func CacheGetUserQuota(id int) (quota int, err error) {
 	if !config.RedisEnabled {
 		return GetUserQuota(id)
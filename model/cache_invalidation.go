@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/redis"
+	"done-hub/common/utils"
+	"encoding/json"
+)
+
+const cacheInvalidationChannel = "done-hub:cache_invalidation"
+
+const (
+	CacheInvalidationChannels = "channels"
+	CacheInvalidationPricing  = "pricing"
+	CacheInvalidationOptions  = "options"
+)
+
+// cacheInvalidationNodeId 当前节点标识，用于忽略自己发布的消息
+var cacheInvalidationNodeId = utils.GetUUID()
+
+// cacheInvalidationSignals 每类缓存一个信号，短时间内的多次变更合并为一次重新加载
+var cacheInvalidationSignals = map[string]chan struct{}{
+	CacheInvalidationChannels: make(chan struct{}, 1),
+	CacheInvalidationPricing:  make(chan struct{}, 1),
+	CacheInvalidationOptions:  make(chan struct{}, 1),
+}
+
+type cacheInvalidationMessage struct {
+	Node string `json:"node"`
+	Kind string `json:"kind"`
+}
+
+// PublishCacheInvalidation 通知其他节点立即重新加载指定的内存缓存
+func PublishCacheInvalidation(kind string) {
+	if !config.RedisEnabled {
+		return
+	}
+
+	message, _ := json.Marshal(cacheInvalidationMessage{
+		Node: cacheInvalidationNodeId,
+		Kind: kind,
+	})
+	if err := redis.RedisPublish(cacheInvalidationChannel, string(message)); err != nil {
+		logger.SysError("failed to publish cache invalidation: " + err.Error())
+	}
+}
+
+// InitCacheInvalidation 订阅缓存失效消息，需要在 Redis 初始化之后调用
+// 定时同步仍然保留，作为消息丢失时的兜底
+func InitCacheInvalidation() {
+	if !config.RedisEnabled {
+		return
+	}
+
+	for kind, signal := range cacheInvalidationSignals {
+		go runCacheReloader(kind, signal)
+	}
+
+	redis.RedisSubscribe(context.Background(), cacheInvalidationChannel, handleCacheInvalidation)
+	logger.SysLog("cache invalidation subscriber started")
+}
+
+func handleCacheInvalidation(payload string) {
+	var message cacheInvalidationMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		return
+	}
+	if message.Node == cacheInvalidationNodeId {
+		return
+	}
+
+	signal, ok := cacheInvalidationSignals[message.Kind]
+	if !ok {
+		return
+	}
+	select {
+	case signal <- struct{}{}:
+	default:
+	}
+}
+
+func runCacheReloader(kind string, signal chan struct{}) {
+	for range signal {
+		logger.SysLog("reloading " + kind + " cache by invalidation event")
+		switch kind {
+		case CacheInvalidationChannels:
+			ChannelGroup.LoadLocal()
+		case CacheInvalidationPricing:
+			if err := PricingInstance.Init(); err != nil {
+				logger.SysError("failed to reload pricing: " + err.Error())
+			}
+		case CacheInvalidationOptions:
+			loadOptionsFromDatabase()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/redis"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// drainCacheInvalidationSignals 清空之前测试留下的信号，测试中不启动重新加载的协程
+func drainCacheInvalidationSignals() {
+	for _, signal := range cacheInvalidationSignals {
+		select {
+		case <-signal:
+		default:
+		}
+	}
+}
+
+// setupCacheInvalidationTest 使用 miniredis 订阅缓存失效消息
+func setupCacheInvalidationTest(t *testing.T) map[string]chan struct{} {
+	logger.Logger = zap.NewNop()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+
+	originRDB, originEnabled := redis.RDB, config.RedisEnabled
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		client.Close()
+		redis.RDB, config.RedisEnabled = originRDB, originEnabled
+		drainCacheInvalidationSignals()
+	})
+
+	redis.RDB = client
+	config.RedisEnabled = true
+	drainCacheInvalidationSignals()
+
+	redis.RedisSubscribe(ctx, cacheInvalidationChannel, handleCacheInvalidation)
+	deadline := time.Now().Add(time.Second)
+	for server.PubSubNumSub(cacheInvalidationChannel)[cacheInvalidationChannel] == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber not ready")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cacheInvalidationSignals
+}
+
+func publishFromNode(t *testing.T, node, kind string) {
+	message, _ := json.Marshal(cacheInvalidationMessage{Node: node, Kind: kind})
+	if err := redis.RedisPublish(cacheInvalidationChannel, string(message)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitSignal(signal chan struct{}) bool {
+	select {
+	case <-signal:
+		return true
+	case <-time.After(200 * time.Millisecond):
+		return false
+	}
+}
+
+func TestCacheInvalidationFromOtherNode(t *testing.T) {
+	signals := setupCacheInvalidationTest(t)
+
+	publishFromNode(t, "other-node", CacheInvalidationPricing)
+	if !waitSignal(signals[CacheInvalidationPricing]) {
+		t.Fatal("pricing should be reloaded by message from other node")
+	}
+	if waitSignal(signals[CacheInvalidationChannels]) {
+		t.Fatal("channels should not be reloaded")
+	}
+}
+
+func TestCacheInvalidationIgnored(t *testing.T) {
+	signals := setupCacheInvalidationTest(t)
+
+	// 自己发布的消息、未知类型和无法解析的消息都会被忽略
+	PublishCacheInvalidation(CacheInvalidationChannels)
+	publishFromNode(t, "other-node", "unknown")
+	redis.RedisPublish(cacheInvalidationChannel, "invalid")
+	if waitSignal(signals[CacheInvalidationChannels]) {
+		t.Fatal("message from own node should be ignored")
+	}
+}
+
+func TestCacheInvalidationCoalesce(t *testing.T) {
+	signals := cacheInvalidationSignals
+	drainCacheInvalidationSignals()
+	defer drainCacheInvalidationSignals()
+
+	// 重新加载前的多次变更只触发一次
+	message, _ := json.Marshal(cacheInvalidationMessage{Node: "other-node", Kind: CacheInvalidationChannels})
+	for i := 0; i < 3; i++ {
+		handleCacheInvalidation(string(message))
+	}
+	if len(signals[CacheInvalidationChannels]) != 1 {
+		t.Fatalf("expected 1 pending reload, got %d", len(signals[CacheInvalidationChannels]))
+	}
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"done-hub/common/utils"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+// CanaryRule 模型的灰度分流规则，按百分比把该模型的部分流量分给指定渠道，其余流量按原有规则选择渠道
+type CanaryRule struct {
+	Id          int                               `json:"id"`
+	ModelName   string                            `json:"model_name" gorm:"type:varchar(255);uniqueIndex"`
+	Targets     datatypes.JSONSlice[CanaryTarget] `json:"targets" gorm:"type:json"`
+	Sticky      bool                              `json:"sticky" gorm:"default:false"`
+	Enabled     bool                              `json:"enabled"`
+	CreatedTime int64                             `json:"created_time" gorm:"bigint"`
+}
+
+type CanaryTarget struct {
+	ChannelId int     `json:"channel_id"`
+	Percent   float64 `json:"percent"`
+}
+
+func (r *CanaryRule) Validate() error {
+	if r.ModelName == "" {
+		return errors.New("模型名称不能为空")
+	}
+	if len(r.Targets) == 0 {
+		return errors.New("请至少添加一个灰度渠道")
+	}
+
+	total := 0.0
+	seen := make(map[int]bool)
+	for _, target := range r.Targets {
+		if target.Percent <= 0 || target.Percent > 100 {
+			return fmt.Errorf("渠道 #%d 的流量比例必须在 0 ~ 100 之间", target.ChannelId)
+		}
+		if seen[target.ChannelId] {
+			return fmt.Errorf("渠道 #%d 重复", target.ChannelId)
+		}
+		seen[target.ChannelId] = true
+		total += target.Percent
+	}
+	if total > 100 {
+		return errors.New("灰度流量比例之和不能超过 100")
+	}
+
+	return nil
+}
+
+func GetAllCanaryRules() ([]*CanaryRule, error) {
+	var rules []*CanaryRule
+	err := DB.Order("id desc").Find(&rules).Error
+	return rules, err
+}
+
+func GetCanaryRule(id int) (*CanaryRule, error) {
+	rule := &CanaryRule{}
+	err := DB.Where("id = ?", id).First(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func CreateCanaryRule(rule *CanaryRule) error {
+	rule.CreatedTime = utils.GetTimestamp()
+	if err := DB.Create(rule).Error; err != nil {
+		return err
+	}
+
+	ChannelGroup.Load()
+	return nil
+}
+
+func UpdateCanaryRule(rule *CanaryRule) error {
+	err := DB.Model(rule).Select("model_name", "targets", "sticky", "enabled").Updates(rule).Error
+	if err != nil {
+		return err
+	}
+
+	ChannelGroup.Load()
+	return nil
+}
+
+func DeleteCanaryRule(id int) error {
+	if err := DB.Delete(&CanaryRule{}, id).Error; err != nil {
+		return err
+	}
+
+	ChannelGroup.Load()
+	return nil
+}
+
+// loadCanaryRules 读取启用的灰度规则，按模型名称索引
+func loadCanaryRules() map[string]*CanaryRule {
+	var rules []*CanaryRule
+	if err := DB.Where("enabled = ?", true).Find(&rules).Error; err != nil {
+		return nil
+	}
+
+	result := make(map[string]*CanaryRule, len(rules))
+	for _, rule := range rules {
+		result[rule.ModelName] = rule
+	}
+	return result
+}
+
+// canaryRoll 返回 [0, 100) 之间的分流值，开启粘性且有令牌时同一令牌的结果固定
+func canaryRoll(rule *CanaryRule, ginContext interface{}) float64 {
+	if rule.Sticky {
+		if c, ok := ginContext.(*gin.Context); ok {
+			if tokenId := c.GetInt("token_id"); tokenId > 0 {
+				h := fnv.New32a()
+				h.Write([]byte(fmt.Sprintf("%d:%s", tokenId, rule.ModelName)))
+				return float64(h.Sum32()%10000) / 100
+			}
+		}
+	}
+
+	return rand.Float64() * 100
+}
+
+// pickCanaryTarget 根据分流值选择灰度渠道，返回 0 表示本次请求走原有规则
+func pickCanaryTarget(rule *CanaryRule, roll float64) int {
+	cumulative := 0.0
+	for _, target := range rule.Targets {
+		cumulative += target.Percent
+		if roll < cumulative {
+			return target.ChannelId
+		}
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+func TestCanaryRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    CanaryRule
+		wantErr bool
+	}{
+		{"valid", CanaryRule{ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 5}, {ChannelId: 2, Percent: 95}}}, false},
+		{"empty model", CanaryRule{Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 5}}}, true},
+		{"no targets", CanaryRule{ModelName: "gpt-4o"}, true},
+		{"zero percent", CanaryRule{ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 0}}}, true},
+		{"over 100 percent", CanaryRule{ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 101}}}, true},
+		{"duplicate channel", CanaryRule{ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 5}, {ChannelId: 1, Percent: 5}}}, true},
+		{"total over 100", CanaryRule{ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 60}, {ChannelId: 2, Percent: 50}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPickCanaryTarget(t *testing.T) {
+	rule := &CanaryRule{Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 1, Percent: 5}, {ChannelId: 2, Percent: 10}}}
+	tests := []struct {
+		roll float64
+		want int
+	}{
+		{0, 1},
+		{4.99, 1},
+		{5, 2},
+		{14.99, 2},
+		{15, 0},
+		{99.99, 0},
+	}
+	for _, tt := range tests {
+		if got := pickCanaryTarget(rule, tt.roll); got != tt.want {
+			t.Errorf("pickCanaryTarget(%v) = %d, want %d", tt.roll, got, tt.want)
+		}
+	}
+}
+
+func TestCanaryRollSticky(t *testing.T) {
+	rule := &CanaryRule{ModelName: "gpt-4o", Sticky: true}
+	c := &gin.Context{}
+	c.Set("token_id", 42)
+
+	roll := canaryRoll(rule, c)
+	if roll < 0 || roll >= 100 {
+		t.Fatalf("roll out of range: %v", roll)
+	}
+	for i := 0; i < 10; i++ {
+		if got := canaryRoll(rule, c); got != roll {
+			t.Fatalf("sticky roll should be stable for the same token, got %v and %v", roll, got)
+		}
+	}
+
+	other := &gin.Context{}
+	other.Set("token_id", 43)
+	if canaryRoll(rule, other) == roll {
+		t.Fatal("different tokens should get different rolls")
+	}
+}
+
+func newCanaryTestChooser(percent float64) *ChannelsChooser {
+	weight := uint(1)
+	channels := make(map[int]*ChannelChoice)
+	for _, id := range []int{1, 2, 3} {
+		channels[id] = &ChannelChoice{Channel: &Channel{Id: id, Weight: &weight}}
+	}
+
+	return &ChannelsChooser{
+		Channels: channels,
+		Rule: map[string]map[string][][]int{
+			"default": {"gpt-4o": {{1, 2}, {3}}},
+		},
+		Canary: map[string]*CanaryRule{
+			"gpt-4o": {ModelName: "gpt-4o", Targets: datatypes.JSONSlice[CanaryTarget]{{ChannelId: 3, Percent: percent}}},
+		},
+	}
+}
+
+func TestChannelsChooserCanary(t *testing.T) {
+	redisEnabled := config.RedisEnabled
+	defer func() { config.RedisEnabled = redisEnabled }()
+	config.RedisEnabled = false
+
+	// 全部流量分给低优先级的灰度渠道，所有选择渠道的入口都生效
+	cc := newCanaryTestChooser(100)
+	next := map[string]func() (*Channel, error){
+		"Next":                 func() (*Channel, error) { return cc.Next("default", "gpt-4o") },
+		"NextByValidatedModel": func() (*Channel, error) { return cc.NextByValidatedModel("default", "gpt-4o", nil) },
+	}
+	for name, fn := range next {
+		for i := 0; i < 20; i++ {
+			channel, err := fn()
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if channel.Id != 3 {
+				t.Fatalf("%s: expected canary channel 3, got %d", name, channel.Id)
+			}
+		}
+	}
+
+	// 灰度渠道不可用时回到原有规则
+	cc.Disable(3)
+	if channel, err := cc.Next("default", "gpt-4o"); err != nil || channel.Id == 3 {
+		t.Fatalf("disabled canary channel should fall back, got %v %v", channel, err)
+	}
+
+	// 未命中灰度的请求不使用灰度渠道，其他渠道都不可用时才退回灰度渠道
+	cc = newCanaryTestChooser(0.0001)
+	cc.Channels[1].Disable = true
+	cc.Channels[2].Disable = true
+	for name, fn := range map[string]func() (*Channel, error){
+		"Next":                 func() (*Channel, error) { return cc.Next("default", "gpt-4o") },
+		"NextByValidatedModel": func() (*Channel, error) { return cc.NextByValidatedModel("default", "gpt-4o", nil) },
+	} {
+		if channel, err := fn(); err != nil || channel.Id != 3 {
+			t.Fatalf("%s: canary channel should be used as the last resort, got %v %v", name, channel, err)
+		}
+	}
+
+	cc.Channels[1].Disable = false
+	for i := 0; i < 20; i++ {
+		if channel, err := cc.Next("default", "gpt-4o"); err != nil || channel.Id != 1 {
+			t.Fatalf("requests missing the canary should skip the canary channel, got %v %v", channel, err)
+		}
+	}
+}
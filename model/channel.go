@@ -32,21 +32,27 @@ type Channel struct {
 	Models             string  `json:"models" form:"models"`
 	Group              string  `json:"group" form:"group" gorm:"type:varchar(255);default:'default'"`
 	Tag                string  `json:"tag" form:"tag" gorm:"type:varchar(32);default:''"`
+	TenantId           int     `json:"tenant_id" form:"tenant_id" gorm:"type:int;default:0;index"`
 	UsedQuota          int64   `json:"used_quota" gorm:"bigint;default:0"`
 	ModelMapping       *string `json:"model_mapping" gorm:"type:text"`
 	ModelHeaders       *string `json:"model_headers" gorm:"type:varchar(1024);default:''"`
 	CustomParameter    *string `json:"custom_parameter" gorm:"type:text"`
+	TransformRules     *string `json:"transform_rules" gorm:"type:text"` // 请求、响应转换规则
 	Priority           *int64  `json:"priority" gorm:"bigint;default:0"`
-	Proxy              *string `json:"proxy" gorm:"type:varchar(255);default:''"`
+	Proxy              *string `json:"proxy" gorm:"type:varchar(1024);default:''"`                              // 多个代理用换行或逗号分隔
+	ProxyRotation      string  `json:"proxy_rotation" form:"proxy_rotation" gorm:"type:varchar(16);default:''"` // 多个代理时的轮换方式：failover（默认）/ round_robin
 	TestModel          string  `json:"test_model" form:"test_model" gorm:"type:varchar(50);default:''"`
 	OnlyChat           bool    `json:"only_chat" form:"only_chat" gorm:"default:false"`
 	PreCost            int     `json:"pre_cost" form:"pre_cost" gorm:"default:1"`
 	CompatibleResponse bool    `json:"compatible_response" gorm:"default:false"`
+	StreamUsage        *bool   `json:"stream_usage" gorm:"default:null"` // 是否请求上游在流中返回 usage，为空时使用供应商默认设置
 
 	DisabledStream *datatypes.JSONSlice[string] `json:"disabled_stream,omitempty" gorm:"type:json"`
 
 	Plugin    *datatypes.JSONType[PluginType] `json:"plugin" form:"plugin" gorm:"type:json"`
 	DeletedAt gorm.DeletedAt                  `json:"-" gorm:"index"`
+
+	CircuitBreaker *CircuitBreakerStatus `json:"circuit_breaker,omitempty" gorm:"-"`
 }
 
 func (c *Channel) AllowStream(modelName string) bool {
@@ -71,6 +77,13 @@ var allowedChannelOrderFields = map[string]bool{
 	"weight":        true,
 }
 
+const (
+	ChannelFilterTagDefault = 0 // 同一标签的渠道只返回一条
+	ChannelFilterTagNone    = 1 // 只返回没有标签的渠道
+	ChannelFilterTagOnly    = 2 // 只返回有标签的渠道，每个标签一条
+	ChannelFilterTagAll     = 3 // 返回全部渠道，不按标签合并
+)
+
 type SearchChannelsParams struct {
 	Channel
 	PaginationParams
@@ -78,11 +91,11 @@ type SearchChannelsParams struct {
 	BaseURL   string `json:"base_url" form:"base_url"`
 }
 
-func GetChannelsList(params *SearchChannelsParams) (*DataResult[Channel], error) {
+func GetChannelsList(params *SearchChannelsParams, tenantScope int) (*DataResult[Channel], error) {
 	var channels []*Channel
 
-	db := DB.Omit("key")
-	tagDB := DB.Model(&Channel{}).Select("Max(id) as id").Where("tag != ''").Group("tag")
+	db := DB.Omit("key").Scopes(ScopeTenant(tenantScope))
+	tagDB := DB.Model(&Channel{}).Select("Max(id) as id").Where("tag != ''").Group("tag").Scopes(ScopeTenant(tenantScope))
 
 	if params.Type != 0 {
 		db = db.Where("type = ?", params.Type)
@@ -138,10 +151,11 @@ func GetChannelsList(params *SearchChannelsParams) (*DataResult[Channel], error)
 	}
 
 	switch params.FilterTag {
-	case 1:
+	case ChannelFilterTagNone:
 		db = db.Where("tag = ''")
-	case 2:
+	case ChannelFilterTagOnly:
 		db = db.Where("id IN (?)", tagDB)
+	case ChannelFilterTagAll:
 	default:
 		db = db.Where("tag = '' OR id IN (?)", tagDB)
 	}
@@ -162,6 +176,17 @@ func GetChannelById(id int) (*Channel, error) {
 	return &channel, err
 }
 
+// GetChannelsByIds ids 为空时返回全部渠道
+func GetChannelsByIds(ids []int) ([]*Channel, error) {
+	var channels []*Channel
+	db := DB.Order("id asc")
+	if len(ids) > 0 {
+		db = db.Where("id IN ?", ids)
+	}
+	err := db.Find(&channels).Error
+	return channels, err
+}
+
 func GetChannelsByTag(tag string) ([]*Channel, error) {
 	var channels []*Channel
 	err := DB.Where("tag = ?", tag).Find(&channels).Error
@@ -390,7 +415,7 @@ func (c *Channel) SetProxy() {
 	if strings.Contains(*c.Proxy, "%s") {
 		md5Str := md5.Sum([]byte(c.Key))
 		idStr := hex.EncodeToString(md5Str[:])
-		*c.Proxy = strings.Replace(*c.Proxy, "%s", idStr, 1)
+		*c.Proxy = strings.ReplaceAll(*c.Proxy, "%s", idStr)
 	}
 
 }
@@ -423,6 +448,13 @@ func (channel *Channel) GetCustomParameter() string {
 	return *channel.CustomParameter
 }
 
+func (channel *Channel) GetTransformRules() string {
+	if channel.TransformRules == nil {
+		return ""
+	}
+	return *channel.TransformRules
+}
+
 func (channel *Channel) Insert() error {
 	err := DB.Omit("UsedQuota").Create(channel).Error
 	if err == nil {
@@ -513,6 +545,7 @@ func UpdateChannelStatusById(id int, status int) {
 
 	isEnabled := status == config.ChannelStatusEnabled
 	go ChannelGroup.ChangeStatus(id, isEnabled)
+	PublishCacheInvalidation(CacheInvalidationChannels)
 
 	// 启用渠道时清除冻结缓存
 	if isEnabled {
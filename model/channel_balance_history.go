@@ -0,0 +1,47 @@
+package model
+
+import (
+	"done-hub/common/utils"
+)
+
+// ChannelBalanceHistory 渠道上游余额的历史记录，用于绘制余额趋势
+type ChannelBalanceHistory struct {
+	Id             int     `json:"id"`
+	ChannelId      int     `json:"channel_id" gorm:"index:idx_channel_balance_time"`
+	Currency       string  `json:"currency" gorm:"type:varchar(16)"`
+	Balance        float64 `json:"balance"`
+	ChannelBalance float64 `json:"channel_balance"`
+	CreatedTime    int64   `json:"created_time" gorm:"bigint;index:idx_channel_balance_time"`
+}
+
+// channelBalanceHistoryRetention 余额历史保留时间（秒）
+const channelBalanceHistoryRetention = 90 * 24 * 3600
+
+func RecordChannelBalance(channelId int, currency string, balance, channelBalance float64) error {
+	return DB.Create(&ChannelBalanceHistory{
+		ChannelId:      channelId,
+		Currency:       currency,
+		Balance:        balance,
+		ChannelBalance: channelBalance,
+		CreatedTime:    utils.GetTimestamp(),
+	}).Error
+}
+
+func GetChannelBalanceHistory(channelId int, startTimestamp, endTimestamp int64) ([]*ChannelBalanceHistory, error) {
+	var histories []*ChannelBalanceHistory
+	db := DB.Where("channel_id = ?", channelId)
+	if startTimestamp > 0 {
+		db = db.Where("created_time >= ?", startTimestamp)
+	}
+	if endTimestamp > 0 {
+		db = db.Where("created_time <= ?", endTimestamp)
+	}
+
+	err := db.Order("created_time asc").Find(&histories).Error
+	return histories, err
+}
+
+// CleanChannelBalanceHistory 删除超过保留时间的余额历史
+func CleanChannelBalanceHistory() error {
+	return DB.Where("created_time < ?", utils.GetTimestamp()-channelBalanceHistoryRetention).Delete(&ChannelBalanceHistory{}).Error
+}
@@ -152,13 +152,16 @@ func UpdateChannelsTag(tag string, channel *Channel) error {
 			ModelMapping:       channel.ModelMapping,
 			ModelHeaders:       channel.ModelHeaders,
 			CustomParameter:    channel.CustomParameter,
+			TransformRules:     channel.TransformRules,
 			Proxy:              channel.Proxy,
+			ProxyRotation:      channel.ProxyRotation,
 			TestModel:          channel.TestModel,
 			OnlyChat:           channel.OnlyChat,
 			Plugin:             channel.Plugin,
 			PreCost:            channel.PreCost,
 			DisabledStream:     channel.DisabledStream,
 			CompatibleResponse: channel.CompatibleResponse,
+			StreamUsage:        channel.StreamUsage,
 		}).Error
 
 	if err != nil {
@@ -0,0 +1,267 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 熔断器状态
+const (
+	CircuitStateClosed   = "closed"
+	CircuitStateOpen     = "open"
+	CircuitStateHalfOpen = "half_open"
+)
+
+// CircuitBreakerStatus 熔断器状态快照，用于渠道接口展示
+type CircuitBreakerStatus struct {
+	State     string  `json:"state"`
+	Requests  int     `json:"requests"`
+	Failures  int     `json:"failures"`
+	ErrorRate float64 `json:"error_rate"`
+	OpenedAt  int64   `json:"opened_at,omitempty"`
+	RetryAt   int64   `json:"retry_at,omitempty"`
+	Probes    int     `json:"probes,omitempty"`
+}
+
+type circuitBucket struct {
+	requests int
+	failures int
+}
+
+type channelCircuit struct {
+	sync.Mutex
+	state          string
+	buckets        map[int64]*circuitBucket
+	openedAt       int64
+	probesInFlight int
+	probeSuccesses int
+	// 每次进入半开状态时递增，避免上一轮的探测请求释放本轮的名额
+	generation int
+}
+
+// CircuitBreaker 渠道熔断器管理器（closed -> open -> half_open -> closed）
+type CircuitBreaker struct {
+	circuits sync.Map // channelId -> *channelCircuit
+	now      func() int64
+}
+
+var ChannelCircuitBreaker = NewCircuitBreaker()
+
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		now: func() int64 { return time.Now().Unix() },
+	}
+}
+
+func (cb *CircuitBreaker) getCircuit(channelId int) *channelCircuit {
+	if value, ok := cb.circuits.Load(channelId); ok {
+		return value.(*channelCircuit)
+	}
+
+	value, _ := cb.circuits.LoadOrStore(channelId, &channelCircuit{
+		state:   CircuitStateClosed,
+		buckets: make(map[int64]*circuitBucket),
+	})
+	return value.(*channelCircuit)
+}
+
+// Allow 判断渠道当前是否允许接收请求，不修改计数
+func (cb *CircuitBreaker) Allow(channelId int) bool {
+	if !config.ChannelCircuitBreakerEnabled || channelId == 0 {
+		return true
+	}
+
+	value, ok := cb.circuits.Load(channelId)
+	if !ok {
+		return true
+	}
+
+	circuit := value.(*channelCircuit)
+	circuit.Lock()
+	defer circuit.Unlock()
+
+	cb.refreshState(circuit)
+	switch circuit.state {
+	case CircuitStateOpen:
+		return false
+	case CircuitStateHalfOpen:
+		return circuit.probesInFlight < cb.maxProbes()
+	default:
+		return true
+	}
+}
+
+// Acquire 向选中的渠道发送请求前调用，半开状态下占用一个探测名额，
+// 返回的函数用于释放名额，调用方需要在请求结束后（通常使用 defer）调用，可以重复调用
+func (cb *CircuitBreaker) Acquire(channelId int) (release func()) {
+	release = func() {}
+	if !config.ChannelCircuitBreakerEnabled || channelId == 0 {
+		return
+	}
+
+	value, ok := cb.circuits.Load(channelId)
+	if !ok {
+		return
+	}
+
+	circuit := value.(*channelCircuit)
+	circuit.Lock()
+	defer circuit.Unlock()
+
+	cb.refreshState(circuit)
+	if circuit.state != CircuitStateHalfOpen {
+		return
+	}
+
+	circuit.probesInFlight++
+	generation := circuit.generation
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			circuit.Lock()
+			defer circuit.Unlock()
+			if circuit.generation == generation && circuit.probesInFlight > 0 {
+				circuit.probesInFlight--
+			}
+		})
+	}
+}
+
+// Record 记录一次请求结果
+func (cb *CircuitBreaker) Record(channelId int, failed bool) {
+	if !config.ChannelCircuitBreakerEnabled || channelId == 0 {
+		return
+	}
+
+	circuit := cb.getCircuit(channelId)
+	circuit.Lock()
+	defer circuit.Unlock()
+
+	cb.refreshState(circuit)
+	now := cb.now()
+
+	switch circuit.state {
+	case CircuitStateHalfOpen:
+		// 探测名额由 Acquire 返回的函数释放
+		if failed {
+			cb.open(channelId, circuit, now, "half-open probe failed")
+			return
+		}
+		circuit.probeSuccesses++
+		if circuit.probeSuccesses >= cb.maxProbes() {
+			cb.close(channelId, circuit)
+		}
+		return
+	case CircuitStateOpen:
+		// 熔断期间仍在进行中的请求返回，不影响状态
+		return
+	}
+
+	bucket, ok := circuit.buckets[now]
+	if !ok {
+		bucket = &circuitBucket{}
+		circuit.buckets[now] = bucket
+	}
+	bucket.requests++
+	if failed {
+		bucket.failures++
+	}
+
+	requests, failures := cb.windowCounts(circuit, now)
+	if !failed || requests < config.ChannelCircuitBreakerMinRequests || requests == 0 {
+		return
+	}
+
+	if float64(failures)/float64(requests) >= config.ChannelCircuitBreakerErrorRate {
+		cb.open(channelId, circuit, now, fmt.Sprintf("error rate %d/%d", failures, requests))
+	}
+}
+
+// Reset 手动将渠道熔断器恢复为关闭状态
+func (cb *CircuitBreaker) Reset(channelId int) {
+	cb.circuits.Delete(channelId)
+}
+
+// Status 获取渠道熔断器状态
+func (cb *CircuitBreaker) Status(channelId int) *CircuitBreakerStatus {
+	status := &CircuitBreakerStatus{State: CircuitStateClosed}
+	value, ok := cb.circuits.Load(channelId)
+	if !ok {
+		return status
+	}
+
+	circuit := value.(*channelCircuit)
+	circuit.Lock()
+	defer circuit.Unlock()
+
+	cb.refreshState(circuit)
+	now := cb.now()
+	status.State = circuit.state
+	status.Requests, status.Failures = cb.windowCounts(circuit, now)
+	if status.Requests > 0 {
+		status.ErrorRate = float64(status.Failures) / float64(status.Requests)
+	}
+	if circuit.state != CircuitStateClosed {
+		status.OpenedAt = circuit.openedAt
+		status.RetryAt = circuit.openedAt + int64(config.ChannelCircuitBreakerOpenSeconds)
+		status.Probes = circuit.probeSuccesses
+	}
+
+	return status
+}
+
+// refreshState 熔断时间结束后转为半开状态
+func (cb *CircuitBreaker) refreshState(circuit *channelCircuit) {
+	if circuit.state != CircuitStateOpen {
+		return
+	}
+
+	if cb.now() >= circuit.openedAt+int64(config.ChannelCircuitBreakerOpenSeconds) {
+		circuit.state = CircuitStateHalfOpen
+		circuit.probesInFlight = 0
+		circuit.probeSuccesses = 0
+		circuit.generation++
+	}
+}
+
+func (cb *CircuitBreaker) windowCounts(circuit *channelCircuit, now int64) (requests, failures int) {
+	windowStart := now - int64(config.ChannelCircuitBreakerWindowSeconds)
+	for second, bucket := range circuit.buckets {
+		if second <= windowStart {
+			delete(circuit.buckets, second)
+			continue
+		}
+		requests += bucket.requests
+		failures += bucket.failures
+	}
+	return
+}
+
+func (cb *CircuitBreaker) open(channelId int, circuit *channelCircuit, now int64, reason string) {
+	circuit.state = CircuitStateOpen
+	circuit.openedAt = now
+	circuit.probesInFlight = 0
+	circuit.probeSuccesses = 0
+	circuit.buckets = make(map[int64]*circuitBucket)
+	logger.SysLog(fmt.Sprintf("channel_circuit_open channel_id=%d open_seconds=%d reason=\"%s\"",
+		channelId, config.ChannelCircuitBreakerOpenSeconds, reason))
+}
+
+func (cb *CircuitBreaker) close(channelId int, circuit *channelCircuit) {
+	circuit.state = CircuitStateClosed
+	circuit.openedAt = 0
+	circuit.probesInFlight = 0
+	circuit.probeSuccesses = 0
+	circuit.buckets = make(map[int64]*circuitBucket)
+	logger.SysLog(fmt.Sprintf("channel_circuit_closed channel_id=%d", channelId))
+}
+
+func (cb *CircuitBreaker) maxProbes() int {
+	if config.ChannelCircuitBreakerHalfOpenProbes < 1 {
+		return 1
+	}
+	return config.ChannelCircuitBreakerHalfOpenProbes
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestCircuitBreaker(t *testing.T) (*CircuitBreaker, *int64) {
+	logger.Logger = zap.NewNop()
+
+	enabled, errorRate, minRequests := config.ChannelCircuitBreakerEnabled, config.ChannelCircuitBreakerErrorRate, config.ChannelCircuitBreakerMinRequests
+	window, openSeconds, probes := config.ChannelCircuitBreakerWindowSeconds, config.ChannelCircuitBreakerOpenSeconds, config.ChannelCircuitBreakerHalfOpenProbes
+	t.Cleanup(func() {
+		config.ChannelCircuitBreakerEnabled, config.ChannelCircuitBreakerErrorRate, config.ChannelCircuitBreakerMinRequests = enabled, errorRate, minRequests
+		config.ChannelCircuitBreakerWindowSeconds, config.ChannelCircuitBreakerOpenSeconds, config.ChannelCircuitBreakerHalfOpenProbes = window, openSeconds, probes
+	})
+
+	config.ChannelCircuitBreakerEnabled = true
+	config.ChannelCircuitBreakerErrorRate = 0.5
+	config.ChannelCircuitBreakerMinRequests = 4
+	config.ChannelCircuitBreakerWindowSeconds = 60
+	config.ChannelCircuitBreakerOpenSeconds = 30
+	config.ChannelCircuitBreakerHalfOpenProbes = 2
+
+	now := int64(1000)
+	cb := NewCircuitBreaker()
+	cb.now = func() int64 { return now }
+	return cb, &now
+}
+
+func TestCircuitBreakerRollingWindow(t *testing.T) {
+	cb, now := newTestCircuitBreaker(t)
+
+	// 请求数不足时不熔断
+	cb.Record(1, true)
+	cb.Record(1, true)
+	cb.Record(1, true)
+	if !cb.Allow(1) {
+		t.Fatal("circuit should stay closed below min requests")
+	}
+
+	// 窗口外的失败不再计入
+	*now += 61
+	cb.Record(1, false)
+	cb.Record(1, false)
+	cb.Record(1, false)
+	cb.Record(1, true)
+	if status := cb.Status(1); status.State != CircuitStateClosed || status.Requests != 4 || status.Failures != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	cb.Record(1, true)
+	cb.Record(1, true)
+	if cb.Allow(1) || cb.Status(1).State != CircuitStateOpen {
+		t.Fatal("circuit should open when error rate reaches threshold")
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	cb, now := newTestCircuitBreaker(t)
+	for i := 0; i < 4; i++ {
+		cb.Record(1, true)
+	}
+	if cb.Allow(1) {
+		t.Fatal("circuit should be open")
+	}
+
+	*now += 30
+	if !cb.Allow(1) || cb.Status(1).State != CircuitStateHalfOpen {
+		t.Fatal("circuit should be half open after open seconds")
+	}
+
+	// 探测名额用完后不再放行，释放后恢复
+	releaseA := cb.Acquire(1)
+	releaseB := cb.Acquire(1)
+	if cb.Allow(1) {
+		t.Fatal("half open circuit should limit probes in flight")
+	}
+	releaseA()
+	releaseA()
+	if !cb.Allow(1) {
+		t.Fatal("released probe should free a slot")
+	}
+
+	// 没有记录结果的请求释放后不会占用名额
+	cb.Acquire(1)()
+	if !cb.Allow(1) {
+		t.Fatal("unrecorded probe should not leak")
+	}
+
+	cb.Record(1, false)
+	releaseB()
+	cb.Record(1, false)
+	if status := cb.Status(1); status.State != CircuitStateClosed {
+		t.Fatalf("circuit should close after successful probes, got %+v", status)
+	}
+
+	// 半开状态下探测失败立即重新熔断，上一轮的释放不影响新的半开状态
+	for i := 0; i < 4; i++ {
+		cb.Record(1, true)
+	}
+	*now += 30
+	stale := cb.Acquire(1)
+	cb.Record(1, true)
+	*now += 30
+	cb.Acquire(1)
+	cb.Acquire(1)
+	stale()
+	if cb.Allow(1) {
+		t.Fatal("stale release should not free a probe slot of the next half open round")
+	}
+}
+
+func TestCircuitBreakerReset(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(t)
+	for i := 0; i < 4; i++ {
+		cb.Record(1, true)
+	}
+	if cb.Allow(1) {
+		t.Fatal("circuit should be open")
+	}
+
+	cb.Reset(1)
+	if !cb.Allow(1) || cb.Status(1).State != CircuitStateClosed {
+		t.Fatal("reset circuit should be closed")
+	}
+
+	config.ChannelCircuitBreakerEnabled = false
+	for i := 0; i < 4; i++ {
+		cb.Record(2, true)
+	}
+	if !cb.Allow(2) {
+		t.Fatal("disabled circuit breaker should always allow")
+	}
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"done-hub/common"
 	"done-hub/common/config"
+	"done-hub/common/utils"
 	"fmt"
 	"os"
 	"strings"
@@ -136,12 +137,7 @@ func getTimestampGroupsSelect(fieldName, groupType, alias string) string {
 	var groupSelect string
 
 	// 获取系统时区信息
-	location := time.Local
-	if tzEnv := os.Getenv("TZ"); tzEnv != "" {
-		if loc, err := time.LoadLocation(tzEnv); err == nil {
-			location = loc
-		}
-	}
+	location := utils.GetLocalLocation()
 	now := time.Now().In(location)
 	_, offsetSeconds := now.Zone()
 
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"done-hub/common/logger"
+	"done-hub/common/stmp"
+	"done-hub/common/utils"
+)
+
+// EmailLog 邮件投递记录
+type EmailLog struct {
+	Id          int    `json:"id"`
+	MessageId   string `json:"message_id" gorm:"type:varchar(64);uniqueIndex"`
+	Recipient   string `json:"recipient" gorm:"type:varchar(255);index"`
+	Subject     string `json:"subject" gorm:"type:varchar(255)"`
+	Provider    string `json:"provider" gorm:"type:varchar(32)"`
+	Status      string `json:"status" gorm:"type:varchar(16);index"`
+	Attempts    int    `json:"attempts" gorm:"default:0"`
+	Error       string `json:"error" gorm:"type:text"`
+	CreatedTime int64  `json:"created_time" gorm:"bigint;index"`
+	UpdatedTime int64  `json:"updated_time" gorm:"bigint"`
+}
+
+type EmailLogSearchParams struct {
+	PaginationParams
+	Recipient string `form:"recipient"`
+	Status    string `form:"status"`
+}
+
+var allowedEmailLogOrderFields = map[string]bool{
+	"id":           true,
+	"status":       true,
+	"attempts":     true,
+	"created_time": true,
+	"updated_time": true,
+}
+
+func init() {
+	stmp.SetDeliveryRecorder(recordEmailDelivery)
+}
+
+func recordEmailDelivery(record stmp.DeliveryRecord) {
+	if DB == nil {
+		return
+	}
+
+	now := utils.GetTimestamp()
+	var err error
+	if record.Status == stmp.DeliveryStatusPending {
+		err = DB.Create(&EmailLog{
+			MessageId:   record.MessageId,
+			Recipient:   record.To,
+			Subject:     record.Subject,
+			Provider:    record.Provider,
+			Status:      record.Status,
+			CreatedTime: now,
+			UpdatedTime: now,
+		}).Error
+	} else {
+		err = DB.Model(&EmailLog{}).Where("message_id = ?", record.MessageId).Updates(map[string]any{
+			"provider":     record.Provider,
+			"status":       record.Status,
+			"attempts":     record.Attempts,
+			"error":        record.Error,
+			"updated_time": now,
+		}).Error
+	}
+
+	if err != nil {
+		logger.SysError("failed to save email log: " + err.Error())
+	}
+}
+
+func GetEmailLogsList(params *EmailLogSearchParams) (*DataResult[EmailLog], error) {
+	var emailLogs []*EmailLog
+	db := DB
+
+	if params.Recipient != "" {
+		db = db.Where("recipient = ?", params.Recipient)
+	}
+
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	return PaginateAndOrder[EmailLog](db, &params.PaginationParams, &emailLogs, allowedEmailLogOrderFields)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/logstream"
 	"done-hub/common/utils"
 	"fmt"
 	"strings"
@@ -20,6 +21,7 @@ type Log struct {
 	Type             int                                `json:"type" gorm:"index:idx_created_at_type"`
 	Content          string                             `json:"content"`
 	Username         string                             `json:"username" gorm:"index:index_username_model_name,priority:2;default:''"`
+	TokenId          int                                `json:"token_id" gorm:"index;default:0"`
 	TokenName        string                             `json:"token_name" gorm:"index;default:''"`
 	ModelName        string                             `json:"model_name" gorm:"index;index:index_username_model_name,priority:1;default:''"`
 	Quota            int                                `json:"quota" gorm:"default:0"`
@@ -29,11 +31,22 @@ type Log struct {
 	RequestTime      int                                `json:"request_time" gorm:"default:0"`
 	IsStream         bool                               `json:"is_stream" gorm:"default:false"`
 	SourceIp         string                             `json:"source_ip" gorm:"default:''"`
+	RequestId        string                             `json:"request_id" gorm:"index;type:varchar(64);default:''"`
+	FirstTokenTime   int                                `json:"first_token_time" gorm:"default:0"`
+	UpstreamTime     int                                `json:"upstream_time" gorm:"default:0"`
+	RetryCount       int                                `json:"retry_count" gorm:"default:0"`
 	Metadata         datatypes.JSONType[map[string]any] `json:"metadata" gorm:"type:json"`
 
 	Channel *Channel `json:"channel" gorm:"foreignKey:Id;references:ChannelId"`
 }
 
+// LogTiming 中继耗时信息，单位为毫秒
+type LogTiming struct {
+	FirstTokenTime int // 本次上游请求开始到首个响应的时间
+	UpstreamTime   int // 本次上游请求的总耗时
+	RetryCount     int // 重试次数
+}
+
 const (
 	LogTypeUnknown = iota
 	LogTypeTopup
@@ -116,14 +129,30 @@ func RecordConsumeLog(
 	promptTokens int,
 	completionTokens int,
 	modelName string,
+	tokenId int,
 	tokenName string,
 	quota int,
 	content string,
 	requestTime int,
 	isStream bool,
+	timing *LogTiming,
 	metadata map[string]any,
 	sourceIp string) {
 	logger.LogInfo(ctx, fmt.Sprintf("record consume log: userId=%d, channelId=%d, promptTokens=%d, completionTokens=%d, modelName=%s, tokenName=%s, quota=%d, content=%s ,sourceIp=%s", userId, channelId, promptTokens, completionTokens, modelName, tokenName, quota, content, sourceIp))
+	PublishLogEvent(&logstream.Event{
+		Type:             logstream.EventTypeConsume,
+		RequestId:        logger.GetRequestId(ctx),
+		UserId:           userId,
+		TokenName:        tokenName,
+		ModelName:        modelName,
+		ChannelId:        channelId,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Quota:            quota,
+		RequestTime:      requestTime,
+		IsStream:         isStream,
+		SourceIp:         sourceIp,
+	})
 	if !config.LogConsumeEnabled {
 		return
 	}
@@ -138,6 +167,7 @@ func RecordConsumeLog(
 		Content:          content,
 		PromptTokens:     promptTokens,
 		CompletionTokens: completionTokens,
+		TokenId:          tokenId,
 		TokenName:        tokenName,
 		ModelName:        modelName,
 		Quota:            quota,
@@ -145,6 +175,13 @@ func RecordConsumeLog(
 		RequestTime:      requestTime,
 		IsStream:         isStream,
 		SourceIp:         sourceIp,
+		RequestId:        logger.GetRequestId(ctx),
+	}
+
+	if timing != nil {
+		log.FirstTokenTime = timing.FirstTokenTime
+		log.UpstreamTime = timing.UpstreamTime
+		log.RetryCount = timing.RetryCount
 	}
 
 	if metadata != nil {
@@ -157,6 +194,23 @@ func RecordConsumeLog(
 	}
 }
 
+// PublishLogEvent 推送实时日志事件，并补充用户名与所属租户，没有人查看实时日志时直接返回
+func PublishLogEvent(event *logstream.Event) {
+	if !logstream.Active() {
+		return
+	}
+
+	if event.UserId > 0 {
+		if event.Username == "" {
+			event.Username, _ = CacheGetUsername(event.UserId)
+		}
+		if config.MultiTenantEnabled {
+			event.TenantId, _ = CacheGetUserTenantId(event.UserId)
+		}
+	}
+	logstream.Publish(event)
+}
+
 type LogsListParams struct {
 	PaginationParams
 	LogType        int    `form:"log_type"`
@@ -167,6 +221,7 @@ type LogsListParams struct {
 	TokenName      string `form:"token_name"`
 	ChannelId      int    `form:"channel_id"`
 	SourceIp       string `form:"source_ip"`
+	RequestId      string `form:"request_id"`
 }
 
 var allowedLogsOrderFields = map[string]bool{
@@ -179,7 +234,7 @@ var allowedLogsOrderFields = map[string]bool{
 	"source_ip":  true,
 }
 
-func GetLogsList(params *LogsListParams) (*DataResult[Log], error) {
+func GetLogsList(params *LogsListParams, tenantScope int) (*DataResult[Log], error) {
 	var tx *gorm.DB
 	var logs []*Log
 
@@ -187,6 +242,10 @@ func GetLogsList(params *LogsListParams) (*DataResult[Log], error) {
 		return db.Select("id, name")
 	})
 
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
+
 	if params.LogType != LogTypeUnknown {
 		tx = tx.Where("type = ?", params.LogType)
 	}
@@ -199,6 +258,9 @@ func GetLogsList(params *LogsListParams) (*DataResult[Log], error) {
 	if params.TokenName != "" {
 		tx = tx.Where("token_name = ?", params.TokenName)
 	}
+	if params.RequestId != "" {
+		tx = tx.Where("request_id = ?", params.RequestId)
+	}
 	if params.StartTimestamp != 0 {
 		tx = tx.Where("created_at >= ?", params.StartTimestamp)
 	}
@@ -216,13 +278,17 @@ func GetLogsList(params *LogsListParams) (*DataResult[Log], error) {
 }
 
 // GetAllLogsList returns all logs matching the criteria without pagination (for export)
-func GetAllLogsList(params *LogsListParams) ([]*Log, error) {
+func GetAllLogsList(params *LogsListParams, tenantScope int) ([]*Log, error) {
 	var logs []*Log
 
 	tx := DB.Preload("Channel", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id, name")
 	})
 
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
+
 	if params.LogType != LogTypeUnknown {
 		tx = tx.Where("type = ?", params.LogType)
 	}
@@ -235,6 +301,9 @@ func GetAllLogsList(params *LogsListParams) ([]*Log, error) {
 	if params.TokenName != "" {
 		tx = tx.Where("token_name = ?", params.TokenName)
 	}
+	if params.RequestId != "" {
+		tx = tx.Where("request_id = ?", params.RequestId)
+	}
 	if params.StartTimestamp != 0 {
 		tx = tx.Where("created_at >= ?", params.StartTimestamp)
 	}
@@ -287,6 +356,9 @@ func GetUserLogsList(userId int, params *LogsListParams) (*DataResult[Log], erro
 	if params.TokenName != "" {
 		tx = tx.Where("token_name = ?", params.TokenName)
 	}
+	if params.RequestId != "" {
+		tx = tx.Where("request_id = ?", params.RequestId)
+	}
 	if params.StartTimestamp != 0 {
 		tx = tx.Where("created_at >= ?", params.StartTimestamp)
 	}
@@ -323,6 +395,9 @@ func GetAllUserLogsList(userId int, params *LogsListParams) ([]*Log, error) {
 	if params.TokenName != "" {
 		tx = tx.Where("token_name = ?", params.TokenName)
 	}
+	if params.RequestId != "" {
+		tx = tx.Where("request_id = ?", params.RequestId)
+	}
 	if params.StartTimestamp != 0 {
 		tx = tx.Where("created_at >= ?", params.StartTimestamp)
 	}
@@ -376,8 +451,12 @@ func SearchUserLogs(userId int, keyword string) (logs []*Log, err error) {
 	return logs, err
 }
 
-func SumUsedQuota(startTimestamp int64, endTimestamp int64, modelName string, username string, tokenName string, channel int) (quota int) {
+// SumUsedQuota tenantScope 为 -1 时统计全部租户
+func SumUsedQuota(startTimestamp int64, endTimestamp int64, modelName string, username string, tokenName string, channel int, tenantScope int) (quota int) {
 	tx := DB.Table("logs").Select(assembleSumSelectStr("quota"))
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
 	if username != "" {
 		tx = tx.Where("username = ?", username)
 	}
@@ -460,3 +539,9 @@ func GetRpmTpmStatistics() (*RpmTpmStatistics, error) {
 		CPM: cpm,
 	}, nil
 }
+
+func GetLogById(id int) (*Log, error) {
+	var log Log
+	err := DB.First(&log, "id = ?", id).Error
+	return &log, err
+}
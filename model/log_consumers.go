@@ -0,0 +1,116 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"errors"
+)
+
+const (
+	ConsumerDimensionUser    = "user"
+	ConsumerDimensionToken   = "token"
+	ConsumerDimensionChannel = "channel"
+)
+
+type TopConsumersParams struct {
+	StartTimestamp int64  `form:"start_timestamp"`
+	EndTimestamp   int64  `form:"end_timestamp"`
+	Dimension      string `form:"dimension"`
+	OrderBy        string `form:"order_by"`
+	Limit          int    `form:"limit"`
+}
+
+// TopConsumer 按用户、令牌或渠道汇总的消费，只返回对应维度的字段
+type TopConsumer struct {
+	UserId           int     `json:"user_id,omitempty" gorm:"column:user_id"`
+	Username         string  `json:"username,omitempty" gorm:"column:username"`
+	TokenName        string  `json:"token_name,omitempty" gorm:"column:token_name"`
+	ChannelId        int     `json:"channel_id,omitempty" gorm:"column:channel_id"`
+	ChannelName      string  `json:"channel_name,omitempty" gorm:"-"`
+	RequestCount     int64   `json:"request_count" gorm:"column:request_count"`
+	Quota            int64   `json:"quota" gorm:"column:quota"`
+	PromptTokens     int64   `json:"prompt_tokens" gorm:"column:prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens" gorm:"column:completion_tokens"`
+	Cost             float64 `json:"cost" gorm:"-"`
+}
+
+// GetTopConsumers 从消费日志中按消费额或请求数排行，时间范围可以任意指定
+func GetTopConsumers(params *TopConsumersParams, tenantScope int) ([]*TopConsumer, error) {
+	var group string
+	switch params.Dimension {
+	case ConsumerDimensionUser, "":
+		group = "user_id, username"
+	case ConsumerDimensionToken:
+		group = "user_id, username, token_name"
+	case ConsumerDimensionChannel:
+		group = "channel_id"
+	default:
+		return nil, errors.New("dimension 只支持 user、token、channel")
+	}
+
+	order := "quota DESC"
+	switch params.OrderBy {
+	case "quota", "":
+	case "request_count":
+		order = "request_count DESC"
+	default:
+		return nil, errors.New("order_by 只支持 quota、request_count")
+	}
+
+	if params.Limit <= 0 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	fields := group + `,
+		COUNT(*) AS request_count,
+		COALESCE(SUM(quota), 0) AS quota,
+		COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+		COALESCE(SUM(completion_tokens), 0) AS completion_tokens`
+
+	tx := DB.Model(&Log{}).Select(fields).Where("type = ?", LogTypeConsume)
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
+	if params.StartTimestamp != 0 {
+		tx = tx.Where("created_at >= ?", params.StartTimestamp)
+	}
+	if params.EndTimestamp != 0 {
+		tx = tx.Where("created_at <= ?", params.EndTimestamp)
+	}
+
+	var result []*TopConsumer
+	if err := tx.Group(group).Order(order).Limit(params.Limit).Scan(&result).Error; err != nil {
+		return nil, err
+	}
+
+	for _, item := range result {
+		item.Cost = float64(item.Quota) / config.QuotaPerUnit
+	}
+
+	if params.Dimension == ConsumerDimensionChannel && len(result) > 0 {
+		channelIds := make([]int, 0, len(result))
+		for _, item := range result {
+			channelIds = append(channelIds, item.ChannelId)
+		}
+		names, err := getChannelNames(channelIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result {
+			item.ChannelName = names[item.ChannelId]
+		}
+	}
+
+	return result, nil
+}
+
+func getChannelNames(channelIds []int) (map[int]string, error) {
+	var channels []*Channel
+	if err := DB.Select("id, name").Where("id IN ?", channelIds).Find(&channels).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(channels))
+	for _, channel := range channels {
+		names[channel.Id] = channel.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"done-hub/common/utils"
+
+	"gorm.io/gorm/clause"
+)
+
+// LogExportBatch 已导出的日志批次，批次区间为 [StartTime, EndTime)，最后一个批次的结束时间即下次导出的起点
+type LogExportBatch struct {
+	Id          int    `json:"id"`
+	StartTime   int64  `json:"start_time" gorm:"bigint;uniqueIndex"`
+	EndTime     int64  `json:"end_time" gorm:"bigint"`
+	Records     int    `json:"records"`
+	Format      string `json:"format" gorm:"type:varchar(16)"`
+	ObjectKey   string `json:"object_key" gorm:"type:varchar(512)"`
+	Size        int64  `json:"size"`
+	CreatedTime int64  `json:"created_time" gorm:"bigint"`
+}
+
+// GetLastLogExportBatch 返回最近导出的批次，没有导出记录时返回 nil
+func GetLastLogExportBatch() (*LogExportBatch, error) {
+	var batches []*LogExportBatch
+	err := DB.Order("end_time desc").Limit(1).Find(&batches).Error
+	if err != nil || len(batches) == 0 {
+		return nil, err
+	}
+	return batches[0], nil
+}
+
+func GetLogExportBatches(limit int) ([]*LogExportBatch, error) {
+	var batches []*LogExportBatch
+	err := DB.Order("end_time desc").Limit(limit).Find(&batches).Error
+	return batches, err
+}
+
+// SaveLogExportBatch 记录导出批次，同一批次重复导出时覆盖原记录
+func SaveLogExportBatch(batch *LogExportBatch) error {
+	batch.CreatedTime = utils.GetTimestamp()
+	return DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "start_time"}},
+		DoUpdates: clause.AssignmentColumns([]string{"end_time", "records", "format", "object_key", "size", "created_time"}),
+	}).Create(batch).Error
+}
+
+// GetLogsForExport 按 id 分页读取 [start, end) 时间区间内的日志
+func GetLogsForExport(start, end int64, afterId, limit int) ([]*Log, error) {
+	var logs []*Log
+	err := DB.Where("created_at >= ? AND created_at < ? AND id > ?", start, end, afterId).
+		Order("id asc").Limit(limit).Find(&logs).Error
+	return logs, err
+}
@@ -0,0 +1,87 @@
+package model
+
+import "done-hub/common/config"
+
+// ChannelPerformance 按渠道汇总的真实请求耗时，单位为毫秒
+type ChannelPerformance struct {
+	ChannelId         int     `json:"channel_id" gorm:"column:channel_id"`
+	ChannelName       string  `json:"channel_name" gorm:"-"`
+	ModelName         string  `json:"model_name,omitempty" gorm:"column:model_name"`
+	RequestCount      int64   `json:"request_count" gorm:"column:request_count"`
+	StreamCount       int64   `json:"stream_count" gorm:"column:stream_count"`
+	AvgFirstTokenTime float64 `json:"avg_first_token_time" gorm:"column:avg_first_token_time"`
+	MaxFirstTokenTime int64   `json:"max_first_token_time" gorm:"column:max_first_token_time"`
+	AvgUpstreamTime   float64 `json:"avg_upstream_time" gorm:"column:avg_upstream_time"`
+	AvgRequestTime    float64 `json:"avg_request_time" gorm:"column:avg_request_time"`
+	RetryCount        int64   `json:"retry_count" gorm:"column:retry_count"`
+	RetriedRequests   int64   `json:"retried_requests" gorm:"column:retried_requests"`
+}
+
+type ChannelPerformanceParams struct {
+	StartTimestamp int64  `form:"start_timestamp"`
+	EndTimestamp   int64  `form:"end_timestamp"`
+	ModelName      string `form:"model_name"`
+	ChannelId      int    `form:"channel_id"`
+	GroupByModel   bool   `form:"group_by_model"`
+}
+
+// GetChannelPerformance 从消费日志中统计各渠道的首字时间、上游耗时和重试情况，
+// 未记录耗时的旧日志不参与平均值计算
+func GetChannelPerformance(params *ChannelPerformanceParams, tenantScope int) ([]*ChannelPerformance, error) {
+	var result []*ChannelPerformance
+
+	fields := `channel_id,
+		COUNT(*) AS request_count,
+		SUM(CASE WHEN is_stream THEN 1 ELSE 0 END) AS stream_count,
+		COALESCE(AVG(CASE WHEN first_token_time > 0 THEN first_token_time END), 0) AS avg_first_token_time,
+		COALESCE(MAX(first_token_time), 0) AS max_first_token_time,
+		COALESCE(AVG(CASE WHEN upstream_time > 0 THEN upstream_time END), 0) AS avg_upstream_time,
+		COALESCE(AVG(request_time), 0) AS avg_request_time,
+		COALESCE(SUM(retry_count), 0) AS retry_count,
+		SUM(CASE WHEN retry_count > 0 THEN 1 ELSE 0 END) AS retried_requests`
+	group := "channel_id"
+	if params.GroupByModel {
+		fields = "model_name, " + fields
+		group = "channel_id, model_name"
+	}
+
+	tx := DB.Model(&Log{}).Select(fields).Where("type = ? AND channel_id > 0", LogTypeConsume)
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
+	if params.StartTimestamp != 0 {
+		tx = tx.Where("created_at >= ?", params.StartTimestamp)
+	}
+	if params.EndTimestamp != 0 {
+		tx = tx.Where("created_at <= ?", params.EndTimestamp)
+	}
+	if params.ModelName != "" {
+		tx = tx.Where("model_name = ?", params.ModelName)
+	}
+	if params.ChannelId != 0 {
+		tx = tx.Where("channel_id = ?", params.ChannelId)
+	}
+
+	if err := tx.Group(group).Order("request_count DESC").Scan(&result).Error; err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	channelIds := make([]int, 0, len(result))
+	for _, item := range result {
+		channelIds = append(channelIds, item.ChannelId)
+	}
+
+	names, err := getChannelNames(channelIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range result {
+		item.ChannelName = names[item.ChannelId]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGetChannelPerformance(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&Log{}, &Channel{}, &User{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originEnabled := DB, config.MultiTenantEnabled
+	t.Cleanup(func() {
+		DB, config.MultiTenantEnabled = originDB, originEnabled
+	})
+	DB = db
+	config.MultiTenantEnabled = false
+
+	db.Create(&Channel{Id: 1, Name: "channel-1"})
+	db.Create(&Channel{Id: 2, Name: "channel-2"})
+	logs := []*Log{
+		{Type: LogTypeConsume, ChannelId: 1, ModelName: "gpt-4o", CreatedAt: 100, IsStream: true, RequestTime: 1000, FirstTokenTime: 200, UpstreamTime: 800, RetryCount: 2},
+		{Type: LogTypeConsume, ChannelId: 1, ModelName: "gpt-4o", CreatedAt: 200, IsStream: true, RequestTime: 600, FirstTokenTime: 400, UpstreamTime: 600},
+		// 未记录耗时的旧日志不参与平均值计算
+		{Type: LogTypeConsume, ChannelId: 1, ModelName: "gpt-4o-mini", CreatedAt: 300, RequestTime: 500},
+		{Type: LogTypeConsume, ChannelId: 2, ModelName: "gpt-4o", CreatedAt: 400, RequestTime: 300, UpstreamTime: 300},
+		{Type: LogTypeSystem, ChannelId: 2, ModelName: "gpt-4o", CreatedAt: 400},
+	}
+	for _, log := range logs {
+		if err = db.Create(log).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := GetChannelPerformance(&ChannelPerformanceParams{}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 channels, got %d", len(result))
+	}
+
+	first := result[0]
+	if first.ChannelId != 1 || first.ChannelName != "channel-1" || first.RequestCount != 3 || first.StreamCount != 2 {
+		t.Fatalf("unexpected channel performance: %+v", first)
+	}
+	if first.AvgFirstTokenTime != 300 || first.MaxFirstTokenTime != 400 || first.AvgUpstreamTime != 700 || first.AvgRequestTime != 700 {
+		t.Fatalf("unexpected channel timing: %+v", first)
+	}
+	if first.RetryCount != 2 || first.RetriedRequests != 1 {
+		t.Fatalf("unexpected channel retries: %+v", first)
+	}
+	if result[1].ChannelId != 2 || result[1].RequestCount != 1 || result[1].AvgFirstTokenTime != 0 {
+		t.Fatalf("unexpected channel performance: %+v", result[1])
+	}
+
+	result, err = GetChannelPerformance(&ChannelPerformanceParams{ChannelId: 1, GroupByModel: true, StartTimestamp: 150}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 models, got %d", len(result))
+	}
+	for _, item := range result {
+		if item.RequestCount != 1 || item.ChannelName != "channel-1" {
+			t.Fatalf("unexpected model performance: %+v", item)
+		}
+	}
+}
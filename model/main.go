@@ -37,6 +37,7 @@ func SetupDB() {
 
 	ChannelGroup.Load()
 	GlobalUserGroupRatio.Load()
+	GlobalTenants.Load()
 	config.RootUserEmail = GetRootUserEmail()
 	NewModelOwnedBys()
 
@@ -150,6 +151,14 @@ func InitDB() (err error) {
 		if err != nil {
 			return err
 		}
+		err = db.AutoMigrate(&RedemptionUsage{})
+		if err != nil {
+			return err
+		}
+		err = db.AutoMigrate(&Referral{})
+		if err != nil {
+			return err
+		}
 		err = db.AutoMigrate(&Log{})
 		if err != nil {
 			return err
@@ -204,11 +213,51 @@ func InitDB() (err error) {
 			return err
 		}
 
+		err = db.AutoMigrate(&PriceVersion{})
+		if err != nil {
+			return err
+		}
+
 		err = DB.AutoMigrate(&WebAuthnCredential{})
 		if err != nil {
 			return err
 		}
 
+		err = db.AutoMigrate(&Tenant{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&TenantPrice{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&EmailLog{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&TokenStatistics{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&ChannelBalanceHistory{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&CanaryRule{})
+		if err != nil {
+			return err
+		}
+
+		err = db.AutoMigrate(&LogExportBatch{})
+		if err != nil {
+			return err
+		}
+
 		if config.UserInvoiceMonth {
 			err = db.AutoMigrate(&StatisticsMonthGeneratedHistory{})
 			if err != nil {
@@ -221,7 +270,10 @@ func InitDB() (err error) {
 			}
 		}
 
-		migrationAfter(DB)
+		if err = migrationAfter(DB); err != nil {
+			logger.SysError("failed to run migrations: " + err.Error())
+			return err
+		}
 
 		logger.SysLog("database migrated")
 		err = createRootAccountIfNeed()
@@ -10,6 +10,7 @@ import (
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func removeKeyIndexMigration() *gormigrate.Migration {
@@ -95,11 +96,16 @@ func migrationBefore(db *gorm.DB) error {
 		return nil
 	}
 
-	m := gormigrate.New(db, gormigrate.DefaultOptions, []*gormigrate.Migration{
+	m := gormigrate.New(db, gormigrate.DefaultOptions, beforeMigrations())
+	return m.Migrate()
+}
+
+// beforeMigrations AutoMigrate 之前执行的迁移
+func beforeMigrations() []*gormigrate.Migration {
+	return []*gormigrate.Migration{
 		removeKeyIndexMigration(),
 		changeTokenKeyColumnType(),
-	})
-	return m.Migrate()
+	}
 }
 
 func addStatistics() *gormigrate.Migration {
@@ -115,6 +121,20 @@ func addStatistics() *gormigrate.Migration {
 	}
 }
 
+// addTokenStatistics 根据历史日志生成按令牌汇总的统计数据
+func addTokenStatistics() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610170001",
+		Migrate: func(tx *gorm.DB) error {
+			go UpdateTokenStatistics(StatisticsUpdateTypeALL)
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return nil
+		},
+	}
+}
+
 func changeChannelApiVersion() *gormigrate.Migration {
 	return &gormigrate.Migration{
 		ID: "202408190001",
@@ -449,13 +469,46 @@ func migrationAfter(db *gorm.DB) error {
 		logger.SysLog("从库不执行迁移后操作")
 		return nil
 	}
-	m := gormigrate.New(db, gormigrate.DefaultOptions, []*gormigrate.Migration{
+	m := gormigrate.New(db, gormigrate.DefaultOptions, afterMigrations())
+	return m.Migrate()
+}
+
+// afterMigrations AutoMigrate 之后执行的迁移，ID 不能重复，否则 gormigrate 会拒绝执行全部迁移
+func afterMigrations() []*gormigrate.Migration {
+	return []*gormigrate.Migration{
 		addStatistics(),
 		changeChannelApiVersion(),
 		initUserGroup(),
 		addOldTokenMaxId(),
 		addExtraRatios(),
 		migrateTokenLimitsStructure(),
-	})
-	return m.Migrate()
+		addTokenStatistics(),
+		backfillReferrals(),
+	}
+}
+
+// backfillReferrals 为邀请记录上线前已注册的被邀请用户补充邀请关系，这些用户注册时已经发放过邀请奖励
+func backfillReferrals() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610170002",
+		Migrate: func(tx *gorm.DB) error {
+			var users []*User
+			return tx.Unscoped().Select("id", "inviter_id", "created_time").Where("inviter_id > 0").
+				FindInBatches(&users, 500, func(batchTx *gorm.DB, batch int) error {
+					referrals := make([]*Referral, 0, len(users))
+					for _, user := range users {
+						referrals = append(referrals, &Referral{
+							InviterId:      user.InviterId,
+							InviteeId:      user.Id,
+							RegisteredTime: user.CreatedTime,
+							RewardGranted:  true,
+						})
+					}
+					return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&referrals).Error
+				}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return nil
+		},
+	}
 }
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func TestMigrationIdsUnique(t *testing.T) {
+	for name, migrations := range map[string][]*gormigrate.Migration{
+		"before": beforeMigrations(),
+		"after":  afterMigrations(),
+	} {
+		seen := make(map[string]bool, len(migrations))
+		for _, migration := range migrations {
+			if migration.ID == "" {
+				t.Errorf("%s migration has empty id", name)
+			}
+			if seen[migration.ID] {
+				t.Errorf("%s migration id %s is duplicated", name, migration.ID)
+			}
+			seen[migration.ID] = true
+		}
+	}
+}
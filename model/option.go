@@ -4,6 +4,8 @@ import (
 	"done-hub/common"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/pathrewrite"
+	"done-hub/mcp/quota"
 	"strings"
 	"time"
 )
@@ -43,6 +45,8 @@ func InitOptionMap() {
 	config.GlobalOption.RegisterBool("AutomaticEnableChannelEnabled", &config.AutomaticEnableChannelEnabled)
 	config.GlobalOption.RegisterBool("ApproximateTokenEnabled", &config.ApproximateTokenEnabled)
 	config.GlobalOption.RegisterBool("LogConsumeEnabled", &config.LogConsumeEnabled)
+	config.GlobalOption.RegisterBool("LogRequestBodyEnabled", &config.LogRequestBodyEnabled)
+	config.GlobalOption.RegisterInt("LogRequestBodyMaxSize", &config.LogRequestBodyMaxSize)
 	config.GlobalOption.RegisterBool("EmptyResponseBillingEnabled", &config.EmptyResponseBillingEnabled)
 	config.GlobalOption.RegisterBool("DisplayInCurrencyEnabled", &config.DisplayInCurrencyEnabled)
 	config.GlobalOption.RegisterFloat("ChannelDisableThreshold", &config.ChannelDisableThreshold)
@@ -55,11 +59,52 @@ func InitOptionMap() {
 		return nil
 	}, "")
 
+	config.GlobalOption.RegisterBool("UsageAnomalyNotifyEnabled", &config.UsageAnomalyNotifyEnabled)
+	config.GlobalOption.RegisterFloat("UsageAnomalyRatio", &config.UsageAnomalyRatio)
+	config.GlobalOption.RegisterInt("UsageAnomalyMinQuota", &config.UsageAnomalyMinQuota)
+
+	config.GlobalOption.RegisterBool("ChannelBalanceCheckEnabled", &config.ChannelBalanceCheckEnabled)
+	config.GlobalOption.RegisterInt("ChannelBalanceCheckInterval", &config.ChannelBalanceCheckInterval)
+	config.GlobalOption.RegisterFloat("ChannelBalanceThreshold", &config.ChannelBalanceThreshold)
+	config.GlobalOption.RegisterBool("ChannelBalanceAutoDisable", &config.ChannelBalanceAutoDisable)
+
 	config.GlobalOption.RegisterString("SMTPServer", &config.SMTPServer)
 	config.GlobalOption.RegisterString("SMTPFrom", &config.SMTPFrom)
 	config.GlobalOption.RegisterInt("SMTPPort", &config.SMTPPort)
 	config.GlobalOption.RegisterString("SMTPAccount", &config.SMTPAccount)
 	config.GlobalOption.RegisterString("SMTPToken", &config.SMTPToken)
+	config.GlobalOption.RegisterString("EmailProvider", &config.EmailProvider)
+	config.GlobalOption.RegisterString("SendGridToken", &config.SendGridToken)
+	config.GlobalOption.RegisterString("MailgunDomain", &config.MailgunDomain)
+	config.GlobalOption.RegisterString("MailgunRegion", &config.MailgunRegion)
+	config.GlobalOption.RegisterString("MailgunToken", &config.MailgunToken)
+	config.GlobalOption.RegisterString("SESRegion", &config.SESRegion)
+	config.GlobalOption.RegisterString("SESAccessKeyId", &config.SESAccessKeyId)
+	config.GlobalOption.RegisterString("SESSecret", &config.SESSecret)
+	config.GlobalOption.RegisterInt("EmailRetryTimes", &config.EmailRetryTimes)
+
+	config.GlobalOption.RegisterBool("BillingWebhookEnabled", &config.BillingWebhookEnabled)
+	config.GlobalOption.RegisterString("BillingWebhookURL", &config.BillingWebhookURL)
+	config.GlobalOption.RegisterString("BillingWebhookSecret", &config.BillingWebhookSecret)
+	config.GlobalOption.RegisterInt("BillingWebhookRetryTimes", &config.BillingWebhookRetryTimes)
+
+	config.GlobalOption.RegisterBool("HookEnabled", &config.HookEnabled)
+	config.GlobalOption.RegisterString("HookURL", &config.HookURL)
+	config.GlobalOption.RegisterString("HookStages", &config.HookStages)
+	config.GlobalOption.RegisterString("HookSecret", &config.HookSecret)
+	config.GlobalOption.RegisterInt("HookTimeout", &config.HookTimeout)
+	config.GlobalOption.RegisterBool("HookFailOpen", &config.HookFailOpen)
+
+	config.GlobalOption.RegisterBool("LogExportEnabled", &config.LogExportEnabled)
+	config.GlobalOption.RegisterString("LogExportFormat", &config.LogExportFormat)
+	config.GlobalOption.RegisterInt("LogExportInterval", &config.LogExportInterval)
+	config.GlobalOption.RegisterString("LogExportStorage", &config.LogExportStorage)
+	config.GlobalOption.RegisterString("LogExportEndpoint", &config.LogExportEndpoint)
+	config.GlobalOption.RegisterString("LogExportRegion", &config.LogExportRegion)
+	config.GlobalOption.RegisterString("LogExportBucket", &config.LogExportBucket)
+	config.GlobalOption.RegisterString("LogExportPrefix", &config.LogExportPrefix)
+	config.GlobalOption.RegisterString("LogExportAccessKeyId", &config.LogExportAccessKeyId)
+	config.GlobalOption.RegisterString("LogExportAccessKeySecret", &config.LogExportAccessKeySecret)
 	config.GlobalOption.RegisterValue("Notice")
 	config.GlobalOption.RegisterValue("About")
 	config.GlobalOption.RegisterValue("HomePageContent")
@@ -92,6 +137,7 @@ func InitOptionMap() {
 	config.GlobalOption.RegisterInt("QuotaForInvitee", &config.QuotaForInvitee)
 	config.GlobalOption.RegisterString("InviterRewardType", &config.InviterRewardType)
 	config.GlobalOption.RegisterInt("InviterRewardValue", &config.InviterRewardValue)
+	config.GlobalOption.RegisterString("InviteRewardTrigger", &config.InviteRewardTrigger)
 	config.GlobalOption.RegisterInt("QuotaRemindThreshold", &config.QuotaRemindThreshold)
 	config.GlobalOption.RegisterInt("PreConsumedQuota", &config.PreConsumedQuota)
 
@@ -102,6 +148,21 @@ func InitOptionMap() {
 	config.GlobalOption.RegisterInt("RetryTimes", &config.RetryTimes)
 	config.GlobalOption.RegisterInt("RetryCooldownSeconds", &config.RetryCooldownSeconds)
 
+	config.GlobalOption.RegisterBool("ChannelCircuitBreakerEnabled", &config.ChannelCircuitBreakerEnabled)
+	config.GlobalOption.RegisterFloat("ChannelCircuitBreakerErrorRate", &config.ChannelCircuitBreakerErrorRate)
+	config.GlobalOption.RegisterInt("ChannelCircuitBreakerMinRequests", &config.ChannelCircuitBreakerMinRequests)
+	config.GlobalOption.RegisterInt("ChannelCircuitBreakerWindowSeconds", &config.ChannelCircuitBreakerWindowSeconds)
+	config.GlobalOption.RegisterInt("ChannelCircuitBreakerOpenSeconds", &config.ChannelCircuitBreakerOpenSeconds)
+	config.GlobalOption.RegisterInt("ChannelCircuitBreakerHalfOpenProbes", &config.ChannelCircuitBreakerHalfOpenProbes)
+	config.GlobalOption.RegisterInt("StreamKeepAliveSeconds", &config.StreamKeepAliveSeconds)
+	config.GlobalOption.RegisterBool("ProxyCooldownOnForbidden", &config.ProxyCooldownOnForbidden)
+
+	config.GlobalOption.RegisterBool("InactiveUserPauseEnabled", &config.InactiveUserPauseEnabled)
+	config.GlobalOption.RegisterInt("InactiveUserDays", &config.InactiveUserDays)
+	config.GlobalOption.RegisterInt("InactiveUserGraceDays", &config.InactiveUserGraceDays)
+	config.GlobalOption.RegisterString("InactiveUserExemptGroups", &config.InactiveUserExemptGroups)
+	config.GlobalOption.RegisterBool("InactiveUserReclaimPromoQuota", &config.InactiveUserReclaimPromoQuota)
+
 	config.GlobalOption.RegisterBool("MjNotifyEnabled", &config.MjNotifyEnabled)
 	config.GlobalOption.RegisterBool("BuiltinChatEnabled", &config.BuiltinChatEnabled)
 	config.GlobalOption.RegisterString("ChatImageRequestProxy", &config.ChatImageRequestProxy)
@@ -118,6 +179,12 @@ func InitOptionMap() {
 
 	config.GlobalOption.RegisterString("CFWorkerImageUrl", &config.CFWorkerImageUrl)
 	config.GlobalOption.RegisterString("CFWorkerImageKey", &config.CFWorkerImageKey)
+	config.GlobalOption.RegisterBool("StorageMirrorEnabled", &config.StorageMirrorEnabled)
+	config.GlobalOption.RegisterInt("ImageInputMaxSizeMB", &config.ImageInputMaxSizeMB)
+	config.GlobalOption.RegisterInt("ImageInputMaxDimension", &config.ImageInputMaxDimension)
+	config.GlobalOption.RegisterInt("ImageInputJPEGQuality", &config.ImageInputJPEGQuality)
+	config.GlobalOption.RegisterInt("ImageInputCacheSeconds", &config.ImageInputCacheSeconds)
+	config.GlobalOption.RegisterInt("ImageInputCacheMaxMB", &config.ImageInputCacheMaxMB)
 	config.GlobalOption.RegisterInt("OldTokenMaxId", &config.OldTokenMaxId)
 	config.GlobalOption.RegisterBool("GitHubOldIdCloseEnabled", &config.GitHubOldIdCloseEnabled)
 
@@ -132,6 +199,7 @@ func InitOptionMap() {
 	}, common.GetDefaultDisableChannelKeywords())
 
 	config.GlobalOption.RegisterInt("RetryTimeOut", &config.RetryTimeOut)
+	config.GlobalOption.RegisterInt("StreamFirstByteTimeout", &config.StreamFirstByteTimeout)
 
 	config.GlobalOption.RegisterBool("EnableSafe", &config.EnableSafe)
 	config.GlobalOption.RegisterString("SafeToolName", &config.SafeToolName)
@@ -141,6 +209,32 @@ func InitOptionMap() {
 		config.SafeKeyWords = strings.Split(value, "\n")
 		return nil
 	}, "")
+	config.GlobalOption.RegisterString("SafeAction", &config.SafeAction)
+	config.GlobalOption.RegisterString("SafeGroups", &config.SafeGroups)
+	config.GlobalOption.RegisterCustom("SafeRegexRules", func() string {
+		return strings.Join(config.SafeRegexRules, "\n")
+	}, func(value string) error {
+		config.SafeRegexRules = strings.Split(value, "\n")
+		return nil
+	}, "")
+	config.GlobalOption.RegisterString("SafeModerationBaseURL", &config.SafeModerationBaseURL)
+	config.GlobalOption.RegisterString("SafeModerationSecret", &config.SafeModerationSecret)
+	config.GlobalOption.RegisterString("SafeModerationModel", &config.SafeModerationModel)
+
+	config.GlobalOption.RegisterBool("IPRateLimitEnabled", &config.IPRateLimitEnabled)
+	config.GlobalOption.RegisterInt("IPRelayRateLimit", &config.IPRelayRateLimit)
+	config.GlobalOption.RegisterInt("IPAuthRateLimit", &config.IPAuthRateLimit)
+	config.GlobalOption.RegisterString("IPRateLimitWhitelist", &config.IPRateLimitWhitelist)
+	config.GlobalOption.RegisterString("GeoRestrictionAction", &config.GeoRestrictionAction)
+	config.GlobalOption.RegisterInt("RequestMaxBodySize", &config.RequestMaxBodySize)
+	config.GlobalOption.RegisterInt("RequestMaxMessages", &config.RequestMaxMessages)
+	config.GlobalOption.RegisterInt("RequestMaxImageSize", &config.RequestMaxImageSize)
+	config.GlobalOption.RegisterInt("RequestMaxOutputTokens", &config.RequestMaxOutputTokens)
+	config.GlobalOption.RegisterInt("IdempotencyKeyTTL", &config.IdempotencyKeyTTL)
+
+	config.GlobalOption.RegisterCustom("PathRewriteRules", pathrewrite.GetRules, pathrewrite.SetRules, "")
+	config.GlobalOption.RegisterCustom("MCPToolQuota", quota.GetToolQuota, quota.SetToolQuota, "")
+	config.GlobalOption.RegisterCustom("ReasoningEffortBudgets", config.ReasoningSettingsInstance.GetEffortBudgetsJSONString, config.ReasoningSettingsInstance.SetEffortBudgets, "")
 
 	// 注册统一请求响应模型配置项
 	config.GlobalOption.RegisterBool("UnifiedRequestResponseModelEnabled", &config.UnifiedRequestResponseModelEnabled)
@@ -182,5 +276,9 @@ func UpdateOption(key string, value string) error {
 	// otherwise it will execute Update (with all fields).
 	DB.Save(&option)
 	// Update OptionMap
-	return config.GlobalOption.Set(key, value)
+	if err := config.GlobalOption.Set(key, value); err != nil {
+		return err
+	}
+	PublishCacheInvalidation(CacheInvalidationOptions)
+	return nil
 }
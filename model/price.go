@@ -11,6 +11,7 @@ import (
 const (
 	TokensPriceType    = "tokens"
 	TimesPriceType     = "times"
+	ImagesPriceType    = "images" // 按生成的图片张数计费，input 为单张价格
 	DefaultPrice       = 30.0
 	DollarRate         = 0.002
 	RMBRate            = 0.014
@@ -113,7 +114,7 @@ func (price *Price) GetInput() float64 {
 }
 
 func (price *Price) GetOutput() float64 {
-	if price.Output <= 0 || price.Type == TimesPriceType {
+	if price.Output <= 0 || price.Type == TimesPriceType || price.Type == ImagesPriceType {
 		return 0
 	}
 
@@ -360,12 +361,20 @@ func GetDefaultPrice() []*Price {
 		"moonshot-v1-32k":  {[]float64{1.7143, 1.7143}, config.ChannelTypeMoonshot}, // ¥0.024 / 1K tokens
 		"moonshot-v1-128k": {[]float64{4.2857, 4.2857}, config.ChannelTypeMoonshot}, // ¥0.06 / 1K tokens
 
+		// https://docs.x.ai/docs/models
+		"grok-4":           {[]float64{1.5, 7.5}, config.ChannelTypeXAI},   // $3 / 1M tokens	$15 / 1M tokens
+		"grok-4-fast":      {[]float64{0.1, 0.25}, config.ChannelTypeXAI},  // $0.2 / 1M tokens	$0.5 / 1M tokens
+		"grok-code-fast-1": {[]float64{0.1, 0.75}, config.ChannelTypeXAI},  // $0.2 / 1M tokens	$1.5 / 1M tokens
+		"grok-3":           {[]float64{1.5, 7.5}, config.ChannelTypeXAI},   // $3 / 1M tokens	$15 / 1M tokens
+		"grok-3-mini":      {[]float64{0.15, 0.25}, config.ChannelTypeXAI}, // $0.3 / 1M tokens	$0.5 / 1M tokens
+
 		"open-mistral-7b":       {[]float64{0.125, 0.125}, config.ChannelTypeMistral}, // 0.25$ / 1M tokens	0.25$ / 1M tokens  0.00025$ / 1k tokens
 		"open-mixtral-8x7b":     {[]float64{0.35, 0.35}, config.ChannelTypeMistral},   // 0.7$ / 1M tokens	0.7$ / 1M tokens  0.0007$ / 1k tokens
 		"mistral-small-latest":  {[]float64{1, 3}, config.ChannelTypeMistral},         // 2$ / 1M tokens	6$ / 1M tokens  0.002$ / 1k tokens
 		"mistral-medium-latest": {[]float64{1.35, 4.05}, config.ChannelTypeMistral},   // 2.7$ / 1M tokens	8.1$ / 1M tokens  0.0027$ / 1k tokens
 		"mistral-large-latest":  {[]float64{4, 12}, config.ChannelTypeMistral},        // 8$ / 1M tokens	24$ / 1M tokens  0.008$ / 1k tokens
 		"mistral-embed":         {[]float64{0.05, 0.05}, config.ChannelTypeMistral},   // 0.1$ / 1M tokens 0.1$ / 1M tokens  0.0001$ / 1k tokens
+		"mistral-ocr-latest":    {[]float64{500, 0}, config.ChannelTypeMistral},       // 1$ / 1000 pages，每页计为 1 个输入 token
 
 		// $0.70/$0.80 /1M Tokens 0.0007$ / 1k tokens
 		"llama2-70b-4096": {[]float64{0.35, 0.4}, config.ChannelTypeGroq},
@@ -500,6 +509,15 @@ func GetDefaultPrice() []*Price {
 		})
 	}
 
+	// 按生成的图片张数计费，$0.07 / image
+	prices = append(prices, &Price{
+		Model:       "grok-2-image",
+		Type:        ImagesPriceType,
+		ChannelType: config.ChannelTypeXAI,
+		Input:       35,
+		Output:      35,
+	})
+
 	return prices
 }
 
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"errors"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+const (
+	PriceVersionStatusPending  = "pending"  // 尚未生效
+	PriceVersionStatusApplied  = "applied"  // 已生效
+	PriceVersionStatusCanceled = "canceled" // 生效前被取消
+)
+
+// PriceVersion 模型价格的版本记录，支持指定生效时间
+// 历史日志按消费时的价格计算并保存额度，价格变更不会影响已有日志
+type PriceVersion struct {
+	Id          int     `json:"id"`
+	Model       string  `json:"model" gorm:"type:varchar(100);index" binding:"required"`
+	Type        string  `json:"type" gorm:"default:'tokens'" binding:"required"`
+	ChannelType int     `json:"channel_type" gorm:"default:0" binding:"gte=0"`
+	Input       float64 `json:"input" gorm:"default:0" binding:"gte=0"`
+	Output      float64 `json:"output" gorm:"default:0" binding:"gte=0"`
+	Locked      bool    `json:"locked" gorm:"default:false"`
+
+	ExtraRatios *datatypes.JSONType[map[string]float64] `json:"extra_ratios,omitempty" gorm:"type:json"`
+
+	EffectiveAt int64  `json:"effective_at" gorm:"bigint;index"`
+	Status      string `json:"status" gorm:"type:varchar(16);index"`
+	CreatedBy   int    `json:"created_by"`
+	CreatedTime int64  `json:"created_time" gorm:"bigint"`
+	AppliedTime int64  `json:"applied_time" gorm:"bigint;default:0"`
+}
+
+func (v *PriceVersion) ToPrice() *Price {
+	return &Price{
+		Model:       v.Model,
+		Type:        v.Type,
+		ChannelType: v.ChannelType,
+		Input:       v.Input,
+		Output:      v.Output,
+		Locked:      v.Locked,
+		ExtraRatios: v.ExtraRatios,
+	}
+}
+
+func GetPriceVersions(modelName string) ([]*PriceVersion, error) {
+	var versions []*PriceVersion
+	db := DB.Order("effective_at desc, id desc")
+	if modelName != "" {
+		db = db.Where("model = ?", modelName)
+	}
+	err := db.Find(&versions).Error
+	return versions, err
+}
+
+// CreatePriceVersion 创建价格版本，生效时间已到时立即应用
+func CreatePriceVersion(version *PriceVersion) error {
+	now := utils.GetTimestamp()
+	version.Id = 0
+	version.CreatedTime = now
+	version.AppliedTime = 0
+	version.Status = PriceVersionStatusPending
+	if version.EffectiveAt <= 0 {
+		version.EffectiveAt = now
+	}
+
+	if err := DB.Create(version).Error; err != nil {
+		return err
+	}
+
+	if version.EffectiveAt <= now {
+		return applyPriceVersion(version)
+	}
+	return nil
+}
+
+// CancelPriceVersion 取消尚未生效的价格版本
+func CancelPriceVersion(id int) error {
+	result := DB.Model(&PriceVersion{}).
+		Where("id = ? AND status = ?", id, PriceVersionStatusPending).
+		Update("status", PriceVersionStatusCanceled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("价格版本不存在或已生效")
+	}
+	return nil
+}
+
+// RecordAppliedPriceVersion 记录通过价格接口直接修改的价格，便于追溯历史
+func RecordAppliedPriceVersion(price *Price, userId int) {
+	now := utils.GetTimestamp()
+	version := &PriceVersion{
+		Model:       price.Model,
+		Type:        price.Type,
+		ChannelType: price.ChannelType,
+		Input:       price.Input,
+		Output:      price.Output,
+		Locked:      price.Locked,
+		ExtraRatios: price.ExtraRatios,
+		EffectiveAt: now,
+		Status:      PriceVersionStatusApplied,
+		CreatedBy:   userId,
+		CreatedTime: now,
+		AppliedTime: now,
+	}
+	if err := DB.Create(version).Error; err != nil {
+		logger.SysError("record price version failed: " + err.Error())
+	}
+}
+
+// ApplyDuePriceVersions 应用所有已到生效时间的价格版本
+func ApplyDuePriceVersions() (int, error) {
+	var versions []*PriceVersion
+	err := DB.Where("status = ? AND effective_at <= ?", PriceVersionStatusPending, utils.GetTimestamp()).
+		Order("effective_at asc, id asc").
+		Find(&versions).Error
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, version := range versions {
+		if err := applyPriceVersion(version); err != nil {
+			logger.SysError(fmt.Sprintf("apply price version %d failed: %s", version.Id, err.Error()))
+			continue
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+func applyPriceVersion(version *PriceVersion) error {
+	price := version.ToPrice()
+
+	var err error
+	if _, ok := PricingInstance.GetAllPrices()[version.Model]; ok {
+		err = PricingInstance.UpdatePrice(version.Model, price)
+	} else {
+		err = PricingInstance.AddPrice(price)
+	}
+	if err != nil {
+		return err
+	}
+
+	version.Status = PriceVersionStatusApplied
+	version.AppliedTime = utils.GetTimestamp()
+	return DB.Model(version).Select("status", "applied_time").Updates(version).Error
+}
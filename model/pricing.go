@@ -66,6 +66,15 @@ func NewPricing() {
 	}
 }
 
+// reload 价格变更后重新加载，并通知其他节点同步刷新
+func (p *Pricing) reload() error {
+	if err := p.Init(); err != nil {
+		return err
+	}
+	PublishCacheInvalidation(CacheInvalidationPricing)
+	return nil
+}
+
 // initializes the Pricing instance
 func (p *Pricing) Init() error {
 	prices, err := GetAllPrices()
@@ -197,7 +206,7 @@ func (p *Pricing) UpdatePrice(modelName string, price *Price) error {
 		return err
 	}
 
-	err := p.Init()
+	err := p.reload()
 
 	return err
 }
@@ -216,7 +225,7 @@ func (p *Pricing) AddPrice(price *Price) error {
 		return err
 	}
 
-	err := p.Init()
+	err := p.reload()
 
 	return err
 }
@@ -236,7 +245,7 @@ func (p *Pricing) DeletePrice(modelName string) error {
 		return err
 	}
 
-	err := p.Init()
+	err := p.reload()
 
 	return err
 }
@@ -280,7 +289,7 @@ func UpdatePriceByPriceService() error {
 			Prices: make(map[string]*Price),
 			Match:  make([]string, 0),
 		}
-		err := p.Init()
+		err := p.reload()
 		if err != nil {
 			logger.SysError("Failed to initialize Pricing:" + err.Error())
 			return err
@@ -297,7 +306,7 @@ func UpdatePriceByPriceService() error {
 			Prices: make(map[string]*Price),
 			Match:  make([]string, 0),
 		}
-		err := p.Init()
+		err := p.reload()
 		if err != nil {
 			logger.SysError("Failed to initialize Pricing:" + err.Error())
 			return err
@@ -314,7 +323,7 @@ func UpdatePriceByPriceService() error {
 			Prices: make(map[string]*Price),
 			Match:  make([]string, 0),
 		}
-		err := p.Init()
+		err := p.reload()
 		if err != nil {
 			logger.SysError("Failed to initialize Pricing:" + err.Error())
 			return err
@@ -399,7 +408,7 @@ func (p *Pricing) SyncPriceWithOverwrite(pricing []*Price) error {
 
 	tx.Commit()
 	logger.SysLog(fmt.Sprintf("本次修改加新增 %d 个价格配置", len(newPrices)))
-	return p.Init()
+	return p.reload()
 }
 
 // SyncPriceOnlyUpdate 只更新系统现有的数据 不含lock的数据
@@ -435,7 +444,7 @@ func (p *Pricing) SyncPriceOnlyUpdate(pricing []*Price) error {
 
 	tx.Commit()
 	logger.SysLog(fmt.Sprintf("本次更新修改 %d 个价格配置", len(newPrices)))
-	return p.Init()
+	return p.reload()
 }
 
 // SyncPriceWithoutOverwrite 只插入系统没有的数据
@@ -463,7 +472,7 @@ func (p *Pricing) SyncPriceWithoutOverwrite(pricing []*Price) error {
 
 	tx.Commit()
 	logger.SysLog(fmt.Sprintf("本次新增 %d 个价格配置", len(newPrices)))
-	return p.Init()
+	return p.reload()
 }
 
 // BatchDeletePrices deletes the prices of multiple models
@@ -537,7 +546,7 @@ func (p *Pricing) BatchSetPrices(batchPrices *BatchPrices, originalModels []stri
 	}
 	tx.Commit()
 
-	return p.Init()
+	return p.reload()
 }
 
 func GetPricesList(pricingType string) []*Price {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Redemption struct {
@@ -20,9 +21,22 @@ type Redemption struct {
 	Quota        int    `json:"quota" gorm:"default:100"`
 	CreatedTime  int64  `json:"created_time" gorm:"bigint"`
 	RedeemedTime int64  `json:"redeemed_time" gorm:"bigint"`
+	BatchId      string `json:"batch_id" gorm:"type:varchar(32);index;default:''"`
+	ExpiredTime  int64  `json:"expired_time" gorm:"bigint;default:0"` // 0 表示永不过期
+	MaxUses      int    `json:"max_uses" gorm:"default:1"`            // 可兑换次数，大于 1 时每个用户限兑换一次
+	UsedCount    int    `json:"used_count" gorm:"default:0"`
 	Count        int    `json:"count" gorm:"-:all"` // only for api request
 }
 
+// RedemptionUsage 多次使用兑换码的兑换记录，用于限制同一用户重复兑换
+type RedemptionUsage struct {
+	Id           int   `json:"id"`
+	RedemptionId int   `json:"redemption_id" gorm:"uniqueIndex:idx_redemption_user"`
+	UserId       int   `json:"user_id" gorm:"uniqueIndex:idx_redemption_user"`
+	Quota        int   `json:"quota"`
+	CreatedTime  int64 `json:"created_time" gorm:"bigint"`
+}
+
 var allowedRedemptionslOrderFields = map[string]bool{
 	"id":            true,
 	"name":          true,
@@ -36,7 +50,7 @@ func GetRedemptionsList(params *GenericParams) (*DataResult[Redemption], error)
 	var redemptions []*Redemption
 	db := DB
 	if params.Keyword != "" {
-		db = db.Where("id = ? or name LIKE ?", utils.String2Int(params.Keyword), params.Keyword+"%")
+		db = db.Where("id = ? or name LIKE ? or batch_id = ?", utils.String2Int(params.Keyword), params.Keyword+"%", params.Keyword)
 	}
 
 	return PaginateAndOrder[Redemption](db, &params.PaginationParams, &redemptions, allowedRedemptionslOrderFields)
@@ -67,21 +81,39 @@ func Redeem(key string, userId int, ip string) (quota int, err error) {
 	}
 
 	err = DB.Transaction(func(tx *gorm.DB) error {
-		err := tx.Set("gorm:query_option", "FOR UPDATE").Where(keyCol+" = ?", key).First(redemption).Error
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(keyCol+" = ?", key).First(redemption).Error
 		if err != nil {
 			return errors.New("无效的兑换码")
 		}
 		if redemption.Status != config.RedemptionCodeStatusEnabled {
 			return errors.New("该兑换码已被使用")
 		}
+		now := utils.GetTimestamp()
+		if redemption.ExpiredTime > 0 && now >= redemption.ExpiredTime {
+			return errors.New("该兑换码已过期")
+		}
+		if redemption.MaxUses > 1 {
+			var usedCount int64
+			tx.Model(&RedemptionUsage{}).Where("redemption_id = ? AND user_id = ?", redemption.Id, userId).Count(&usedCount)
+			if usedCount > 0 {
+				return errors.New("您已兑换过该兑换码")
+			}
+			// 并发兑换时由唯一索引兜底
+			err = tx.Create(&RedemptionUsage{
+				RedemptionId: redemption.Id,
+				UserId:       userId,
+				Quota:        redemption.Quota,
+				CreatedTime:  now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
 		err = tx.Model(&User{}).Where("id = ?", userId).Update("quota", gorm.Expr("quota + ?", redemption.Quota)).Error
 		if err != nil {
 			return err
 		}
-		redemption.RedeemedTime = utils.GetTimestamp()
-		redemption.Status = config.RedemptionCodeStatusUsed
-		err = tx.Save(redemption).Error
-		return err
+		return useRedemption(tx, redemption, now)
 	})
 	if err != nil {
 		return 0, errors.New("兑换失败，" + err.Error())
@@ -96,7 +128,7 @@ func Redeem(key string, userId int, ip string) (quota int, err error) {
 	RecordQuotaLog(userId, LogTypeTopup, redemption.Quota, ip, fmt.Sprintf("通过兑换码充值 %s", common.LogQuota(redemption.Quota)))
 
 	// 处理邀请人充值返利
-	err = ProcessInviterReward(userId, redemption.Quota, ip)
+	err = ProcessInviterReward(userId, redemption.Quota, ip, false)
 	if err != nil {
 		logger.SysError("failed to process inviter reward for redemption: " + err.Error())
 	}
@@ -104,6 +136,30 @@ func Redeem(key string, userId int, ip string) (quota int, err error) {
 	return redemption.Quota, nil
 }
 
+// useRedemption 原子地增加兑换次数，次数已用完时返回错误，用完后将兑换码标记为已使用
+func useRedemption(tx *gorm.DB, redemption *Redemption, now int64) error {
+	result := tx.Model(&Redemption{}).
+		Where("id = ? AND status = ? AND used_count < max_uses", redemption.Id, config.RedemptionCodeStatusEnabled).
+		Updates(map[string]any{
+			"used_count":    gorm.Expr("used_count + 1"),
+			"redeemed_time": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("该兑换码已被使用")
+	}
+
+	err := tx.Model(&Redemption{}).
+		Where("id = ? AND used_count >= max_uses", redemption.Id).
+		Update("status", config.RedemptionCodeStatusUsed).Error
+	if err != nil {
+		return err
+	}
+	return tx.First(redemption, redemption.Id).Error
+}
+
 func (redemption *Redemption) Insert() error {
 	var err error
 	err = DB.Create(redemption).Error
@@ -118,7 +174,7 @@ func (redemption *Redemption) SelectUpdate() error {
 // Update Make sure your token's fields is completed, because this will update non-zero values
 func (redemption *Redemption) Update() error {
 	var err error
-	err = DB.Model(redemption).Select("name", "status", "quota", "redeemed_time").Updates(redemption).Error
+	err = DB.Model(redemption).Select("name", "status", "quota", "redeemed_time", "expired_time").Updates(redemption).Error
 	return err
 }
 
@@ -140,6 +196,25 @@ func DeleteRedemptionById(id int) (err error) {
 	return redemption.Delete()
 }
 
+// InsertRedemptionBatch 批量写入同一批次的兑换码，任一写入失败时整批回滚
+func InsertRedemptionBatch(redemptions []*Redemption) error {
+	return DB.CreateInBatches(redemptions, 500).Error
+}
+
+func GetRedemptionsByBatchId(batchId string) ([]*Redemption, error) {
+	var redemptions []*Redemption
+	err := DB.Where("batch_id = ?", batchId).Order("id asc").Find(&redemptions).Error
+	return redemptions, err
+}
+
+// VoidRedemptionBatch 作废批次中仍可兑换的兑换码（包括尚未用完的多次使用兑换码），返回作废的数量
+func VoidRedemptionBatch(batchId string) (int64, error) {
+	result := DB.Model(&Redemption{}).
+		Where("batch_id = ? AND status = ?", batchId, config.RedemptionCodeStatusEnabled).
+		Update("status", config.RedemptionCodeStatusDisabled)
+	return result.RowsAffected, result.Error
+}
+
 type RedemptionStatistics struct {
 	Count  int64 `json:"count"`
 	Quota  int64 `json:"quota"`
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestUseRedemption(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&Redemption{}); err != nil {
+		t.Fatal(err)
+	}
+
+	redemption := &Redemption{Key: "test", Status: config.RedemptionCodeStatusEnabled, Quota: 100, MaxUses: 2}
+	if err = db.Create(redemption).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 使用读取时的旧数据兑换，次数以数据库中的值为准
+	stale := *redemption
+	for i := 0; i < 2; i++ {
+		current := stale
+		if err = useRedemption(db, &current, 1000); err != nil {
+			t.Fatalf("use %d: %v", i+1, err)
+		}
+	}
+
+	stale.UsedCount = 0
+	if err = useRedemption(db, &stale, 1000); err == nil {
+		t.Fatal("redemption should not be used more than max uses")
+	}
+
+	if err = db.First(redemption, redemption.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+	if redemption.UsedCount != 2 || redemption.Status != config.RedemptionCodeStatusUsed || redemption.RedeemedTime != 1000 {
+		t.Fatalf("unexpected redemption: %+v", redemption)
+	}
+}
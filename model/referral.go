@@ -0,0 +1,185 @@
+package model
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	InviteRewardTriggerSignup     = "signup"      // 注册时发放邀请奖励
+	InviteRewardTriggerFirstTopup = "first_topup" // 被邀请人首次充值时发放邀请奖励
+)
+
+// Referral 邀请关系记录，每个被邀请人一条，用于统计邀请转化与奖励发放
+type Referral struct {
+	Id              int   `json:"id"`
+	InviterId       int   `json:"inviter_id" gorm:"index"`
+	InviteeId       int   `json:"invitee_id" gorm:"uniqueIndex"`
+	RegisteredTime  int64 `json:"registered_time" gorm:"bigint;index"`
+	FirstTopupTime  int64 `json:"first_topup_time" gorm:"bigint;default:0"` // 0 表示尚未充值
+	FirstTopupQuota int   `json:"first_topup_quota" gorm:"default:0"`
+	TopupQuota      int   `json:"topup_quota" gorm:"default:0"`        // 被邀请人累计充值额度
+	RewardGranted   bool  `json:"reward_granted" gorm:"default:false"` // 邀请奖励是否已发放
+	InviterReward   int   `json:"inviter_reward" gorm:"default:0"`     // 邀请人累计获得的奖励，包括邀请奖励与充值返利
+	InviteeReward   int   `json:"invitee_reward" gorm:"default:0"`
+}
+
+func IsValidInviteRewardTrigger(trigger string) bool {
+	return trigger == InviteRewardTriggerSignup || trigger == InviteRewardTriggerFirstTopup
+}
+
+// createReferralWithTx 记录新用户的邀请关系，邀请奖励按 InviteRewardTrigger 在注册时发放或推迟到首次充值
+func createReferralWithTx(tx *gorm.DB, invitee *User, inviterId int) error {
+	referral := &Referral{
+		InviterId:      inviterId,
+		InviteeId:      invitee.Id,
+		RegisteredTime: invitee.CreatedTime,
+	}
+
+	if config.InviteRewardTrigger != InviteRewardTriggerFirstTopup {
+		grantInviteRewardWithTx(tx, referral)
+	}
+
+	return tx.Create(referral).Error
+}
+
+// grantInviteRewardWithTx 给邀请双方发放邀请奖励，并记录到邀请关系中
+func grantInviteRewardWithTx(tx *gorm.DB, referral *Referral) {
+	referral.RewardGranted = true
+
+	if config.QuotaForInvitee > 0 {
+		_ = IncreaseUserQuotaWithTx(tx, referral.InviteeId, config.QuotaForInvitee)
+		RecordLogWithTx(tx, referral.InviteeId, LogTypeSystem, fmt.Sprintf("使用邀请码赠送 %s", common.LogQuota(config.QuotaForInvitee)))
+		referral.InviteeReward += config.QuotaForInvitee
+	}
+	// 注册时的邀请奖励保持原有逻辑，充值时的返利使用新的配置
+	if config.QuotaForInviter > 0 {
+		_ = IncreaseUserQuotaWithTx(tx, referral.InviterId, config.QuotaForInviter)
+		RecordLogWithTx(tx, referral.InviterId, LogTypeSystem, fmt.Sprintf("邀请用户赠送 %s", common.LogQuota(config.QuotaForInviter)))
+		referral.InviterReward += config.QuotaForInviter
+	}
+}
+
+// recordReferralTopup 记录被邀请人的在线支付充值，邀请奖励推迟到首次充值发放时在此补发
+func recordReferralTopup(inviterId, inviteeId, rechargeQuota int) {
+	granted := false
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		referral := &Referral{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("invitee_id = ?", inviteeId).First(referral).Error
+		if err == gorm.ErrRecordNotFound {
+			// 早于邀请记录上线的用户，注册时已经按旧逻辑发放过奖励
+			referral = &Referral{
+				InviterId:      inviterId,
+				InviteeId:      inviteeId,
+				RegisteredTime: utils.GetTimestamp(),
+				RewardGranted:  true,
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if referral.FirstTopupTime == 0 {
+			referral.FirstTopupTime = utils.GetTimestamp()
+			referral.FirstTopupQuota = rechargeQuota
+		}
+		referral.TopupQuota += rechargeQuota
+
+		if !referral.RewardGranted {
+			grantInviteRewardWithTx(tx, referral)
+			granted = true
+		}
+
+		return tx.Save(referral).Error
+	})
+	if err != nil {
+		logger.SysError("failed to record referral topup: " + err.Error())
+		return
+	}
+
+	if granted {
+		CacheUpdateUserQuota(inviterId)
+		CacheUpdateUserQuota(inviteeId)
+	}
+}
+
+// addReferralRebate 累计邀请人从该被邀请人获得的充值返利
+func addReferralRebate(inviteeId, rebateQuota int) {
+	err := DB.Model(&Referral{}).Where("invitee_id = ?", inviteeId).
+		Update("inviter_reward", gorm.Expr("inviter_reward + ?", rebateQuota)).Error
+	if err != nil {
+		logger.SysError("failed to update referral rebate: " + err.Error())
+	}
+}
+
+// ReferralReport 邀请转化报表中单个邀请人的汇总数据
+type ReferralReport struct {
+	InviterId      int     `json:"inviter_id"`
+	Username       string  `json:"username"`
+	Invitees       int64   `json:"invitees"`
+	Converted      int64   `json:"converted"`
+	ConversionRate float64 `json:"conversion_rate"`
+	TopupQuota     int64   `json:"topup_quota"`
+	InviterReward  int64   `json:"inviter_reward"`
+	InviteeReward  int64   `json:"invitee_reward"`
+	PendingRewards int64   `json:"pending_rewards"` // 尚未发放邀请奖励的被邀请人数
+}
+
+// GetReferralReport 按邀请人汇总注册时间在 [start, end] 内的邀请转化情况，按转化人数倒序
+func GetReferralReport(startTimestamp, endTimestamp int64, limit int) ([]*ReferralReport, error) {
+	var reports []*ReferralReport
+	db := DB.Model(&Referral{}).Select(
+		"inviter_id",
+		"count(*) as invitees",
+		"sum(case when first_topup_time > 0 then 1 else 0 end) as converted",
+		"sum(topup_quota) as topup_quota",
+		"sum(inviter_reward) as inviter_reward",
+		"sum(invitee_reward) as invitee_reward",
+		"sum(case when reward_granted then 0 else 1 end) as pending_rewards",
+	)
+	if startTimestamp > 0 {
+		db = db.Where("registered_time >= ?", startTimestamp)
+	}
+	if endTimestamp > 0 {
+		db = db.Where("registered_time <= ?", endTimestamp)
+	}
+
+	err := db.Group("inviter_id").Order("converted desc").Order("invitees desc").Limit(limit).Scan(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	inviterIds := make([]int, 0, len(reports))
+	for _, report := range reports {
+		inviterIds = append(inviterIds, report.InviterId)
+		if report.Invitees > 0 {
+			report.ConversionRate = float64(report.Converted) / float64(report.Invitees)
+		}
+	}
+
+	var users []*User
+	if len(inviterIds) > 0 {
+		DB.Unscoped().Select("id", "username").Where("id IN ?", inviterIds).Find(&users)
+	}
+	usernames := make(map[int]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+	for _, report := range reports {
+		report.Username = usernames[report.InviterId]
+	}
+
+	return reports, nil
+}
+
+// GetUserReferrals 查询邀请人的邀请记录
+func GetUserReferrals(inviterId int) ([]*Referral, error) {
+	var referrals []*Referral
+	err := DB.Where("inviter_id = ?", inviterId).Order("id desc").Find(&referrals).Error
+	return referrals, err
+}
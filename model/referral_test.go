@@ -0,0 +1,78 @@
+package model
+
+import (
+	"done-hub/common/config"
+	commonlogger "done-hub/common/logger"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestProcessInviterRewardPaidOnly 测试只有在线支付计入首次充值并发放推迟的邀请奖励
+func TestProcessInviterRewardPaidOnly(t *testing.T) {
+	commonlogger.Logger = zap.NewNop()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&User{}, &Referral{}, &Log{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB := DB
+	trigger, forInviter, forInvitee := config.InviteRewardTrigger, config.QuotaForInviter, config.QuotaForInvitee
+	rewardType, rewardValue := config.InviterRewardType, config.InviterRewardValue
+	t.Cleanup(func() {
+		DB = originDB
+		config.InviteRewardTrigger, config.QuotaForInviter, config.QuotaForInvitee = trigger, forInviter, forInvitee
+		config.InviterRewardType, config.InviterRewardValue = rewardType, rewardValue
+	})
+	DB = db
+	config.InviteRewardTrigger = InviteRewardTriggerFirstTopup
+	config.QuotaForInviter = 100
+	config.QuotaForInvitee = 50
+	config.InviterRewardType = ""
+	config.InviterRewardValue = 0
+
+	session := db.Session(&gorm.Session{SkipHooks: true})
+	for _, user := range []*User{{Id: 1}, {Id: 2, InviterId: 1}} {
+		user.Username = fmt.Sprintf("user%d", user.Id)
+		user.AccessToken = fmt.Sprintf("access%d", user.Id)
+		user.AffCode = fmt.Sprintf("aff%d", user.Id)
+		if err = session.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = db.Create(&Referral{InviterId: 1, InviteeId: 2}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 兑换码充值不计入首次充值
+	if err = ProcessInviterReward(2, 1000, "", false); err != nil {
+		t.Fatal(err)
+	}
+	referral := &Referral{}
+	db.Where("invitee_id = ?", 2).First(referral)
+	if referral.FirstTopupTime != 0 || referral.TopupQuota != 0 || referral.RewardGranted {
+		t.Fatalf("redemption should not count as a first topup: %+v", referral)
+	}
+
+	if err = ProcessInviterReward(2, 2000, "", true); err != nil {
+		t.Fatal(err)
+	}
+	db.Where("invitee_id = ?", 2).First(referral)
+	if referral.FirstTopupQuota != 2000 || referral.TopupQuota != 2000 || !referral.RewardGranted {
+		t.Fatalf("paid order should count as the first topup: %+v", referral)
+	}
+
+	var quotas []int
+	db.Model(&User{}).Order("id").Pluck("quota", &quotas)
+	if len(quotas) != 2 || quotas[0] != 100 || quotas[1] != 50 {
+		t.Fatalf("invite rewards should be granted once, got %v", quotas)
+	}
+}
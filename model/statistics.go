@@ -2,6 +2,7 @@ package model
 
 import (
 	"done-hub/common"
+	"done-hub/common/utils"
 	"fmt"
 	"os"
 	"strings"
@@ -207,14 +208,12 @@ const (
 	StatisticsUpdateTypeALL       StatisticsUpdateType = 3
 )
 
-func UpdateStatistics(updateType StatisticsUpdateType) error {
-	sql := `
-	%s statistics (date, user_id, channel_id, model_name, request_count, quota, prompt_tokens, completion_tokens, request_time)
+// statisticsUpdateSQL 从日志表汇总数据写入统计表，groupColumns 为统计表的主键（不含 date）
+const statisticsUpdateSQL = `
+	%s %s (date, %s, request_count, quota, prompt_tokens, completion_tokens, request_time)
 	SELECT 
 		%s as date,
-		user_id,
-		channel_id,
-		model_name, 
+		%s,
 		count(1) as request_count,
 		sum(quota) as quota,
 		sum(prompt_tokens) as prompt_tokens,
@@ -224,23 +223,32 @@ func UpdateStatistics(updateType StatisticsUpdateType) error {
 	WHERE
 		type = 2
 		%s
-	GROUP BY date, channel_id, user_id, model_name
+	GROUP BY date, %s
 	ORDER BY date, model_name
 	%s
 	`
 
+func UpdateStatistics(updateType StatisticsUpdateType) error {
+	err := updateStatisticsTable("statistics", "user_id, channel_id, model_name", updateType)
+	if err != nil {
+		return err
+	}
+	return UpdateTokenStatistics(updateType)
+}
+
+// UpdateTokenStatistics 按令牌汇总每日用量
+func UpdateTokenStatistics(updateType StatisticsUpdateType) error {
+	return updateStatisticsTable("token_statistics", "user_id, token_id, model_name", updateType)
+}
+
+func updateStatisticsTable(table, groupColumns string, updateType StatisticsUpdateType) error {
 	sqlPrefix := ""
 	sqlWhere := ""
 	sqlDate := ""
 	sqlSuffix := ""
 
 	// 统一获取时区信息
-	location := time.Local
-	if tzEnv := os.Getenv("TZ"); tzEnv != "" {
-		if loc, err := time.LoadLocation(tzEnv); err == nil {
-			location = loc
-		}
-	}
+	location := utils.GetLocalLocation()
 	now := time.Now().In(location)
 	_, offsetSeconds := now.Zone()
 
@@ -273,7 +281,7 @@ func UpdateStatistics(updateType StatisticsUpdateType) error {
 			tzName = tzEnv
 		}
 		sqlDate = fmt.Sprintf("DATE_TRUNC('day', TO_TIMESTAMP(created_at) AT TIME ZONE '%s')::DATE", tzName)
-		sqlSuffix = `ON CONFLICT (date, user_id, channel_id, model_name) DO UPDATE SET
+		sqlSuffix = `ON CONFLICT (date, ` + groupColumns + `) DO UPDATE SET
 		request_count = EXCLUDED.request_count,
 		quota = EXCLUDED.quota,
 		prompt_tokens = EXCLUDED.prompt_tokens,
@@ -315,6 +323,5 @@ func UpdateStatistics(updateType StatisticsUpdateType) error {
 		sqlWhere = fmt.Sprintf("AND created_at >= %d AND created_at < %d", yesterdayTimestamp, todayTimestamp)
 	}
 
-	err := DB.Exec(fmt.Sprintf(sql, sqlPrefix, sqlDate, sqlWhere, sqlSuffix)).Error
-	return err
+	return DB.Exec(fmt.Sprintf(statisticsUpdateSQL, sqlPrefix, table, groupColumns, sqlDate, groupColumns, sqlWhere, groupColumns, sqlSuffix)).Error
 }
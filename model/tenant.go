@@ -0,0 +1,276 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/utils"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTenantId 未开启多租户或未匹配到租户时使用的默认租户
+const DefaultTenantId = 0
+
+const (
+	TenantStatusEnabled  = 1
+	TenantStatusDisabled = 2
+)
+
+// Tenant 租户，每个租户拥有独立的用户、渠道和令牌
+// 请求通过域名或 X-Tenant 请求头选择租户
+type Tenant struct {
+	Id          int    `json:"id"`
+	Code        string `json:"code" gorm:"type:varchar(32);uniqueIndex"`
+	Name        string `json:"name" gorm:"type:varchar(64)"`
+	Domains     string `json:"domains" gorm:"type:varchar(1024);default:''"` // 绑定的域名，多个用逗号分隔
+	Status      int    `json:"status" gorm:"type:int;default:1"`
+	Remark      string `json:"remark" gorm:"type:varchar(255);default:''"`
+	CreatedTime int64  `json:"created_time" gorm:"bigint"`
+}
+
+type SearchTenantParams struct {
+	Tenant
+	PaginationParams
+}
+
+var allowedTenantOrderFields = map[string]bool{
+	"id":           true,
+	"code":         true,
+	"name":         true,
+	"status":       true,
+	"created_time": true,
+}
+
+func GetTenantsList(params *SearchTenantParams) (*DataResult[Tenant], error) {
+	var tenants []*Tenant
+	db := DB
+
+	if params.Name != "" {
+		db = db.Where("name LIKE ?", params.Name+"%")
+	}
+
+	if params.Code != "" {
+		db = db.Where("code = ?", params.Code)
+	}
+
+	if params.Status != 0 {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	return PaginateAndOrder(db, &params.PaginationParams, &tenants, allowedTenantOrderFields)
+}
+
+func GetTenantById(id int) (*Tenant, error) {
+	var tenant Tenant
+	err := DB.Where("id = ?", id).First(&tenant).Error
+	return &tenant, err
+}
+
+func (t *Tenant) validate() error {
+	t.Code = strings.TrimSpace(t.Code)
+	if t.Code == "" {
+		return errors.New("租户标识不能为空")
+	}
+	if t.Status == 0 {
+		t.Status = TenantStatusEnabled
+	}
+	t.Domains = strings.Join(splitTenantDomains(t.Domains), ",")
+	return nil
+}
+
+func (t *Tenant) Insert() error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+	t.CreatedTime = utils.GetTimestamp()
+
+	err := DB.Create(t).Error
+	if err == nil {
+		GlobalTenants.Load()
+	}
+	return err
+}
+
+func (t *Tenant) Update() error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	err := DB.Select("code", "name", "domains", "status", "remark").Updates(t).Error
+	if err == nil {
+		GlobalTenants.Load()
+	}
+	return err
+}
+
+// Delete 删除租户，租户下仍有用户或渠道时不允许删除
+func (t *Tenant) Delete() error {
+	var count int64
+	DB.Model(&User{}).Where("tenant_id = ?", t.Id).Count(&count)
+	if count > 0 {
+		return errors.New("租户下仍有用户，无法删除")
+	}
+	DB.Model(&Channel{}).Where("tenant_id = ?", t.Id).Count(&count)
+	if count > 0 {
+		return errors.New("租户下仍有渠道，无法删除")
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ?", t.Id).Delete(&TenantPrice{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(t).Error
+	})
+	if err == nil {
+		GlobalTenants.Load()
+	}
+	return err
+}
+
+func splitTenantDomains(domains string) []string {
+	result := make([]string, 0)
+	for _, domain := range strings.Split(domains, ",") {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" {
+			result = append(result, domain)
+		}
+	}
+	return result
+}
+
+// TenantCache 租户的内存缓存，按标识和域名查找
+type TenantCache struct {
+	sync.RWMutex
+	byId     map[int]*Tenant
+	byCode   map[string]*Tenant
+	byDomain map[string]*Tenant
+	prices   map[int]map[string]*TenantPrice
+}
+
+var GlobalTenants = TenantCache{}
+
+func (tc *TenantCache) Load() {
+	if !config.MultiTenantEnabled {
+		return
+	}
+
+	var tenants []*Tenant
+	if err := DB.Find(&tenants).Error; err != nil {
+		return
+	}
+
+	var tenantPrices []*TenantPrice
+	if err := DB.Find(&tenantPrices).Error; err != nil {
+		return
+	}
+
+	byId := make(map[int]*Tenant, len(tenants))
+	byCode := make(map[string]*Tenant, len(tenants))
+	byDomain := make(map[string]*Tenant)
+	for _, tenant := range tenants {
+		byId[tenant.Id] = tenant
+		byCode[tenant.Code] = tenant
+		for _, domain := range splitTenantDomains(tenant.Domains) {
+			byDomain[domain] = tenant
+		}
+	}
+
+	prices := make(map[int]map[string]*TenantPrice)
+	for _, price := range tenantPrices {
+		if prices[price.TenantId] == nil {
+			prices[price.TenantId] = make(map[string]*TenantPrice)
+		}
+		prices[price.TenantId][price.Model] = price
+	}
+
+	tc.Lock()
+	defer tc.Unlock()
+	tc.byId = byId
+	tc.byCode = byCode
+	tc.byDomain = byDomain
+	tc.prices = prices
+}
+
+func (tc *TenantCache) GetById(id int) *Tenant {
+	tc.RLock()
+	defer tc.RUnlock()
+	return tc.byId[id]
+}
+
+func (tc *TenantCache) GetByCode(code string) *Tenant {
+	tc.RLock()
+	defer tc.RUnlock()
+	return tc.byCode[code]
+}
+
+func (tc *TenantCache) GetPrice(tenantId int, modelName string) *TenantPrice {
+	tc.RLock()
+	defer tc.RUnlock()
+	return tc.prices[tenantId][modelName]
+}
+
+// GetServerAddress 返回租户的访问地址：使用绑定的第一个域名，协议与 ServerAddress 相同；
+// 未开启多租户或租户未绑定域名时返回 ServerAddress
+func (tc *TenantCache) GetServerAddress(tenantId int) string {
+	serverAddress := strings.TrimSuffix(config.ServerAddress, "/")
+	if !config.MultiTenantEnabled {
+		return serverAddress
+	}
+
+	tenant := tc.GetById(tenantId)
+	if tenant == nil {
+		return serverAddress
+	}
+	domains := splitTenantDomains(tenant.Domains)
+	if len(domains) == 0 {
+		return serverAddress
+	}
+
+	scheme := "https"
+	if parsed, err := url.Parse(serverAddress); err == nil && parsed.Scheme != "" {
+		scheme = parsed.Scheme
+	}
+	return scheme + "://" + domains[0]
+}
+
+// GetByHost 根据请求的 Host 查找租户，忽略端口
+func (tc *TenantCache) GetByHost(host string) *Tenant {
+	host = strings.ToLower(host)
+	if index := strings.LastIndex(host, ":"); index != -1 && !strings.HasSuffix(host, "]") {
+		host = host[:index]
+	}
+
+	tc.RLock()
+	defer tc.RUnlock()
+	return tc.byDomain[host]
+}
+
+// ScopeTenant 按租户过滤查询，tenantId 小于 0 时不过滤
+func ScopeTenant(tenantId int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !config.MultiTenantEnabled || tenantId < 0 {
+			return db
+		}
+		return db.Where("tenant_id = ?", tenantId)
+	}
+}
+
+// TenantAccessible 管理范围是否包含指定租户，scope 小于 0 时可访问全部租户
+func TenantAccessible(scope, tenantId int) bool {
+	return !config.MultiTenantEnabled || scope < 0 || scope == tenantId
+}
+
+func GetUserTenantId(id int) (tenantId int, err error) {
+	err = DB.Model(&User{}).Where("id = ?", id).Select("tenant_id").Find(&tenantId).Error
+	return tenantId, err
+}
+
+// FilterTenant 只使用指定租户的渠道
+func FilterTenant(tenantId int) ChannelsFilterFunc {
+	return func(_ int, choice *ChannelChoice) bool {
+		return choice.Channel.TenantId != tenantId
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// TenantPrice 租户单独设置的模型价格，覆盖全局价格，未设置的模型按全局价格计费
+type TenantPrice struct {
+	Id       int     `json:"id"`
+	TenantId int     `json:"tenant_id" gorm:"uniqueIndex:idx_tenant_price_model"`
+	Model    string  `json:"model" gorm:"type:varchar(100);uniqueIndex:idx_tenant_price_model" binding:"required"`
+	Type     string  `json:"type" gorm:"default:'tokens'"`
+	Input    float64 `json:"input" gorm:"default:0" binding:"gte=0"`
+	Output   float64 `json:"output" gorm:"default:0" binding:"gte=0"`
+}
+
+func GetTenantPrices(tenantId int) ([]*TenantPrice, error) {
+	var prices []*TenantPrice
+	err := DB.Where("tenant_id = ?", tenantId).Order("model").Find(&prices).Error
+	return prices, err
+}
+
+// SaveTenantPrice 新增或更新租户的模型价格
+func SaveTenantPrice(price *TenantPrice) error {
+	price.Model = strings.TrimSpace(price.Model)
+	if price.Model == "" {
+		return errors.New("模型名称不能为空")
+	}
+	switch price.Type {
+	case "":
+		price.Type = TokensPriceType
+	case TokensPriceType, TimesPriceType, ImagesPriceType:
+	default:
+		return errors.New("无效的计费类型")
+	}
+
+	err := DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "model"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "input", "output"}),
+	}).Create(price).Error
+	if err == nil {
+		GlobalTenants.Load()
+	}
+	return err
+}
+
+func DeleteTenantPrice(tenantId int, modelName string) error {
+	err := DB.Where("tenant_id = ? AND model = ?", tenantId, modelName).Delete(&TenantPrice{}).Error
+	if err == nil {
+		GlobalTenants.Load()
+	}
+	return err
+}
+
+// GetTenantPrice 获取租户的模型价格，租户设置了该模型的价格时覆盖全局价格的计费类型与单价，
+// 其余字段（额外倍率等）沿用全局价格
+func GetTenantPrice(tenantId int, modelName string) *Price {
+	price := PricingInstance.GetPrice(modelName)
+	if !config.MultiTenantEnabled || tenantId == DefaultTenantId {
+		return price
+	}
+
+	tenantPrice := GlobalTenants.GetPrice(tenantId, modelName)
+	if tenantPrice == nil {
+		return price
+	}
+
+	override := *price
+	override.Type = tenantPrice.Type
+	override.Input = tenantPrice.Input
+	override.Output = tenantPrice.Output
+	return &override
+}
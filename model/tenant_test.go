@@ -0,0 +1,217 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTenantTestDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&Tenant{}, &TenantPrice{}, &User{}, &Channel{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originEnabled := DB, config.MultiTenantEnabled
+	t.Cleanup(func() {
+		DB, config.MultiTenantEnabled = originDB, originEnabled
+		GlobalTenants.Load()
+	})
+	DB = db
+	config.MultiTenantEnabled = true
+}
+
+func TestTenantAccessible(t *testing.T) {
+	originEnabled := config.MultiTenantEnabled
+	defer func() { config.MultiTenantEnabled = originEnabled }()
+
+	config.MultiTenantEnabled = true
+	tests := []struct {
+		scope, tenantId int
+		want            bool
+	}{
+		{-1, 0, true},
+		{-1, 2, true},
+		{2, 2, true},
+		{0, 0, true},
+		{1, 2, false},
+		{0, 2, false},
+	}
+	for _, tt := range tests {
+		if got := TenantAccessible(tt.scope, tt.tenantId); got != tt.want {
+			t.Errorf("TenantAccessible(%d, %d) = %v, want %v", tt.scope, tt.tenantId, got, tt.want)
+		}
+	}
+
+	// 未开启多租户时不限制
+	config.MultiTenantEnabled = false
+	if !TenantAccessible(1, 2) {
+		t.Error("all tenants should be accessible when multi tenant is disabled")
+	}
+}
+
+func TestTenantScopedQueries(t *testing.T) {
+	setupTenantTestDB(t)
+
+	users := []*User{
+		{Username: "root", AccessToken: "a", AffCode: "a", TenantId: 0},
+		{Username: "alice", AccessToken: "b", AffCode: "b", TenantId: 1},
+		{Username: "bob", AccessToken: "c", AffCode: "c", TenantId: 2},
+	}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	channels := []*Channel{
+		{Name: "default", TenantId: 0},
+		{Name: "tenant-1", TenantId: 1},
+	}
+	if err := DB.Create(&channels).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	params := &GenericParams{PaginationParams: PaginationParams{Page: 1, Size: 10}}
+	tests := []struct {
+		scope int
+		want  []string
+	}{
+		{-1, []string{"root", "alice", "bob"}},
+		{0, []string{"root"}},
+		{1, []string{"alice"}},
+	}
+	for _, tt := range tests {
+		result, err := GetUsersList(params, tt.scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make(map[string]bool)
+		for _, user := range *result.Data {
+			names[user.Username] = true
+		}
+		if len(names) != len(tt.want) {
+			t.Fatalf("scope %d: unexpected users %v", tt.scope, names)
+		}
+		for _, name := range tt.want {
+			if !names[name] {
+				t.Fatalf("scope %d: missing user %s", tt.scope, name)
+			}
+		}
+	}
+
+	channels = nil
+	if err := DB.Scopes(ScopeTenant(1)).Find(&channels).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 1 || channels[0].Name != "tenant-1" {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+
+	tenantId, err := GetUserTenantId(3)
+	if err != nil || tenantId != 2 {
+		t.Fatalf("unexpected tenant id %d: %v", tenantId, err)
+	}
+
+	// 未开启多租户时不过滤
+	config.MultiTenantEnabled = false
+	channels = nil
+	DB.Scopes(ScopeTenant(1)).Find(&channels)
+	if len(channels) != 2 {
+		t.Fatalf("scope should be ignored when multi tenant is disabled, got %d channels", len(channels))
+	}
+}
+
+func TestTenantScopedLogs(t *testing.T) {
+	setupTenantTestDB(t)
+	if err := DB.AutoMigrate(&Log{}); err != nil {
+		t.Fatal(err)
+	}
+
+	users := []*User{
+		{Id: 1, Username: "alice", AccessToken: "a", AffCode: "a", TenantId: 1},
+		{Id: 2, Username: "bob", AccessToken: "b", AffCode: "b", TenantId: 2},
+	}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	logs := []*Log{
+		{UserId: 1, Username: "alice", Type: LogTypeConsume, Quota: 100, CreatedAt: 1},
+		{UserId: 2, Username: "bob", Type: LogTypeConsume, Quota: 200, CreatedAt: 1},
+	}
+	if err := DB.Create(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		scope int
+		quota int
+		count int
+	}{
+		{-1, 300, 2},
+		{1, 100, 1},
+		{2, 200, 1},
+	}
+	for _, tt := range tests {
+		if quota := SumUsedQuota(0, 0, "", "", "", 0, tt.scope); quota != tt.quota {
+			t.Errorf("scope %d: expected quota %d, got %d", tt.scope, tt.quota, quota)
+		}
+		exported, err := GetAllLogsList(&LogsListParams{}, tt.scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(exported) != tt.count {
+			t.Errorf("scope %d: expected %d exported logs, got %d", tt.scope, tt.count, len(exported))
+		}
+	}
+}
+
+func TestGetTenantPrice(t *testing.T) {
+	setupTenantTestDB(t)
+
+	originPricing := PricingInstance
+	defer func() { PricingInstance = originPricing }()
+	PricingInstance = &Pricing{
+		Prices: map[string]*Price{
+			"gpt-4o": {Model: "gpt-4o", Type: TokensPriceType, Input: 2.5, Output: 10},
+		},
+	}
+
+	if err := DB.Create(&Tenant{Id: 1, Code: "t1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTenantPrice(&TenantPrice{TenantId: 1, Model: "gpt-4o", Input: 5, Output: 20}); err != nil {
+		t.Fatal(err)
+	}
+	// 重复保存时更新已有价格
+	if err := SaveTenantPrice(&TenantPrice{TenantId: 1, Model: "gpt-4o", Input: 4, Output: 16}); err != nil {
+		t.Fatal(err)
+	}
+
+	if price := GetTenantPrice(1, "gpt-4o"); price.Input != 4 || price.Output != 16 || price.Type != TokensPriceType {
+		t.Fatalf("unexpected tenant price: %+v", price)
+	}
+	if price := GetTenantPrice(2, "gpt-4o"); price.Input != 2.5 {
+		t.Fatalf("other tenants should use the global price: %+v", price)
+	}
+	if price := GetTenantPrice(DefaultTenantId, "gpt-4o"); price.Input != 2.5 {
+		t.Fatalf("default tenant should use the global price: %+v", price)
+	}
+	if PricingInstance.Prices["gpt-4o"].Input != 2.5 {
+		t.Fatal("tenant price should not modify the global price")
+	}
+
+	if err := DeleteTenantPrice(1, "gpt-4o"); err != nil {
+		t.Fatal(err)
+	}
+	if price := GetTenantPrice(1, "gpt-4o"); price.Input != 2.5 {
+		t.Fatalf("deleted tenant price should fall back to the global price: %+v", price)
+	}
+
+	if err := SaveTenantPrice(&TenantPrice{TenantId: 1, Model: "gpt-4o", Type: "unknown"}); err == nil {
+		t.Fatal("invalid price type should be rejected")
+	}
+}
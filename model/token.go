@@ -19,6 +19,7 @@ var (
 	ErrTokenExpired           = errors.New("令牌已过期")
 	ErrTokenQuotaExhausted    = errors.New("令牌额度已用尽")
 	ErrTokenStatusUnavailable = errors.New("令牌状态不可用")
+	ErrTokenPaused            = errors.New("账户长期未活跃，令牌已暂停，请登录后自动恢复")
 	ErrTokenInvalid           = errors.New("无效的令牌")
 	ErrTokenQuotaGet          = errors.New("获取令牌额度失败")
 )
@@ -64,8 +65,11 @@ func (token *Token) AfterCreate(tx *gorm.DB) (err error) {
 }
 
 type TokenSetting struct {
-	Heartbeat HeartbeatSetting `json:"heartbeat,omitempty"`
-	Limits    LimitsConfig     `json:"limits,omitempty"`
+	Heartbeat       HeartbeatSetting   `json:"heartbeat,omitempty"`
+	Limits          LimitsConfig       `json:"limits,omitempty"`
+	Compression     CompressionSetting `json:"compression,omitempty"`
+	ContentSafety   bool               `json:"content_safety,omitempty"`   // 令牌单独开启内容审查（不受审查分组限制）
+	ReasoningFormat string             `json:"reasoning_format,omitempty"` // 推理内容返回方式：passthrough（默认）/ strip / think
 }
 
 type HeartbeatSetting struct {
@@ -73,9 +77,21 @@ type HeartbeatSetting struct {
 	TimeoutSeconds int  `json:"timeout_seconds"`
 }
 
+// CompressionSetting 提示词压缩设置
+type CompressionSetting struct {
+	Enabled     bool    `json:"enabled"`
+	Mode        string  `json:"mode"`         // heuristic / summarize_oldest
+	TargetRatio float64 `json:"target_ratio"` // 压缩后 token 数占原始 token 数的目标比例
+	MinTokens   int     `json:"min_tokens"`   // 低于该 token 数时不压缩
+	KeepRecent  int     `json:"keep_recent"`  // 始终保留原样的最近消息条数
+}
+
 type LimitsConfig struct {
-	LimitModelSetting LimitModelSetting `json:"limit_model_setting,omitempty"`
-	LimitsIPSetting   LimitsIPSetting   `json:"limits_ip_setting,omitempty"`
+	LimitModelSetting    LimitModelSetting    `json:"limit_model_setting,omitempty"`
+	LimitsIPSetting      LimitsIPSetting      `json:"limits_ip_setting,omitempty"`
+	LimitMCPToolSetting  LimitMCPToolSetting  `json:"limit_mcp_tool_setting,omitempty"`
+	LimitsRegionSetting  LimitsRegionSetting  `json:"limits_region_setting,omitempty"`
+	LimitsRequestSetting LimitsRequestSetting `json:"limits_request_setting,omitempty"`
 }
 
 type LimitModelSetting struct {
@@ -88,6 +104,27 @@ type LimitsIPSetting struct {
 	Whitelist []string `json:"whitelist"`
 }
 
+// LimitsRegionSetting 令牌允许访问的地区，国家代码（如 CN）或行政区代码（如 US-CA）
+type LimitsRegionSetting struct {
+	Enabled bool     `json:"enabled"`
+	Regions []string `json:"regions"`
+}
+
+// LimitsRequestSetting 请求大小限制，与全局、分组限制同时生效，0 为不限制
+type LimitsRequestSetting struct {
+	Enabled         bool `json:"enabled"`
+	MaxBodySize     int  `json:"max_body_size"` // MB
+	MaxMessages     int  `json:"max_messages"`
+	MaxImageSize    int  `json:"max_image_size"` // MB
+	MaxOutputTokens int  `json:"max_output_tokens"`
+}
+
+// LimitMCPToolSetting 令牌可调用的 MCP 工具，开启后只能调用列表中的工具
+type LimitMCPToolSetting struct {
+	Enabled bool     `json:"enabled"`
+	Tools   []string `json:"tools"`
+}
+
 func GetUserTokensList(userId int, params *GenericParams) (*DataResult[Token], error) {
 	var tokens []*Token
 	db := DB.Where("user_id = ?", userId)
@@ -157,6 +194,8 @@ func ValidateUserToken(key string) (token *Token, err error) {
 			return nil, ErrTokenQuotaExhausted
 		case config.TokenStatusExpired:
 			return nil, ErrTokenExpired
+		case config.TokenStatusPaused:
+			return nil, ErrTokenPaused
 		default:
 			return nil, ErrTokenStatusUnavailable
 		}
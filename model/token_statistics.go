@@ -0,0 +1,142 @@
+package model
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"errors"
+	"time"
+)
+
+// TokenStatistics 按令牌、模型汇总的每日用量，由 UpdateStatistics 定时从日志表生成
+type TokenStatistics struct {
+	Date             time.Time `gorm:"primary_key;type:date" json:"date"`
+	UserId           int       `json:"user_id" gorm:"primary_key"`
+	TokenId          int       `json:"token_id" gorm:"primary_key"`
+	ModelName        string    `json:"model_name" gorm:"primary_key;type:varchar(255)"`
+	RequestCount     int       `json:"request_count"`
+	Quota            int       `json:"quota"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	RequestTime      int       `json:"request_time"`
+}
+
+const (
+	UsageGroupByDay   = "day"
+	UsageGroupByToken = "token"
+	UsageGroupByModel = "model"
+)
+
+type UsageQueryParams struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	GroupBy   string `form:"group_by"`
+	TokenId   int    `form:"token_id"`
+	ModelName string `form:"model_name"`
+}
+
+// UsageSummary 用户用量汇总，Date、TokenId、ModelName 只返回分组使用的字段，
+// 按令牌分组时 TokenName 为令牌当前的名称
+type UsageSummary struct {
+	Date             string  `gorm:"column:date" json:"date,omitempty"`
+	TokenId          *int    `gorm:"column:token_id" json:"token_id,omitempty"`
+	TokenName        string  `gorm:"-" json:"token_name,omitempty"`
+	ModelName        string  `gorm:"column:model_name" json:"model_name,omitempty"`
+	RequestCount     int64   `gorm:"column:request_count" json:"request_count"`
+	Quota            int64   `gorm:"column:quota" json:"quota"`
+	PromptTokens     int64   `gorm:"column:prompt_tokens" json:"prompt_tokens"`
+	CompletionTokens int64   `gorm:"column:completion_tokens" json:"completion_tokens"`
+	Cost             float64 `gorm:"-" json:"cost"`
+}
+
+// GetUserUsageSummary 查询用户在指定日期范围内的用量，按天、令牌或模型分组
+func GetUserUsageSummary(userId int, params *UsageQueryParams) ([]*UsageSummary, error) {
+	var groupColumn, selectColumn string
+	switch params.GroupBy {
+	case UsageGroupByDay:
+		groupColumn = "date"
+		if common.UsingPostgreSQL {
+			selectColumn = "TO_CHAR(date, 'YYYY-MM-DD') as date"
+		} else if common.UsingSQLite {
+			selectColumn = "strftime('%Y-%m-%d', date) as date"
+		} else {
+			selectColumn = "DATE_FORMAT(date, '%Y-%m-%d') as date"
+		}
+	case UsageGroupByToken:
+		groupColumn = "token_id"
+		selectColumn = "token_id"
+	case UsageGroupByModel:
+		groupColumn = "model_name"
+		selectColumn = "model_name"
+	default:
+		return nil, errors.New("group_by 只支持 day、token、model")
+	}
+
+	db := DB.Table("token_statistics").
+		Select(selectColumn+", sum(request_count) as request_count, sum(quota) as quota, sum(prompt_tokens) as prompt_tokens, sum(completion_tokens) as completion_tokens").
+		Where("user_id = ? AND date BETWEEN ? AND ?", userId, params.StartDate, params.EndDate)
+
+	if params.TokenId != 0 {
+		db = db.Where("token_id = ?", params.TokenId)
+	}
+	if params.ModelName != "" {
+		db = db.Where("model_name = ?", params.ModelName)
+	}
+
+	var summaries []*UsageSummary
+	err := db.Group(groupColumn).Order(groupColumn).Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summary := range summaries {
+		summary.Cost = float64(summary.Quota) / config.QuotaPerUnit
+	}
+
+	if params.GroupBy == UsageGroupByToken {
+		fillUsageTokenNames(summaries)
+	}
+	return summaries, nil
+}
+
+// fillUsageTokenNames 填充令牌当前的名称，已删除的令牌同样显示
+func fillUsageTokenNames(summaries []*UsageSummary) {
+	tokenIds := make([]int, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary.TokenId != nil {
+			tokenIds = append(tokenIds, *summary.TokenId)
+		}
+	}
+
+	names, err := getTokenNames(tokenIds)
+	if err != nil {
+		return
+	}
+	for _, summary := range summaries {
+		if summary.TokenId != nil {
+			summary.TokenName = names[*summary.TokenId]
+		}
+	}
+}
+
+// getTokenNames 按令牌 ID 查询令牌当前的名称，包括已删除的令牌
+func getTokenNames(tokenIds []int) (map[int]string, error) {
+	names := make(map[int]string, len(tokenIds))
+	ids := make([]int, 0, len(tokenIds))
+	for _, id := range tokenIds {
+		if id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	var tokens []*Token
+	if err := DB.Unscoped().Select("id, name").Where("id IN ?", ids).Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		names[token.Id] = token.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGetUserUsageSummary(t *testing.T) {
+	t.Setenv("TZ", "UTC")
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&Log{}, &Token{}, &TokenStatistics{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originSQLite, originQuotaPerUnit := DB, common.UsingSQLite, config.QuotaPerUnit
+	t.Cleanup(func() {
+		DB, common.UsingSQLite, config.QuotaPerUnit = originDB, originSQLite, originQuotaPerUnit
+	})
+	DB = db
+	common.UsingSQLite = true
+	config.QuotaPerUnit = 100
+
+	// 两个令牌同名，其中一个已删除
+	session := db.Session(&gorm.Session{SkipHooks: true})
+	tokens := []*Token{
+		{Id: 1, UserId: 1, Key: "key1", Name: "same"},
+		{Id: 2, UserId: 1, Key: "key2", Name: "same"},
+		{Id: 3, UserId: 2, Key: "key3", Name: "other"},
+	}
+	for _, token := range tokens {
+		if err = session.Create(token).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = db.Delete(&Token{}, 2).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	day1 := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC).Unix()
+	day2 := time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC).Unix()
+	logs := []*Log{
+		{Type: LogTypeConsume, UserId: 1, TokenId: 1, TokenName: "same", ModelName: "gpt-4o", Quota: 100, PromptTokens: 10, CompletionTokens: 5, CreatedAt: day1},
+		{Type: LogTypeConsume, UserId: 1, TokenId: 1, TokenName: "same", ModelName: "gpt-4o-mini", Quota: 50, PromptTokens: 4, CompletionTokens: 2, CreatedAt: day2},
+		{Type: LogTypeConsume, UserId: 1, TokenId: 2, TokenName: "same", ModelName: "gpt-4o", Quota: 200, PromptTokens: 20, CompletionTokens: 10, CreatedAt: day2},
+		{Type: LogTypeConsume, UserId: 2, TokenId: 3, TokenName: "other", ModelName: "gpt-4o", Quota: 1000, CreatedAt: day1},
+		{Type: LogTypeSystem, UserId: 1, TokenId: 1, ModelName: "gpt-4o", Quota: 1000, CreatedAt: day1},
+	}
+	for _, log := range logs {
+		if err = db.Create(log).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = UpdateTokenStatistics(StatisticsUpdateTypeALL); err != nil {
+		t.Fatal(err)
+	}
+
+	params := &UsageQueryParams{StartDate: "2026-10-01", EndDate: "2026-10-02", GroupBy: UsageGroupByToken}
+	summaries, err := GetUserUsageSummary(1, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("tokens with the same name should be counted separately, got %d rows", len(summaries))
+	}
+	if *summaries[0].TokenId != 1 || summaries[0].TokenName != "same" || summaries[0].Quota != 150 || summaries[0].RequestCount != 2 {
+		t.Fatalf("unexpected token usage: %+v", summaries[0])
+	}
+	if *summaries[1].TokenId != 2 || summaries[1].TokenName != "same" || summaries[1].Quota != 200 || summaries[1].Cost != 2 {
+		t.Fatalf("unexpected deleted token usage: %+v", summaries[1])
+	}
+
+	params.GroupBy = UsageGroupByDay
+	summaries, err = GetUserUsageSummary(1, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 || summaries[0].Date != "2026-10-01" || summaries[0].Quota != 100 || summaries[1].Quota != 250 || summaries[1].TokenId != nil {
+		t.Fatalf("unexpected daily usage: %+v %+v", summaries[0], summaries[1])
+	}
+
+	params.GroupBy = UsageGroupByModel
+	params.TokenId = 1
+	summaries, err = GetUserUsageSummary(1, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 || summaries[0].ModelName != "gpt-4o" || summaries[0].Quota != 100 || summaries[1].PromptTokens != 4 {
+		t.Fatalf("unexpected model usage: %+v", summaries)
+	}
+
+	params.GroupBy = "channel"
+	if _, err = GetUserUsageSummary(1, params); err == nil {
+		t.Fatal("unsupported group by should return an error")
+	}
+}
+
+func TestGetUsageAnomaliesByToken(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&Token{}, &User{}, &TokenStatistics{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB, originEnabled := DB, config.MultiTenantEnabled
+	t.Cleanup(func() {
+		DB, config.MultiTenantEnabled = originDB, originEnabled
+	})
+	DB = db
+	config.MultiTenantEnabled = false
+
+	session := db.Session(&gorm.Session{SkipHooks: true})
+	if err = session.Create(&User{Id: 1, Username: "alice", AccessToken: "access1", AffCode: "aff1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []*Token{{Id: 1, UserId: 1, Key: "key1", Name: "same"}, {Id: 2, UserId: 1, Key: "key2", Name: "same"}} {
+		if err = session.Create(token).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 与 UpdateTokenStatistics 一样以 YYYY-MM-DD 写入日期
+	stats := []struct {
+		date                     string
+		tokenId, quota, requests int
+		modelName                string
+	}{
+		{"2026-10-01", 1, 100, 1, "gpt-4o"},
+		{"2026-10-02", 1, 2000, 10, "gpt-4o"},
+		{"2026-10-02", 1, 1000, 5, "gpt-4o-mini"},
+		// 同名的另一个令牌消费平稳，不应与突增的令牌合并
+		{"2026-10-01", 2, 1000, 1, "gpt-4o"},
+		{"2026-10-02", 2, 1000, 1, "gpt-4o"},
+	}
+	for _, stat := range stats {
+		err = db.Exec("INSERT INTO token_statistics (date, user_id, token_id, model_name, quota, request_count) VALUES (?, 1, ?, ?, ?, ?)",
+			stat.date, stat.tokenId, stat.modelName, stat.quota, stat.requests).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	anomalies, err := GetUsageAnomalies(&UsageAnomalyParams{Date: "2026-10-02", Dimension: ConsumerDimensionToken, Ratio: 10, MinQuota: 500}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anomalies) != 1 {
+		t.Fatalf("expected 1 anomaly, got %d", len(anomalies))
+	}
+	anomaly := anomalies[0]
+	if anomaly.TokenId != 1 || anomaly.TokenName != "same" || anomaly.Username != "alice" {
+		t.Fatalf("unexpected anomaly: %+v", anomaly)
+	}
+	if anomaly.CurrentQuota != 3000 || anomaly.PreviousQuota != 100 || anomaly.CurrentRequests != 15 || anomaly.Ratio != 30 {
+		t.Fatalf("unexpected anomaly usage: %+v", anomaly)
+	}
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"done-hub/common/config"
+	"errors"
+	"sort"
+	"time"
+)
+
+type UsageAnomalyParams struct {
+	Date      string  `form:"date"`
+	Dimension string  `form:"dimension"`
+	Ratio     float64 `form:"ratio"`
+	MinQuota  int64   `form:"min_quota"`
+}
+
+// UsageAnomaly 消费环比异常，Ratio 为当日与前一日消费额之比，前一日没有消费时为 0
+type UsageAnomaly struct {
+	UserId           int     `json:"user_id,omitempty" gorm:"column:user_id"`
+	Username         string  `json:"username,omitempty" gorm:"-"`
+	TokenId          int     `json:"token_id,omitempty" gorm:"column:token_id"`
+	TokenName        string  `json:"token_name,omitempty" gorm:"-"`
+	ChannelId        int     `json:"channel_id,omitempty" gorm:"column:channel_id"`
+	ChannelName      string  `json:"channel_name,omitempty" gorm:"-"`
+	CurrentQuota     int64   `json:"current_quota" gorm:"column:current_quota"`
+	PreviousQuota    int64   `json:"previous_quota" gorm:"column:previous_quota"`
+	CurrentRequests  int64   `json:"current_requests" gorm:"column:current_requests"`
+	PreviousRequests int64   `json:"previous_requests" gorm:"column:previous_requests"`
+	Ratio            float64 `json:"ratio" gorm:"-"`
+}
+
+// GetUsageAnomalies 对比指定日期与前一日的统计数据，找出消费额突增的用户、令牌或渠道
+// 当日消费低于 MinQuota 的不参与判断，避免小额波动产生大量告警
+func GetUsageAnomalies(params *UsageAnomalyParams, tenantScope int) ([]*UsageAnomaly, error) {
+	var table, group string
+	switch params.Dimension {
+	case ConsumerDimensionUser, "":
+		table, group = "statistics", "user_id"
+	case ConsumerDimensionToken:
+		table, group = "token_statistics", "user_id, token_id"
+	case ConsumerDimensionChannel:
+		table, group = "statistics", "channel_id"
+	default:
+		return nil, errors.New("dimension 只支持 user、token、channel")
+	}
+
+	if params.Ratio <= 1 {
+		params.Ratio = config.UsageAnomalyRatio
+	}
+	if params.MinQuota <= 0 {
+		params.MinQuota = int64(config.UsageAnomalyMinQuota)
+	}
+
+	current, err := time.Parse("2006-01-02", params.Date)
+	if err != nil {
+		return nil, errors.New("date 格式错误，应为 YYYY-MM-DD")
+	}
+	currentDate := current.Format("2006-01-02")
+	previousDate := current.AddDate(0, 0, -1).Format("2006-01-02")
+
+	fields := group + `,
+		COALESCE(SUM(CASE WHEN date = ? THEN quota ELSE 0 END), 0) AS current_quota,
+		COALESCE(SUM(CASE WHEN date = ? THEN quota ELSE 0 END), 0) AS previous_quota,
+		COALESCE(SUM(CASE WHEN date = ? THEN request_count ELSE 0 END), 0) AS current_requests,
+		COALESCE(SUM(CASE WHEN date = ? THEN request_count ELSE 0 END), 0) AS previous_requests`
+
+	tx := DB.Table(table).
+		Select(fields, currentDate, previousDate, currentDate, previousDate).
+		Where("date BETWEEN ? AND ?", previousDate, currentDate)
+	if config.MultiTenantEnabled && tenantScope >= 0 {
+		tx = tx.Where("user_id IN (?)", DB.Model(&User{}).Select("id").Where("tenant_id = ?", tenantScope))
+	}
+
+	var rows []*UsageAnomaly
+	if err := tx.Group(group).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]*UsageAnomaly, 0)
+	for _, row := range rows {
+		if row.CurrentQuota < params.MinQuota {
+			continue
+		}
+		if row.PreviousQuota > 0 {
+			row.Ratio = float64(row.CurrentQuota) / float64(row.PreviousQuota)
+			if row.Ratio < params.Ratio {
+				continue
+			}
+		}
+		anomalies = append(anomalies, row)
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		return anomalies[i].CurrentQuota > anomalies[j].CurrentQuota
+	})
+
+	if err := fillAnomalyNames(anomalies, params.Dimension); err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+func fillAnomalyNames(anomalies []*UsageAnomaly, dimension string) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	if dimension == ConsumerDimensionChannel {
+		channelIds := make([]int, 0, len(anomalies))
+		for _, item := range anomalies {
+			channelIds = append(channelIds, item.ChannelId)
+		}
+		names, err := getChannelNames(channelIds)
+		if err != nil {
+			return err
+		}
+		for _, item := range anomalies {
+			item.ChannelName = names[item.ChannelId]
+		}
+		return nil
+	}
+
+	userIds := make([]int, 0, len(anomalies))
+	for _, item := range anomalies {
+		userIds = append(userIds, item.UserId)
+	}
+	var users []*User
+	if err := DB.Select("id, username").Where("id IN ?", userIds).Find(&users).Error; err != nil {
+		return err
+	}
+	names := make(map[int]string, len(users))
+	for _, user := range users {
+		names[user.Id] = user.Username
+	}
+	for _, item := range anomalies {
+		item.Username = names[item.UserId]
+	}
+
+	if dimension != ConsumerDimensionToken {
+		return nil
+	}
+	tokenIds := make([]int, 0, len(anomalies))
+	for _, item := range anomalies {
+		tokenIds = append(tokenIds, item.TokenId)
+	}
+	tokenNames, err := getTokenNames(tokenIds)
+	if err != nil {
+		return err
+	}
+	for _, item := range anomalies {
+		item.TokenName = tokenNames[item.TokenId]
+	}
+	return nil
+}
@@ -44,6 +44,7 @@ type User struct {
 	UsedQuota         int            `json:"used_quota" gorm:"type:int;default:0;column:used_quota"` // used quota
 	RequestCount      int            `json:"request_count" gorm:"type:int;default:0;"`               // request number
 	Group             string         `json:"group" gorm:"type:varchar(32);default:'default'"`
+	TenantId          int            `json:"tenant_id" gorm:"type:int;default:0;index"`
 	AffCode           string         `json:"aff_code" gorm:"type:varchar(32);column:aff_code;uniqueIndex"`
 	AffCount          int            `json:"aff_count" gorm:"type:int;default:0;column:aff_count"`
 	AffQuota          int            `json:"aff_quota" gorm:"type:int;default:0;column:aff_quota"`
@@ -51,6 +52,8 @@ type User struct {
 	InviterId         int            `json:"inviter_id" gorm:"type:int;column:inviter_id;index"`
 	LastLoginTime     int64          `json:"last_login_time" gorm:"bigint;default:0"`
 	LastLoginIp       string         `json:"last_login_ip" gorm:"type:varchar(128);default:''"`
+	InactiveFlaggedAt int64          `json:"inactive_flagged_at" gorm:"bigint;default:0"` // 被标记为不活跃的时间
+	InactivePausedAt  int64          `json:"inactive_paused_at" gorm:"bigint;default:0"`  // 因不活跃暂停令牌的时间
 	CreatedTime       int64          `json:"created_time" gorm:"bigint"`
 	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
 }
@@ -73,9 +76,9 @@ var allowedUserOrderFields = map[string]bool{
 	"last_login_ip":   true,
 }
 
-func GetUsersList(params *GenericParams) (*DataResult[User], error) {
+func GetUsersList(params *GenericParams, tenantScope int) (*DataResult[User], error) {
 	var users []*User
-	db := DB.Omit("password")
+	db := DB.Omit("password").Scopes(ScopeTenant(tenantScope))
 	if params.Keyword != "" {
 		groupCol := "`group`"
 		if common.UsingPostgreSQL {
@@ -162,15 +165,11 @@ func (user *User) Insert(inviterId int) error {
 		RecordLog(user.Id, LogTypeSystem, fmt.Sprintf("新用户注册赠送 %s", common.LogQuota(config.QuotaForNewUser)))
 	}
 	if inviterId != 0 {
-		if config.QuotaForInvitee > 0 {
-			_ = IncreaseUserQuota(user.Id, config.QuotaForInvitee)
-			RecordLog(user.Id, LogTypeSystem, fmt.Sprintf("使用邀请码赠送 %s", common.LogQuota(config.QuotaForInvitee)))
-		}
-		// 注册时的邀请奖励保持原有逻辑，充值时的返利使用新的配置
-		if config.QuotaForInviter > 0 {
-			_ = IncreaseUserQuota(inviterId, config.QuotaForInviter)
-			RecordLog(inviterId, LogTypeSystem, fmt.Sprintf("邀请用户赠送 %s", common.LogQuota(config.QuotaForInviter)))
+		if err := createReferralWithTx(DB, user, inviterId); err != nil {
+			return err
 		}
+		CacheUpdateUserQuota(user.Id)
+		CacheUpdateUserQuota(inviterId)
 	}
 	return nil
 }
@@ -209,14 +208,8 @@ func (user *User) InsertWithTx(tx *gorm.DB, inviterId int) error {
 		RecordLogWithTx(tx, user.Id, LogTypeSystem, fmt.Sprintf("新用户注册赠送 %s", common.LogQuota(config.QuotaForNewUser)))
 	}
 	if inviterId != 0 {
-		if config.QuotaForInvitee > 0 {
-			_ = IncreaseUserQuotaWithTx(tx, user.Id, config.QuotaForInvitee)
-			RecordLogWithTx(tx, user.Id, LogTypeSystem, fmt.Sprintf("使用邀请码赠送 %s", common.LogQuota(config.QuotaForInvitee)))
-		}
-		// 注册时的邀请奖励保持原有逻辑，充值时的返利使用新的配置
-		if config.QuotaForInviter > 0 {
-			_ = IncreaseUserQuotaWithTx(tx, inviterId, config.QuotaForInviter)
-			RecordLogWithTx(tx, inviterId, LogTypeSystem, fmt.Sprintf("邀请用户赠送 %s", common.LogQuota(config.QuotaForInviter)))
+		if err := createReferralWithTx(tx, user, inviterId); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -278,6 +271,11 @@ func ClearUserGroupAndTokensCache(userId int) {
 		logger.SysError(fmt.Sprintf("清理用户分组缓存失败 userId=%d: %v", userId, err))
 	}
 
+	// 清理用户租户缓存
+	userTenantKey := fmt.Sprintf(UserTenantCacheKey, userId)
+	redis.RedisDel(userTenantKey)
+	cache.DeleteCache(userTenantKey)
+
 	// 获取用户所有Token的Key
 	var tokenKeys []string
 	err := DB.Model(&Token{}).Where("user_id = ?", userId).Pluck("key", &tokenKeys).Error
@@ -742,8 +740,9 @@ func ChangeUserQuota(id int, quota int, isRecharge bool) (err error) {
 	return nil
 }
 
-// ProcessInviterReward 处理邀请人的充值返利
-func ProcessInviterReward(userId int, rechargeQuota int, ip string) error {
+// ProcessInviterReward 处理邀请人的充值返利，paid 表示在线支付的订单，
+// 只有在线支付计入邀请转化与首次充值奖励，避免通过兑换码刷邀请奖励
+func ProcessInviterReward(userId int, rechargeQuota int, ip string, paid bool) error {
 	// 获取用户信息，查看是否有邀请人
 	user := &User{}
 	err := DB.Where("id = ?", userId).First(user).Error
@@ -756,6 +755,10 @@ func ProcessInviterReward(userId int, rechargeQuota int, ip string) error {
 		return nil
 	}
 
+	if paid {
+		recordReferralTopup(user.InviterId, userId, rechargeQuota)
+	}
+
 	// 如果奖励值为0或奖励类型为空，直接返回
 	if config.InviterRewardValue == 0 || config.InviterRewardType == "" {
 		return nil
@@ -794,6 +797,8 @@ func ProcessInviterReward(userId int, rechargeQuota int, ip string) error {
 		logger.SysError("failed to update inviter aff_quota: " + err.Error())
 	}
 
+	addReferralRebate(userId, rewardQuota)
+
 	// 记录日志
 	RecordLog(user.InviterId, LogTypeSystem, logMessage)
 
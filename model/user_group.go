@@ -3,8 +3,6 @@ package model
 import (
 	"done-hub/common/config"
 	"done-hub/common/limit"
-	"done-hub/common/logger"
-	"fmt"
 	"sync"
 )
 
@@ -19,6 +17,13 @@ type UserGroup struct {
 	Min       int     `json:"min" form:"min" gorm:"default:0"`                 // 晋级条件最小值
 	Max       int     `json:"max" form:"max" gorm:"default:0"`                 // 晋级条件最大值
 	Enable    *bool   `json:"enable" form:"enable" gorm:"default:true"`        // 是否启用
+	// 允许访问的地区，多个用逗号分隔，为空时不限制
+	AllowedRegions string `json:"allowed_regions" form:"allowed_regions" gorm:"type:varchar(255);default:''"`
+	// 请求大小限制，0 为不限制
+	MaxBodySize     int `json:"max_body_size" form:"max_body_size" gorm:"default:0"` // MB
+	MaxMessages     int `json:"max_messages" form:"max_messages" gorm:"default:0"`
+	MaxImageSize    int `json:"max_image_size" form:"max_image_size" gorm:"default:0"` // MB
+	MaxOutputTokens int `json:"max_output_tokens" form:"max_output_tokens" gorm:"default:0"`
 }
 
 type SearchUserGroupParams struct {
@@ -79,7 +84,7 @@ func (c *UserGroup) Create() error {
 }
 
 func (c *UserGroup) Update() error {
-	err := DB.Select("name", "ratio", "public", "api_rate", "promotion", "min", "max").Updates(c).Error
+	err := DB.Select("name", "ratio", "public", "api_rate", "promotion", "min", "max", "allowed_regions", "max_body_size", "max_messages", "max_image_size", "max_output_tokens").Updates(c).Error
 	if err == nil {
 		GlobalUserGroupRatio.Load()
 	}
@@ -220,7 +225,6 @@ func CheckAndUpgradeUserGroup(userId int, rechargeAmount int) error {
 
 	// Calculate cumulative recharge amount
 	cumulativeAmount := user.Quota + user.UsedQuota + rechargeAmount
-	logger.SysError(fmt.Sprintf("use:%f q:%f  cumulative:%f rechargeAmount:%f", (float64)(user.UsedQuota)/config.QuotaPerUnit, (float64)(user.Quota)/config.QuotaPerUnit, (float64)(cumulativeAmount)/config.QuotaPerUnit, rechargeAmount))
 	// Get all promotion-enabled user groups
 	var promotionGroups []*UserGroup
 	err = DB.Where("promotion = ? AND enable = ?", true, true).Find(&promotionGroups).Error
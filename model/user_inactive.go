@@ -0,0 +1,246 @@
+package model
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/common/redis"
+	"done-hub/common/stmp"
+	"done-hub/common/utils"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const inactiveUserBatchSize = 200
+
+// InactiveUserPolicyResult 一次不活跃账户检查的结果
+type InactiveUserPolicyResult struct {
+	Flagged        int `json:"flagged"`
+	Paused         int `json:"paused"`
+	Reactivated    int `json:"reactivated"`
+	ReclaimedQuota int `json:"reclaimed_quota"`
+}
+
+// RunInactiveUserPolicy 标记长期不活跃的账户并通知，宽限期过后暂停其令牌
+func RunInactiveUserPolicy() (*InactiveUserPolicyResult, error) {
+	result := &InactiveUserPolicyResult{}
+	if config.InactiveUserDays <= 0 {
+		return result, nil
+	}
+
+	now := utils.GetTimestamp()
+	exemptGroups := parseInactiveExemptGroups()
+
+	if err := flagInactiveUsers(now, exemptGroups, result); err != nil {
+		return result, err
+	}
+
+	if err := pauseInactiveUsers(now, exemptGroups, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func parseInactiveExemptGroups() map[string]bool {
+	groups := make(map[string]bool)
+	for _, group := range strings.Split(config.InactiveUserExemptGroups, ",") {
+		group = strings.TrimSpace(group)
+		if group != "" {
+			groups[group] = true
+		}
+	}
+	return groups
+}
+
+// inactiveUserQuery 不包含管理员和已禁用的用户
+func inactiveUserQuery() *gorm.DB {
+	return DB.Model(&User{}).
+		Select("id", "username", "display_name", "email", "role", "status", "quota", "aff_quota", "inviter_id", "last_login_time", "created_time", "inactive_flagged_at", "inactive_paused_at", quotePostgresField("group")).
+		Where("status = ? AND role < ?", config.UserStatusEnabled, config.RoleAdminUser)
+}
+
+// hasTokenAccessSince 判断用户在指定时间后是否使用过令牌
+func hasTokenAccessSince(userId int, since int64) bool {
+	var count int64
+	DB.Model(&Token{}).Where("user_id = ? AND accessed_time >= ?", userId, since).Count(&count)
+	return count > 0
+}
+
+func flagInactiveUsers(now int64, exemptGroups map[string]bool, result *InactiveUserPolicyResult) error {
+	cutoff := now - int64(config.InactiveUserDays)*86400
+	pauseAt := now + int64(config.InactiveUserGraceDays)*86400
+
+	lastId := 0
+	for {
+		var users []*User
+		err := inactiveUserQuery().
+			Where("id > ? AND inactive_flagged_at = 0 AND last_login_time < ? AND created_time < ?", lastId, cutoff, cutoff).
+			Order("id asc").
+			Limit(inactiveUserBatchSize).
+			Find(&users).Error
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		lastId = users[len(users)-1].Id
+
+		for _, user := range users {
+			if exemptGroups[user.Group] || hasTokenAccessSince(user.Id, cutoff) {
+				continue
+			}
+
+			err = DB.Model(&User{}).Where("id = ?", user.Id).Update("inactive_flagged_at", now).Error
+			if err != nil {
+				logger.SysError(fmt.Sprintf("flag inactive user %d failed: %s", user.Id, err.Error()))
+				continue
+			}
+			result.Flagged++
+
+			if user.Email == "" {
+				continue
+			}
+			userName := user.DisplayName
+			if userName == "" {
+				userName = user.Username
+			}
+			if err = stmp.SendInactiveAccountEmail(userName, user.Email, config.InactiveUserDays, pauseAt); err != nil {
+				logger.SysError(fmt.Sprintf("send inactive account email to user %d failed: %s", user.Id, err.Error()))
+			}
+		}
+	}
+}
+
+func pauseInactiveUsers(now int64, exemptGroups map[string]bool, result *InactiveUserPolicyResult) error {
+	graceCutoff := now - int64(config.InactiveUserGraceDays)*86400
+
+	lastId := 0
+	for {
+		var users []*User
+		err := inactiveUserQuery().
+			Where("id > ? AND inactive_flagged_at > 0 AND inactive_paused_at = 0", lastId).
+			Order("id asc").
+			Limit(inactiveUserBatchSize).
+			Find(&users).Error
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		lastId = users[len(users)-1].Id
+
+		for _, user := range users {
+			// 标记后重新活跃（或后来被加入豁免分组）的用户清除标记
+			if exemptGroups[user.Group] || user.LastLoginTime >= user.InactiveFlaggedAt || hasTokenAccessSince(user.Id, user.InactiveFlaggedAt) {
+				if err = ResumeInactiveUser(user.Id); err == nil {
+					result.Reactivated++
+				}
+				continue
+			}
+
+			if user.InactiveFlaggedAt > graceCutoff {
+				continue
+			}
+
+			reclaimed, err := pauseInactiveUser(user, now)
+			if err != nil {
+				logger.SysError(fmt.Sprintf("pause inactive user %d failed: %s", user.Id, err.Error()))
+				continue
+			}
+			result.Paused++
+			result.ReclaimedQuota += reclaimed
+		}
+	}
+}
+
+// pauseInactiveUser 暂停用户的全部可用令牌，按配置回收赠送额度
+func pauseInactiveUser(user *User, now int64) (reclaimed int, err error) {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Token{}).
+			Where("user_id = ? AND status = ?", user.Id, config.TokenStatusEnabled).
+			Update("status", config.TokenStatusPaused).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&User{}).Where("id = ?", user.Id).Update("inactive_paused_at", now).Error; err != nil {
+			return err
+		}
+
+		if !config.InactiveUserReclaimPromoQuota {
+			return nil
+		}
+
+		reclaimed = getReclaimablePromoQuota(tx, user)
+		if reclaimed == 0 && user.AffQuota == 0 {
+			return nil
+		}
+		return tx.Model(&User{}).Where("id = ?", user.Id).Updates(map[string]interface{}{
+			"quota":     gorm.Expr("quota - ?", reclaimed),
+			"aff_quota": 0,
+		}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ClearUserGroupAndTokensCache(user.Id)
+	if (reclaimed > 0 || user.AffQuota > 0) && config.RedisEnabled {
+		redis.RedisDel(fmt.Sprintf(UserQuotaCacheKey, user.Id))
+	}
+
+	content := fmt.Sprintf("账户超过 %d 天未活跃，令牌已自动暂停", config.InactiveUserDays)
+	if reclaimed > 0 || user.AffQuota > 0 {
+		content += fmt.Sprintf("，回收赠送额度 %s，清空待领取邀请奖励 %s", common.LogQuota(reclaimed), common.LogQuota(user.AffQuota))
+	}
+	RecordLog(user.Id, LogTypeSystem, content)
+
+	return reclaimed, nil
+}
+
+// getReclaimablePromoQuota 从未充值过的用户，剩余额度中不超过注册/邀请赠送部分的额度视为可回收
+func getReclaimablePromoQuota(tx *gorm.DB, user *User) int {
+	var count int64
+	tx.Model(&Order{}).Where("user_id = ? AND status = ?", user.Id, OrderStatusSuccess).Count(&count)
+	if count > 0 {
+		return 0
+	}
+	tx.Model(&Log{}).Where("user_id = ? AND type = ?", user.Id, LogTypeTopup).Count(&count)
+	if count > 0 {
+		return 0
+	}
+
+	promoQuota := config.QuotaForNewUser
+	if user.InviterId > 0 {
+		promoQuota += config.QuotaForInvitee
+	}
+
+	return max(min(user.Quota, promoQuota), 0)
+}
+
+// ResumeInactiveUser 用户重新活跃后清除标记并恢复被暂停的令牌
+func ResumeInactiveUser(userId int) error {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Token{}).
+			Where("user_id = ? AND status = ?", userId, config.TokenStatusPaused).
+			Update("status", config.TokenStatusEnabled).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+			"inactive_flagged_at": 0,
+			"inactive_paused_at":  0,
+		}).Error
+	})
+	if err != nil {
+		logger.SysError(fmt.Sprintf("resume inactive user %d failed: %s", userId, err.Error()))
+		return err
+	}
+
+	ClearUserGroupAndTokensCache(userId)
+	return nil
+}
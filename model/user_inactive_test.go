@@ -0,0 +1,204 @@
+package model
+
+import (
+	"done-hub/common/config"
+	commonlogger "done-hub/common/logger"
+	"done-hub/common/utils"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupInactiveUserTestDB(t *testing.T) *gorm.DB {
+	commonlogger.Logger = zap.NewNop()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&User{}, &Token{}, &Order{}, &Log{}); err != nil {
+		t.Fatal(err)
+	}
+
+	originDB := DB
+	days, graceDays, exemptGroups, reclaim := config.InactiveUserDays, config.InactiveUserGraceDays, config.InactiveUserExemptGroups, config.InactiveUserReclaimPromoQuota
+	quotaForNewUser, quotaForInvitee := config.QuotaForNewUser, config.QuotaForInvitee
+	t.Cleanup(func() {
+		DB = originDB
+		config.InactiveUserDays, config.InactiveUserGraceDays, config.InactiveUserExemptGroups, config.InactiveUserReclaimPromoQuota = days, graceDays, exemptGroups, reclaim
+		config.QuotaForNewUser, config.QuotaForInvitee = quotaForNewUser, quotaForInvitee
+	})
+	DB = db
+
+	config.InactiveUserDays = 90
+	config.InactiveUserGraceDays = 7
+	config.InactiveUserExemptGroups = "vip, svip"
+	config.InactiveUserReclaimPromoQuota = false
+	return db
+}
+
+func createInactiveTestUser(t *testing.T, db *gorm.DB, user *User, tokenAccessedTime int64) {
+	user.Username = fmt.Sprintf("user%d", user.Id)
+	user.AccessToken = fmt.Sprintf("access%d", user.Id)
+	user.AffCode = fmt.Sprintf("aff%d", user.Id)
+	if user.Group == "" {
+		user.Group = "default"
+	}
+	if user.Status == 0 {
+		user.Status = config.UserStatusEnabled
+	}
+	if user.Role == 0 {
+		user.Role = config.RoleCommonUser
+	}
+
+	session := db.Session(&gorm.Session{SkipHooks: true})
+	if err := session.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	token := &Token{UserId: user.Id, Key: fmt.Sprintf("key%d", user.Id), Status: config.TokenStatusEnabled, AccessedTime: tokenAccessedTime}
+	if err := session.Create(token).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getInactiveTestUser(t *testing.T, db *gorm.DB, id int) (*User, *Token) {
+	user := &User{}
+	if err := db.First(user, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	token := &Token{}
+	if err := db.Where("user_id = ?", id).First(token).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user, token
+}
+
+func TestRunInactiveUserPolicyFlag(t *testing.T) {
+	db := setupInactiveUserTestDB(t)
+
+	now := utils.GetTimestamp()
+	old := now - 100*86400
+	createInactiveTestUser(t, db, &User{Id: 1, LastLoginTime: old, CreatedTime: old}, old)
+	// 豁免分组
+	createInactiveTestUser(t, db, &User{Id: 2, Group: "svip", LastLoginTime: old, CreatedTime: old}, old)
+	// 近期使用过令牌
+	createInactiveTestUser(t, db, &User{Id: 3, LastLoginTime: old, CreatedTime: old}, now-86400)
+	// 近期登录过
+	createInactiveTestUser(t, db, &User{Id: 4, LastLoginTime: now - 86400, CreatedTime: old}, old)
+	// 新注册的用户
+	createInactiveTestUser(t, db, &User{Id: 5, CreatedTime: now - 86400}, 0)
+	// 管理员和已禁用的用户
+	createInactiveTestUser(t, db, &User{Id: 6, Role: config.RoleAdminUser, LastLoginTime: old, CreatedTime: old}, old)
+	createInactiveTestUser(t, db, &User{Id: 7, Status: config.UserStatusDisabled, LastLoginTime: old, CreatedTime: old}, old)
+
+	result, err := RunInactiveUserPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Flagged != 1 || result.Paused != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	for id := 1; id <= 7; id++ {
+		user, token := getInactiveTestUser(t, db, id)
+		if flagged := user.InactiveFlaggedAt > 0; flagged != (id == 1) {
+			t.Errorf("user %d flagged = %v", id, flagged)
+		}
+		if token.Status != config.TokenStatusEnabled {
+			t.Errorf("token of user %d should not be paused during grace period", id)
+		}
+	}
+
+	// 已标记的用户不会重复标记
+	result, err = RunInactiveUserPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Flagged != 0 {
+		t.Fatalf("flagged user should not be flagged again: %+v", result)
+	}
+
+	// 未开启时不做处理
+	config.InactiveUserDays = 0
+	db.Model(&User{}).Where("id = ?", 1).Update("inactive_flagged_at", now-8*86400)
+	if result, _ = RunInactiveUserPolicy(); result.Paused != 0 {
+		t.Fatalf("policy should be disabled when inactive days is 0: %+v", result)
+	}
+}
+
+func TestRunInactiveUserPolicyPause(t *testing.T) {
+	db := setupInactiveUserTestDB(t)
+	config.InactiveUserReclaimPromoQuota = true
+	config.QuotaForNewUser = 100
+	config.QuotaForInvitee = 50
+
+	now := utils.GetTimestamp()
+	old := now - 100*86400
+	expired := now - 8*86400
+	// 宽限期已过
+	createInactiveTestUser(t, db, &User{Id: 1, Quota: 500, AffQuota: 20, InviterId: 9, LastLoginTime: old, CreatedTime: old, InactiveFlaggedAt: expired}, old)
+	// 充值过的用户不回收额度
+	createInactiveTestUser(t, db, &User{Id: 2, Quota: 500, LastLoginTime: old, CreatedTime: old, InactiveFlaggedAt: expired}, old)
+	// 仍在宽限期内
+	createInactiveTestUser(t, db, &User{Id: 3, Quota: 500, LastLoginTime: old, CreatedTime: old, InactiveFlaggedAt: now - 86400}, old)
+	// 标记后重新登录
+	createInactiveTestUser(t, db, &User{Id: 4, LastLoginTime: now - 86400, CreatedTime: old, InactiveFlaggedAt: expired}, old)
+	if err := db.Create(&Order{UserId: 2, Status: OrderStatusSuccess}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := RunInactiveUserPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Paused != 2 || result.Reactivated != 1 || result.ReclaimedQuota != 150 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	user, token := getInactiveTestUser(t, db, 1)
+	if token.Status != config.TokenStatusPaused || user.InactivePausedAt == 0 {
+		t.Fatalf("user 1 should be paused: %+v", user)
+	}
+	if user.Quota != 350 || user.AffQuota != 0 {
+		t.Fatalf("promo quota of user 1 should be reclaimed, quota=%d aff_quota=%d", user.Quota, user.AffQuota)
+	}
+
+	user, token = getInactiveTestUser(t, db, 2)
+	if token.Status != config.TokenStatusPaused || user.Quota != 500 {
+		t.Fatalf("user 2 should be paused without reclaiming quota, quota=%d", user.Quota)
+	}
+
+	user, token = getInactiveTestUser(t, db, 3)
+	if token.Status != config.TokenStatusEnabled || user.InactivePausedAt != 0 {
+		t.Fatal("user 3 should not be paused during grace period")
+	}
+
+	user, _ = getInactiveTestUser(t, db, 4)
+	if user.InactiveFlaggedAt != 0 {
+		t.Fatal("reactivated user 4 should be unflagged")
+	}
+
+	var count int64
+	db.Model(&Log{}).Where("type = ?", LogTypeSystem).Count(&count)
+	if count != 2 {
+		t.Fatalf("expected 2 pause logs, got %d", count)
+	}
+
+	// 已暂停的用户不会重复处理
+	if result, _ = RunInactiveUserPolicy(); result.Paused != 0 {
+		t.Fatalf("paused user should not be paused again: %+v", result)
+	}
+
+	// 重新活跃后恢复被暂停的令牌
+	if err = ResumeInactiveUser(1); err != nil {
+		t.Fatal(err)
+	}
+	user, token = getInactiveTestUser(t, db, 1)
+	if token.Status != config.TokenStatusEnabled || user.InactiveFlaggedAt != 0 || user.InactivePausedAt != 0 {
+		t.Fatalf("user 1 should be resumed: %+v", user)
+	}
+}
@@ -37,6 +37,14 @@ func InitBatchUpdater() {
 	}()
 }
 
+// FlushBatchUpdate 退出前把内存中尚未落库的批量更新写入数据库
+func FlushBatchUpdate() {
+	if !config.BatchUpdateEnabled {
+		return
+	}
+	batchUpdate()
+}
+
 func addNewRecord(type_ int, id int, value int) {
 	batchUpdateLocks[type_].Lock()
 	defer batchUpdateLocks[type_].Unlock()
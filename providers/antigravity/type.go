@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 	"done-hub/providers/gemini"
 )
 
@@ -104,20 +105,7 @@ func (c *OAuth2Credentials) Refresh(ctx context.Context, proxyURL string, maxRet
 			time.Sleep(backoff)
 		}
 
-		// 创建 HTTP 客户端
-		client := &http.Client{
-			Timeout: 30 * time.Second,
-		}
-
-		// 如果有代理配置，设置代理
-		if proxyURL != "" {
-			proxyURLParsed, err := url.Parse(proxyURL)
-			if err == nil {
-				client.Transport = &http.Transport{
-					Proxy: http.ProxyURL(proxyURLParsed),
-				}
-			}
-		}
+		client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
 		// 发送刷新请求
 		req, err := http.NewRequest("POST", TokenEndpoint, strings.NewReader(data.Encode()))
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"done-hub/common"
+	"done-hub/common/config"
 	"done-hub/common/requester"
 	"done-hub/model"
 	"done-hub/providers/base"
@@ -123,6 +124,7 @@ func (p *AzureDatabricksProvider) convertRequest(request *types.ChatCompletionRe
 		databricksRequest.FrequencyPenalty = float64(*request.FrequencyPenalty)
 	}
 
+	request.NormalizeReasoning()
 	if request.Reasoning != nil {
 		var opErr *types.OpenAIErrorWithStatusCode
 		databricksRequest.MaxTokens, databricksRequest.Thinking, opErr = getThinking(databricksRequest.MaxTokens, request.Reasoning)
@@ -147,15 +149,10 @@ func getThinking(maxTokens int, reasoning *types.ChatReasoning) (newMaxTokens in
 			return
 		}
 		thinking.BudgetTokens = reasoning.MaxTokens
+	} else if budget, ok := config.ReasoningSettingsInstance.GetBudgetByEffort(reasoning.Effort); ok {
+		thinking.BudgetTokens = budget
 	} else {
-		switch reasoning.Effort {
-		case "low":
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.2)
-		case "medium":
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.5)
-		default:
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.8)
-		}
+		thinking.BudgetTokens = int(float64(maxTokens) * 0.8)
 	}
 	if thinking.BudgetTokens < 128 {
 		thinking.BudgetTokens = 128
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"done-hub/model"
+	"done-hub/providers/base"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryChannelBalance 查询渠道的上游余额，未实现明细接口的供应商按美元余额返回
+func QueryChannelBalance(channel *model.Channel) (*base.UpstreamBalance, error) {
+	req, err := http.NewRequest("POST", "/balance", nil)
+	if err != nil {
+		return nil, err
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	req.Header.Set("Content-Type", "application/json")
+
+	provider := GetProvider(channel, c)
+	if provider == nil {
+		return nil, errors.New("provider not found")
+	}
+
+	if detailProvider, ok := provider.(base.BalanceDetailInterface); ok {
+		return detailProvider.BalanceDetail()
+	}
+
+	balanceProvider, ok := provider.(base.BalanceInterface)
+	if !ok {
+		return nil, base.ErrBalanceNotSupported
+	}
+
+	balance, err := balanceProvider.Balance()
+	if err != nil {
+		return nil, err
+	}
+
+	return &base.UpstreamBalance{
+		Currency:       "USD",
+		Balance:        balance,
+		ChannelBalance: balance,
+	}, nil
+}
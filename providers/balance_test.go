@@ -0,0 +1,18 @@
+package providers
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"done-hub/providers/base"
+	"errors"
+	"testing"
+)
+
+func TestQueryChannelBalanceNotSupported(t *testing.T) {
+	proxy := ""
+	channel := &model.Channel{Id: 1, Type: config.ChannelTypeAnthropic, Key: "sk-test", Proxy: &proxy}
+
+	if _, err := QueryChannelBalance(channel); !errors.Is(err, base.ErrBalanceNotSupported) {
+		t.Fatalf("expected balance not supported error, got %v", err)
+	}
+}
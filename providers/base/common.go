@@ -4,7 +4,9 @@ import (
 	"context"
 	"done-hub/common"
 	"done-hub/common/config"
+	"done-hub/common/logger"
 	"done-hub/common/requester"
+	"done-hub/common/transform"
 	"done-hub/common/utils"
 	"done-hub/model"
 	"done-hub/types"
@@ -12,6 +14,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tidwall/gjson"
@@ -34,6 +37,7 @@ type ProviderConfig struct {
 	Rerank              string
 	ChatRealtime        string
 	Responses           string
+	OCR                 string
 }
 
 func (pc *ProviderConfig) SetAPIUri(customMapping map[string]interface{}) {
@@ -109,6 +113,12 @@ func (p *BaseProvider) CommonRequestHeaders(headers map[string]string) {
 	if headers["Content-Type"] == "" {
 		headers["Content-Type"] = "application/json"
 	}
+	// 透传请求 ID，便于与上游日志对照
+	if p.Context != nil {
+		if requestId := p.Context.GetString(logger.RequestIdKey); requestId != "" {
+			headers[logger.RequestIdHeader] = requestId
+		}
+	}
 	// 自定义header
 	if p.Channel.ModelHeaders != nil {
 		var customHeaders map[string]string
@@ -135,6 +145,15 @@ func (p *BaseProvider) SetContext(c *gin.Context) {
 	// 这样即使客户端断开，上游请求也会继续完成，确保计费和日志正常记录
 	if c != nil && p.Requester != nil {
 		p.Requester.Context = context.WithoutCancel(c.Request.Context())
+		// 流式请求在上游开始输出前可以安全地切换渠道
+		p.Requester.FirstByteTimeout = 0
+		if c.GetBool("is_stream") && config.StreamFirstByteTimeout > 0 {
+			p.Requester.FirstByteTimeout = time.Duration(config.StreamFirstByteTimeout) * time.Second
+		}
+		if p.Channel != nil {
+			p.Requester.ProxyRotation = p.Channel.ProxyRotation
+			p.Requester.Transform = transform.Get(p.Channel.GetTransformRules())
+		}
 	}
 }
 
@@ -142,6 +161,29 @@ func (p *BaseProvider) GetContext() *gin.Context {
 	return p.Context
 }
 
+// ResponseFormatErrorHeader 结构化输出不符合 response_format 时在响应头中返回原因
+const ResponseFormatErrorHeader = "X-Response-Format-Error"
+
+// CheckResponseFormat 校验非流式输出是否符合 response_format，被截断的输出不做校验。
+// 上游已按实际输出计费，校验失败时仍原样返回输出，通过响应头告知客户端
+func CheckResponseFormat(c *gin.Context, request *types.ChatCompletionRequest, response *types.ChatCompletionResponse) {
+	if request == nil || !request.ResponseFormat.IsJSON() || response == nil || len(response.Choices) == 0 {
+		return
+	}
+	if response.Choices[0].FinishReason == types.FinishReasonLength {
+		return
+	}
+	content, ok := response.Choices[0].Message.Content.(string)
+	if !ok {
+		return
+	}
+
+	if err := request.ResponseFormat.ValidateOutput(content); err != nil && c != nil {
+		c.Header(ResponseFormatErrorHeader, err.Error())
+		logger.LogWarn(c.Request.Context(), "invalid response format: "+err.Error())
+	}
+}
+
 func (p *BaseProvider) SetOriginalModel(ModelName string) {
 	p.OriginalModel = ModelName
 }
@@ -248,6 +290,8 @@ func (p *BaseProvider) GetAPIUri(relayMode int) string {
 		return p.Config.ChatRealtime
 	case config.RelayModeResponses:
 		return p.Config.Responses
+	case config.RelayModeOCR:
+		return p.Config.OCR
 	default:
 		return ""
 	}
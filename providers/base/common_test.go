@@ -0,0 +1,48 @@
+package base
+
+import (
+	"done-hub/common/logger"
+	"done-hub/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TestCheckResponseFormat 测试结构化输出校验失败时原样返回内容并通过响应头告知
+func TestCheckResponseFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.Logger = zap.NewNop()
+
+	request := &types.ChatCompletionRequest{ResponseFormat: &types.ChatCompletionResponseFormat{Type: types.ResponseFormatTypeJsonObject}}
+	tests := []struct {
+		name         string
+		content      string
+		finishReason string
+		invalid      bool
+	}{
+		{"valid json", `{"ok":true}`, types.FinishReasonStop, false},
+		{"invalid json", `not json`, types.FinishReasonStop, true},
+		{"truncated output", `{"ok":`, types.FinishReasonLength, false},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+		response := &types.ChatCompletionResponse{Choices: []types.ChatCompletionChoice{{
+			Message:      types.ChatCompletionMessage{Role: types.ChatMessageRoleAssistant, Content: tt.content},
+			FinishReason: tt.finishReason,
+		}}}
+
+		CheckResponseFormat(c, request, response)
+		if invalid := w.Header().Get(ResponseFormatErrorHeader) != ""; invalid != tt.invalid {
+			t.Errorf("%s: invalid = %v, want %v", tt.name, invalid, tt.invalid)
+		}
+		if response.Choices[0].Message.Content != tt.content {
+			t.Errorf("%s: content should be returned unchanged", tt.name)
+		}
+	}
+}
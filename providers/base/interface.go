@@ -4,6 +4,7 @@ import (
 	"done-hub/common/requester"
 	"done-hub/model"
 	"done-hub/types"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -67,6 +68,12 @@ type EmbeddingsInterface interface {
 	CreateEmbeddings(request *types.EmbeddingRequest) (*types.EmbeddingResponse, *types.OpenAIErrorWithStatusCode)
 }
 
+// OCR接口
+type OCRInterface interface {
+	ProviderInterface
+	CreateOCR(request *types.OCRRequest) (*types.OCRResponse, *types.OpenAIErrorWithStatusCode)
+}
+
 // 审查接口
 type ModerationInterface interface {
 	ProviderInterface
@@ -123,6 +130,15 @@ type BalanceInterface interface {
 	Balance() (float64, error)
 }
 
+// ErrBalanceNotSupported 渠道不支持余额查询
+var ErrBalanceNotSupported = errors.New("不支持余额查询")
+
+// 余额详情接口，返回上游原始币种下的余额明细
+type BalanceDetailInterface interface {
+	BalanceInterface
+	BalanceDetail() (*UpstreamBalance, error)
+}
+
 // type ProviderResponseHandler interface {
 // 	// 响应处理函数
 // 	ResponseHandler(resp *http.Response) (OpenAIResponse any, errWithCode *types.OpenAIErrorWithStatusCode)
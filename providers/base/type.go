@@ -7,3 +7,14 @@ type BalanceResponse struct {
 	TotalRemaining float64 `json:"total_remaining"`
 	TotalAvailable float64 `json:"total_available"`
 }
+
+// UpstreamBalance 上游余额明细，金额单位与 Currency 一致
+type UpstreamBalance struct {
+	Currency string             `json:"currency"`
+	Balance  float64            `json:"balance"`
+	Total    float64            `json:"total,omitempty"`
+	Used     float64            `json:"used,omitempty"`
+	Details  map[string]float64 `json:"details,omitempty"`
+	// ChannelBalance 写入渠道 balance 字段的值，与渠道列表中展示的余额保持一致
+	ChannelBalance float64 `json:"channel_balance"`
+}
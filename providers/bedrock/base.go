@@ -62,7 +62,15 @@ func requestErrorHandle(resp *http.Response) *types.OpenAIError {
 		return nil
 	}
 
-	return errorHandle(bedrockError)
+	openaiErr := errorHandle(bedrockError)
+	if openaiErr != nil {
+		// 异常名称只在响应头中返回，如 ThrottlingException:http://internal.amazon.com/coral/com.amazon.bedrock/
+		if errorType := resp.Header.Get("X-Amzn-ErrorType"); errorType != "" {
+			openaiErr.Code, _, _ = strings.Cut(errorType, ":")
+		}
+	}
+
+	return openaiErr
 }
 
 // 错误处理
@@ -10,6 +10,7 @@ import (
 )
 
 func (p *BedrockProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	// 发送请求
 	response, errWithCode := p.Send(request)
@@ -23,6 +24,7 @@ func (p *BedrockProvider) CreateChatCompletion(request *types.ChatCompletionRequ
 }
 
 func (p *BedrockProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	// 发送请求
 	response, errWithCode := p.Send(request)
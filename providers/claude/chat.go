@@ -31,9 +31,15 @@ type ClaudeStreamHandler struct {
 	StreamTolls int
 	Prefix      string
 	Context     *gin.Context // 添加 Context 用于获取响应模型名称
+
+	toolCalls types.ToolCallIndexer // 为并行的工具调用分配 index
+
+	jsonMode bool // 正在输出模拟 json_schema 的工具参数
 }
 
 func (p *ClaudeProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	// 解析模型名中的推理力度后缀，如 claude-sonnet-4-5-high
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	claudeRequest, errWithCode := ConvertFromChatOpenai(request)
 	if errWithCode != nil {
@@ -57,6 +63,7 @@ func (p *ClaudeProvider) CreateChatCompletion(request *types.ChatCompletionReque
 }
 
 func (p *ClaudeProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	claudeRequest, errWithCode := ConvertFromChatOpenai(request)
 	if errWithCode != nil {
@@ -123,6 +130,9 @@ func (p *ClaudeProvider) getChatRequest(claudeRequest *ClaudeRequest) (*http.Req
 }
 
 func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest, *types.OpenAIErrorWithStatusCode) {
+	// 将 reasoning_effort 统一合并到 reasoning 中，再按对照表换算为 budget_tokens
+	request.NormalizeReasoning()
+
 	claudeRequest := ClaudeRequest{
 		Model:         request.Model,
 		Messages:      make([]Message, 0),
@@ -149,6 +159,8 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest
 
 	// 处理 system 字段（支持 cache_control）
 	systemMessage := ""
+	systemBlocks := make([]MessageContent, 0)
+	systemCached := false
 	mgsLen := len(request.Messages) - 1
 	isThink := request.OneOtherArg == "thinking" || request.Reasoning != nil
 
@@ -167,6 +179,13 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest
 			// 如果没有预设的 system 字段，从 messages 中提取
 			if request.System == nil {
 				systemMessage += msg.StringContent()
+				blocks := convertTextBlocks(&msg)
+				for _, block := range blocks {
+					if block.CacheControl != nil {
+						systemCached = true
+					}
+				}
+				systemBlocks = append(systemBlocks, blocks...)
 			}
 			continue
 		}
@@ -174,21 +193,30 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest
 		if err != nil {
 			return nil, common.ErrorWrapper(err, "conversion_error", http.StatusBadRequest)
 		}
-		if messageContent != nil {
-			claudeRequest.Messages = append(claudeRequest.Messages, *messageContent)
+		if messageContent == nil {
+			continue
+		}
+		// 并行工具调用的多个结果需要合并到同一条 user 消息中
+		if msg.Role == types.ChatMessageRoleTool && mergeToolResult(&claudeRequest, messageContent) {
+			continue
 		}
+		claudeRequest.Messages = append(claudeRequest.Messages, *messageContent)
 	}
 
 	// 如果没有预设的 system 字段，且从 messages 中提取到了 system message
-	if request.System == nil && systemMessage != "" {
+	// system 中带有 cache_control 时使用数组格式，保留缓存断点
+	if request.System == nil && systemCached {
+		claudeRequest.System = systemBlocks
+	} else if request.System == nil && systemMessage != "" {
 		claudeRequest.System = systemMessage
 	}
 
 	for _, tool := range request.Tools {
 		tool := Tools{
-			Name:        tool.Function.Name,
-			Description: tool.Function.Description,
-			InputSchema: tool.Function.Parameters,
+			Name:         tool.Function.Name,
+			Description:  tool.Function.Description,
+			InputSchema:  tool.Function.Parameters,
+			CacheControl: tool.CacheControl,
 		}
 		claudeRequest.Tools = append(claudeRequest.Tools, tool)
 	}
@@ -198,6 +226,16 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest
 		claudeRequest.ToolChoice = ConvertToolChoice(toolType, toolFunc)
 	}
 
+	// parallel_tool_calls=false 对应 Claude 的 disable_parallel_tool_use
+	if len(claudeRequest.Tools) > 0 && request.ParallelToolCallsDisabled() {
+		if claudeRequest.ToolChoice == nil {
+			claudeRequest.ToolChoice = &ToolChoice{Type: "auto"}
+		}
+		if claudeRequest.ToolChoice.Type != "none" {
+			claudeRequest.ToolChoice.DisableParallelToolUse = true
+		}
+	}
+
 	if claudeRequest.MaxTokens == 0 {
 		claudeRequest.MaxTokens = config.ClaudeSettingsInstance.GetDefaultMaxTokens(request.Model)
 	}
@@ -214,6 +252,8 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*ClaudeRequest
 		claudeRequest.TopP = nil
 	}
 
+	applyResponseFormat(request, &claudeRequest)
+
 	return &claudeRequest, nil
 }
 
@@ -236,15 +276,16 @@ func getThinking(maxTokens int, reasoning *types.ChatReasoning) (newMaxtokens in
 			return
 		}
 		thinking.BudgetTokens = reasoning.MaxTokens
+	} else if budget, ok := config.ReasoningSettingsInstance.GetBudgetByEffort(reasoning.Effort); ok {
+		// 按推理力度对照表换算思考预算
+		thinking.BudgetTokens = budget
 	} else {
-		switch reasoning.Effort {
-		case "low":
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.2)
-		case "medium":
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.5)
-		default:
-			thinking.BudgetTokens = int(float64(maxTokens) * 0.8)
-		}
+		thinking.BudgetTokens = int(float64(maxTokens) * 0.8)
+	}
+
+	// 思考预算必须小于 max_tokens，超出时下调预算
+	if thinking.BudgetTokens >= newMaxtokens {
+		thinking.BudgetTokens = newMaxtokens - 1
 	}
 
 	// 如果低于1024,则设置为1024
@@ -268,11 +309,62 @@ func ConvertToolChoice(toolType, toolFunc string) *ToolChoice {
 		choice.Name = toolFunc
 	case types.ToolChoiceTypeRequired:
 		choice.Type = "any"
+	case types.ToolChoiceTypeNone:
+		choice.Type = "none"
 	}
 
 	return choice
 }
 
+// convertTextBlocks 将消息中的文本转换为 Claude 文本块，保留 cache_control
+// 消息级别的 cache_control 只作用于最后一个块（与 Anthropic 的缓存断点语义一致）
+func convertTextBlocks(msg *types.ChatCompletionMessage) []MessageContent {
+	blocks := make([]MessageContent, 0)
+	for _, part := range msg.ParseContent() {
+		if part.Type != types.ContentTypeText || part.Text == "" {
+			continue
+		}
+		blocks = append(blocks, MessageContent{
+			Type:         "text",
+			Text:         part.Text,
+			CacheControl: part.CacheControl,
+		})
+	}
+	applyMessageCacheControl(blocks, msg.CacheControl)
+	return blocks
+}
+
+func applyMessageCacheControl(blocks []MessageContent, cacheControl any) {
+	if cacheControl == nil || len(blocks) == 0 {
+		return
+	}
+	last := &blocks[len(blocks)-1]
+	if last.CacheControl == nil {
+		last.CacheControl = cacheControl
+	}
+}
+
+// mergeToolResult 上一条消息同为工具结果时合并，返回是否已合并
+func mergeToolResult(claudeRequest *ClaudeRequest, message *Message) bool {
+	if len(claudeRequest.Messages) == 0 {
+		return false
+	}
+	last := &claudeRequest.Messages[len(claudeRequest.Messages)-1]
+	if last.Role != message.Role {
+		return false
+	}
+	lastContent, ok := last.Content.([]MessageContent)
+	if !ok || len(lastContent) == 0 || lastContent[len(lastContent)-1].Type != ContentTypeToolResult {
+		return false
+	}
+	content, ok := message.Content.([]MessageContent)
+	if !ok {
+		return false
+	}
+	last.Content = append(lastContent, content...)
+	return true
+}
+
 func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 	message := Message{
 		Role: convertRole(msg.Role),
@@ -281,10 +373,14 @@ func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 	content := make([]MessageContent, 0)
 
 	if msg.ToolCalls != nil {
+		// 工具调用前的文本内容一并保留
+		content = append(content, convertTextBlocks(&types.ChatCompletionMessage{Content: msg.Content})...)
 		for _, toolCall := range msg.ToolCalls {
 			inputParam := make(map[string]any)
-			if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &inputParam); err != nil {
-				return nil, err
+			if strings.TrimSpace(toolCall.Function.Arguments) != "" {
+				if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &inputParam); err != nil {
+					return nil, err
+				}
 			}
 			content = append(content, MessageContent{
 				Type:  ContentTypeToolUes,
@@ -294,6 +390,7 @@ func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 			})
 		}
 
+		applyMessageCacheControl(content, msg.CacheControl)
 		message.Content = content
 		return &message, nil
 	}
@@ -305,6 +402,7 @@ func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 			ToolUseId: msg.ToolCallID,
 		})
 
+		applyMessageCacheControl(content, msg.CacheControl)
 		message.Content = content
 		return &message, nil
 	}
@@ -312,19 +410,16 @@ func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 	openaiContent := msg.ParseContent()
 	for _, part := range openaiContent {
 		if part.Type == types.ContentTypeText {
-			msgContent := MessageContent{
-				Type: "text",
-				Text: part.Text,
-			}
-			// 传递 cache_control 字段
-			if msg.CacheControl != nil {
-				msgContent.CacheControl = msg.CacheControl
-			}
-			content = append(content, msgContent)
+			// 传递内容块上的 cache_control 字段
+			content = append(content, MessageContent{
+				Type:         "text",
+				Text:         part.Text,
+				CacheControl: part.CacheControl,
+			})
 			continue
 		}
 		if part.Type == types.ContentTypeImageURL {
-			mimeType, data, err := image.GetImageFromUrl(part.ImageURL.URL)
+			mimeType, data, err := image.GetImageFromUrlWithLimit(part.ImageURL.URL, image.ClaudeLimit)
 			if err != nil {
 				return nil, common.ErrorWrapper(err, "image_url_invalid", http.StatusBadRequest)
 			}
@@ -340,10 +435,12 @@ func convertMessageContent(msg *types.ChatCompletionMessage) (*Message, error) {
 					MediaType: mimeType,
 					Data:      data,
 				},
+				CacheControl: part.CacheControl,
 			})
 		}
 	}
 
+	applyMessageCacheControl(content, msg.CacheControl)
 	message.Content = content
 
 	return &message, nil
@@ -364,6 +461,14 @@ func ConvertToChatOpenai(provider base.ProviderInterface, response *ClaudeRespon
 	thinkingContent := ""
 
 	for _, content := range response.Content {
+		// 模拟 json_schema 的工具调用按文本内容返回
+		if content.Type == ContentTypeToolUes && isJSONResponseTool(request, content.Name) {
+			input, _ := json.Marshal(content.Input)
+			content.Type = types.ContentTypeText
+			content.Text = string(input)
+			response.StopReason = "end_turn"
+		}
+
 		switch content.Type {
 		case ContentTypeToolUes:
 			if len(choices) == 0 {
@@ -411,6 +516,10 @@ func ConvertToChatOpenai(provider base.ProviderInterface, response *ClaudeRespon
 
 	}
 
+	for _, choice := range choices {
+		types.ReindexToolCalls(choice.Message.ToolCalls)
+	}
+
 	if len(choices) == 0 {
 		// 如果没有内容，则返回一个空的响应
 		choices = append(choices, types.ChatCompletionChoice{
@@ -448,6 +557,8 @@ func ConvertToChatOpenai(provider base.ProviderInterface, response *ClaudeRespon
 
 	openaiResponse.Usage = usage
 
+	base.CheckResponseFormat(provider.GetContext(), request, openaiResponse)
+
 	return openaiResponse, nil
 }
 
@@ -505,8 +616,13 @@ func (h *ClaudeStreamHandler) HandlerStream(rawLine *[]byte, dataChan chan strin
 }
 
 func (h *ClaudeStreamHandler) convertToOpenaiStream(claudeResponse *ClaudeStreamResponse, dataChan chan string) {
+	if !h.convertJSONResponse(claudeResponse) {
+		return
+	}
+
+	// claudeResponse.Index 是内容块的序号，不是候选序号
 	choice := types.ChatCompletionStreamChoice{
-		Index: claudeResponse.Index,
+		Index: 0,
 		Delta: types.ChatCompletionStreamChoiceDelta{
 			Role:    claudeResponse.Message.Role,
 			Content: claudeResponse.Delta.Text,
@@ -521,8 +637,9 @@ func (h *ClaudeStreamHandler) convertToOpenaiStream(claudeResponse *ClaudeStream
 
 	if claudeResponse.ContentBlock.Type == ContentTypeToolUes {
 		toolCalls = append(toolCalls, &types.ChatCompletionToolCalls{
-			Id:   claudeResponse.ContentBlock.Id,
-			Type: types.ChatMessageRoleFunction,
+			Id:    claudeResponse.ContentBlock.Id,
+			Index: h.toolCalls.Next(),
+			Type:  types.ChatMessageRoleFunction,
 			Function: &types.ChatCompletionToolCallsFunction{
 				Name:      claudeResponse.ContentBlock.Name,
 				Arguments: "",
@@ -537,7 +654,8 @@ func (h *ClaudeStreamHandler) convertToOpenaiStream(claudeResponse *ClaudeStream
 			return
 		}
 		toolCalls = append(toolCalls, &types.ChatCompletionToolCalls{
-			Type: types.ChatMessageRoleFunction,
+			Index: h.toolCalls.Current(),
+			Type:  types.ChatMessageRoleFunction,
 			Function: &types.ChatCompletionToolCallsFunction{
 				Arguments: claudeResponse.Delta.PartialJson,
 			},
@@ -553,7 +671,8 @@ func (h *ClaudeStreamHandler) convertToOpenaiStream(claudeResponse *ClaudeStream
 	if claudeResponse.ContentBlock.Type != ContentTypeToolUes && claudeResponse.Delta.Type != "input_json_delta" && h.StreamTolls != StreamTollsNone {
 		if h.StreamTolls == StreamTollsUse {
 			toolCalls = append(toolCalls, &types.ChatCompletionToolCalls{
-				Type: types.ChatMessageRoleFunction,
+				Index: h.toolCalls.Current(),
+				Type:  types.ChatMessageRoleFunction,
 				Function: &types.ChatCompletionToolCallsFunction{
 					Arguments: "{}",
 				},
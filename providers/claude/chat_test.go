@@ -0,0 +1,77 @@
+package claude
+
+import (
+	"done-hub/types"
+	"testing"
+)
+
+func TestGetThinking(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxTokens  int
+		reasoning  *types.ChatReasoning
+		wantMax    int
+		wantBudget int
+	}{
+		{"budget within max tokens", 32000, &types.ChatReasoning{Effort: "medium"}, 32000, 8192},
+		{"budget not scaled by max tokens", 64000, &types.ChatReasoning{Effort: "medium"}, 64000, 8192},
+		{"minimal raised to minimum budget", 32000, &types.ChatReasoning{Effort: "minimal"}, 32000, 1024},
+		{"budget clamped under max tokens", 4096, &types.ChatReasoning{Effort: "high"}, 4096, 4095},
+		{"minimum budget", 1024, &types.ChatReasoning{Effort: "low"}, 1280, 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxTokens, thinking, err := getThinking(tt.maxTokens, tt.reasoning)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Message)
+			}
+			if maxTokens != tt.wantMax || thinking.BudgetTokens != tt.wantBudget {
+				t.Fatalf("got max_tokens=%d budget=%d, want max_tokens=%d budget=%d", maxTokens, thinking.BudgetTokens, tt.wantMax, tt.wantBudget)
+			}
+		})
+	}
+}
+
+func TestConvertParallelToolCalls(t *testing.T) {
+	disabled, enabled := false, true
+	tests := []struct {
+		name        string
+		parallel    *bool
+		toolChoice  any
+		wantType    string
+		wantDisable bool
+	}{
+		{"not set", nil, nil, "", false},
+		{"enabled", &enabled, nil, "", false},
+		{"disabled", &disabled, nil, "auto", true},
+		{"disabled with required", &disabled, types.ToolChoiceTypeRequired, "any", true},
+		{"disabled with none", &disabled, types.ToolChoiceTypeNone, "none", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &types.ChatCompletionRequest{
+				Model:             "claude-sonnet-4",
+				MaxTokens:         1024,
+				Messages:          []types.ChatCompletionMessage{{Role: types.ChatMessageRoleUser, Content: "hi"}},
+				Tools:             []*types.ChatCompletionTool{{Type: "function", Function: types.ChatCompletionFunction{Name: "search"}}},
+				ToolChoice:        tt.toolChoice,
+				ParallelToolCalls: tt.parallel,
+			}
+			claudeRequest, err := ConvertFromChatOpenai(request)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Message)
+			}
+			if tt.wantType == "" {
+				if claudeRequest.ToolChoice != nil {
+					t.Fatalf("tool_choice should not be set, got %+v", claudeRequest.ToolChoice)
+				}
+				return
+			}
+			if claudeRequest.ToolChoice == nil || claudeRequest.ToolChoice.Type != tt.wantType || claudeRequest.ToolChoice.DisableParallelToolUse != tt.wantDisable {
+				t.Fatalf("unexpected tool_choice: %+v", claudeRequest.ToolChoice)
+			}
+		})
+	}
+}
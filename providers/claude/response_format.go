@@ -0,0 +1,106 @@
+package claude
+
+import (
+	"done-hub/types"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonResponseToolName 模拟 json_schema 时强制调用的工具名
+const jsonResponseToolName = "json_response"
+
+const jsonObjectInstruction = "Respond only with a single valid JSON object. Do not wrap it in markdown code fences or add any other text."
+
+// applyResponseFormat 将 OpenAI 的 response_format 转换为 Claude 的实现方式
+// json_schema 通过强制工具调用约束输出；开启 thinking 或已有自定义工具时无法强制工具调用，改为提示词约束
+func applyResponseFormat(request *types.ChatCompletionRequest, claudeRequest *ClaudeRequest) {
+	format := request.ResponseFormat
+	if !format.IsJSON() {
+		return
+	}
+
+	schema := format.GetSchema()
+	if schema == nil {
+		appendSystemText(claudeRequest, jsonObjectInstruction)
+		return
+	}
+
+	if claudeRequest.Thinking != nil || len(claudeRequest.Tools) > 0 {
+		schemaText, _ := json.Marshal(schema)
+		appendSystemText(claudeRequest, fmt.Sprintf("%s The JSON must conform to this JSON Schema:\n%s", jsonObjectInstruction, schemaText))
+		return
+	}
+
+	description := format.JsonSchema.Description
+	if description == "" {
+		description = "Respond with a JSON object that conforms to the schema"
+		if format.JsonSchema.Name != "" {
+			description += " " + format.JsonSchema.Name
+		}
+	}
+
+	claudeRequest.Tools = append(claudeRequest.Tools, Tools{
+		Name:        jsonResponseToolName,
+		Description: description,
+		InputSchema: schema,
+	})
+	claudeRequest.ToolChoice = &ToolChoice{Type: "tool", Name: jsonResponseToolName}
+}
+
+// isJSONResponseTool 判断工具调用是否为模拟 json_schema 的工具
+func isJSONResponseTool(request *types.ChatCompletionRequest, name string) bool {
+	if name != jsonResponseToolName || request == nil || request.ResponseFormat.GetSchema() == nil {
+		return false
+	}
+	for _, tool := range request.Tools {
+		if tool.Function.Name == jsonResponseToolName {
+			return false
+		}
+	}
+	return true
+}
+
+func appendSystemText(claudeRequest *ClaudeRequest, text string) {
+	switch system := claudeRequest.System.(type) {
+	case nil:
+		claudeRequest.System = text
+	case string:
+		if system == "" {
+			claudeRequest.System = text
+		} else {
+			claudeRequest.System = system + "\n\n" + text
+		}
+	case []MessageContent:
+		claudeRequest.System = append(system, MessageContent{Type: types.ContentTypeText, Text: text})
+	case []any:
+		claudeRequest.System = append(system, map[string]any{"type": types.ContentTypeText, "text": text})
+	}
+}
+
+// convertJSONResponse 将模拟 json_schema 的工具调用流转换为文本内容，返回 false 表示该事件不需要输出
+func (h *ClaudeStreamHandler) convertJSONResponse(claudeResponse *ClaudeStreamResponse) bool {
+	if !h.Request.ResponseFormat.IsJSON() {
+		return true
+	}
+
+	if claudeResponse.ContentBlock.Type == ContentTypeToolUes && isJSONResponseTool(h.Request, claudeResponse.ContentBlock.Name) {
+		h.jsonMode = true
+		return false
+	}
+
+	if h.jsonMode {
+		switch {
+		case claudeResponse.Delta.Type == ContentStreamTypeInputJsonDelta:
+			if claudeResponse.Delta.PartialJson == "" {
+				return false
+			}
+			claudeResponse.Delta.Text = claudeResponse.Delta.PartialJson
+			claudeResponse.Delta.Type = ""
+			claudeResponse.Delta.PartialJson = ""
+		case claudeResponse.Delta.StopReason == "tool_use":
+			claudeResponse.Delta.StopReason = "end_turn"
+		}
+	}
+
+	return true
+}
@@ -18,6 +18,7 @@ import (
 
 // CreateChatCompletion 创建聊天完成
 func (p *ClaudeCodeProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	claudeRequest, errWithCode := claude.ConvertFromChatOpenai(request)
 	if errWithCode != nil {
@@ -44,6 +45,7 @@ func (p *ClaudeCodeProvider) CreateChatCompletion(request *types.ChatCompletionR
 
 // CreateChatCompletionStream 创建流式聊天完成
 func (p *ClaudeCodeProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	claudeRequest, errWithCode := claude.ConvertFromChatOpenai(request)
 	if errWithCode != nil {
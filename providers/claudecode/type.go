@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"done-hub/common/logger"
+	"done-hub/common/requester"
 )
 
 type OAuth2Credentials struct {
@@ -59,6 +60,10 @@ func (c *OAuth2Credentials) Refresh(ctx context.Context, proxyURL string, maxRet
 	data.Set("client_id", clientID)
 	data.Set("refresh_token", c.RefreshToken)
 
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var lastErr error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
@@ -66,28 +71,18 @@ func (c *OAuth2Credentials) Refresh(ctx context.Context, proxyURL string, maxRet
 			if backoff > 30*time.Second {
 				backoff = 30 * time.Second
 			}
-			if ctx != nil {
-				logger.LogError(ctx, fmt.Sprintf("[ClaudeCode] Token refresh retry %d/%d after %v", attempt, maxRetries, backoff))
-			} else {
-				logger.SysLog(fmt.Sprintf("[ClaudeCode] Token refresh retry %d/%d after %v", attempt, maxRetries, backoff))
+			logger.LogError(ctx, fmt.Sprintf("[ClaudeCode] Token refresh retry %d/%d after %v", attempt, maxRetries, backoff))
+			// 调用方取消或超时后不再重试
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("token refresh canceled: %w, last error: %v", ctx.Err(), lastErr)
+			case <-time.After(backoff):
 			}
-			time.Sleep(backoff)
 		}
 
-		client := &http.Client{
-			Timeout: 30 * time.Second,
-		}
+		client := requester.NewProxyClient(proxyURL, 30*time.Second)
 
-		if proxyURL != "" {
-			proxyURLParsed, err := url.Parse(proxyURL)
-			if err == nil {
-				client.Transport = &http.Transport{
-					Proxy: http.ProxyURL(proxyURLParsed),
-				}
-			}
-		}
-
-		req, err := http.NewRequest("POST", TokenEndpoint, strings.NewReader(data.Encode()))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenEndpoint, strings.NewReader(data.Encode()))
 		if err != nil {
 			lastErr = fmt.Errorf("failed to create refresh request: %w", err)
 			continue
@@ -145,11 +140,7 @@ func (c *OAuth2Credentials) Refresh(ctx context.Context, proxyURL string, maxRet
 			c.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 		}
 
-		if ctx != nil {
-			logger.LogInfo(ctx, fmt.Sprintf("[ClaudeCode] Token refreshed successfully, expires at: %s", c.ExpiresAt.Format(time.RFC3339)))
-		} else {
-			logger.SysLog(fmt.Sprintf("[ClaudeCode] Token refreshed successfully, expires at: %s", c.ExpiresAt.Format(time.RFC3339)))
-		}
+		logger.LogInfo(ctx, fmt.Sprintf("[ClaudeCode] Token refreshed successfully, expires at: %s", c.ExpiresAt.Format(time.RFC3339)))
 		return nil
 	}
 
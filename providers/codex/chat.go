@@ -100,8 +100,8 @@ func (p *CodexProvider) chatToResponsesRequest(request *types.ChatCompletionRequ
 	// 使用标准的转换方法
 	responsesRequest := request.ToResponsesRequest()
 
-	// 0. 解析模型名称中的 reasoning effort 后缀 (-high, -medium, -low)
-	effort, cleanModel := parseReasoningEffortFromModelSuffix(responsesRequest.Model)
+	// 0. 解析模型名称中的 reasoning effort 后缀 (-minimal, -low, -medium, -high)
+	effort, cleanModel := types.ParseReasoningEffortSuffix(responsesRequest.Model)
 	if effort != "" {
 		responsesRequest.Model = cleanModel
 		if responsesRequest.Reasoning == nil {
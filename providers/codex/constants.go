@@ -10,23 +10,6 @@ var BaseModelList = []string{
 	"codex-mini-latest",
 }
 
-// reasoningEffortSuffixes 支持的推理力度后缀
-var reasoningEffortSuffixes = []string{"-high", "-medium", "-low"}
-
-// parseReasoningEffortFromModelSuffix 从模型名中解析推理力度后缀
-// 例如: "gpt-5-codex-high" → effort="high", model="gpt-5-codex"
-//
-//	"gpt-5.1-codex-mini-low" → effort="low", model="gpt-5.1-codex-mini"
-//	"gpt-5-codex" → effort="", model="gpt-5-codex" (无变化)
-func parseReasoningEffortFromModelSuffix(model string) (effort string, originModel string) {
-	for _, suffix := range reasoningEffortSuffixes {
-		if strings.HasSuffix(model, suffix) {
-			return suffix[1:], model[:len(model)-len(suffix)]
-		}
-	}
-	return "", model
-}
-
 // normalizeCodexModelName 规范化 Codex 模型名称
 // gpt-5-* 系列（除 gpt-5-codex、gpt-5-codex-mini 等 codex 系列）统一映射为基础模型
 // 这是因为 Codex 后端只识别有限的模型标识符
@@ -89,9 +89,9 @@ func (p *CodexProvider) CreateResponsesStream(request *types.OpenAIResponsesRequ
 
 // prepareCodexRequest 准备 Codex 请求参数
 func (p *CodexProvider) prepareCodexRequest(request *types.OpenAIResponsesRequest) {
-	// 0. 解析模型名称中的 reasoning effort 后缀 (-high, -medium, -low)
+	// 0. 解析模型名称中的 reasoning effort 后缀 (-minimal, -low, -medium, -high)
 	// 例如: gpt-5-codex-high → model=gpt-5-codex, reasoning.effort=high
-	effort, cleanModel := parseReasoningEffortFromModelSuffix(request.Model)
+	effort, cleanModel := types.ParseReasoningEffortSuffix(request.Model)
 	if effort != "" {
 		request.Model = cleanModel
 		if request.Reasoning == nil {
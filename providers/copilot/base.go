@@ -0,0 +1,147 @@
+package copilot
+
+import (
+	"context"
+	"done-hub/common/logger"
+	"done-hub/common/requester"
+	"done-hub/common/utils"
+	"done-hub/model"
+	"done-hub/providers/base"
+	"done-hub/providers/openai"
+	"done-hub/types"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type CopilotProviderFactory struct{}
+
+// 创建 CopilotProvider
+// 渠道密钥填写 GitHub token（gho_/ghu_ 开头的 OAuth token），请求时自动换取 Copilot token
+func (f CopilotProviderFactory) Create(channel *model.Channel) base.ProviderInterface {
+	provider := &CopilotProvider{
+		OpenAIProvider: openai.OpenAIProvider{
+			BaseProvider: base.BaseProvider{
+				Config:    getConfig(),
+				Channel:   channel,
+				Requester: requester.NewHTTPRequester(*channel.Proxy, openai.RequestErrorHandle),
+			},
+			SupportStreamOptions: true,
+		},
+		sessionID: utils.GetUUID(),
+	}
+
+	provider.RequestHandleBefore = provider.prepareToken
+	provider.HeadersHandler = provider.applyCopilotHeaders
+
+	return provider
+}
+
+func getConfig() base.ProviderConfig {
+	return base.ProviderConfig{
+		BaseURL:         DefaultAPIEndpoint,
+		ChatCompletions: "/chat/completions",
+		Embeddings:      "/embeddings",
+		ModelList:       "/models",
+	}
+}
+
+type CopilotProvider struct {
+	openai.OpenAIProvider
+	token     *Token
+	sessionID string
+}
+
+func (p *CopilotProvider) getProxyURL() string {
+	if p.Channel.Proxy != nil {
+		return *p.Channel.Proxy
+	}
+	return ""
+}
+
+// getToken 获取 Copilot token，并使用 token 返回的 API 地址（个人/企业版地址不同）
+func (p *CopilotProvider) getToken() (*Token, error) {
+	if p.token != nil && !p.token.ExpiresWithin(tokenRefreshBuffer) {
+		return p.token, nil
+	}
+
+	ctx := context.Background()
+	if p.Context != nil {
+		ctx = p.Context.Request.Context()
+	}
+
+	token, err := GetToken(ctx, p.Channel.Id, p.Channel.Key, p.getProxyURL())
+	if err != nil {
+		return nil, err
+	}
+
+	p.token = token
+	if p.Channel.GetBaseURL() == "" && token.APIEndpoint != "" {
+		p.Config.BaseURL = token.APIEndpoint
+	}
+
+	return token, nil
+}
+
+func (p *CopilotProvider) prepareToken(_ *types.ChatCompletionRequest) *types.OpenAIErrorWithStatusCode {
+	if _, err := p.getToken(); err != nil {
+		return tokenErrorWrapper(err)
+	}
+	return nil
+}
+
+// applyCopilotHeaders 使用 Copilot token 替换鉴权，并模拟 VS Code Copilot Chat 的请求头。
+// 换取 token 失败时移除鉴权头，不能把 GitHub token 直接发给 Copilot API
+func (p *CopilotProvider) applyCopilotHeaders(headers map[string]string) {
+	token, err := p.getToken()
+	if err != nil {
+		logger.SysError(fmt.Sprintf("[Copilot] channel_id=%d get token failed: %s", p.Channel.Id, err.Error()))
+		delete(headers, "Authorization")
+	} else {
+		headers["Authorization"] = "Bearer " + token.Token
+	}
+
+	headers["Editor-Version"] = EditorVersion
+	headers["Editor-Plugin-Version"] = EditorPluginVersion
+	headers["Copilot-Integration-Id"] = IntegrationID
+	headers["User-Agent"] = UserAgent
+	headers["Openai-Intent"] = "conversation-panel"
+	headers["X-Github-Api-Version"] = APIVersion
+	headers["Vscode-Machineid"] = MachineID(p.Channel.Key)
+	headers["Vscode-Sessionid"] = p.sessionID
+	if _, ok := headers[logger.RequestIdHeader]; !ok {
+		headers[logger.RequestIdHeader] = utils.GetUUID()
+	}
+}
+
+// CreateEmbeddings 换取 token 失败时直接返回错误，不发送请求
+func (p *CopilotProvider) CreateEmbeddings(request *types.EmbeddingRequest) (*types.EmbeddingResponse, *types.OpenAIErrorWithStatusCode) {
+	if _, err := p.getToken(); err != nil {
+		return nil, tokenErrorWrapper(err)
+	}
+	return p.OpenAIProvider.CreateEmbeddings(request)
+}
+
+// GetModelList 从 Copilot 获取模型列表
+func (p *CopilotProvider) GetModelList() ([]string, error) {
+	if _, err := p.getToken(); err != nil {
+		return nil, err
+	}
+	return p.OpenAIProvider.GetModelList()
+}
+
+func tokenErrorWrapper(err error) *types.OpenAIErrorWithStatusCode {
+	statusCode := http.StatusInternalServerError
+	if tokenErr, ok := err.(*TokenError); ok && tokenErr.Unauthorized {
+		statusCode = http.StatusUnauthorized
+	}
+
+	return &types.OpenAIErrorWithStatusCode{
+		OpenAIError: types.OpenAIError{
+			Message: strings.TrimSpace(err.Error()),
+			Type:    "copilot_error",
+			Code:    "copilot_token_error",
+		},
+		StatusCode: statusCode,
+	}
+}
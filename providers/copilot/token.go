@@ -0,0 +1,199 @@
+package copilot
+
+import (
+	"context"
+	"crypto/sha256"
+	"done-hub/common/cache"
+	"done-hub/common/requester"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	TokenCacheKey = "api_token:copilot"
+	// TokenEndpoint 使用 GitHub token 换取 Copilot token 的接口
+	TokenEndpoint = "https://api.github.com/copilot_internal/v2/token"
+	// DefaultAPIEndpoint 换取 token 的响应中未返回 endpoints.api 时使用
+	DefaultAPIEndpoint = "https://api.githubcopilot.com"
+
+	EditorVersion       = "vscode/1.99.3"
+	EditorPluginVersion = "copilot-chat/0.26.7"
+	UserAgent           = "GitHubCopilotChat/0.26.7"
+	IntegrationID       = "vscode-chat"
+	APIVersion          = "2025-04-01"
+
+	// tokenRefreshBuffer token 剩余有效期不足该值时视为过期
+	tokenRefreshBuffer = 2 * time.Minute
+)
+
+var refreshGroup singleflight.Group
+
+// Token Copilot 短期 token，通常有效期 30 分钟
+type Token struct {
+	Token       string `json:"token"`
+	ExpiresAt   int64  `json:"expires_at"`
+	APIEndpoint string `json:"api_endpoint"`
+}
+
+// ExpiresWithin 判断 token 是否会在 d 时间内过期
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	if t == nil || t.Token == "" {
+		return true
+	}
+	return time.Now().Add(d).Unix() >= t.ExpiresAt
+}
+
+type tokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expires_at"`
+	RefreshIn int64  `json:"refresh_in"`
+	Endpoints struct {
+		API string `json:"api"`
+	} `json:"endpoints"`
+	ErrorDetails *struct {
+		Message string `json:"message"`
+	} `json:"error_details,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// TokenError 换取 token 失败，Unauthorized 为 true 时表示 GitHub token 无效或账号没有 Copilot 订阅，重试无意义
+type TokenError struct {
+	StatusCode   int
+	Message      string
+	Unauthorized bool
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("copilot token exchange failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// MachineID 根据 GitHub token 生成稳定的 machine id，同一个账号始终使用同一个设备标识
+func MachineID(githubToken string) string {
+	sum := sha256.Sum256([]byte("copilot-machine:" + githubToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func tokenCacheKey(channelId int) string {
+	return fmt.Sprintf("%s:%d", TokenCacheKey, channelId)
+}
+
+// ExchangeToken 使用 GitHub token 换取 Copilot token
+func ExchangeToken(ctx context.Context, githubToken, proxyURL string) (*Token, error) {
+	githubToken = strings.TrimSpace(githubToken)
+	if githubToken == "" {
+		return nil, fmt.Errorf("github token is empty")
+	}
+
+	client := requester.NewProxyClient(proxyURL, 30*time.Second)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, TokenEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+githubToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Editor-Version", EditorVersion)
+	req.Header.Set("Editor-Plugin-Version", EditorPluginVersion)
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("X-Github-Api-Version", APIVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var tokenResp tokenResponse
+	_ = json.Unmarshal(body, &tokenResp)
+
+	if resp.StatusCode != http.StatusOK || tokenResp.Token == "" {
+		message := tokenResp.Message
+		if tokenResp.ErrorDetails != nil && tokenResp.ErrorDetails.Message != "" {
+			message = tokenResp.ErrorDetails.Message
+		}
+		if message == "" {
+			message = string(body)
+		}
+		return nil, &TokenError{
+			StatusCode:   resp.StatusCode,
+			Message:      message,
+			Unauthorized: resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound,
+		}
+	}
+
+	token := &Token{
+		Token:       tokenResp.Token,
+		ExpiresAt:   tokenResp.ExpiresAt,
+		APIEndpoint: strings.TrimRight(tokenResp.Endpoints.API, "/"),
+	}
+	if token.ExpiresAt == 0 {
+		refreshIn := tokenResp.RefreshIn
+		if refreshIn <= 0 {
+			refreshIn = 1500
+		}
+		token.ExpiresAt = time.Now().Unix() + refreshIn
+	}
+	if token.APIEndpoint == "" {
+		token.APIEndpoint = DefaultAPIEndpoint
+	}
+
+	return token, nil
+}
+
+// GetCachedToken 读取渠道缓存的 Copilot token，不存在或解析失败时返回 nil
+func GetCachedToken(channelId int) *Token {
+	cached, err := cache.GetCache[string](tokenCacheKey(channelId))
+	if err != nil || cached == "" {
+		return nil
+	}
+	token := &Token{}
+	if json.Unmarshal([]byte(cached), token) != nil {
+		return nil
+	}
+	return token
+}
+
+// RefreshToken 换取新的 Copilot token 并写入缓存
+func RefreshToken(ctx context.Context, channelId int, githubToken, proxyURL string) (*Token, error) {
+	token, err := ExchangeToken(ctx, githubToken, proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Until(time.Unix(token.ExpiresAt, 0)) - tokenRefreshBuffer
+	if ttl > 0 {
+		if data, marshalErr := json.Marshal(token); marshalErr == nil {
+			_ = cache.SetCache(tokenCacheKey(channelId), string(data), ttl)
+		}
+	}
+
+	return token, nil
+}
+
+// GetToken 获取渠道可用的 Copilot token，缓存中没有或即将过期时重新换取
+func GetToken(ctx context.Context, channelId int, githubToken, proxyURL string) (*Token, error) {
+	if token := GetCachedToken(channelId); token != nil && !token.ExpiresWithin(tokenRefreshBuffer) {
+		return token, nil
+	}
+
+	// 同一渠道的并发请求只换取一次 token
+	v, err, _ := refreshGroup.Do(tokenCacheKey(channelId), func() (interface{}, error) {
+		return RefreshToken(ctx, channelId, githubToken, proxyURL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Token), nil
+}
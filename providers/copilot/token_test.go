@@ -0,0 +1,59 @@
+package copilot
+
+import (
+	"done-hub/common/cache"
+	"done-hub/common/logger"
+	"done-hub/model"
+	"done-hub/types"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMachineIDStable(t *testing.T) {
+	id := MachineID("gho_test")
+	if len(id) != 64 {
+		t.Fatalf("expected 64 hex chars, got %d", len(id))
+	}
+	if id != MachineID("gho_test") {
+		t.Fatal("machine id should be stable for the same token")
+	}
+	if id == MachineID("gho_other") {
+		t.Fatal("machine id should differ between tokens")
+	}
+}
+
+func TestTokenExpiresWithin(t *testing.T) {
+	var nilToken *Token
+	if !nilToken.ExpiresWithin(time.Minute) {
+		t.Fatal("nil token should be treated as expired")
+	}
+
+	token := &Token{Token: "tid=1", ExpiresAt: time.Now().Add(10 * time.Minute).Unix()}
+	if token.ExpiresWithin(time.Minute) {
+		t.Fatal("token with 10 minutes left should not expire within 1 minute")
+	}
+	if !token.ExpiresWithin(15 * time.Minute) {
+		t.Fatal("token with 10 minutes left should expire within 15 minutes")
+	}
+}
+
+func TestApplyCopilotHeadersWithoutToken(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	cache.InitCacheManager()
+
+	proxy := ""
+	provider := CopilotProviderFactory{}.Create(&model.Channel{Id: 1, Proxy: &proxy}).(*CopilotProvider)
+
+	// 换取 token 失败时不能把渠道密钥作为鉴权头发送
+	headers := map[string]string{"Authorization": "Bearer gho_test"}
+	provider.applyCopilotHeaders(headers)
+	if _, ok := headers["Authorization"]; ok {
+		t.Fatalf("authorization header should be removed, got %q", headers["Authorization"])
+	}
+
+	if _, errWithCode := provider.CreateEmbeddings(&types.EmbeddingRequest{Model: "text-embedding-3-small"}); errWithCode == nil {
+		t.Fatal("embeddings should fail without a copilot token")
+	}
+}
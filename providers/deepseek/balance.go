@@ -1,6 +1,7 @@
 package deepseek
 
 import (
+	"done-hub/providers/base"
 	"errors"
 	"strconv"
 )
@@ -11,36 +12,57 @@ type Response struct {
 }
 
 type BalanceInfo struct {
-	Currency     string `json:"currency"`
-	TotalBalance string `json:"total_balance"`
+	Currency        string `json:"currency"`
+	TotalBalance    string `json:"total_balance"`
+	GrantedBalance  string `json:"granted_balance"`
+	ToppedUpBalance string `json:"topped_up_balance"`
 }
 
 func (p *DeepseekProvider) Balance() (float64, error) {
+	detail, err := p.BalanceDetail()
+	if err != nil {
+		return 0, err
+	}
 
+	return detail.ChannelBalance, nil
+}
+
+func (p *DeepseekProvider) BalanceDetail() (*base.UpstreamBalance, error) {
 	fullRequestURL := p.GetFullRequestURL("/user/balance", "")
 	headers := p.GetRequestHeaders()
 
 	req, err := p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// 发送请求
 	var info Response
 	_, errWithCode := p.Requester.SendRequest(req, &info, false)
 	if errWithCode != nil {
-		return 0, errors.New(errWithCode.OpenAIError.Message)
+		return nil, errors.New(errWithCode.OpenAIError.Message)
 	}
 
 	if len(info.BalanceInfo) == 0 {
-		return 0, errors.New("获取余额失败")
+		return nil, errors.New("获取余额失败")
 	}
 
-	balance, err := strconv.ParseFloat(info.BalanceInfo[0].TotalBalance, 64)
+	balanceInfo := info.BalanceInfo[0]
+	balance, err := strconv.ParseFloat(balanceInfo.TotalBalance, 64)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	granted, _ := strconv.ParseFloat(balanceInfo.GrantedBalance, 64)
+	toppedUp, _ := strconv.ParseFloat(balanceInfo.ToppedUpBalance, 64)
 
 	p.Channel.UpdateBalance(balance)
-	return balance, nil
+	return &base.UpstreamBalance{
+		Currency: balanceInfo.Currency,
+		Balance:  balance,
+		Details: map[string]float64{
+			"granted":   granted,
+			"topped_up": toppedUp,
+		},
+		ChannelBalance: balance,
+	}, nil
 }
@@ -26,6 +26,8 @@ type GeminiStreamHandler struct {
 
 	Key     string
 	Context *gin.Context // 添加 Context 用于获取响应模型名称
+
+	toolCalls types.ToolCallIndexer
 }
 
 type OpenAIStreamHandler struct {
@@ -34,6 +36,8 @@ type OpenAIStreamHandler struct {
 }
 
 func (p *GeminiProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	// 解析模型名中的推理力度后缀，如 gemini-2.5-flash-low
+	request.ApplyReasoningEffortSuffix()
 	if p.UseOpenaiAPI {
 		return p.OpenAIProvider.CreateChatCompletion(request)
 	}
@@ -60,6 +64,7 @@ func (p *GeminiProvider) CreateChatCompletion(request *types.ChatCompletionReque
 }
 
 func (p *GeminiProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 
 	channel := p.GetChannel()
 	if p.UseOpenaiAPI {
@@ -535,6 +540,9 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*GeminiChatReq
 	// 如果启用 thinking 但历史 assistant 消息不以 thinking/redacted_thinking 开头，则不启用
 	canEnableThinking := shouldEnableThinking(request.Messages)
 
+	// 将 reasoning_effort 统一合并到 reasoning 中
+	request.NormalizeReasoning()
+
 	// 1. 基础检查：是否有 reasoning 参数
 	if request.Reasoning != nil {
 
@@ -542,6 +550,11 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*GeminiChatReq
 			budget := request.Reasoning.MaxTokens
 			maxTokens := request.MaxTokens
 
+			// 2. gemini-3 以前的模型不支持 thinkingLevel，按推理力度对照表换算为 thinkingBudget
+			if budget == 0 && request.Reasoning.Effort != "" && !model_utils.HasPrefixCaseInsensitive(request.Model, "gemini-3") {
+				budget, _ = config.ReasoningSettingsInstance.GetBudgetByEffort(request.Reasoning.Effort)
+			}
+
 			// 3. Token 校验与调整：验证 thinkingBudget < maxOutputTokens
 			// Gemini API 要求 Budget 必须严格小于 MaxOutputTokens
 			if maxTokens > 0 && budget >= maxTokens {
@@ -561,8 +574,8 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*GeminiChatReq
 				hasConfig = true
 			}
 
-			// 5. 设置 ThinkingLevel (映射 effort 参数)
-			if request.Reasoning.Effort != "" {
+			// 5. 设置 ThinkingLevel (映射 effort 参数，仅 gemini-3 系列支持)
+			if request.Reasoning.Effort != "" && model_utils.HasPrefixCaseInsensitive(request.Model, "gemini-3") {
 				effortToLevelMap := map[string]string{
 					"minimal": "MINIMAL",
 					"low":     "LOW",
@@ -644,6 +657,7 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*GeminiChatReq
 
 		if len(geminiRequest.Tools) == 0 && len(geminiChatTools.FunctionDeclarations) > 0 {
 			geminiRequest.Tools = append(geminiRequest.Tools, geminiChatTools)
+			geminiRequest.ToolConfig = convertToolChoice(request)
 		}
 	}
 
@@ -677,6 +691,29 @@ func ConvertFromChatOpenai(request *types.ChatCompletionRequest) (*GeminiChatReq
 	return &geminiRequest, nil
 }
 
+// convertToolChoice 将 OpenAI 的 tool_choice 转换为 Gemini 的 functionCallingConfig
+func convertToolChoice(request *types.ChatCompletionRequest) *GeminiToolConfig {
+	if request.ToolChoice == nil {
+		return nil
+	}
+
+	callingConfig := &GeminiFunctionCallingConfig{}
+	toolType, toolFunc := request.ParseToolChoice()
+	switch toolType {
+	case types.ToolChoiceTypeNone:
+		callingConfig.Mode = "NONE"
+	case types.ToolChoiceTypeRequired:
+		callingConfig.Mode = "ANY"
+	case types.ToolChoiceTypeFunction:
+		callingConfig.Mode = "ANY"
+		callingConfig.AllowedFunctionNames = []string{toolFunc}
+	default:
+		return nil
+	}
+
+	return &GeminiToolConfig{FunctionCallingConfig: callingConfig}
+}
+
 func removeAdditionalPropertiesWithDepth(schema interface{}, depth int) interface{} {
 	if depth >= 5 {
 		return schema
@@ -772,7 +809,9 @@ func ConvertToChatOpenai(provider base.ProviderInterface, response *GeminiChatRe
 	} else {
 		// 正常的 generateContent 响应处理
 		for _, candidate := range response.Candidates {
-			openaiResponse.Choices = append(openaiResponse.Choices, candidate.ToOpenAIChoice(request))
+			choice := candidate.ToOpenAIChoice(request)
+			types.ReindexToolCalls(choice.Message.ToolCalls)
+			openaiResponse.Choices = append(openaiResponse.Choices, choice)
 		}
 	}
 
@@ -780,6 +819,9 @@ func ConvertToChatOpenai(provider base.ProviderInterface, response *GeminiChatRe
 	*usage = ConvertOpenAIUsageWithFallback(response.UsageMetadata, response)
 	openaiResponse.Usage = usage
 
+	// 校验结构化输出，流式输出不做校验
+	base.CheckResponseFormat(provider.GetContext(), request, openaiResponse)
+
 	return
 }
 
@@ -838,8 +880,13 @@ func (h *GeminiStreamHandler) convertToOpenaiStream(geminiResponse *GeminiChatRe
 	}
 
 	if len(choices) > 0 && (choices[0].Delta.ToolCalls != nil || choices[0].Delta.FunctionCall != nil) {
+		// 工具调用可能分布在多个分片中，index 需要跨分片连续
+		offset := h.toolCalls.Reserve(len(choices[0].Delta.ToolCalls))
 		choices := choices[0].ConvertOpenaiStream()
 		for _, choice := range choices {
+			for _, toolCall := range choice.Delta.ToolCalls {
+				toolCall.Index += offset
+			}
 			chatCompletionCopy := streamResponse
 			chatCompletionCopy.Choices = []types.ChatCompletionStreamChoice{choice}
 			responseBody, _ := json.Marshal(chatCompletionCopy)
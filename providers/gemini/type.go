@@ -164,7 +164,7 @@ type GeminiToolConfig struct {
 }
 
 type GeminiFunctionCallingConfig struct {
-	Model                string `json:"model,omitempty"`
+	Mode                 string `json:"mode,omitempty"`
 	AllowedFunctionNames any    `json:"allowedFunctionNames,omitempty"`
 }
 type GeminiInlineData struct {
@@ -424,8 +424,14 @@ type GeminiFunctionResponseContent struct {
 func (g *GeminiFunctionCall) ToOpenAITool() *types.ChatCompletionToolCalls {
 	args, _ := json.Marshal(g.Args)
 
+	// 新版本的 Gemini 会返回调用 ID，回传工具结果时需要保持一致
+	id := g.Id
+	if id == "" {
+		id = "call_" + utils.GetRandomString(24)
+	}
+
 	return &types.ChatCompletionToolCalls{
-		Id:    "call_" + utils.GetRandomString(24),
+		Id:    id,
 		Type:  types.ChatMessageRoleFunction,
 		Index: 0,
 		Function: &types.ChatCompletionToolCallsFunction{
@@ -783,7 +789,7 @@ func OpenAIToGeminiChatContent(openaiContents []types.ChatCompletionMessage) ([]
 								if endPos > 0 {
 									imageUrl := string(textRunes[pos+1 : endPos])
 									// 处理图片URL
-									mimeType, data, err := image.GetImageFromUrl(imageUrl)
+									mimeType, data, err := image.GetImageFromUrlWithLimit(imageUrl, image.Limit{})
 									if err == nil {
 										content.Parts = append(content.Parts, GeminiPart{
 											InlineData: &GeminiInlineData{
@@ -829,7 +835,7 @@ func OpenAIToGeminiChatContent(openaiContents []types.ChatCompletionMessage) ([]
 						if imageNum > GeminiVisionMaxImageNum {
 							continue
 						}
-						mimeType, data, err := image.GetImageFromUrl(openaiPart.ImageURL.URL)
+						mimeType, data, err := image.GetImageFromUrlWithLimit(openaiPart.ImageURL.URL, image.Limit{})
 						if err != nil {
 							return nil, "", common.ErrorWrapper(err, "image_url_invalid", http.StatusBadRequest)
 						}
@@ -20,6 +20,7 @@ import (
 
 // CreateChatCompletion 创建聊天补全（非流式）
 func (p *GeminiCliProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	// 转换为Gemini格式
 	geminiRequest, errWithCode := gemini.ConvertFromChatOpenai(request)
 	if errWithCode != nil {
@@ -51,6 +52,7 @@ func (p *GeminiCliProvider) CreateChatCompletion(request *types.ChatCompletionRe
 
 // CreateChatCompletionStream 创建聊天补全（流式）
 func (p *GeminiCliProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	// 转换为Gemini格式
 	geminiRequest, errWithCode := gemini.ConvertFromChatOpenai(request)
 	if errWithCode != nil {
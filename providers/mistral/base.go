@@ -45,6 +45,7 @@ func getMistralConfig(baseURL string) base.ProviderConfig {
 		ChatCompletions: "/v1/chat/completions",
 		Embeddings:      "/v1/embeddings",
 		ModelList:       "/v1/models",
+		OCR:             "/v1/ocr",
 	}
 }
 
@@ -60,12 +61,24 @@ func RequestErrorHandle(resp *http.Response) *types.OpenAIError {
 }
 
 // 错误处理
-func errorHandle(MistralError *MistralError) *types.OpenAIError {
-	if MistralError.Object != "error" {
+func errorHandle(mistralError *MistralError) *types.OpenAIError {
+	if mistralError == nil {
 		return nil
 	}
+
+	message := mistralError.errorMsg()
+	if message == "" {
+		return nil
+	}
+
+	errType := mistralError.Type
+	if errType == "" {
+		errType = "mistral_error"
+	}
+
 	return &types.OpenAIError{
-		Message: MistralError.Message.Detail[0].errorMsg(),
-		Type:    MistralError.Type,
+		Message: message,
+		Type:    errType,
+		Code:    mistralError.Code,
 	}
 }
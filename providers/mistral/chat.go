@@ -0,0 +1,385 @@
+package mistral
+
+import (
+	"crypto/sha256"
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/common/requester"
+	"done-hub/common/utils"
+	"done-hub/types"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Mistral 要求 tool call id 为 9 位字母数字
+var toolCallIdRegex = regexp.MustCompile(`^[a-zA-Z0-9]{9}$`)
+
+const toolCallIdChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+type mistralStreamHandler struct {
+	Usage   *types.Usage
+	Request *types.ChatCompletionRequest
+	Model   string
+}
+
+func (p *MistralProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	req, errWithCode := p.getChatRequest(request)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	defer req.Body.Close()
+
+	response := &MistralChatResponse{}
+	// 发送请求
+	_, errWithCode = p.Requester.SendRequest(req, response, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.convertToChatOpenai(response, request)
+}
+
+func (p *MistralProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	req, errWithCode := p.getChatRequest(request)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	defer req.Body.Close()
+
+	// 发送请求
+	resp, errWithCode := p.Requester.SendRequestRaw(req)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	chatHandler := &mistralStreamHandler{
+		Usage:   p.Usage,
+		Request: request,
+		Model:   p.GetResponseModelName(request.Model),
+	}
+
+	return requester.RequestStream(p.Requester, resp, chatHandler.handlerStream)
+}
+
+func (p *MistralProvider) getChatRequest(request *types.ChatCompletionRequest) (*http.Request, *types.OpenAIErrorWithStatusCode) {
+	url, errWithCode := p.GetSupportedAPIUri(config.RelayModeChatCompletions)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// 获取请求地址
+	fullRequestURL := p.GetFullRequestURL(url, request.Model)
+
+	// 获取请求头
+	headers := p.GetRequestHeaders()
+	if request.Stream {
+		headers["Accept"] = "text/event-stream"
+	}
+
+	mistralRequest := convertFromChatOpenai(request)
+
+	// 使用BaseProvider的统一方法创建请求，支持额外参数处理
+	return p.NewRequestWithCustomParams(http.MethodPost, fullRequestURL, mistralRequest, headers, request.Model)
+}
+
+// convertFromChatOpenai 转换为 Mistral 请求，丢弃 Mistral 不支持的参数（Mistral 会对未知参数返回 422）
+func convertFromChatOpenai(request *types.ChatCompletionRequest) *MistralChatRequest {
+	mistralRequest := &MistralChatRequest{
+		Model:             request.Model,
+		Messages:          make([]MistralMessage, 0, len(request.Messages)),
+		Temperature:       request.Temperature,
+		TopP:              request.TopP,
+		MaxTokens:         request.MaxTokens,
+		Stream:            request.Stream,
+		Stop:              request.Stop,
+		RandomSeed:        request.Seed,
+		ToolChoice:        request.ToolChoice,
+		PresencePenalty:   request.PresencePenalty,
+		FrequencyPenalty:  request.FrequencyPenalty,
+		N:                 request.N,
+		Prediction:        request.Prediction,
+		ParallelToolCalls: request.ParallelToolCalls,
+	}
+
+	if mistralRequest.MaxTokens == 0 && request.MaxCompletionTokens > 0 {
+		mistralRequest.MaxTokens = request.MaxCompletionTokens
+	}
+
+	if request.ResponseFormat != nil {
+		mistralRequest.ResponseFormat = request.ResponseFormat
+	}
+
+	for _, tool := range request.Tools {
+		mistralRequest.Tools = append(mistralRequest.Tools, MistralTool{
+			Type:     "function",
+			Function: tool.Function,
+		})
+	}
+	for _, function := range request.Functions {
+		mistralRequest.Tools = append(mistralRequest.Tools, MistralTool{
+			Type:     "function",
+			Function: *function,
+		})
+	}
+
+	for _, message := range request.Messages {
+		mistralRequest.Messages = append(mistralRequest.Messages, convertMessage(message))
+	}
+
+	return mistralRequest
+}
+
+func convertMessage(message types.ChatCompletionMessage) MistralMessage {
+	mistralMessage := MistralMessage{
+		Role: message.Role,
+	}
+
+	switch message.Role {
+	case types.ChatMessageRoleTool:
+		mistralMessage.ToolCallId = normalizeToolCallId(message.ToolCallID)
+		if message.Name != nil {
+			mistralMessage.Name = *message.Name
+		}
+	case types.ChatMessageRoleFunction:
+		// 旧版 function 结果按 tool 消息发送
+		mistralMessage.Role = types.ChatMessageRoleTool
+		if message.Name != nil {
+			mistralMessage.Name = *message.Name
+			mistralMessage.ToolCallId = normalizeToolCallId(*message.Name)
+		}
+	}
+
+	for _, toolCall := range message.ToolCalls {
+		if toolCall == nil || toolCall.Function == nil {
+			continue
+		}
+		mistralMessage.ToolCalls = append(mistralMessage.ToolCalls, MistralToolCall{
+			Id:       normalizeToolCallId(toolCall.Id),
+			Type:     "function",
+			Function: toolCall.Function,
+			Index:    toolCall.Index,
+		})
+	}
+	if message.FunctionCall != nil && message.Name != nil {
+		mistralMessage.ToolCalls = append(mistralMessage.ToolCalls, MistralToolCall{
+			Id:       normalizeToolCallId(*message.Name),
+			Type:     "function",
+			Function: message.FunctionCall,
+		})
+	}
+
+	if content, ok := message.Content.(string); ok || message.Content == nil {
+		mistralMessage.Content = content
+		return mistralMessage
+	}
+
+	chunks := make([]MistralContentChunk, 0)
+	for _, part := range message.ParseContent() {
+		switch part.Type {
+		case types.ContentTypeText:
+			chunks = append(chunks, MistralContentChunk{Type: "text", Text: part.Text})
+		case types.ContentTypeImageURL:
+			if part.ImageURL != nil {
+				chunks = append(chunks, MistralContentChunk{Type: "image_url", ImageURL: part.ImageURL.URL})
+			}
+		case "input_audio":
+			if part.InputAudio != nil {
+				chunks = append(chunks, MistralContentChunk{Type: "input_audio", InputAudio: part.InputAudio.Data})
+			}
+		}
+	}
+	mistralMessage.Content = chunks
+
+	return mistralMessage
+}
+
+// normalizeToolCallId 将其他模型生成的 tool call id 转换为 Mistral 接受的格式，同一个 id 总是得到相同的结果
+func normalizeToolCallId(id string) string {
+	if id == "" || toolCallIdRegex.MatchString(id) {
+		return id
+	}
+
+	sum := sha256.Sum256([]byte(id))
+	var builder strings.Builder
+	for i := 0; i < 9; i++ {
+		builder.WriteByte(toolCallIdChars[int(sum[i])%len(toolCallIdChars)])
+	}
+	return builder.String()
+}
+
+// parseContent 解析 Mistral 的 content，可能是字符串，也可能是 text / thinking 分块数组
+func parseContent(raw json.RawMessage) (content string, reasoning string) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+
+	if json.Unmarshal(raw, &content) == nil {
+		return
+	}
+
+	var chunks []MistralContentChunk
+	if json.Unmarshal(raw, &chunks) != nil {
+		return
+	}
+
+	var contentBuilder, reasoningBuilder strings.Builder
+	for _, chunk := range chunks {
+		switch chunk.Type {
+		case "text":
+			contentBuilder.WriteString(chunk.Text)
+		case "thinking":
+			for _, thinking := range chunk.Thinking {
+				reasoningBuilder.WriteString(thinking.Text)
+			}
+		}
+	}
+
+	return contentBuilder.String(), reasoningBuilder.String()
+}
+
+func convertToolCalls(toolCalls []MistralToolCall) []*types.ChatCompletionToolCalls {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	openaiToolCalls := make([]*types.ChatCompletionToolCalls, 0, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		index := toolCall.Index
+		if index == 0 {
+			index = i
+		}
+		openaiToolCalls = append(openaiToolCalls, &types.ChatCompletionToolCalls{
+			Id:       toolCall.Id,
+			Type:     "function",
+			Function: toolCall.Function,
+			Index:    index,
+		})
+	}
+	return openaiToolCalls
+}
+
+func convertFinishReason(finishReason *string) any {
+	if finishReason == nil {
+		return nil
+	}
+
+	switch *finishReason {
+	case "model_length", "length":
+		return types.FinishReasonLength
+	case "error":
+		return types.FinishReasonStop
+	default:
+		return *finishReason
+	}
+}
+
+func (p *MistralProvider) convertToChatOpenai(response *MistralChatResponse, request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	openaiResponse := &types.ChatCompletionResponse{
+		ID:      response.Id,
+		Object:  "chat.completion",
+		Created: response.Created,
+		Model:   p.GetResponseModelName(request.Model),
+		Choices: make([]types.ChatCompletionChoice, 0, len(response.Choices)),
+	}
+	if openaiResponse.Created == int64(0) {
+		openaiResponse.Created = utils.GetTimestamp()
+	}
+
+	for _, choice := range response.Choices {
+		content, reasoning := parseContent(choice.Message.Content)
+		openaiChoice := types.ChatCompletionChoice{
+			Index: choice.Index,
+			Message: types.ChatCompletionMessage{
+				Role:             types.ChatMessageRoleAssistant,
+				Content:          content,
+				ReasoningContent: reasoning,
+				ToolCalls:        convertToolCalls(choice.Message.ToolCalls),
+			},
+		}
+		if finishReason, ok := convertFinishReason(choice.FinishReason).(string); ok {
+			openaiChoice.FinishReason = finishReason
+		}
+		openaiChoice.CheckChoice(request)
+		openaiResponse.Choices = append(openaiResponse.Choices, openaiChoice)
+	}
+
+	if response.Usage != nil && response.Usage.TotalTokens > 0 {
+		openaiResponse.Usage = response.Usage
+	} else {
+		openaiResponse.Usage = &types.Usage{
+			PromptTokens: p.Usage.PromptTokens,
+		}
+		openaiResponse.Usage.CompletionTokens = common.CountTokenText(openaiResponse.GetContent(), request.Model)
+		openaiResponse.Usage.TotalTokens = openaiResponse.Usage.PromptTokens + openaiResponse.Usage.CompletionTokens
+	}
+
+	*p.Usage = *openaiResponse.Usage
+
+	return openaiResponse, nil
+}
+
+// 转换为OpenAI聊天流式请求体
+func (h *mistralStreamHandler) handlerStream(rawLine *[]byte, dataChan chan string, errChan chan error) {
+	if !strings.HasPrefix(string(*rawLine), "data:") {
+		*rawLine = nil
+		return
+	}
+
+	*rawLine = []byte(strings.TrimSpace(string((*rawLine)[5:])))
+	if string(*rawLine) == "[DONE]" {
+		errChan <- io.EOF
+		*rawLine = requester.StreamClosed
+		return
+	}
+
+	var chatResponse MistralChatResponse
+	if err := json.Unmarshal(*rawLine, &chatResponse); err != nil {
+		errChan <- common.ErrorToOpenAIError(err)
+		return
+	}
+
+	if chatResponse.Usage != nil && chatResponse.Usage.CompletionTokens > 0 {
+		h.Usage.PromptTokens = chatResponse.Usage.PromptTokens
+		h.Usage.CompletionTokens = chatResponse.Usage.CompletionTokens
+		h.Usage.TotalTokens = chatResponse.Usage.TotalTokens
+	}
+
+	if len(chatResponse.Choices) == 0 {
+		*rawLine = nil
+		return
+	}
+
+	chatCompletion := types.ChatCompletionStreamResponse{
+		ID:      chatResponse.Id,
+		Object:  "chat.completion.chunk",
+		Created: chatResponse.Created,
+		Model:   h.Model,
+		Choices: make([]types.ChatCompletionStreamChoice, 0, len(chatResponse.Choices)),
+	}
+
+	for _, choice := range chatResponse.Choices {
+		content, reasoning := parseContent(choice.Delta.Content)
+		streamChoice := types.ChatCompletionStreamChoice{
+			Index: choice.Index,
+			Delta: types.ChatCompletionStreamChoiceDelta{
+				Role:             choice.Delta.Role,
+				Content:          content,
+				ReasoningContent: reasoning,
+				ToolCalls:        convertToolCalls(choice.Delta.ToolCalls),
+			},
+			FinishReason: convertFinishReason(choice.FinishReason),
+		}
+		streamChoice.CheckChoice(h.Request)
+		chatCompletion.Choices = append(chatCompletion.Choices, streamChoice)
+
+		if content != "" {
+			h.Usage.TextBuilder.WriteString(content)
+		}
+	}
+
+	responseBody, _ := json.Marshal(chatCompletion)
+	dataChan <- string(responseBody)
+}
@@ -0,0 +1,82 @@
+package mistral
+
+import (
+	"done-hub/types"
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeToolCallId(t *testing.T) {
+	if got := normalizeToolCallId("abcDEF123"); got != "abcDEF123" {
+		t.Fatalf("valid id should be kept, got %s", got)
+	}
+
+	id := normalizeToolCallId("call_6f1e2b0c9d8a4e7f")
+	if !toolCallIdRegex.MatchString(id) {
+		t.Fatalf("normalized id should be 9 alphanumeric chars, got %s", id)
+	}
+	if id != normalizeToolCallId("call_6f1e2b0c9d8a4e7f") {
+		t.Fatal("normalized id should be stable")
+	}
+}
+
+func TestConvertFromChatOpenai(t *testing.T) {
+	seed := 42
+	name := "get_weather"
+	request := &types.ChatCompletionRequest{
+		Model:               "mistral-large-latest",
+		MaxCompletionTokens: 100,
+		Seed:                &seed,
+		User:                "u1",
+		Messages: []types.ChatCompletionMessage{
+			{Role: types.ChatMessageRoleUser, Content: "weather?"},
+			{Role: types.ChatMessageRoleAssistant, ToolCalls: []*types.ChatCompletionToolCalls{
+				{Id: "call_abc", Type: "function", Function: &types.ChatCompletionToolCallsFunction{Name: name, Arguments: "{}"}},
+			}},
+			{Role: types.ChatMessageRoleTool, ToolCallID: "call_abc", Name: &name, Content: "sunny"},
+		},
+	}
+
+	mistralRequest := convertFromChatOpenai(request)
+	if mistralRequest.MaxTokens != 100 || mistralRequest.RandomSeed == nil || *mistralRequest.RandomSeed != 42 {
+		t.Fatalf("unexpected parameter mapping: %+v", mistralRequest)
+	}
+
+	toolCallId := mistralRequest.Messages[1].ToolCalls[0].Id
+	if toolCallId != mistralRequest.Messages[2].ToolCallId || !toolCallIdRegex.MatchString(toolCallId) {
+		t.Fatalf("tool call ids should be normalized consistently, got %s and %s", toolCallId, mistralRequest.Messages[2].ToolCallId)
+	}
+
+	body, _ := json.Marshal(mistralRequest)
+	var raw map[string]any
+	_ = json.Unmarshal(body, &raw)
+	for _, key := range []string{"user", "seed", "max_completion_tokens"} {
+		if _, ok := raw[key]; ok {
+			t.Fatalf("unsupported parameter %s should not be sent", key)
+		}
+	}
+}
+
+func TestParseContentWithThinking(t *testing.T) {
+	content, reasoning := parseContent(json.RawMessage(`[{"type":"thinking","thinking":[{"type":"text","text":"let me think"}]},{"type":"text","text":"answer"}]`))
+	if content != "answer" || reasoning != "let me think" {
+		t.Fatalf("unexpected content=%q reasoning=%q", content, reasoning)
+	}
+
+	content, reasoning = parseContent(json.RawMessage(`"plain"`))
+	if content != "plain" || reasoning != "" {
+		t.Fatalf("unexpected content=%q reasoning=%q", content, reasoning)
+	}
+}
+
+func TestErrorHandle(t *testing.T) {
+	err := errorHandle(&MistralError{Object: "error", Message: json.RawMessage(`"Prompt too large"`), Type: "invalid_request_message_error"})
+	if err == nil || err.Message != "Prompt too large" {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	err = errorHandle(&MistralError{Object: "error", Message: json.RawMessage(`{"detail":[{"type":"extra_forbidden","loc":["body","user"],"msg":"Extra inputs are not permitted"}]}`)})
+	if err == nil || err.Message == "" {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package mistral
+
+import (
+	"done-hub/common/config"
+	"done-hub/providers/openai"
+	"done-hub/types"
+	"net/http"
+)
+
+func (p *MistralProvider) CreateEmbeddings(request *types.EmbeddingRequest) (*types.EmbeddingResponse, *types.OpenAIErrorWithStatusCode) {
+	url, errWithCode := p.GetSupportedAPIUri(config.RelayModeEmbeddings)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	fullRequestURL := p.GetFullRequestURL(url, request.Model)
+	headers := p.GetRequestHeaders()
+
+	// Mistral 使用 output_dimension 指定维度，且不接受 user 参数
+	mistralRequest := &MistralEmbeddingRequest{
+		Model:           request.Model,
+		Input:           request.Input,
+		EncodingFormat:  request.EncodingFormat,
+		OutputDimension: request.Dimensions,
+	}
+
+	req, errWithCode := p.NewRequestWithCustomParams(http.MethodPost, fullRequestURL, mistralRequest, headers, request.Model)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	defer req.Body.Close()
+
+	response := &openai.OpenAIProviderEmbeddingsResponse{}
+	_, errWithCode = p.Requester.SendRequest(req, response, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if response.Usage != nil && response.Usage.PromptTokens > 0 {
+		*p.Usage = *response.Usage
+	}
+
+	return &response.EmbeddingResponse, nil
+}
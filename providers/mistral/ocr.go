@@ -0,0 +1,41 @@
+package mistral
+
+import (
+	"done-hub/common/config"
+	"done-hub/types"
+	"net/http"
+)
+
+// CreateOCR 文档识别，按处理的页数计费：每页计为 1 个输入 token
+func (p *MistralProvider) CreateOCR(request *types.OCRRequest) (*types.OCRResponse, *types.OpenAIErrorWithStatusCode) {
+	url, errWithCode := p.GetSupportedAPIUri(config.RelayModeOCR)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	fullRequestURL := p.GetFullRequestURL(url, request.Model)
+	headers := p.GetRequestHeaders()
+
+	req, errWithCode := p.NewRequestWithCustomParams(http.MethodPost, fullRequestURL, request, headers, request.Model)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	defer req.Body.Close()
+
+	response := &types.OCRResponse{}
+	_, errWithCode = p.Requester.SendRequest(req, response, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	pages := response.UsageInfo.PagesProcessed
+	if pages == 0 {
+		pages = len(response.Pages)
+	}
+	p.Usage.PromptTokens = pages
+	p.Usage.CompletionTokens = 0
+	p.Usage.TotalTokens = pages
+
+	response.Model = p.GetResponseModelName(request.Model)
+
+	return response, nil
+}
@@ -1,13 +1,40 @@
 package mistral
 
 import (
+	"done-hub/types"
 	"encoding/json"
+	"strings"
 )
 
+// MistralError message 可能是字符串，也可能是参数校验失败时的 {"detail": [...]}
 type MistralError struct {
-	Object  string               `json:"object"`
-	Type    string               `json:"type,omitempty"`
-	Message MistralErrorMessages `json:"message,omitempty"`
+	Object  string          `json:"object"`
+	Type    string          `json:"type,omitempty"`
+	Code    any             `json:"code,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+	Detail  string          `json:"detail,omitempty"`
+}
+
+func (m *MistralError) errorMsg() string {
+	if len(m.Message) > 0 {
+		var msg string
+		if json.Unmarshal(m.Message, &msg) == nil {
+			return msg
+		}
+
+		var messages MistralErrorMessages
+		if json.Unmarshal(m.Message, &messages) == nil && len(messages.Detail) > 0 {
+			errMsgs := make([]string, 0, len(messages.Detail))
+			for _, detail := range messages.Detail {
+				errMsgs = append(errMsgs, detail.errorMsg())
+			}
+			return strings.Join(errMsgs, "; ")
+		}
+
+		return string(m.Message)
+	}
+
+	return m.Detail
 }
 
 type MistralErrorMessages struct {
@@ -45,3 +72,80 @@ type ModelDetails struct {
 	Created int64  `json:"created"`
 	OwnedBy string `json:"owned_by"`
 }
+
+type MistralChatRequest struct {
+	Model             string           `json:"model"`
+	Messages          []MistralMessage `json:"messages"`
+	Temperature       *float64         `json:"temperature,omitempty"`
+	TopP              *float64         `json:"top_p,omitempty"`
+	MaxTokens         int              `json:"max_tokens,omitempty"`
+	Stream            bool             `json:"stream,omitempty"`
+	Stop              any              `json:"stop,omitempty"`
+	RandomSeed        *int             `json:"random_seed,omitempty"`
+	ResponseFormat    any              `json:"response_format,omitempty"`
+	Tools             []MistralTool    `json:"tools,omitempty"`
+	ToolChoice        any              `json:"tool_choice,omitempty"`
+	PresencePenalty   *float64         `json:"presence_penalty,omitempty"`
+	FrequencyPenalty  *float64         `json:"frequency_penalty,omitempty"`
+	N                 *int             `json:"n,omitempty"`
+	Prediction        any              `json:"prediction,omitempty"`
+	ParallelToolCalls *bool            `json:"parallel_tool_calls,omitempty"`
+}
+
+type MistralMessage struct {
+	Role       string            `json:"role"`
+	Content    any               `json:"content"`
+	ToolCalls  []MistralToolCall `json:"tool_calls,omitempty"`
+	ToolCallId string            `json:"tool_call_id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Prefix     bool              `json:"prefix,omitempty"`
+}
+
+type MistralContentChunk struct {
+	Type       string                `json:"type"`
+	Text       string                `json:"text,omitempty"`
+	ImageURL   string                `json:"image_url,omitempty"`
+	InputAudio string                `json:"input_audio,omitempty"`
+	Thinking   []MistralContentChunk `json:"thinking,omitempty"`
+}
+
+type MistralTool struct {
+	Type     string                       `json:"type"`
+	Function types.ChatCompletionFunction `json:"function"`
+}
+
+type MistralToolCall struct {
+	Id       string                                 `json:"id,omitempty"`
+	Type     string                                 `json:"type,omitempty"`
+	Function *types.ChatCompletionToolCallsFunction `json:"function"`
+	Index    int                                    `json:"index"`
+}
+
+type MistralResponseMessage struct {
+	Role      string            `json:"role,omitempty"`
+	Content   json.RawMessage   `json:"content,omitempty"`
+	ToolCalls []MistralToolCall `json:"tool_calls,omitempty"`
+}
+
+type MistralChatChoice struct {
+	Index        int                    `json:"index"`
+	Message      MistralResponseMessage `json:"message"`
+	Delta        MistralResponseMessage `json:"delta"`
+	FinishReason *string                `json:"finish_reason"`
+}
+
+type MistralChatResponse struct {
+	Id      string              `json:"id"`
+	Object  string              `json:"object"`
+	Created int64               `json:"created"`
+	Model   string              `json:"model"`
+	Choices []MistralChatChoice `json:"choices"`
+	Usage   *types.Usage        `json:"usage,omitempty"`
+}
+
+type MistralEmbeddingRequest struct {
+	Model           string `json:"model"`
+	Input           any    `json:"input"`
+	EncodingFormat  string `json:"encoding_format,omitempty"`
+	OutputDimension int    `json:"output_dimension,omitempty"`
+}
@@ -1,6 +1,7 @@
 package moonshot
 
 import (
+	"done-hub/providers/base"
 	"errors"
 	"strings"
 )
@@ -19,24 +20,41 @@ type BalanceInfo struct {
 }
 
 func (p *MoonshotProvider) Balance() (float64, error) {
+	detail, err := p.BalanceDetail()
+	if err != nil {
+		return 0, err
+	}
 
+	return detail.ChannelBalance, nil
+}
+
+func (p *MoonshotProvider) BalanceDetail() (*base.UpstreamBalance, error) {
 	fullRequestURL := p.GetFullRequestURL("/v1/users/me/balance", "")
 	headers := p.GetRequestHeaders()
 
 	req, err := p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	// 发送请求
 	var info Response
 	_, errWithCode := p.Requester.SendRequest(req, &info, false)
 	if errWithCode != nil {
-		return 0, errors.New(errWithCode.OpenAIError.Message)
+		return nil, errors.New(errWithCode.OpenAIError.Message)
 	}
 	if info.Code != 0 || !strings.EqualFold(info.Scode, "0x0") {
-		return 0, errors.New("获取余额失败")
+		return nil, errors.New("获取余额失败")
 	}
+
 	balance := info.Data.AvailableBalance / 7 //RMB TO USD
 	p.Channel.UpdateBalance(balance)
-	return balance, nil
+	return &base.UpstreamBalance{
+		Currency: "CNY",
+		Balance:  info.Data.AvailableBalance,
+		Details: map[string]float64{
+			"voucher": info.Data.VoucherBalance,
+			"cash":    info.Data.CashBalance,
+		},
+		ChannelBalance: balance,
+	}, nil
 }
@@ -40,7 +40,7 @@ func (p *MoonshotProvider) CreateChatCompletion(request *types.ChatCompletionReq
 			TotalTokens:      0,
 		}
 		// 那么需要计算
-		response.Usage.CompletionTokens = common.CountTokenText(response.GetContent(), request.Model)
+		response.Usage.CompletionTokens = common.CountTokenText(response.GetUsageText(), request.Model)
 		response.Usage.TotalTokens = response.Usage.PromptTokens + response.Usage.CompletionTokens
 	}
 
@@ -142,7 +142,7 @@ func convertFromChatOpenai(request *types.ChatCompletionRequest) (*ChatRequest,
 			if openaiPart.Type == types.ContentTypeText {
 				ollamaMessage.Content += openaiPart.Text
 			} else if openaiPart.Type == types.ContentTypeImageURL {
-				_, data, err := image.GetImageFromUrl(openaiPart.ImageURL.URL)
+				_, data, err := image.GetImageFromUrlWithLimit(openaiPart.ImageURL.URL, image.Limit{})
 				if err != nil {
 					return nil, common.ErrorWrapper(err, "image_url_invalid", http.StatusBadRequest)
 				}
@@ -1,14 +1,24 @@
 package openai
 
 import (
+	"done-hub/providers/base"
 	"errors"
 	"fmt"
 	"time"
 )
 
 func (p *OpenAIProvider) Balance() (float64, error) {
+	detail, err := p.BalanceDetail()
+	if err != nil {
+		return 0, err
+	}
+
+	return detail.ChannelBalance, nil
+}
+
+func (p *OpenAIProvider) BalanceDetail() (*base.UpstreamBalance, error) {
 	if !p.BalanceAction {
-		return 0, errors.New("不支持余额查询")
+		return nil, base.ErrBalanceNotSupported
 	}
 
 	fullRequestURL := p.GetFullRequestURL("/v1/dashboard/billing/subscription", "")
@@ -16,14 +26,14 @@ func (p *OpenAIProvider) Balance() (float64, error) {
 
 	req, err := p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// 发送请求
 	var subscription OpenAISubscriptionResponse
 	_, errWithCode := p.Requester.SendRequest(req, &subscription, false)
 	if errWithCode != nil {
-		return 0, errors.New(errWithCode.OpenAIError.Message)
+		return nil, errors.New(errWithCode.OpenAIError.Message)
 	}
 
 	now := time.Now()
@@ -36,15 +46,22 @@ func (p *OpenAIProvider) Balance() (float64, error) {
 	fullRequestURL = p.GetFullRequestURL(fmt.Sprintf("/v1/dashboard/billing/usage?start_date=%s&end_date=%s", startDate, endDate), "")
 	req, err = p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	usage := OpenAIUsageResponse{}
 	_, errWithCode = p.Requester.SendRequest(req, &usage, false)
 	if errWithCode != nil {
-		return 0, errWithCode
+		return nil, errWithCode
 	}
 
-	balance := subscription.HardLimitUSD - usage.TotalUsage/100
+	used := usage.TotalUsage / 100
+	balance := subscription.HardLimitUSD - used
 	p.Channel.UpdateBalance(balance)
-	return balance, nil
+	return &base.UpstreamBalance{
+		Currency:       "USD",
+		Balance:        balance,
+		Total:          subscription.HardLimitUSD,
+		Used:           used,
+		ChannelBalance: balance,
+	}, nil
 }
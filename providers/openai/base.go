@@ -18,6 +18,8 @@ type OpenAIProviderFactory struct{}
 
 type UsageHandler func(usage *types.Usage) (ForcedFormatting bool)
 type RequestHandleBefore func(request *types.ChatCompletionRequest) (errWithCode *types.OpenAIErrorWithStatusCode)
+type HeadersHandler func(headers map[string]string)
+type ChatRequestBodyHandler func(request *types.ChatCompletionRequest) any
 
 type OpenAIProvider struct {
 	base.BaseProvider
@@ -29,6 +31,10 @@ type OpenAIProvider struct {
 
 	UsageHandler        UsageHandler
 	RequestHandleBefore RequestHandleBefore
+	// HeadersHandler 在默认请求头生成后调用，用于替换鉴权或追加渠道专属请求头
+	HeadersHandler HeadersHandler
+	// ChatRequestBodyHandler 返回实际发送的聊天请求体，用于追加渠道专属参数
+	ChatRequestBodyHandler ChatRequestBodyHandler
 }
 
 // 创建 OpenAIProvider
@@ -61,6 +67,11 @@ func CreateOpenAIProvider(channel *model.Channel, baseURL string) *OpenAIProvide
 	return OpenAIProvider
 }
 
+// SetSupportStreamOptions 按渠道设置覆盖是否注入 stream_options.include_usage
+func (p *OpenAIProvider) SetSupportStreamOptions(support bool) {
+	p.SupportStreamOptions = support
+}
+
 func getOpenAIConfig(baseURL string, channel *model.Channel) base.ProviderConfig {
 	providerConfig := base.ProviderConfig{
 		BaseURL:             baseURL,
@@ -195,6 +206,10 @@ func (p *OpenAIProvider) GetRequestHeaders() (headers map[string]string) {
 		headers["Authorization"] = fmt.Sprintf("Bearer %s", p.Channel.Key)
 	}
 
+	if p.HeadersHandler != nil {
+		p.HeadersHandler(headers)
+	}
+
 	return headers
 }
 
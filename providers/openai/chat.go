@@ -29,6 +29,15 @@ type OpenAIStreamHandler struct {
 	UsageHandler     UsageHandler
 }
 
+// GetChatRequestBody 返回发送给上游的聊天请求体，cache_control 只有 Claude 渠道支持，这里去掉
+func (p *OpenAIProvider) GetChatRequestBody(request *types.ChatCompletionRequest) any {
+	request = request.WithoutCacheControl()
+	if p.ChatRequestBodyHandler != nil {
+		return p.ChatRequestBodyHandler(request)
+	}
+	return request
+}
+
 func (p *OpenAIProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (openaiResponse *types.ChatCompletionResponse, errWithCode *types.OpenAIErrorWithStatusCode) {
 	if p.RequestHandleBefore != nil {
 		errWithCode = p.RequestHandleBefore(request)
@@ -43,7 +52,7 @@ func (p *OpenAIProvider) CreateChatCompletion(request *types.ChatCompletionReque
 		request.Messages = common.FilterEmptyContentMessages(request.Messages)
 	}
 
-	req, errWithCode := p.GetRequestTextBody(config.RelayModeChatCompletions, request.Model, request)
+	req, errWithCode := p.GetRequestTextBody(config.RelayModeChatCompletions, request.Model, p.GetChatRequestBody(request))
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -73,7 +82,7 @@ func (p *OpenAIProvider) CreateChatCompletion(request *types.ChatCompletionReque
 			TotalTokens:      0,
 		}
 		// 那么需要计算
-		response.Usage.CompletionTokens = common.CountTokenText(response.GetContent(), request.Model)
+		response.Usage.CompletionTokens = common.CountTokenText(response.GetUsageText(), request.Model)
 		response.Usage.TotalTokens = response.Usage.PromptTokens + response.Usage.CompletionTokens
 	} else if p.UsageHandler != nil {
 		p.UsageHandler(response.Usage)
@@ -81,7 +90,9 @@ func (p *OpenAIProvider) CreateChatCompletion(request *types.ChatCompletionReque
 
 	*p.Usage = *response.Usage
 
-	p.Usage.ExtraBilling = getChatExtraBilling(request)
+	if extraBilling := getChatExtraBilling(request); extraBilling != nil {
+		p.Usage.ExtraBilling = extraBilling
+	}
 
 	// 修改响应中的模型名称为用户请求的原始模型名称
 	responseModel := p.GetResponseModelName(request.Model)
@@ -114,7 +125,7 @@ func (p *OpenAIProvider) CreateChatCompletionStream(request *types.ChatCompletio
 		// 避免误传导致报错
 		request.StreamOptions = nil
 	}
-	req, errWithCode := p.GetRequestTextBody(config.RelayModeChatCompletions, request.Model, request)
+	req, errWithCode := p.GetRequestTextBody(config.RelayModeChatCompletions, request.Model, p.GetChatRequestBody(request))
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -174,6 +185,7 @@ func (h *OpenAIStreamHandler) HandlerChatStream(rawLine *[]byte, dataChan chan s
 		return
 	}
 
+	stripUsage := false
 	if openaiResponse.Usage != nil {
 		if openaiResponse.Usage.CompletionTokens > 0 {
 			if h.UsageHandler != nil && h.UsageHandler(openaiResponse.Usage) {
@@ -190,6 +202,10 @@ func (h *OpenAIStreamHandler) HandlerChatStream(rawLine *[]byte, dataChan chan s
 			*rawLine = nil
 			return
 		}
+
+		// usage 统一由结束时的 usage 块返回（仅客户端请求 include_usage 时），避免重复或泄露给未请求的客户端
+		openaiResponse.Usage = nil
+		stripUsage = true
 	} else {
 		if len(openaiResponse.Choices) > 0 && openaiResponse.Choices[0].Usage != nil {
 			if openaiResponse.Choices[0].Usage.CompletionTokens > 0 {
@@ -216,7 +232,7 @@ func (h *OpenAIStreamHandler) HandlerChatStream(rawLine *[]byte, dataChan chan s
 
 	// 始终累积流式内容到 TextBuilder，用于流中断时的 token 计算备用
 	// 即使上游返回了 Usage 信息，流中断时最终的 Usage 可能不完整
-	responseText := openaiResponse.GetResponseText()
+	responseText := openaiResponse.GetUsageText()
 	if responseText != "" {
 		h.Usage.TextBuilder.WriteString(responseText)
 	}
@@ -232,7 +248,7 @@ func (h *OpenAIStreamHandler) HandlerChatStream(rawLine *[]byte, dataChan chan s
 		h.EscapeJSON = true
 	}
 
-	if h.EscapeJSON {
+	if h.EscapeJSON || stripUsage {
 		if data, err := json.Marshal(openaiResponse.ChatCompletionStreamResponse); err == nil {
 			dataChan <- string(data)
 			return
@@ -255,9 +271,9 @@ func otherProcessing(request *types.ChatCompletionRequest, otherArg string) {
 		if otherArg != "" && request.Reasoning == nil {
 			request.ReasoningEffort = &otherArg
 		}
-		// 如果有 Reasoning 设置，优先使用 Reasoning.Effort 设置 ReasoningEffort
-		if request.Reasoning != nil && request.Reasoning.Effort != "" {
-			request.ReasoningEffort = &request.Reasoning.Effort
+		// 如果有 Reasoning 设置，优先使用 Reasoning.Effort 设置 ReasoningEffort，只有思考预算时按对照表反推
+		if effort := request.ReasoningEffortFromBudget(); effort != "" {
+			request.ReasoningEffort = &effort
 		}
 	}
 }
@@ -0,0 +1,50 @@
+package openrouter
+
+import (
+	"done-hub/providers/base"
+	"errors"
+)
+
+type CreditsResponse struct {
+	Data struct {
+		TotalCredits float64 `json:"total_credits"`
+		TotalUsage   float64 `json:"total_usage"`
+	} `json:"data"`
+}
+
+func (p *OpenRouterProvider) Balance() (float64, error) {
+	detail, err := p.BalanceDetail()
+	if err != nil {
+		return 0, err
+	}
+
+	return detail.ChannelBalance, nil
+}
+
+// https://openrouter.ai/docs/api-reference/get-credits
+func (p *OpenRouterProvider) BalanceDetail() (*base.UpstreamBalance, error) {
+	fullRequestURL := p.GetFullRequestURL("/v1/credits", "")
+	headers := p.GetRequestHeaders()
+
+	req, err := p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
+	if err != nil {
+		return nil, err
+	}
+
+	// 发送请求
+	var info CreditsResponse
+	_, errWithCode := p.Requester.SendRequest(req, &info, false)
+	if errWithCode != nil {
+		return nil, errors.New(errWithCode.OpenAIError.Message)
+	}
+
+	balance := info.Data.TotalCredits - info.Data.TotalUsage
+	p.Channel.UpdateBalance(balance)
+	return &base.UpstreamBalance{
+		Currency:       "USD",
+		Balance:        balance,
+		Total:          info.Data.TotalCredits,
+		Used:           info.Data.TotalUsage,
+		ChannelBalance: balance,
+	}, nil
+}
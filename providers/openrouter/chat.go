@@ -51,7 +51,7 @@ func (p *OpenRouterProvider) CreateChatCompletion(request *types.ChatCompletionR
 			TotalTokens:      0,
 		}
 		// 那么需要计算
-		response.Usage.CompletionTokens = common.CountTokenText(response.GetContent(), request.Model)
+		response.Usage.CompletionTokens = common.CountTokenText(response.GetUsageText(), request.Model)
 		response.Usage.TotalTokens = response.Usage.PromptTokens + response.Usage.CompletionTokens
 	}
 
@@ -128,7 +128,7 @@ func (p *OpenRouterProvider) ConvertFromChatOpenai(request *ChatCompletionReques
 		needConvert := false
 		for indexP, part := range openaiContent {
 			if part.Type == types.ContentTypeImageURL {
-				mimeType, data, err := image.GetImageFromUrl(part.ImageURL.URL)
+				mimeType, data, err := image.GetImageFromUrlWithLimit(part.ImageURL.URL, image.Limit{})
 				if err != nil {
 					continue
 				}
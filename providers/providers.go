@@ -18,6 +18,7 @@ import (
 	"done-hub/providers/cloudflareAI"
 	"done-hub/providers/codex"
 	"done-hub/providers/cohere"
+	"done-hub/providers/copilot"
 	"done-hub/providers/coze"
 	"done-hub/providers/deepseek"
 	"done-hub/providers/gemini"
@@ -103,10 +104,15 @@ func init() {
 		config.ChannelTypeCodex:           codex.CodexProviderFactory{},
 		config.ChannelTypeAntigravity:     antigravity.AntigravityProviderFactory{},
 		config.ChannelTypeVertexAIExpress: vertexai_express.VertexAIExpressProviderFactory{},
+		config.ChannelTypeCopilot:         copilot.CopilotProviderFactory{},
 	}
 }
 
 // 获取供应商
+type streamOptionsSetter interface {
+	SetSupportStreamOptions(support bool)
+}
+
 func GetProvider(channel *model.Channel, c *gin.Context) base.ProviderInterface {
 	factory, ok := providerFactories[channel.Type]
 	var provider base.ProviderInterface
@@ -123,5 +129,12 @@ func GetProvider(channel *model.Channel, c *gin.Context) base.ProviderInterface
 	}
 	provider.SetContext(c)
 
+	// 渠道单独设置了流式 usage 时覆盖供应商默认值
+	if channel.StreamUsage != nil {
+		if streamOptionsProvider, ok := provider.(streamOptionsSetter); ok {
+			streamOptionsProvider.SetSupportStreamOptions(*channel.StreamUsage)
+		}
+	}
+
 	return provider
 }
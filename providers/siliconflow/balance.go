@@ -1,6 +1,7 @@
 package siliconflow
 
 import (
+	"done-hub/providers/base"
 	"errors"
 	"strconv"
 )
@@ -12,37 +13,57 @@ type Response struct {
 }
 
 type UserInfo struct {
-	ID           string `json:"id"`
-	Balance      string `json:"balance"`
-	TotalBalance string `json:"totalBalance"`
+	ID            string `json:"id"`
+	Balance       string `json:"balance"`
+	ChargeBalance string `json:"chargeBalance"`
+	TotalBalance  string `json:"totalBalance"`
 }
 
 func (p *SiliconflowProvider) Balance() (float64, error) {
+	detail, err := p.BalanceDetail()
+	if err != nil {
+		return 0, err
+	}
 
+	return detail.ChannelBalance, nil
+}
+
+func (p *SiliconflowProvider) BalanceDetail() (*base.UpstreamBalance, error) {
 	fullRequestURL := p.GetFullRequestURL("/v1/user/info", "")
 	headers := p.GetRequestHeaders()
 
 	req, err := p.Requester.NewRequest("GET", fullRequestURL, p.Requester.WithHeader(headers))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// 发送请求
 	var info Response
 	_, errWithCode := p.Requester.SendRequest(req, &info, false)
 	if errWithCode != nil {
-		return 0, errors.New(errWithCode.OpenAIError.Message)
+		return nil, errors.New(errWithCode.OpenAIError.Message)
 	}
 
 	if info.Data == nil {
-		return 0, errors.New("获取余额失败")
+		return nil, errors.New("获取余额失败")
 	}
 
 	balance, err := strconv.ParseFloat(info.Data.TotalBalance, 64)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	// balance 为赠送余额，chargeBalance 为充值余额
+	granted, _ := strconv.ParseFloat(info.Data.Balance, 64)
+	charged, _ := strconv.ParseFloat(info.Data.ChargeBalance, 64)
 
 	p.Channel.UpdateBalance(balance)
-	return balance, nil
+	return &base.UpstreamBalance{
+		Currency: "CNY",
+		Balance:  balance,
+		Details: map[string]float64{
+			"granted": granted,
+			"charged": charged,
+		},
+		ChannelBalance: balance,
+	}, nil
 }
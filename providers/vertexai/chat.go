@@ -11,6 +11,7 @@ import (
 )
 
 func (p *VertexAIProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	// 发送请求
 	response, errWithCode := p.Send(request)
@@ -24,6 +25,7 @@ func (p *VertexAIProvider) CreateChatCompletion(request *types.ChatCompletionReq
 }
 
 func (p *VertexAIProvider) CreateChatCompletionStream(request *types.ChatCompletionRequest) (requester.StreamReaderInterface[string], *types.OpenAIErrorWithStatusCode) {
+	request.ApplyReasoningEffortSuffix()
 	request.OneOtherArg = p.GetOtherArg()
 	// 发送请求
 	response, errWithCode := p.Send(request)
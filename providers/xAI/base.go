@@ -7,7 +7,6 @@ import (
 	"done-hub/providers/base"
 	"done-hub/providers/openai"
 	"done-hub/types"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -17,8 +16,7 @@ type XAIProviderFactory struct{}
 
 // 创建 XAIProvider
 func (f XAIProviderFactory) Create(channel *model.Channel) base.ProviderInterface {
-	fmt.Println("Creating XAIProvider for channel:")
-	return &XAIProvider{
+	provider := &XAIProvider{
 		OpenAIProvider: openai.OpenAIProvider{
 			BaseProvider: base.BaseProvider{
 				Config:    getConfig(),
@@ -29,6 +27,8 @@ func (f XAIProviderFactory) Create(channel *model.Channel) base.ProviderInterfac
 			RequestHandleBefore: requestHandler,
 		},
 	}
+	provider.ChatRequestBodyHandler = provider.getChatRequest
+	return provider
 }
 
 func getConfig() base.ProviderConfig {
@@ -66,6 +66,16 @@ func RequestErrorHandle(resp *http.Response) *types.OpenAIError {
 func usageHandler(usage *types.Usage) (ForcedFormatting bool) {
 	usage.CompletionTokens = usage.TotalTokens - usage.PromptTokens
 
+	// Live Search 按实际使用的来源数计费
+	if usage.NumSourcesUsed > 0 {
+		if usage.ExtraBilling == nil {
+			usage.ExtraBilling = make(map[string]types.ExtraBilling)
+		}
+		usage.ExtraBilling[types.APITollTypeLiveSearch] = types.ExtraBilling{
+			CallCount: usage.NumSourcesUsed,
+		}
+	}
+
 	return true
 }
 
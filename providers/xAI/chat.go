@@ -0,0 +1,152 @@
+package xAI
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/providers/openai"
+	"done-hub/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const deferredCompletionPath = "/v1/chat/deferred-completion/"
+
+// 延迟补全的轮询间隔和最长等待时间
+var (
+	deferredPollInterval = 2 * time.Second
+	deferredMaxWait      = 10 * time.Minute
+)
+
+type deferredSubmitResponse struct {
+	RequestId string `json:"request_id"`
+}
+
+// xAIChatRequest 追加 xAI 专属参数的聊天请求，参数从原始请求体中读取
+type xAIChatRequest struct {
+	*types.ChatCompletionRequest
+	SearchParameters any   `json:"search_parameters,omitempty"` // Live Search 参数
+	Deferred         *bool `json:"deferred,omitempty"`          // 延迟补全
+}
+
+// getChatRequest 从原始请求体读取 xAI 专属参数，流式请求不支持延迟补全
+func (p *XAIProvider) getChatRequest(request *types.ChatCompletionRequest) any {
+	chatRequest := &xAIChatRequest{ChatCompletionRequest: request}
+	if p.Context == nil {
+		return chatRequest
+	}
+	rawBody, ok := p.GetRawBody()
+	if !ok {
+		return chatRequest
+	}
+
+	var extra struct {
+		SearchParameters any   `json:"search_parameters"`
+		Deferred         *bool `json:"deferred"`
+	}
+	if err := json.Unmarshal(rawBody, &extra); err != nil {
+		return chatRequest
+	}
+	chatRequest.SearchParameters = extra.SearchParameters
+	if !request.Stream {
+		chatRequest.Deferred = extra.Deferred
+	}
+	return chatRequest
+}
+
+// CreateChatCompletion 请求携带 deferred: true 时使用 xAI 的延迟补全模式：
+// 先提交请求拿到 request_id，再由网关轮询结果，客户端最终得到普通的补全响应
+func (p *XAIProvider) CreateChatCompletion(request *types.ChatCompletionRequest) (*types.ChatCompletionResponse, *types.OpenAIErrorWithStatusCode) {
+	chatRequest := p.getChatRequest(request).(*xAIChatRequest)
+	if chatRequest.Deferred == nil || !*chatRequest.Deferred {
+		return p.OpenAIProvider.CreateChatCompletion(request)
+	}
+
+	if errWithCode := requestHandler(request); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req, errWithCode := p.GetRequestTextBody(config.RelayModeChatCompletions, request.Model, chatRequest)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	defer req.Body.Close()
+
+	submitResponse := &deferredSubmitResponse{}
+	_, errWithCode = p.Requester.SendRequest(req, submitResponse, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	if submitResponse.RequestId == "" {
+		return nil, common.StringErrorWrapper("deferred request_id is empty", "xAI_error", http.StatusInternalServerError)
+	}
+
+	response, errWithCode := p.pollDeferredCompletion(submitResponse.RequestId, request.Model)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if response.Usage == nil || response.Usage.TotalTokens == 0 {
+		response.Usage = &types.Usage{
+			PromptTokens: p.Usage.PromptTokens,
+		}
+		response.Usage.CompletionTokens = common.CountTokenText(response.GetContent(), request.Model)
+		response.Usage.TotalTokens = response.Usage.PromptTokens + response.Usage.CompletionTokens
+	} else {
+		usageHandler(response.Usage)
+	}
+
+	*p.Usage = *response.Usage
+	response.Model = p.GetResponseModelName(request.Model)
+
+	return &response.ChatCompletionResponse, nil
+}
+
+// pollDeferredCompletion 轮询延迟补全结果，上游返回 202 表示仍在处理中
+func (p *XAIProvider) pollDeferredCompletion(requestId, modelName string) (*openai.OpenAIProviderChatResponse, *types.OpenAIErrorWithStatusCode) {
+	fullRequestURL := p.GetFullRequestURL(deferredCompletionPath+requestId, modelName)
+	headers := p.GetRequestHeaders()
+	deadline := time.Now().Add(deferredMaxWait)
+
+	for {
+		req, err := p.Requester.NewRequest(http.MethodGet, fullRequestURL, p.Requester.WithHeader(headers))
+		if err != nil {
+			return nil, common.ErrorWrapper(err, "new_request_failed", http.StatusInternalServerError)
+		}
+
+		resp, errWithCode := p.Requester.SendRequestRaw(req)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			response := &openai.OpenAIProviderChatResponse{}
+			err = json.NewDecoder(resp.Body).Decode(response)
+			resp.Body.Close()
+			if err != nil {
+				return nil, common.ErrorWrapper(err, "decode_response_failed", http.StatusInternalServerError)
+			}
+			if openaiErr := openai.ErrorHandle(&response.OpenAIErrorResponse); openaiErr != nil {
+				return nil, &types.OpenAIErrorWithStatusCode{
+					OpenAIError: *openaiErr,
+					StatusCode:  http.StatusBadRequest,
+				}
+			}
+			return response, nil
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			return nil, common.ErrorWrapper(fmt.Errorf("unexpected deferred completion status: %d", resp.StatusCode), "xAI_error", http.StatusBadGateway)
+		}
+
+		if time.Now().Add(deferredPollInterval).After(deadline) {
+			return nil, common.ErrorWrapper(errors.New("deferred completion timed out"), "xAI_deferred_timeout", http.StatusGatewayTimeout)
+		}
+
+		// 与普通请求一致，客户端断开后仍继续等待结果以保证计费
+		time.Sleep(deferredPollInterval)
+	}
+}
@@ -0,0 +1,78 @@
+package xAI
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/requester"
+	"done-hub/model"
+	"done-hub/types"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeferredChatCompletion(t *testing.T) {
+	requester.HTTPClient = &http.Client{}
+	deferredPollInterval = 10 * time.Millisecond
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/chat/completions":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"deferred":true`) || !strings.Contains(string(body), `"search_parameters":{"mode":"auto"}`) {
+				t.Errorf("xAI parameters should be forwarded, got %s", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"request_id":"req-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == deferredCompletionPath+"req-1":
+			if polls.Add(1) < 2 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"chat-1","object":"chat.completion","model":"grok-3","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}],"usage":{"prompt_tokens":10,"completion_tokens":2,"total_tokens":15,"num_sources_used":3},"citations":["https://x.ai"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	proxy := ""
+	baseURL := server.URL
+	provider := XAIProviderFactory{}.Create(&model.Channel{Key: "xai-test", Proxy: &proxy, BaseURL: &baseURL}).(*XAIProvider)
+	usage := &types.Usage{}
+	provider.SetUsage(usage)
+
+	// xAI 专属参数从原始请求体读取
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	c.Set(config.GinRequestBodyKey, []byte(`{"model":"grok-3","deferred":true,"search_parameters":{"mode":"auto"}}`))
+	provider.SetContext(c)
+
+	response, errWithCode := provider.CreateChatCompletion(&types.ChatCompletionRequest{
+		Model:    "grok-3",
+		Messages: []types.ChatCompletionMessage{{Role: "user", Content: "hello"}},
+	})
+	if errWithCode != nil {
+		t.Fatalf("unexpected error: %s", errWithCode.Message)
+	}
+	if polls.Load() != 2 {
+		t.Fatalf("expected 2 polls, got %d", polls.Load())
+	}
+	if response.GetContent() != "hi" || response.Citations == nil {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if usage.CompletionTokens != 5 {
+		t.Fatalf("expected completion tokens to include reasoning, got %d", usage.CompletionTokens)
+	}
+	if usage.ExtraBilling[types.APITollTypeLiveSearch].CallCount != 3 {
+		t.Fatalf("expected 3 live search sources to be billed, got %+v", usage.ExtraBilling)
+	}
+}
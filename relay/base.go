@@ -110,6 +110,7 @@ func (r *relayBase) SetFirstResponseTime(firstResponseTime time.Time) {
 
 func (r *relayBase) GetError(err *types.OpenAIErrorWithStatusCode) (int, any) {
 	newErr := FilterOpenAIErr(r.c, err)
+	normalizeUpstreamError(r.c, err, &newErr)
 	return newErr.StatusCode, types.OpenAIErrorResponse{
 		Error: newErr.OpenAIError,
 	}
@@ -2,11 +2,10 @@ package relay
 
 import (
 	"done-hub/common"
-	"done-hub/common/config"
 	"done-hub/common/requester"
 	"done-hub/common/utils"
 	providersBase "done-hub/providers/base"
-	"done-hub/safty"
+	"done-hub/relay/relay_util"
 	"done-hub/types"
 	"encoding/json"
 	"errors"
@@ -51,6 +50,7 @@ func (r *relayChat) setRequest() error {
 	}
 
 	r.setOriginalModel(r.chatRequest.Model)
+	r.chatRequest.Messages = relay_util.CompressChatMessagesByToken(r.c, r.chatRequest.Messages, r.originalModel)
 
 	otherArg := r.getOtherArg()
 
@@ -110,19 +110,6 @@ func (r *relayChat) send() (err *types.OpenAIErrorWithStatusCode, done bool) {
 	}
 
 	r.chatRequest.Model = r.modelName
-	// 内容审查
-	if config.EnableSafe {
-		for _, message := range r.chatRequest.Messages {
-			if message.Content != nil {
-				CheckResult, _ := safty.CheckContent(message.Content)
-				if !CheckResult.IsSafe {
-					err = common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-					done = true
-					return
-				}
-			}
-		}
-	}
 
 	if r.chatRequest.Stream {
 		var response requester.StreamReaderInterface[string]
@@ -130,6 +117,7 @@ func (r *relayChat) send() (err *types.OpenAIErrorWithStatusCode, done bool) {
 		if err != nil {
 			return
 		}
+		response = relay_util.WrapReasoningFormatStream(response, relay_util.GetReasoningFormat(r.c))
 
 		if r.heartbeat != nil {
 			r.heartbeat.Stop()
@@ -152,6 +140,7 @@ func (r *relayChat) send() (err *types.OpenAIErrorWithStatusCode, done bool) {
 		if r.heartbeat != nil {
 			r.heartbeat.Stop()
 		}
+		relay_util.ApplyReasoningFormat(response, relay_util.GetReasoningFormat(r.c))
 
 		err = responseJsonClient(r.c, response)
 
@@ -196,6 +185,7 @@ func (r *relayChat) compatibleSend(resProvider providersBase.ResponsesInterface)
 		if err != nil {
 			return
 		}
+		response = relay_util.WrapReasoningFormatStream(response, relay_util.GetReasoningFormat(r.c))
 
 		if r.heartbeat != nil {
 			r.heartbeat.Stop()
@@ -218,7 +208,9 @@ func (r *relayChat) compatibleSend(resProvider providersBase.ResponsesInterface)
 		if r.heartbeat != nil {
 			r.heartbeat.Stop()
 		}
-		err = responseJsonClient(r.c, response.ToChat())
+		chatResponse := response.ToChat()
+		relay_util.ApplyReasoningFormat(chatResponse, relay_util.GetReasoningFormat(r.c))
+		err = responseJsonClient(r.c, chatResponse)
 	}
 
 	if err != nil {
@@ -14,7 +14,6 @@ import (
 	"done-hub/providers/openai"
 	"done-hub/providers/vertexai"
 	"done-hub/relay/transformer"
-	"done-hub/safty"
 	"done-hub/types"
 	"encoding/json"
 	"fmt"
@@ -110,12 +109,6 @@ func (r *relayClaudeOnly) send() (err *types.OpenAIErrorWithStatusCode, done boo
 	}
 
 	r.claudeRequest.Model = r.modelName
-	// 内容审查
-	if safetyErr := r.performContentSafety(); safetyErr != nil {
-		err = safetyErr
-		done = true
-		return
-	}
 
 	if r.claudeRequest.Stream {
 		var response requester.StreamReaderInterface[string]
@@ -183,23 +176,6 @@ func (r *relayClaudeOnly) HandleStreamError(err *types.OpenAIErrorWithStatusCode
 
 // 公共工具函数
 
-// performContentSafety 执行内容安全检查
-func (r *relayClaudeOnly) performContentSafety() *types.OpenAIErrorWithStatusCode {
-	if !config.EnableSafe {
-		return nil
-	}
-
-	for _, message := range r.claudeRequest.Messages {
-		if message.Content != nil {
-			CheckResult, _ := safty.CheckContent(message.Content)
-			if !CheckResult.IsSafe {
-				return common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-			}
-		}
-	}
-	return nil
-}
-
 // convertFinishReason 转换停止原因从OpenAI格式到Claude格式
 func convertFinishReason(finishReason string) string {
 	switch finishReason {
@@ -272,13 +248,6 @@ func (r *relayClaudeOnly) sendCustomChannelWithClaudeFormat() (err *types.OpenAI
 		return err, true
 	}
 
-	// 内容审查
-	if safetyErr := r.performContentSafety(); safetyErr != nil {
-		err = safetyErr
-		done = true
-		return
-	}
-
 	openaiRequest.Model = r.modelName
 
 	// 获取OpenAI provider来处理请求
@@ -750,6 +719,11 @@ skipThinking:
 		// 处理工具选择
 		if r.claudeRequest.ToolChoice != nil {
 			openaiRequest.ToolChoice = r.claudeRequest.ToolChoice
+			// disable_parallel_tool_use 对应 OpenAI 的 parallel_tool_calls=false
+			if r.claudeRequest.ToolChoice.DisableParallelToolUse {
+				parallelToolCalls := false
+				openaiRequest.ParallelToolCalls = &parallelToolCalls
+			}
 		}
 	}
 
@@ -1767,13 +1741,6 @@ func (r *relayClaudeOnly) sendVertexAIGeminiWithClaudeFormat() (err *types.OpenA
 	// 	return common.ErrorWrapper(transformErr, "request_transform_failed", http.StatusInternalServerError), true
 	// }
 
-	// 内容审查
-	if safetyErr := r.performContentSafety(); safetyErr != nil {
-		err = safetyErr
-		done = true
-		return
-	}
-
 	// 2. 直接调用 VertexAI API（暂时使用现有的 provider，后续可以优化为直接 HTTP 调用）
 	// 为了保持兼容性，我们先转换为 OpenAI 格式，然后使用现有的 provider
 	// VertexAI 使用不清理schema的转换方法，因为后续会有专门的 CleanGeminiRequestBytes 处理
@@ -1967,13 +1934,6 @@ func (r *relayClaudeOnly) sendGeminiWithClaudeFormat() (err *types.OpenAIErrorWi
 		return err, true
 	}
 
-	// 内容审查
-	if safetyErr := r.performContentSafety(); safetyErr != nil {
-		err = safetyErr
-		done = true
-		return
-	}
-
 	openaiRequest.Model = r.modelName
 
 	// 获取 Gemini provider
@@ -2037,13 +1997,6 @@ func (r *relayClaudeOnly) sendAntigravityWithClaudeFormat() (err *types.OpenAIEr
 		return err, true
 	}
 
-	// 内容审查
-	if safetyErr := r.performContentSafety(); safetyErr != nil {
-		err = safetyErr
-		done = true
-		return
-	}
-
 	openaiRequest.Model = r.modelName
 
 	// 获取 Antigravity provider
@@ -5,14 +5,17 @@ import (
 	"context"
 	"done-hub/common"
 	"done-hub/common/config"
+	"done-hub/common/hook"
 	"done-hub/common/logger"
 	"done-hub/common/requester"
+	"done-hub/common/storage"
 	"done-hub/common/utils"
 	"done-hub/controller"
 	"done-hub/metrics"
 	"done-hub/model"
 	"done-hub/providers"
 	providersBase "done-hub/providers/base"
+	"done-hub/relay/relay_util"
 	"done-hub/types"
 	"encoding/json"
 	"errors"
@@ -21,6 +24,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -60,6 +64,8 @@ func Path2Relay(c *gin.Context, path string) RelayBaseInterface {
 		}
 	} else if strings.HasPrefix(path, "/v1/responses") {
 		relay = NewRelayResponses(c)
+	} else if strings.HasPrefix(path, "/v1/ocr") {
+		relay = NewRelayOCR(c)
 	}
 
 	return relay
@@ -239,7 +245,12 @@ func fetchChannel(c *gin.Context, modelName string) (channel *model.Channel, fai
 	channelId := c.GetInt("specific_channel_id")
 	ignore := c.GetBool("specific_channel_id_ignore")
 	if channelId > 0 && !ignore {
-		return fetchChannelById(channelId)
+		channel, fail = fetchChannelById(channelId)
+		// 管理员指定渠道时也不能使用其他租户的渠道
+		if fail == nil && config.MultiTenantEnabled && channel.TenantId != c.GetInt("tenant_id") {
+			return nil, errors.New(model.ErrInvalidChannelId)
+		}
+		return
 	}
 
 	return fetchChannelByModel(c, modelName)
@@ -279,6 +290,10 @@ func buildChannelFilters(c *gin.Context, modelName string) []model.ChannelsFilte
 		filters = append(filters, model.FilterDisabledStream(modelName))
 	}
 
+	if config.MultiTenantEnabled {
+		filters = append(filters, model.FilterTenant(c.GetInt("tenant_id")))
+	}
+
 	return filters
 }
 
@@ -301,6 +316,26 @@ func fetchChannelByModel(c *gin.Context, modelName string) (*model.Channel, erro
 	return channel, nil
 }
 
+// mirrorImageResponse 上游返回的图片链接会过期，开启转存后替换为自有存储的链接
+func mirrorImageResponse(c *gin.Context, response *types.ImageResponse) {
+	if response == nil || !storage.MirrorEnabled() {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range response.Data {
+		if response.Data[i].URL == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(item *types.ImageResponseDataInner) {
+			defer wg.Done()
+			item.URL = storage.MirrorURL(c.Request.Context(), item.URL)
+		}(&response.Data[i])
+	}
+	wg.Wait()
+}
+
 func responseJsonClient(c *gin.Context, data interface{}) *types.OpenAIErrorWithStatusCode {
 	// 将data转换为 JSON，禁用 HTML 转义以避免 & 被转为 \u0026
 	var buf bytes.Buffer
@@ -340,6 +375,11 @@ func responseStreamClient(c *gin.Context, stream requester.StreamReaderInterface
 	ctx := c.Request.Context()
 	clientDisconnected := false
 
+	keepAlive, stopKeepAlive := newStreamKeepAlive()
+	defer stopKeepAlive()
+	// 此时上游已返回流式响应，从流开始计时，首个数据到达前上游停顿也会发送保活
+	lastWrite := time.Now()
+
 	go func() {
 		defer close(done)
 
@@ -363,6 +403,7 @@ func responseStreamClient(c *gin.Context, stream requester.StreamReaderInterface
 					default:
 						c.Writer.Write([]byte("data: " + data + "\n\n"))
 						c.Writer.Flush()
+						lastWrite = time.Now()
 					}
 				}
 
@@ -401,6 +442,14 @@ func responseStreamClient(c *gin.Context, stream requester.StreamReaderInterface
 				}
 				return
 
+			case <-keepAlive:
+				// 上游长时间无输出时发送 SSE 注释行保活
+				if !clientDisconnected && time.Since(lastWrite) >= streamKeepAliveInterval() {
+					c.Writer.Write([]byte(relay_util.KeepAliveStreamText))
+					c.Writer.Flush()
+					lastWrite = time.Now()
+				}
+
 			case <-ctx.Done():
 				clientDisconnected = true
 			}
@@ -422,6 +471,11 @@ func responseGeneralStreamClient(c *gin.Context, stream requester.StreamReaderIn
 	ctx := c.Request.Context()
 	clientDisconnected := false
 
+	keepAlive, stopKeepAlive := newStreamKeepAlive()
+	defer stopKeepAlive()
+	// 此时上游已返回流式响应，从流开始计时，首个数据到达前上游停顿也会发送保活
+	lastWrite := time.Now()
+
 	go func() {
 		defer close(done)
 
@@ -442,6 +496,7 @@ func responseGeneralStreamClient(c *gin.Context, stream requester.StreamReaderIn
 					default:
 						fmt.Fprint(c.Writer, data)
 						c.Writer.Flush()
+						lastWrite = time.Now()
 					}
 				}
 
@@ -471,6 +526,14 @@ func responseGeneralStreamClient(c *gin.Context, stream requester.StreamReaderIn
 				}
 				return
 
+			case <-keepAlive:
+				// 上游长时间无输出时发送 SSE 注释行保活
+				if !clientDisconnected && time.Since(lastWrite) >= streamKeepAliveInterval() {
+					c.Writer.Write([]byte(relay_util.KeepAliveStreamText))
+					c.Writer.Flush()
+					lastWrite = time.Now()
+				}
+
 			case <-ctx.Done():
 				clientDisconnected = true
 			}
@@ -535,11 +598,20 @@ func shouldRetry(c *gin.Context, apiErr *types.OpenAIErrorWithStatusCode, channe
 
 	metrics.RecordProvider(c, apiErr.StatusCode)
 
+	if code, ok := apiErr.Code.(string); ok && code == hook.ErrCodeSkipChannel {
+		return channelId == 0 || ignore
+	}
+
 	if apiErr.LocalError ||
 		(channelId > 0 && !ignore) {
 		return false
 	}
 
+	// 流式请求等待首字节超时时还未向客户端输出，可以切换渠道
+	if code, ok := apiErr.Code.(string); ok && code == requester.ErrCodeFirstByteTimeout {
+		return true
+	}
+
 	switch apiErr.StatusCode {
 	case http.StatusTooManyRequests, http.StatusTemporaryRedirect:
 		return true
@@ -848,3 +920,19 @@ func mergeCustomParamsForPreMapping(requestMap map[string]interface{}, customPar
 
 	return requestMap
 }
+
+// newStreamKeepAlive 按全局配置创建保活定时器，未开启时返回 nil 通道（永不触发）
+func newStreamKeepAlive() (<-chan time.Time, func()) {
+	interval := streamKeepAliveInterval()
+	if interval <= 0 {
+		return nil, func() {}
+	}
+
+	// 以半个间隔检查，保证两次输出之间的空闲时间不超过 1.5 倍间隔
+	ticker := time.NewTicker(interval / 2)
+	return ticker.C, ticker.Stop
+}
+
+func streamKeepAliveInterval() time.Duration {
+	return time.Duration(config.StreamKeepAliveSeconds) * time.Second
+}
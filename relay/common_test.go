@@ -0,0 +1,58 @@
+package relay
+
+import (
+	"done-hub/common/config"
+	"done-hub/relay/relay_util"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stalledStream 先停顿一段时间再输出一条数据
+type stalledStream struct {
+	delay time.Duration
+}
+
+func (s *stalledStream) Recv() (<-chan string, <-chan error) {
+	dataChan := make(chan string)
+	errChan := make(chan error)
+	go func() {
+		time.Sleep(s.delay)
+		dataChan <- `{"id":"1"}`
+		errChan <- io.EOF
+	}()
+	return dataChan, errChan
+}
+
+func (s *stalledStream) Close() {}
+
+// TestResponseStreamClientKeepAlive 测试上游在首个数据前停顿时也会发送保活
+func TestResponseStreamClientKeepAlive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originSeconds := config.StreamKeepAliveSeconds
+	t.Cleanup(func() { config.StreamKeepAliveSeconds = originSeconds })
+	config.StreamKeepAliveSeconds = 1
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+	if _, err := responseStreamClient(c, &stalledStream{delay: 1600 * time.Millisecond}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	body := w.Body.String()
+	ping := strings.Index(body, relay_util.KeepAliveStreamText)
+	data := strings.Index(body, "data: ")
+	if ping < 0 || ping > data {
+		t.Fatalf("expected keep-alive before the first chunk, got %q", body)
+	}
+	if !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Fatalf("stream should end with [DONE], got %q", body)
+	}
+}
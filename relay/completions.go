@@ -2,11 +2,9 @@ package relay
 
 import (
 	"done-hub/common"
-	"done-hub/common/config"
 	"done-hub/common/requester"
 	"done-hub/common/utils"
 	providersBase "done-hub/providers/base"
-	"done-hub/safty"
 	"done-hub/types"
 	"encoding/json"
 	"errors"
@@ -69,18 +67,6 @@ func (r *relayCompletions) send() (err *types.OpenAIErrorWithStatusCode, done bo
 
 	r.request.Model = r.modelName
 
-	// 内容审查
-	if config.EnableSafe {
-		if r.request.Prompt != nil {
-			CheckResult, _ := safty.CheckContent(r.request.Prompt)
-			if !CheckResult.IsSafe {
-				err = common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-				done = true
-				return
-			}
-		}
-	}
-
 	if r.request.Stream {
 		var response requester.StreamReaderInterface[string]
 		response, err = provider.CreateCompletionStream(&r.request)
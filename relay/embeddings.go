@@ -2,9 +2,7 @@ package relay
 
 import (
 	"done-hub/common"
-	"done-hub/common/config"
 	providersBase "done-hub/providers/base"
-	"done-hub/safty"
 	"done-hub/types"
 	"net/http"
 	"strings"
@@ -49,18 +47,6 @@ func (r *relayEmbeddings) send() (err *types.OpenAIErrorWithStatusCode, done boo
 		return
 	}
 
-	// 内容审查
-	if config.EnableSafe {
-		if r.request.Input != nil {
-			CheckResult, _ := safty.CheckContent(r.request)
-			if !CheckResult.IsSafe {
-				err = common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-				done = true
-				return
-			}
-		}
-	}
-
 	r.request.Model = r.modelName
 
 	response, err := provider.CreateEmbeddings(&r.request)
@@ -0,0 +1,132 @@
+package relay
+
+import (
+	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"done-hub/types"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 统一的上游错误码，写入 OpenAI 格式错误的 code 字段，客户端无需再按渠道类型区分错误格式
+const (
+	ErrorCodeInvalidRequest        = "invalid_request"
+	ErrorCodeContextLengthExceeded = "context_length_exceeded"
+	ErrorCodeContentFiltered       = "content_filtered"
+	ErrorCodeModelNotFound         = "model_not_found"
+	ErrorCodeUpstreamAuth          = "upstream_auth_error"
+	ErrorCodeRateLimited           = "rate_limited"
+	ErrorCodeUpstreamOverloaded    = "upstream_overloaded"
+	ErrorCodeUpstreamTimeout       = "upstream_timeout"
+	ErrorCodeUpstreamError         = "upstream_error"
+)
+
+// 各错误码对应的 OpenAI 错误类型
+var errorCodeTypes = map[string]string{
+	ErrorCodeInvalidRequest:        "invalid_request_error",
+	ErrorCodeContextLengthExceeded: "invalid_request_error",
+	ErrorCodeContentFiltered:       "invalid_request_error",
+	ErrorCodeModelNotFound:         "invalid_request_error",
+	ErrorCodeUpstreamAuth:          "server_error",
+	ErrorCodeRateLimited:           "rate_limit_error",
+	ErrorCodeUpstreamOverloaded:    "server_error",
+	ErrorCodeUpstreamTimeout:       "server_error",
+	ErrorCodeUpstreamError:         "server_error",
+}
+
+// 各家错误中的类型/状态/异常名，统一转为小写后匹配
+// Anthropic: error.type；Gemini: error.status；Azure/OpenAI: error.code；Bedrock: x-amzn-ErrorType
+var upstreamErrorKeywords = []struct {
+	code     string
+	keywords []string
+}{
+	{ErrorCodeContextLengthExceeded, []string{"context_length_exceeded", "request_too_large", "string_above_max_length"}},
+	{ErrorCodeContentFiltered, []string{"content_filter", "content_policy_violation", "safety", "responsible_ai_policy_violation"}},
+	{ErrorCodeModelNotFound, []string{"not_found_error", "not_found", "deploymentnotfound", "model_not_found", "resourcenotfoundexception"}},
+	{ErrorCodeUpstreamAuth, []string{"authentication_error", "permission_error", "permission_denied", "unauthenticated", "invalid_api_key", "accessdeniedexception", "unrecognizedclientexception"}},
+	{ErrorCodeRateLimited, []string{"rate_limit_error", "rate_limit_exceeded", "resource_exhausted", "throttlingexception", "servicequotaexceededexception", "insufficient_quota"}},
+	{ErrorCodeUpstreamOverloaded, []string{"overloaded_error", "unavailable", "serviceunavailableexception", "modelnotreadyexception"}},
+	{ErrorCodeUpstreamTimeout, []string{"deadline_exceeded", "modeltimeoutexception", "timeout"}},
+	{ErrorCodeInvalidRequest, []string{"invalid_request_error", "invalid_argument", "failed_precondition", "validationexception", "badrequest"}},
+	{ErrorCodeUpstreamError, []string{"api_error", "internal", "internalserverexception", "modelerrorexception", "server_error"}},
+}
+
+// 部分上游只在错误信息中说明上下文超长
+var contextLengthMessages = []string{
+	"maximum context length",
+	"context length",
+	"prompt is too long",
+	"input is too long",
+	"exceeds the maximum number of tokens",
+	"too many tokens",
+}
+
+// classifyUpstreamError 根据上游错误的类型、状态码和信息归类为统一错误码
+func classifyUpstreamError(err *types.OpenAIErrorWithStatusCode) string {
+	fields := []string{err.Type, err.Param}
+	if err.Code != nil {
+		fields = append(fields, fmt.Sprint(err.Code))
+	}
+
+	message := strings.ToLower(err.Message)
+	if utils.ContainsString(message, contextLengthMessages) {
+		return ErrorCodeContextLengthExceeded
+	}
+
+	for _, item := range upstreamErrorKeywords {
+		for _, field := range fields {
+			field = strings.ToLower(field)
+			if field == "" {
+				continue
+			}
+			for _, keyword := range item.keywords {
+				if strings.Contains(field, keyword) {
+					return item.code
+				}
+			}
+		}
+	}
+
+	switch {
+	case err.StatusCode == http.StatusBadRequest, err.StatusCode == http.StatusUnprocessableEntity:
+		return ErrorCodeInvalidRequest
+	case err.StatusCode == http.StatusRequestEntityTooLarge:
+		return ErrorCodeContextLengthExceeded
+	case err.StatusCode == http.StatusNotFound:
+		return ErrorCodeModelNotFound
+	case err.StatusCode == http.StatusUnauthorized, err.StatusCode == http.StatusForbidden:
+		return ErrorCodeUpstreamAuth
+	case err.StatusCode == http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case err.StatusCode == http.StatusRequestTimeout, err.StatusCode == http.StatusGatewayTimeout:
+		return ErrorCodeUpstreamTimeout
+	case err.StatusCode == http.StatusServiceUnavailable, err.StatusCode == 529:
+		return ErrorCodeUpstreamOverloaded
+	default:
+		return ErrorCodeUpstreamError
+	}
+}
+
+// normalizeUpstreamError 记录上游原始错误，并将过滤后的错误统一为 OpenAI 格式的 type 和 code
+// 本地错误保持原样；过滤时改写了状态码（如隐藏渠道密钥失效）则按新的状态码归类
+func normalizeUpstreamError(c *gin.Context, raw *types.OpenAIErrorWithStatusCode, filtered *types.OpenAIErrorWithStatusCode) {
+	if raw == nil || raw.LocalError || filtered.LocalError {
+		return
+	}
+
+	logger.LogError(c.Request.Context(), fmt.Sprintf("upstream_error_raw channel_id=%d channel_type=%d status_code=%d type=\"%s\" code=\"%v\" param=\"%s\" error=\"%s\"",
+		c.GetInt("channel_id"), c.GetInt("channel_type"), raw.StatusCode, raw.Type, raw.Code, raw.Param, utils.TruncateBase64InMessage(raw.Message)))
+
+	var code string
+	if filtered.StatusCode == raw.StatusCode {
+		code = classifyUpstreamError(raw)
+	} else {
+		code = classifyUpstreamError(&types.OpenAIErrorWithStatusCode{StatusCode: filtered.StatusCode})
+	}
+
+	filtered.Code = code
+	filtered.Type = errorCodeTypes[code]
+}
@@ -0,0 +1,48 @@
+package relay
+
+import (
+	"done-hub/types"
+	"testing"
+)
+
+// TestClassifyUpstreamError 测试各家上游错误归类为统一错误码
+func TestClassifyUpstreamError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  types.OpenAIErrorWithStatusCode
+		want string
+	}{
+		{"anthropic overloaded", upstreamErr(529, "overloaded_error", "error", "", "Overloaded"), ErrorCodeUpstreamOverloaded},
+		{"anthropic rate limit", upstreamErr(429, "rate_limit_error", "error", "", "Number of request tokens has exceeded your per-minute rate limit"), ErrorCodeRateLimited},
+		{"anthropic prompt too long", upstreamErr(400, "invalid_request_error", "error", "", "prompt is too long: 210000 tokens > 200000 maximum"), ErrorCodeContextLengthExceeded},
+		{"gemini resource exhausted", upstreamErr(429, "gemini_error", 429, "RESOURCE_EXHAUSTED", "Resource has been exhausted"), ErrorCodeRateLimited},
+		{"gemini invalid argument", upstreamErr(400, "gemini_error", 400, "INVALID_ARGUMENT", "Invalid JSON payload received"), ErrorCodeInvalidRequest},
+		{"gemini permission denied", upstreamErr(403, "gemini_error", 403, "PERMISSION_DENIED", "Permission denied"), ErrorCodeUpstreamAuth},
+		{"azure content filter", upstreamErr(400, "", "content_filter", "prompt", "The response was filtered"), ErrorCodeContentFiltered},
+		{"azure deployment not found", upstreamErr(404, "", "DeploymentNotFound", "", "The API deployment for this resource does not exist"), ErrorCodeModelNotFound},
+		{"bedrock throttling", upstreamErr(429, "Bedrock Error", "ThrottlingException", "", "Too many requests"), ErrorCodeRateLimited},
+		{"bedrock timeout", upstreamErr(408, "Bedrock Error", "ModelTimeoutException", "", "Model has timed out"), ErrorCodeUpstreamTimeout},
+		{"unknown by status", upstreamErr(502, "upstream_error", "bad_response_status_code", "502", "bad response status code 502"), ErrorCodeUpstreamError},
+		{"unknown not found", upstreamErr(404, "upstream_error", "bad_response_status_code", "404", "bad response status code 404"), ErrorCodeModelNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyUpstreamError(&tt.err); got != tt.want {
+				t.Errorf("classifyUpstreamError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func upstreamErr(statusCode int, errType string, code any, param, message string) types.OpenAIErrorWithStatusCode {
+	return types.OpenAIErrorWithStatusCode{
+		StatusCode: statusCode,
+		OpenAIError: types.OpenAIError{
+			Type:    errType,
+			Code:    code,
+			Param:   param,
+			Message: message,
+		},
+	}
+}
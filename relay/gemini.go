@@ -5,11 +5,9 @@ import (
 	"done-hub/common/config"
 	"done-hub/common/requester"
 	"done-hub/providers/gemini"
-	"done-hub/safty"
 	"done-hub/types"
 	"encoding/json"
 	"errors"
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -101,23 +99,6 @@ func (r *relayGeminiOnly) send() (err *types.OpenAIErrorWithStatusCode, done boo
 		return nil, false
 	}
 
-	// 内容审查：使用 gjson 直接在原始 bytes 上提取 text，避免完整 JSON 反序列化
-	if config.EnableSafe {
-		contents := gjson.GetBytes(r.requestBody, "contents")
-		for _, content := range contents.Array() {
-			for _, part := range content.Get("parts").Array() {
-				if text := part.Get("text").String(); text != "" {
-					CheckResult, _ := safty.CheckContent(text)
-					if !CheckResult.IsSafe {
-						err = common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-						done = true
-						return
-					}
-				}
-			}
-		}
-	}
-
 	r.geminiRequest.Model = r.modelName
 
 	if r.geminiRequest.Stream {
@@ -0,0 +1,41 @@
+package relay
+
+import (
+	"done-hub/common"
+	"done-hub/common/hook"
+	"done-hub/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// runPreUpstreamHook 执行 pre_upstream 阶段的扩展钩子，拒绝请求时返回 done，跳过渠道时返回可以切换渠道重试的错误
+func runPreUpstreamHook(c *gin.Context) (*types.OpenAIErrorWithStatusCode, bool) {
+	if !hook.Enabled(hook.StagePreUpstream) {
+		return nil, false
+	}
+
+	result := hook.RunWithContext(c, hook.NewEvent(c, hook.StagePreUpstream))
+	switch {
+	case result.Reject:
+		return common.StringErrorWrapperLocal(result.Message, "hook_rejected", result.StatusCode), true
+	case result.SkipChannel:
+		return common.StringErrorWrapperLocal(result.Message, hook.ErrCodeSkipChannel, http.StatusServiceUnavailable), false
+	}
+	return nil, false
+}
+
+// runPostUpstreamHook 执行 post_upstream 阶段的扩展钩子，返回的元数据会记录到消费日志
+func runPostUpstreamHook(c *gin.Context, apiErr *types.OpenAIErrorWithStatusCode) {
+	if !hook.Enabled(hook.StagePostUpstream) {
+		return
+	}
+
+	event := hook.NewEvent(c, hook.StagePostUpstream)
+	event.StatusCode = http.StatusOK
+	if apiErr != nil {
+		event.StatusCode = apiErr.StatusCode
+		event.Error = apiErr.OpenAIError.Message
+	}
+	hook.RunWithContext(c, event)
+}
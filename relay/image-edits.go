@@ -3,6 +3,7 @@ package relay
 import (
 	"done-hub/common"
 	providersBase "done-hub/providers/base"
+	"done-hub/relay/relay_util"
 	"done-hub/types"
 	"errors"
 	"net/http"
@@ -38,6 +39,7 @@ func (r *relayImageEdits) setRequest() error {
 		r.request.Size = "1024x1024"
 	}
 
+	r.c.Set(relay_util.ImageCountContextKey, r.request.N)
 	r.setOriginalModel(r.request.Model)
 
 	return nil
@@ -62,6 +64,8 @@ func (r *relayImageEdits) send() (err *types.OpenAIErrorWithStatusCode, done boo
 	if err != nil {
 		return
 	}
+	r.c.Set(relay_util.GeneratedImageCountContextKey, len(response.Data))
+	mirrorImageResponse(r.c, response)
 	err = responseJsonClient(r.c, response)
 
 	if err != nil {
@@ -3,6 +3,7 @@ package relay
 import (
 	"done-hub/common"
 	providersBase "done-hub/providers/base"
+	"done-hub/relay/relay_util"
 	"done-hub/types"
 	"errors"
 	"net/http"
@@ -52,6 +53,7 @@ func (r *relayImageGenerations) setRequest() error {
 		}
 	}
 
+	r.c.Set(relay_util.ImageCountContextKey, r.request.N)
 	r.setOriginalModel(r.request.Model)
 
 	return nil
@@ -117,6 +119,7 @@ func (r *relayImageGenerations) setGeminiRequest() error {
 		r.request.N = 1
 	}
 
+	r.c.Set(relay_util.ImageCountContextKey, r.request.N)
 	r.setOriginalModel(r.request.Model)
 
 	return nil
@@ -141,6 +144,8 @@ func (r *relayImageGenerations) send() (err *types.OpenAIErrorWithStatusCode, do
 	if err != nil {
 		return
 	}
+	r.c.Set(relay_util.GeneratedImageCountContextKey, len(response.Data))
+	mirrorImageResponse(r.c, response)
 	err = responseJsonClient(r.c, response)
 
 	if err != nil {
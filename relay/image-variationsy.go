@@ -3,6 +3,7 @@ package relay
 import (
 	"done-hub/common"
 	providersBase "done-hub/providers/base"
+	"done-hub/relay/relay_util"
 	"done-hub/types"
 	"net/http"
 
@@ -33,6 +34,7 @@ func (r *relayImageVariations) setRequest() error {
 		r.request.Size = "1024x1024"
 	}
 
+	r.c.Set(relay_util.ImageCountContextKey, r.request.N)
 	r.setOriginalModel(r.request.Model)
 
 	return nil
@@ -60,6 +62,8 @@ func (r *relayImageVariations) send() (err *types.OpenAIErrorWithStatusCode, don
 	if err != nil {
 		return
 	}
+	r.c.Set(relay_util.GeneratedImageCountContextKey, len(response.Data))
+	mirrorImageResponse(r.c, response)
 	err = responseJsonClient(r.c, response)
 
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"done-hub/common"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/logstream"
 	"done-hub/common/utils"
 	"done-hub/metrics"
 	"done-hub/model"
@@ -49,6 +50,7 @@ func Relay(c *gin.Context) {
 	c.Set("is_stream", relay.IsStream())
 	if err := relay.setProvider(relay.getOriginalModel()); err != nil {
 		openaiErr := common.StringErrorWrapperLocal(err.Error(), "one_hub_error", http.StatusServiceUnavailable)
+		publishRelayError(c, 0, relay.getOriginalModel(), openaiErr)
 		relay.HandleJsonError(openaiErr)
 		return
 	}
@@ -59,13 +61,15 @@ func Relay(c *gin.Context) {
 	}
 
 	apiErr, done := RelayHandler(relay)
+	channel := relay.getProvider().GetChannel()
 	if apiErr == nil {
 		metrics.RecordProvider(c, 200)
 		return
 	}
 
-	channel := relay.getProvider().GetChannel()
 	go processChannelRelayError(c.Request.Context(), channel.Id, channel.Name, c.GetString("new_model"), apiErr, channel.Type)
+	recordFailoverHop(c, channel.Id, apiErr)
+	publishRelayError(c, channel.Id, relay.getModelName(), apiErr)
 
 	retryTimes := config.RetryTimes
 	// 在重试开始前计算并缓存总渠道数，避免重试过程中动态变化
@@ -159,6 +163,8 @@ func Relay(c *gin.Context) {
 		}
 
 		go processChannelRelayError(c.Request.Context(), channel.Id, channel.Name, modelName, apiErr, channel.Type)
+		recordFailoverHop(c, channel.Id, apiErr)
+		publishRelayError(c, channel.Id, relay.getModelName(), apiErr)
 		if done || !shouldRetry(c, apiErr, channel.Type) {
 			logger.LogError(c.Request.Context(), fmt.Sprintf("retry_stop_condition model=%s channel_id=%d attempt=%d/%d done=%t should_retry=%t",
 				modelName, channel.Id, attemptCount, actualRetryTimes, done, shouldRetry(c, apiErr, channel.Type)))
@@ -189,6 +195,19 @@ func Relay(c *gin.Context) {
 }
 
 func RelayHandler(relay RelayBaseInterface) (err *types.OpenAIErrorWithStatusCode, done bool) {
+	// 半开状态的熔断器只允许少量探测请求，名额在本次请求结束时释放
+	channelId := relay.getProvider().GetChannel().Id
+	release := model.ChannelCircuitBreaker.Acquire(channelId)
+	defer func() {
+		recordChannelCircuit(channelId, err)
+		release()
+		relay.getContext().Set(config.GinRelayFailedKey, err != nil)
+	}()
+
+	if err, done = runPreUpstreamHook(relay.getContext()); err != nil {
+		return
+	}
+
 	promptTokens, tonkeErr := relay.getPromptTokens()
 	if tonkeErr != nil {
 		err = common.ErrorWrapperLocal(tonkeErr, "token_error", http.StatusBadRequest)
@@ -208,11 +227,21 @@ func RelayHandler(relay RelayBaseInterface) (err *types.OpenAIErrorWithStatusCod
 		return
 	}
 
+	quota.StartUpstream()
 	err, done = relay.send()
+	quota.EndUpstream()
+	runPostUpstreamHook(relay.getContext(), err)
 	// 最后处理流式中断时计算tokens
 	if usage.CompletionTokens == 0 && usage.TextBuilder.Len() > 0 {
 		usage.CompletionTokens = common.CountTokenText(usage.TextBuilder.String(), relay.getModelName())
 		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		quota.SetUsageEstimated(true)
+	}
+	// 上游返回的 usage 缺少 prompt tokens 时使用本地计算的值，避免少计费
+	if usage.PromptTokens == 0 && promptTokens > 0 && usage.CompletionTokens > 0 {
+		usage.PromptTokens = promptTokens
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		quota.SetUsageEstimated(true)
 	}
 
 	// 即使出错，只要有实际输出就记录计费，避免上游已计费但本地未记录
@@ -233,6 +262,55 @@ func RelayHandler(relay RelayBaseInterface) (err *types.OpenAIErrorWithStatusCod
 	return
 }
 
+// recordFailoverHop 记录失败的渠道，重试成功时写入消费日志
+func recordFailoverHop(c *gin.Context, channelId int, apiErr *types.OpenAIErrorWithStatusCode) {
+	path, _ := utils.GetGinValue[[]relay_util.FailoverHop](c, relay_util.FailoverPathContextKey)
+	hop := relay_util.FailoverHop{
+		ChannelId:  channelId,
+		StatusCode: apiErr.StatusCode,
+	}
+	if code, ok := apiErr.Code.(string); ok {
+		hop.Code = code
+	}
+	c.Set(relay_util.FailoverPathContextKey, append(path, hop))
+}
+
+// publishRelayError 推送失败的中继请求到实时日志
+func publishRelayError(c *gin.Context, channelId int, modelName string, apiErr *types.OpenAIErrorWithStatusCode) {
+	model.PublishLogEvent(&logstream.Event{
+		Type:       logstream.EventTypeError,
+		RequestId:  c.GetString(logger.RequestIdKey),
+		UserId:     c.GetInt("id"),
+		TokenName:  c.GetString("token_name"),
+		ModelName:  modelName,
+		ChannelId:  channelId,
+		IsStream:   c.GetBool("is_stream"),
+		SourceIp:   c.ClientIP(),
+		Attempt:    max(c.GetInt("attempt_count"), 1),
+		StatusCode: apiErr.StatusCode,
+		Error:      apiErr.OpenAIError.Message,
+	})
+}
+
+// recordChannelCircuit 将上游请求结果计入渠道熔断器，本地错误和客户端错误不计为失败
+func recordChannelCircuit(channelId int, apiErr *types.OpenAIErrorWithStatusCode) {
+	if apiErr != nil && apiErr.LocalError {
+		return
+	}
+
+	failed := false
+	if apiErr != nil {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode == http.StatusRequestTimeout,
+			apiErr.StatusCode >= http.StatusInternalServerError:
+			failed = true
+		}
+	}
+
+	model.ChannelCircuitBreaker.Record(channelId, failed)
+}
+
 func shouldCooldowns(c *gin.Context, channel *model.Channel, apiErr *types.OpenAIErrorWithStatusCode) bool {
 	modelName := c.GetString("new_model")
 	channelId := channel.Id
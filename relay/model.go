@@ -25,6 +25,14 @@ type OpenAIModels struct {
 	Object  string  `json:"object"`
 	Created int     `json:"created"`
 	OwnedBy *string `json:"owned_by"`
+
+	// 扩展字段，未配置模型信息时不返回
+	ContextLength    int                `json:"context_length,omitempty"`
+	MaxTokens        int                `json:"max_tokens,omitempty"`
+	InputModalities  []string           `json:"input_modalities,omitempty"`
+	OutputModalities []string           `json:"output_modalities,omitempty"`
+	Capabilities     *ModelCapabilities `json:"capabilities,omitempty"`
+	Pricing          *ModelPricing      `json:"pricing,omitempty"`
 }
 
 // filterModelsByTokenLimit 根据令牌的模型限制过滤模型列表
@@ -93,7 +101,7 @@ func ListModelsByToken(c *gin.Context) {
 
 	var groupOpenAIModels []*OpenAIModels
 	for _, modelName := range models {
-		groupOpenAIModels = append(groupOpenAIModels, getOpenAIModelWithName(modelName))
+		groupOpenAIModels = append(groupOpenAIModels, withModelMetadata(c, getOpenAIModelWithName(modelName)))
 	}
 
 	// 根据 OwnedBy 排序
@@ -228,8 +236,10 @@ func ListModelsForAdmin(c *gin.Context) {
 func RetrieveModel(c *gin.Context) {
 	modelName := c.Param("model")
 	openaiModel := getOpenAIModelWithName(modelName)
-	if *openaiModel.OwnedBy != model.UnknownOwnedBy {
-		c.JSON(200, openaiModel)
+	// 令牌限制了可用模型时，不返回未授权的模型
+	allowed := len(filterModelsByTokenLimit(c, []string{modelName})) > 0
+	if allowed && *openaiModel.OwnedBy != model.UnknownOwnedBy {
+		c.JSON(200, withModelMetadata(c, openaiModel))
 	} else {
 		openAIError := types.OpenAIError{
 			Message: fmt.Sprintf("The model '%s' does not exist", modelName),
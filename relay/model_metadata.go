@@ -0,0 +1,98 @@
+package relay
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModelCapabilities 模型支持的能力，根据模型信息中的标签和输入模态推断
+type ModelCapabilities struct {
+	Tools      bool `json:"tools"`
+	Vision     bool `json:"vision"`
+	JSONSchema bool `json:"json_schema"`
+}
+
+// ModelPricing 调用方实际支付的美元价格，已计入分组倍率
+// tokens 计费时为每百万 Token 的价格，times、images 计费时 input 为每次或每张的价格
+type ModelPricing struct {
+	Type       string  `json:"type"`
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	GroupRatio float64 `json:"group_ratio"`
+}
+
+var capabilityTags = map[string][]string{
+	"tools":       {"tools", "tool_use", "function_call", "function_calling", "functions"},
+	"vision":      {"vision", "image_input"},
+	"json_schema": {"json_schema", "structured_outputs", "structured_output"},
+}
+
+// withModelMetadata 为模型列表补充上下文长度、模态、能力和价格信息，多租户模式下使用租户的价格
+func withModelMetadata(c *gin.Context, openAIModel *OpenAIModels) *OpenAIModels {
+	price := model.GetTenantPrice(c.GetInt("tenant_id"), openAIModel.Id)
+	openAIModel.Pricing = getModelPricing(price, c.GetFloat64("group_ratio"))
+
+	info := price.ModelInfo
+	if info == nil {
+		return openAIModel
+	}
+
+	openAIModel.ContextLength = info.ContextLength
+	openAIModel.MaxTokens = info.MaxTokens
+	openAIModel.InputModalities = info.InputModalities
+	openAIModel.OutputModalities = info.OutputModalities
+	openAIModel.Capabilities = getModelCapabilities(info)
+
+	return openAIModel
+}
+
+func getModelPricing(price *model.Price, groupRatio float64) *ModelPricing {
+	if groupRatio <= 0 {
+		groupRatio = 1
+	}
+
+	pricing := &ModelPricing{
+		Type:       price.Type,
+		GroupRatio: groupRatio,
+	}
+	if price.Type == model.TokensPriceType {
+		pricing.Input = price.GetInput() * groupRatio * 1000000 / config.QuotaPerUnit
+		pricing.Output = price.GetOutput() * groupRatio * 1000000 / config.QuotaPerUnit
+	} else {
+		// 按次和按张计费时每次扣除 1000 倍的输入倍率
+		pricing.Input = price.GetInput() * groupRatio * 1000 / config.QuotaPerUnit
+	}
+
+	return pricing
+}
+
+func getModelCapabilities(info *model.ModelInfoResponse) *ModelCapabilities {
+	tags := make(map[string]bool, len(info.Tags))
+	for _, tag := range info.Tags {
+		tags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	hasTag := func(capability string) bool {
+		for _, tag := range capabilityTags[capability] {
+			if tags[tag] {
+				return true
+			}
+		}
+		return false
+	}
+
+	capabilities := &ModelCapabilities{
+		Tools:      hasTag("tools"),
+		Vision:     hasTag("vision"),
+		JSONSchema: hasTag("json_schema"),
+	}
+	for _, modality := range info.InputModalities {
+		if modality == "image" {
+			capabilities.Vision = true
+		}
+	}
+
+	return capabilities
+}
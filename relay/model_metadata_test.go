@@ -0,0 +1,87 @@
+package relay
+
+import (
+	"done-hub/common/config"
+	"done-hub/model"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestGetModelPricing 测试价格换算为每百万 Token 和每次调用的美元价格
+func TestGetModelPricing(t *testing.T) {
+	pricing := getModelPricing(&model.Price{Type: model.TokensPriceType, Input: 1.25, Output: 5}, 2)
+	if want := 1.25 * 2 * 1000000 / config.QuotaPerUnit; pricing.Input != want {
+		t.Errorf("input = %f, want %f", pricing.Input, want)
+	}
+	if want := 5 * 2 * 1000000 / config.QuotaPerUnit; pricing.Output != want {
+		t.Errorf("output = %f, want %f", pricing.Output, want)
+	}
+
+	pricing = getModelPricing(&model.Price{Type: model.TimesPriceType, Input: 10, Output: 10}, 0)
+	if want := 10 * 1000 / config.QuotaPerUnit; pricing.Input != want || pricing.Output != 0 || pricing.GroupRatio != 1 {
+		t.Errorf("unexpected times pricing: %+v", pricing)
+	}
+}
+
+// TestGetModelCapabilities 测试根据标签和输入模态推断模型能力
+func TestGetModelCapabilities(t *testing.T) {
+	capabilities := getModelCapabilities(&model.ModelInfoResponse{
+		Tags:            []string{"Function_Calling", "structured_outputs"},
+		InputModalities: []string{"text", "image"},
+	})
+	if !capabilities.Tools || !capabilities.Vision || !capabilities.JSONSchema {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+
+	capabilities = getModelCapabilities(&model.ModelInfoResponse{InputModalities: []string{"text"}})
+	if capabilities.Tools || capabilities.Vision || capabilities.JSONSchema {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+}
+
+// TestWithModelMetadataTenantPrice 测试多租户模式下返回租户的价格
+func TestWithModelMetadataTenantPrice(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&model.Tenant{}, &model.TenantPrice{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&model.Tenant{Id: 1, Code: "t1"})
+	db.Create(&model.TenantPrice{TenantId: 1, Model: "gpt-4o", Type: model.TokensPriceType, Input: 5, Output: 20})
+
+	originDB, originPricing, originMultiTenant := model.DB, model.PricingInstance, config.MultiTenantEnabled
+	t.Cleanup(func() {
+		model.DB, model.PricingInstance, config.MultiTenantEnabled = originDB, originPricing, originMultiTenant
+	})
+	model.DB = db
+	model.PricingInstance = &model.Pricing{Prices: map[string]*model.Price{
+		"gpt-4o": {Model: "gpt-4o", Type: model.TokensPriceType, Input: 2.5, Output: 10},
+	}}
+	config.MultiTenantEnabled = true
+	model.GlobalTenants.Load()
+
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		tenantId int
+		input    float64
+	}{
+		{1, 5},
+		{2, 2.5},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("tenant_id", tt.tenantId)
+		c.Set("group_ratio", 1.0)
+		metadata := withModelMetadata(c, &OpenAIModels{Id: "gpt-4o"})
+		if want := tt.input * 1000000 / config.QuotaPerUnit; metadata.Pricing.Input != want {
+			t.Errorf("tenant %d: input = %f, want %f", tt.tenantId, metadata.Pricing.Input, want)
+		}
+	}
+}
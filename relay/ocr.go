@@ -0,0 +1,59 @@
+package relay
+
+import (
+	"done-hub/common"
+	providersBase "done-hub/providers/base"
+	"done-hub/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type relayOCR struct {
+	relayBase
+	request types.OCRRequest
+}
+
+func NewRelayOCR(c *gin.Context) *relayOCR {
+	relay := &relayOCR{}
+	relay.c = c
+	return relay
+}
+
+func (r *relayOCR) setRequest() error {
+	if err := common.UnmarshalBodyReusable(r.c, &r.request); err != nil {
+		return err
+	}
+
+	r.setOriginalModel(r.request.Model)
+
+	return nil
+}
+
+// getPromptTokens OCR 按页计费，预扣费时先按 1 页计算，完成后按实际页数结算
+func (r *relayOCR) getPromptTokens() (int, error) {
+	return 1, nil
+}
+
+func (r *relayOCR) send() (err *types.OpenAIErrorWithStatusCode, done bool) {
+	provider, ok := r.provider.(providersBase.OCRInterface)
+	if !ok {
+		err = common.StringErrorWrapperLocal("channel not implemented", "channel_error", http.StatusServiceUnavailable)
+		done = true
+		return
+	}
+
+	r.request.Model = r.modelName
+
+	response, err := provider.CreateOCR(&r.request)
+	if err != nil {
+		return
+	}
+	err = responseJsonClient(r.c, response)
+
+	if err != nil {
+		done = true
+	}
+
+	return
+}
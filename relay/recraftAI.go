@@ -36,6 +36,10 @@ func buildChannelFiltersForRecraftAI(c *gin.Context, modelName string) []modelPk
 		}
 	}
 
+	if config.MultiTenantEnabled {
+		filters = append(filters, modelPkg.FilterTenant(c.GetInt("tenant_id")))
+	}
+
 	return filters
 }
 
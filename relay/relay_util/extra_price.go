@@ -16,6 +16,8 @@ type ExtraServicePriceConfig struct {
 	CodeInterpreter float64 `json:"code_interpreter"`
 
 	ImageGeneration map[string]map[string]float64 `json:"image_generation"`
+	// xAI Live Search 每个来源的价格
+	LiveSearch float64 `json:"live_search"`
 }
 
 var defaultExtraServicePrices = ExtraServicePriceConfig{
@@ -25,6 +27,7 @@ var defaultExtraServicePrices = ExtraServicePriceConfig{
 	},
 	FileSearch:      0.0025,
 	CodeInterpreter: 0.03,
+	LiveSearch:      0.025,
 	ImageGeneration: map[string]map[string]float64{
 		"low": {
 			"1024x1024": 0.011,
@@ -62,6 +65,8 @@ func getDefaultExtraServicePrice(serviceType, modelName, extraType string) float
 		return defaultExtraServicePrices.FileSearch
 	case types.APITollTypeCodeInterpreter:
 		return defaultExtraServicePrices.CodeInterpreter
+	case types.APITollTypeLiveSearch:
+		return defaultExtraServicePrices.LiveSearch
 
 	case types.APITollTypeImageGeneration:
 		if extraType == "" {
@@ -12,6 +12,7 @@ import (
 )
 
 const HeartbeatStreamText = "::PING\n\n"
+const KeepAliveStreamText = ": ping\n\n"
 const HeartbeatJsonText = "\n"
 
 // 心跳配置
@@ -0,0 +1,290 @@
+package relay_util
+
+import (
+	"done-hub/common"
+	"done-hub/common/config"
+	"done-hub/model"
+	"done-hub/types"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PromptCompressionModeHeuristic = "heuristic"        // 删除低信息量词、重复行和多余空白
+	PromptCompressionModeSummarize = "summarize_oldest" // 将最早的对话替换为摘要
+
+	PromptCompressionContextKey = "prompt_compression"
+
+	defaultCompressionTargetRatio = 0.7
+	defaultCompressionKeepRecent  = 4
+	compressionSummaryMaxRunes    = 200
+)
+
+// 低信息量词表（参考 LLMLingua 的思路，去除对语义贡献较小的词）
+var compressionStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "was": true, "were": true,
+	"be": true, "been": true, "being": true, "am": true, "of": true, "to": true, "in": true,
+	"on": true, "at": true, "by": true, "for": true, "with": true, "that": true, "this": true,
+	"these": true, "those": true, "it": true, "its": true, "as": true, "so": true, "very": true,
+	"really": true, "just": true, "quite": true, "actually": true, "basically": true,
+	"please": true, "kindly": true, "also": true, "then": true, "there": true, "which": true,
+}
+
+// PromptCompressionResult 记录压缩前后的 token 数
+type PromptCompressionResult struct {
+	Mode             string `json:"mode"`
+	OriginalTokens   int    `json:"original_tokens"`
+	CompressedTokens int    `json:"compressed_tokens"`
+	DroppedMessages  int    `json:"dropped_messages,omitempty"`
+}
+
+func (r *PromptCompressionResult) SavedTokens() int {
+	return r.OriginalTokens - r.CompressedTokens
+}
+
+// CompressChatMessagesByToken 根据令牌设置压缩聊天消息，未开启或无收益时返回原消息
+func CompressChatMessagesByToken(c *gin.Context, messages []types.ChatCompletionMessage, modelName string) []types.ChatCompletionMessage {
+	tokenSetting, exists := c.Get("token_setting")
+	if !exists {
+		return messages
+	}
+
+	setting, ok := tokenSetting.(*model.TokenSetting)
+	if !ok || setting == nil || !setting.Compression.Enabled {
+		return messages
+	}
+
+	compressed, result := CompressChatMessages(messages, &setting.Compression, modelName)
+	if result == nil {
+		return messages
+	}
+
+	c.Set(PromptCompressionContextKey, result)
+	return compressed
+}
+
+// CompressChatMessages 按设置压缩消息，目标为 原始 token 数 * TargetRatio
+func CompressChatMessages(messages []types.ChatCompletionMessage, setting *model.CompressionSetting, modelName string) ([]types.ChatCompletionMessage, *PromptCompressionResult) {
+	if setting == nil || len(messages) == 0 {
+		return messages, nil
+	}
+
+	originalTokens := countCompressionTokens(messages, modelName)
+	if setting.MinTokens > 0 && originalTokens < setting.MinTokens {
+		return messages, nil
+	}
+
+	ratio := setting.TargetRatio
+	if ratio <= 0 || ratio >= 1 {
+		ratio = defaultCompressionTargetRatio
+	}
+	keepRecent := setting.KeepRecent
+	if keepRecent <= 0 {
+		keepRecent = defaultCompressionKeepRecent
+	}
+	targetTokens := int(float64(originalTokens) * ratio)
+
+	var compressed []types.ChatCompletionMessage
+	dropped := 0
+	mode := setting.Mode
+	switch mode {
+	case PromptCompressionModeSummarize:
+		compressed, dropped = summarizeOldestMessages(messages, keepRecent, targetTokens, modelName)
+	default:
+		mode = PromptCompressionModeHeuristic
+		compressed = heuristicCompressMessages(messages, keepRecent, targetTokens, modelName)
+	}
+
+	compressedTokens := countCompressionTokens(compressed, modelName)
+	if compressedTokens >= originalTokens {
+		return messages, nil
+	}
+
+	return compressed, &PromptCompressionResult{
+		Mode:             mode,
+		OriginalTokens:   originalTokens,
+		CompressedTokens: compressedTokens,
+		DroppedMessages:  dropped,
+	}
+}
+
+func countCompressionTokens(messages []types.ChatCompletionMessage, modelName string) int {
+	return common.CountTokenMessages(messages, modelName, config.PreCostNotImage)
+}
+
+// isCompressibleMessage 系统提示和工具调用相关消息不参与压缩，避免破坏调用链
+func isCompressibleMessage(message *types.ChatCompletionMessage) bool {
+	switch message.Role {
+	case types.ChatMessageRoleUser, types.ChatMessageRoleAssistant:
+	default:
+		return false
+	}
+
+	return len(message.ToolCalls) == 0 && message.FunctionCall == nil
+}
+
+func heuristicCompressMessages(messages []types.ChatCompletionMessage, keepRecent, targetTokens int, modelName string) []types.ChatCompletionMessage {
+	compressed := make([]types.ChatCompletionMessage, len(messages))
+	copy(compressed, messages)
+
+	limit := len(compressed) - keepRecent
+	for i := 0; i < limit; i++ {
+		if !isCompressibleMessage(&compressed[i]) {
+			continue
+		}
+
+		switch content := compressed[i].Content.(type) {
+		case string:
+			compressed[i].Content = compressText(content)
+		case []any:
+			parts := make([]any, 0, len(content))
+			for _, part := range content {
+				partMap, ok := part.(map[string]any)
+				if !ok || partMap["type"] != types.ContentTypeText {
+					parts = append(parts, part)
+					continue
+				}
+				text, _ := partMap["text"].(string)
+				newPart := make(map[string]any, len(partMap))
+				for k, v := range partMap {
+					newPart[k] = v
+				}
+				newPart["text"] = compressText(text)
+				parts = append(parts, newPart)
+			}
+			compressed[i].Content = parts
+		default:
+			continue
+		}
+
+		if countCompressionTokens(compressed, modelName) <= targetTokens {
+			break
+		}
+	}
+
+	return compressed
+}
+
+// compressText 合并连续重复的行与连续的空白，去除低信息量词，不相邻的重复行保持不变，
+// 代码块（``` 或 ~~~ 围起的代码与缩进代码）原样保留
+func compressText(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	previous := ""
+	blank := false
+	fence := ""
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			fence = trimmed[:3]
+		} else if fence != "" && strings.HasPrefix(trimmed, fence) {
+			fence = ""
+			result = append(result, line)
+			previous, blank = "", false
+			continue
+		}
+		if fence != "" || (trimmed != "" && isIndentedCodeLine(line)) {
+			result = append(result, line)
+			previous, blank = "", false
+			continue
+		}
+
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			// 连续的空行合并为一行，保留段落分隔
+			if len(result) > 0 && !blank {
+				result = append(result, "")
+				blank = true
+			}
+			continue
+		}
+
+		line = strings.Join(words, " ")
+		if line == previous {
+			continue
+		}
+		previous = line
+
+		kept := make([]string, 0, len(words))
+		for _, word := range words {
+			if compressionStopWords[strings.ToLower(strings.Trim(word, ",.;:!?"))] {
+				continue
+			}
+			kept = append(kept, word)
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		result = append(result, strings.Join(kept, " "))
+		blank = false
+	}
+
+	if blank {
+		result = result[:len(result)-1]
+	}
+	return strings.Join(result, "\n")
+}
+
+// isIndentedCodeLine 以 4 个空格或制表符缩进的行视为代码
+func isIndentedCodeLine(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// summarizeOldestMessages 从最早的普通对话开始移除消息，直到达到目标 token 数，
+// 被移除的消息以摘要的形式合并到一条消息中
+func summarizeOldestMessages(messages []types.ChatCompletionMessage, keepRecent, targetTokens int, modelName string) ([]types.ChatCompletionMessage, int) {
+	// 跳过开头的系统提示
+	start := 0
+	for start < len(messages) && (messages[start].Role == types.ChatMessageRoleSystem || messages[start].Role == types.ChatMessageRoleDeveloper) {
+		start++
+	}
+
+	limit := len(messages) - keepRecent
+	end := start
+	var summary strings.Builder
+	for end < limit && isCompressibleMessage(&messages[end]) {
+		summary.WriteString(fmt.Sprintf("- %s: %s\n", messages[end].Role, summarizeText(messages[end].StringContent())))
+		end++
+
+		candidate := buildSummarizedMessages(messages, start, end, summary.String())
+		if countCompressionTokens(candidate, modelName) <= targetTokens {
+			return candidate, end - start
+		}
+	}
+
+	if end == start {
+		return messages, 0
+	}
+
+	return buildSummarizedMessages(messages, start, end, summary.String()), end - start
+}
+
+func buildSummarizedMessages(messages []types.ChatCompletionMessage, start, end int, summary string) []types.ChatCompletionMessage {
+	result := make([]types.ChatCompletionMessage, 0, len(messages)-(end-start)+1)
+	result = append(result, messages[:start]...)
+	result = append(result, types.ChatCompletionMessage{
+		Role:    types.ChatMessageRoleUser,
+		Content: "Summary of earlier conversation:\n" + summary,
+	})
+	result = append(result, messages[end:]...)
+	return result
+}
+
+// summarizeText 取第一句话作为摘要
+func summarizeText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if idx := strings.IndexAny(text, ".!?。！？"); idx > 0 {
+		_, size := utf8.DecodeRuneInString(text[idx:])
+		text = text[:idx+size]
+	}
+
+	if utf8.RuneCountInString(text) > compressionSummaryMaxRunes {
+		runes := []rune(text)
+		text = string(runes[:compressionSummaryMaxRunes]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,26 @@
+package relay_util
+
+import "testing"
+
+func TestCompressText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"whitespace runs", "hello    world\t\tagain", "hello world again"},
+		{"consecutive duplicates", "step one\nstep one\n  step one  \nstep two", "step one\nstep two"},
+		{"non consecutive duplicates", "retry\nwait\nretry", "retry\nwait\nretry"},
+		{"blank lines", "first\n\n\n\nsecond\n\n", "first\n\nsecond"},
+		{"stop words", "please check the result", "check result"},
+		{"fenced code", "see the code\n```go\nif  a  {\n\n\n\treturn   the\n}\n```\nthe end", "see code\n```go\nif  a  {\n\n\n\treturn   the\n}\n```\nend"},
+		{"tilde fence", "~~~\nx = a  is  b\nx = a  is  b\n~~~", "~~~\nx = a  is  b\nx = a  is  b\n~~~"},
+		{"indented code", "example:\n    for i in  range(10):\n        print( i )", "example:\n    for i in  range(10):\n        print( i )"},
+	}
+
+	for _, tt := range tests {
+		if got := compressText(tt.text); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"done-hub/common"
 	"done-hub/common/config"
+	"done-hub/common/geoip"
+	"done-hub/common/graceful"
+	"done-hub/common/hook"
 	"done-hub/common/logger"
+	"done-hub/common/utils"
+	"done-hub/common/webhook"
 	"done-hub/model"
 	"done-hub/types"
 	"errors"
@@ -35,7 +40,36 @@ type Quota struct {
 
 	startTime         time.Time
 	firstResponseTime time.Time
+	upstreamStartTime time.Time
+	upstreamDuration  time.Duration
+	retryCount        int
 	extraBillingData  map[string]ExtraBillingData
+	compression       *PromptCompressionResult
+	requestPath       string
+	requestBody       string
+	geoLocation       *geoip.Location
+	geoFlagged        bool
+	usageEstimated    bool
+	imageCount        int
+	failoverPath      []FailoverHop
+	hookMetadata      map[string]any
+}
+
+const (
+	// ImageCountContextKey 请求的图片数量，用于按张计费时预扣额度
+	ImageCountContextKey = "image_count"
+	// GeneratedImageCountContextKey 上游实际返回的图片数量，用于按张计费时结算
+	GeneratedImageCountContextKey = "generated_image_count"
+)
+
+// FailoverPathContextKey 本次请求失败切换过的渠道，记录在日志中
+const FailoverPathContextKey = "failover_path"
+
+// FailoverHop 一次失败的渠道尝试
+type FailoverHop struct {
+	ChannelId  int    `json:"channel_id"`
+	StatusCode int    `json:"status_code"`
+	Code       string `json:"code,omitempty"`
 }
 
 func NewQuota(c *gin.Context, modelName string, promptTokens int) *Quota {
@@ -51,7 +85,8 @@ func NewQuota(c *gin.Context, modelName string, promptTokens int) *Quota {
 		isBackupGroup: isBackupGroup, // 记录是否使用备用分组
 	}
 
-	quota.price = *model.PricingInstance.GetPrice(quota.modelName)
+	// 开启多租户时使用租户单独设置的价格
+	quota.price = *model.GetTenantPrice(c.GetInt("tenant_id"), quota.modelName)
 
 	// 记录分组信息用于日志
 	if isBackupGroup {
@@ -64,6 +99,27 @@ func NewQuota(c *gin.Context, modelName string, promptTokens int) *Quota {
 		quota.backupGroupName = ""
 	}
 
+	if compression, ok := utils.GetGinValue[*PromptCompressionResult](c, PromptCompressionContextKey); ok {
+		quota.compression = compression
+	}
+
+	quota.imageCount = max(c.GetInt(ImageCountContextKey), 1)
+	// attempt_count 只在发生重试时设置，首次请求为 0
+	quota.retryCount = max(c.GetInt("attempt_count")-1, 0)
+	if path, ok := utils.GetGinValue[[]FailoverHop](c, FailoverPathContextKey); ok {
+		quota.failoverPath = path
+	}
+
+	if location, ok := utils.GetGinValue[*geoip.Location](c, geoip.ContextKey); ok {
+		quota.geoLocation = location
+	}
+	quota.geoFlagged = c.GetBool(geoip.FlaggedContextKey)
+
+	if config.LogRequestBodyEnabled {
+		quota.requestPath = c.Request.URL.Path
+		quota.requestBody = getLogRequestBody(c)
+	}
+
 	quota.groupRatio = c.GetFloat64("group_ratio") // 这里的倍率已经在 common.go 中正确设置了
 	quota.inputRatio = quota.price.GetInput() * quota.groupRatio
 	quota.outputRatio = quota.price.GetOutput() * quota.groupRatio
@@ -75,6 +131,8 @@ func NewQuota(c *gin.Context, modelName string, promptTokens int) *Quota {
 func (q *Quota) PreQuotaConsumption() *types.OpenAIErrorWithStatusCode {
 	if q.price.Type == model.TimesPriceType {
 		q.preConsumedQuota = int(1000 * q.inputRatio)
+	} else if q.price.Type == model.ImagesPriceType {
+		q.preConsumedQuota = int(1000 * q.inputRatio * float64(q.imageCount))
 	} else if q.price.Input != 0 || q.price.Output != 0 {
 		q.preConsumedQuota = int(float64(q.promptTokens)*q.inputRatio) + config.PreConsumedQuota
 	}
@@ -175,30 +233,91 @@ func (q *Quota) completedQuotaConsumption(usage *types.Usage, tokenName string,
 		usage.PromptTokens,
 		usage.CompletionTokens,
 		q.modelName,
+		q.tokenId,
 		tokenName,
 		quota,
 		"",
 		q.getRequestTime(),
 		isStream,
+		q.getLogTiming(),
 		q.GetLogMeta(usage),
 		sourceIp,
 	)
 	model.UpdateUserUsedQuotaAndRequestCount(q.userId, quota)
+	q.emitBillingWebhook(ctx, usage, tokenName, quota, isStream)
+	q.runPostBillingHook(ctx, usage, tokenName, quota, isStream)
 
 	return quotaErr
 }
 
+// runPostBillingHook 执行 post_billing 阶段的扩展钩子，已经在后台协程中，不影响请求耗时
+func (q *Quota) runPostBillingHook(ctx context.Context, usage *types.Usage, tokenName string, quota int, isStream bool) {
+	if !hook.Enabled(hook.StagePostBilling) {
+		return
+	}
+
+	group := q.groupName
+	if q.isBackupGroup {
+		group = q.backupGroupName
+	}
+
+	hook.Run(ctx, &hook.Event{
+		Stage:            hook.StagePostBilling,
+		RequestId:        logger.GetRequestId(ctx),
+		CreatedAt:        utils.GetTimestamp(),
+		UserId:           q.userId,
+		TokenId:          q.tokenId,
+		TokenName:        tokenName,
+		Group:            group,
+		Model:            q.modelName,
+		ChannelId:        q.channelId,
+		IsStream:         isStream,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Quota:            quota,
+		Metadata:         q.hookMetadata,
+	})
+}
+
+func (q *Quota) emitBillingWebhook(ctx context.Context, usage *types.Usage, tokenName string, quota int, isStream bool) {
+	if !config.BillingWebhookEnabled {
+		return
+	}
+
+	group := q.groupName
+	if q.isBackupGroup {
+		group = q.backupGroupName
+	}
+	username, _ := model.CacheGetUsername(q.userId)
+
+	webhook.EmitBilling(&webhook.BillingEvent{
+		RequestId:        logger.GetRequestId(ctx),
+		UserId:           q.userId,
+		Username:         username,
+		TokenId:          q.tokenId,
+		TokenName:        tokenName,
+		ChannelId:        q.channelId,
+		Model:            q.modelName,
+		Group:            group,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Quota:            quota,
+		IsStream:         isStream,
+	})
+}
+
 func (q *Quota) Undo(c *gin.Context) {
 	tokenId := c.GetInt("token_id")
 	if q.HandelStatus {
-		go func(ctx context.Context) {
+		ctx := c.Request.Context()
+		graceful.GoBackground(func() {
 			// return pre-consumed quota
 			if err := model.PostConsumeTokenQuota(tokenId, -q.preConsumedQuota); err != nil {
 				logger.LogError(ctx, "error return pre-consumed quota: "+err.Error())
 			}
 			// 刷新缓存配额，保持一致
 			_ = model.CacheUpdateUserQuota(q.userId)
-		}(c.Request.Context())
+		})
 	}
 }
 
@@ -206,8 +325,15 @@ func (q *Quota) Consume(c *gin.Context, usage *types.Usage, isStream bool) {
 	tokenName := c.GetString("token_name")
 	sourceIp := c.ClientIP() // 在 goroutine 外提取，避免 Gin Context 回收后数据竞争
 	q.startTime = c.GetTime("requestStartTime")
-	// 如果没有报错，则消费配额
-	go func(ctx context.Context) {
+	if imageCount, ok := utils.GetGinValue[int](c, GeneratedImageCountContextKey); ok {
+		q.imageCount = imageCount
+	}
+	if metadata, ok := utils.GetGinValue[map[string]any](c, hook.MetadataContextKey); ok {
+		q.hookMetadata = metadata
+	}
+	ctx := c.Request.Context()
+	// 如果没有报错，则消费配额；退出时会等待扣费完成，避免丢失账单
+	graceful.GoBackground(func() {
 		defer func() {
 			if r := recover(); r != nil {
 				logger.LogError(ctx, fmt.Sprintf("panic in completedQuotaConsumption: %v", r))
@@ -217,7 +343,7 @@ func (q *Quota) Consume(c *gin.Context, usage *types.Usage, isStream bool) {
 		if err != nil {
 			logger.LogError(ctx, err.Error())
 		}
-	}(c.Request.Context())
+	})
 }
 
 func (q *Quota) GetInputRatio() float64 {
@@ -254,9 +380,81 @@ func (q *Quota) GetLogMeta(usage *types.Usage) map[string]any {
 		meta["extra_billing"] = q.extraBillingData
 	}
 
+	if breakdown := q.getCostBreakdown(usage); breakdown != nil {
+		meta["cost_breakdown"] = breakdown
+	}
+
+	if q.price.Type == model.ImagesPriceType {
+		meta["image_count"] = q.imageCount
+	}
+
+	if q.usageEstimated {
+		meta["usage_estimated"] = true
+	}
+
+	if len(q.failoverPath) > 0 {
+		meta["failover_path"] = q.failoverPath
+	}
+
+	if q.geoLocation != nil {
+		meta["geo_country"] = q.geoLocation.Country
+		if q.geoLocation.Region != "" {
+			meta["geo_region"] = q.geoLocation.Region
+		}
+	}
+	if q.geoFlagged {
+		meta["geo_flagged"] = true
+	}
+
+	if len(q.hookMetadata) > 0 {
+		meta["hook"] = q.hookMetadata
+	}
+
+	if q.requestBody != "" {
+		meta["request_path"] = q.requestPath
+		meta["request_body"] = q.requestBody
+	}
+
+	if q.compression != nil {
+		meta["prompt_compression"] = map[string]any{
+			"mode":              q.compression.Mode,
+			"original_tokens":   q.compression.OriginalTokens,
+			"compressed_tokens": q.compression.CompressedTokens,
+			"dropped_messages":  q.compression.DroppedMessages,
+			"saved_tokens":      q.compression.SavedTokens(),
+			"saved_quota":       q.compressionSavedQuota(),
+		}
+	}
+
 	return meta
 }
 
+// compressionSavedQuota 按输入价格估算压缩节省的额度，按次或按张计费时节省的 token 不影响费用
+func (q *Quota) compressionSavedQuota() int {
+	if q.compression == nil || q.price.Type == model.TimesPriceType || q.price.Type == model.ImagesPriceType {
+		return 0
+	}
+	return int(math.Ceil(float64(q.compression.SavedTokens()) * q.inputRatio))
+}
+
+// logRequestBodyContextKey 缓存待记录的请求体，重试时 provider 可能已经清除了原始请求体
+const logRequestBodyContextKey = "log_request_body"
+
+func getLogRequestBody(c *gin.Context) string {
+	if body, ok := utils.GetGinValue[string](c, logRequestBodyContextKey); ok {
+		return body
+	}
+
+	raw, ok := utils.GetGinValue[[]byte](c, config.GinRequestBodyKey)
+	if !ok || len(raw) == 0 || len(raw) > config.LogRequestBodyMaxSize {
+		return ""
+	}
+
+	body := string(raw)
+	c.Set(logRequestBodyContextKey, body)
+	return body
+}
+
 func (q *Quota) getRequestTime() int {
 	return int(time.Since(q.startTime).Milliseconds())
 }
@@ -265,6 +463,8 @@ func (q *Quota) getRequestTime() int {
 func (q *Quota) GetTotalQuota(promptTokens, completionTokens int, extraBilling map[string]types.ExtraBilling) (quota int) {
 	if q.price.Type == model.TimesPriceType {
 		quota = int(1000 * q.inputRatio)
+	} else if q.price.Type == model.ImagesPriceType {
+		quota = int(1000 * q.inputRatio * float64(q.imageCount))
 	} else {
 		quota = int(math.Ceil((float64(promptTokens) * q.inputRatio) + (float64(completionTokens) * q.outputRatio)))
 	}
@@ -285,7 +485,9 @@ func (q *Quota) GetTotalQuota(promptTokens, completionTokens int, extraBilling m
 		))
 	}
 
-	if q.inputRatio != 0 && quota <= 0 {
+	// 按张计费且上游没有返回图片时不计费
+	noImage := q.price.Type == model.ImagesPriceType && q.imageCount == 0
+	if q.inputRatio != 0 && quota <= 0 && !noImage {
 		quota = 1
 	}
 	totalTokens := promptTokens + completionTokens
@@ -303,6 +505,40 @@ func (q *Quota) GetTotalQuota(promptTokens, completionTokens int, extraBilling m
 	return quota
 }
 
+// getCostBreakdown 按计费维度拆分额度（已乘分组倍率）
+// prompt / completion 为按基础价格计算的额度，缓存、推理、音频等维度为相对基础价格的调整额（缓存折扣时为负数）
+func (q *Quota) getCostBreakdown(usage *types.Usage) map[string]float64 {
+	if usage == nil || q.price.Type != model.TokensPriceType {
+		return nil
+	}
+
+	breakdown := map[string]float64{
+		"prompt":     float64(usage.PromptTokens) * q.inputRatio,
+		"completion": float64(usage.CompletionTokens) * q.outputRatio,
+	}
+
+	for key, value := range usage.GetExtraTokens() {
+		if value == 0 {
+			continue
+		}
+		sideRatio := q.outputRatio
+		if model.GetExtraPriceIsPrompt(key) {
+			sideRatio = q.inputRatio
+		}
+		breakdown[key] = float64(model.GetIncreaseTokens(value, q.price.GetExtraRatio(key))) * sideRatio
+	}
+
+	for key, value := range q.extraBillingData {
+		breakdown["extra_billing_"+key] = math.Ceil(value.Price*config.QuotaPerUnit) * float64(value.CallCount) * q.groupRatio
+	}
+
+	for key, value := range breakdown {
+		breakdown[key] = math.Round(value*100) / 100
+	}
+
+	return breakdown
+}
+
 // 获取计算的 token 数
 func (q *Quota) getComputeTokensByUsage(usage *types.Usage) (promptTokens, completionTokens int) {
 	promptTokens = usage.PromptTokens
@@ -354,10 +590,42 @@ func (q *Quota) GetFirstResponseTime() int64 {
 	return q.firstResponseTime.Sub(q.startTime).Milliseconds()
 }
 
+// SetUsageEstimated 标记本次用量为本地估算（上游未返回 usage）
+func (q *Quota) SetUsageEstimated(estimated bool) {
+	q.usageEstimated = estimated
+}
+
 func (q *Quota) SetFirstResponseTime(firstResponseTime time.Time) {
 	q.firstResponseTime = firstResponseTime
 }
 
+// StartUpstream 记录本次上游请求的开始时间
+func (q *Quota) StartUpstream() {
+	q.upstreamStartTime = time.Now()
+}
+
+// EndUpstream 记录本次上游请求（包括流式传输）的耗时
+func (q *Quota) EndUpstream() {
+	if q.upstreamStartTime.IsZero() {
+		return
+	}
+	q.upstreamDuration = time.Since(q.upstreamStartTime)
+}
+
+// getLogTiming 首字时间从本次上游请求开始计算，不包含前面失败重试的耗时，便于横向比较渠道
+func (q *Quota) getLogTiming() *model.LogTiming {
+	timing := &model.LogTiming{
+		UpstreamTime: int(q.upstreamDuration.Milliseconds()),
+		RetryCount:   q.retryCount,
+	}
+
+	if !q.firstResponseTime.IsZero() && !q.upstreamStartTime.IsZero() {
+		timing.FirstTokenTime = max(int(q.firstResponseTime.Sub(q.upstreamStartTime).Milliseconds()), 0)
+	}
+
+	return timing
+}
+
 type ExtraBillingData struct {
 	Type      string  `json:"type"`
 	CallCount int     `json:"call_count"`
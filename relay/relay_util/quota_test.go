@@ -0,0 +1,78 @@
+package relay_util
+
+import (
+	"done-hub/model"
+	"testing"
+	"time"
+)
+
+func TestGetTotalQuotaByImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		imageCount int
+		want       int
+	}{
+		{"multiple images", 3, 6000},
+		{"no image returned", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Quota{
+				price:      model.Price{Type: model.ImagesPriceType, Input: 2},
+				inputRatio: 2,
+				groupRatio: 1,
+				imageCount: tt.imageCount,
+			}
+			if quota := q.GetTotalQuota(100, 0, nil); quota != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, quota)
+			}
+		})
+	}
+}
+
+func TestQuotaLogTiming(t *testing.T) {
+	q := &Quota{retryCount: 2}
+	if timing := q.getLogTiming(); timing.FirstTokenTime != 0 || timing.UpstreamTime != 0 || timing.RetryCount != 2 {
+		t.Fatalf("unexpected timing before upstream request: %+v", timing)
+	}
+
+	// 首字时间从本次上游请求开始计算
+	q.startTime = time.Now().Add(-5 * time.Second)
+	q.upstreamStartTime = time.Now().Add(-2 * time.Second)
+	q.firstResponseTime = q.upstreamStartTime.Add(300 * time.Millisecond)
+	q.EndUpstream()
+
+	timing := q.getLogTiming()
+	if timing.FirstTokenTime != 300 {
+		t.Fatalf("expected first token time 300ms, got %d", timing.FirstTokenTime)
+	}
+	if timing.UpstreamTime < 2000 || timing.UpstreamTime >= 5000 {
+		t.Fatalf("upstream time should not include earlier retries, got %d", timing.UpstreamTime)
+	}
+
+	// 首个响应早于上游请求开始时不记录负值
+	q.firstResponseTime = q.upstreamStartTime.Add(-time.Second)
+	if timing = q.getLogTiming(); timing.FirstTokenTime != 0 {
+		t.Fatalf("expected first token time 0, got %d", timing.FirstTokenTime)
+	}
+}
+
+func TestCompressionSavedQuota(t *testing.T) {
+	compression := &PromptCompressionResult{OriginalTokens: 1000, CompressedTokens: 600}
+	tests := []struct {
+		name  string
+		price model.Price
+		want  int
+	}{
+		{"tokens price", model.Price{Type: model.TokensPriceType, Input: 1.5}, 600},
+		{"times price", model.Price{Type: model.TimesPriceType, Input: 1.5}, 0},
+	}
+
+	for _, tt := range tests {
+		q := &Quota{price: tt.price, inputRatio: 1.5, compression: compression}
+		if saved := q.compressionSavedQuota(); saved != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, saved)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package relay_util
+
+import (
+	"done-hub/common/requester"
+	"done-hub/model"
+	"done-hub/types"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 推理内容（reasoning_content）返回方式
+const (
+	ReasoningFormatPassthrough = "passthrough" // 原样返回
+	ReasoningFormatStrip       = "strip"       // 去掉推理内容
+	ReasoningFormatThink       = "think"       // 以 <think></think> 包裹合并到 content 开头
+
+	// ReasoningFormatHeader 请求级别覆盖令牌设置
+	ReasoningFormatHeader = "X-Reasoning-Format"
+)
+
+const (
+	thinkOpenTag  = "<think>\n"
+	thinkCloseTag = "\n</think>\n\n"
+)
+
+func IsValidReasoningFormat(format string) bool {
+	switch format {
+	case "", ReasoningFormatPassthrough, ReasoningFormatStrip, ReasoningFormatThink:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetReasoningFormat 获取本次请求的推理内容返回方式，请求头优先于令牌设置
+func GetReasoningFormat(c *gin.Context) string {
+	if format := strings.ToLower(strings.TrimSpace(c.GetHeader(ReasoningFormatHeader))); format != "" && IsValidReasoningFormat(format) {
+		return format
+	}
+
+	if tokenSetting, exists := c.Get("token_setting"); exists {
+		if setting, ok := tokenSetting.(*model.TokenSetting); ok && setting != nil && setting.ReasoningFormat != "" {
+			return setting.ReasoningFormat
+		}
+	}
+
+	return ReasoningFormatPassthrough
+}
+
+// ApplyReasoningFormat 按返回方式处理非流式响应中的推理内容
+func ApplyReasoningFormat(response *types.ChatCompletionResponse, format string) {
+	if response == nil || format == "" || format == ReasoningFormatPassthrough {
+		return
+	}
+
+	for i := range response.Choices {
+		message := &response.Choices[i].Message
+		reasoning := message.ReasoningContent
+		if reasoning == "" {
+			reasoning = message.Reasoning
+		}
+		message.ReasoningContent = ""
+		message.Reasoning = ""
+
+		if format != ReasoningFormatThink || reasoning == "" {
+			continue
+		}
+
+		content, _ := message.Content.(string)
+		message.Content = thinkOpenTag + reasoning + thinkCloseTag + content
+	}
+}
+
+// WrapReasoningFormatStream 按返回方式处理流式响应中的推理内容
+func WrapReasoningFormatStream(stream requester.StreamReaderInterface[string], format string) requester.StreamReaderInterface[string] {
+	if format == "" || format == ReasoningFormatPassthrough {
+		return stream
+	}
+
+	return &reasoningFormatStream{
+		stream:   stream,
+		format:   format,
+		thinking: make(map[int64]bool),
+	}
+}
+
+type reasoningFormatStream struct {
+	stream   requester.StreamReaderInterface[string]
+	format   string
+	thinking map[int64]bool // 各 choice 是否处于未闭合的 <think> 中
+}
+
+func (s *reasoningFormatStream) Recv() (<-chan string, <-chan error) {
+	dataChan, errChan := s.stream.Recv()
+	outData := make(chan string)
+	outErr := make(chan error)
+
+	// 单个协程按顺序转发，保证数据在结束信号之前送达
+	go func() {
+		for {
+			select {
+			case data, ok := <-dataChan:
+				if !ok {
+					close(outData)
+					return
+				}
+				if data = s.transform(data); data != "" {
+					outData <- data
+				}
+			case err := <-errChan:
+				outErr <- err
+				return
+			}
+		}
+	}()
+
+	return outData, outErr
+}
+
+func (s *reasoningFormatStream) Close() {
+	s.stream.Close()
+}
+
+func (s *reasoningFormatStream) transform(data string) string {
+	choices := gjson.Get(data, "choices")
+	if !choices.IsArray() {
+		return data
+	}
+
+	for i, choice := range choices.Array() {
+		deltaPath := "choices." + strconv.Itoa(i) + ".delta"
+		reasoning := choice.Get("delta.reasoning_content").String()
+		if reasoning == "" {
+			reasoning = choice.Get("delta.reasoning").String()
+		}
+		data, _ = sjson.Delete(data, deltaPath+".reasoning_content")
+		data, _ = sjson.Delete(data, deltaPath+".reasoning")
+
+		if s.format != ReasoningFormatThink {
+			continue
+		}
+
+		index := choice.Get("index").Int()
+		content := choice.Get("delta.content").String()
+		newContent := content
+
+		if reasoning != "" {
+			if !s.thinking[index] {
+				s.thinking[index] = true
+				reasoning = thinkOpenTag + reasoning
+			}
+			newContent = reasoning
+			if content != "" {
+				newContent += thinkCloseTag + content
+				s.thinking[index] = false
+			}
+		} else if s.thinking[index] {
+			// 推理结束：正文、工具调用或结束标记出现时闭合 <think>
+			finishReason := choice.Get("finish_reason")
+			if content != "" || choice.Get("delta.tool_calls").Exists() || (finishReason.Exists() && finishReason.Type != gjson.Null) {
+				newContent = thinkCloseTag + content
+				s.thinking[index] = false
+			}
+		}
+
+		if newContent != content {
+			data, _ = sjson.Set(data, deltaPath+".content", newContent)
+		}
+	}
+
+	return data
+}
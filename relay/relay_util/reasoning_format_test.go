@@ -0,0 +1,110 @@
+package relay_util
+
+import (
+	"done-hub/types"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+type fakeStream struct {
+	data []string
+}
+
+func (s *fakeStream) Recv() (<-chan string, <-chan error) {
+	dataChan := make(chan string)
+	errChan := make(chan error)
+	go func() {
+		for _, data := range s.data {
+			dataChan <- data
+		}
+		errChan <- io.EOF
+	}()
+	return dataChan, errChan
+}
+
+func (s *fakeStream) Close() {}
+
+func collectContent(t *testing.T, format string, chunks []string) (string, bool) {
+	t.Helper()
+	stream := WrapReasoningFormatStream(&fakeStream{data: chunks}, format)
+	dataChan, errChan := stream.Recv()
+
+	var builder strings.Builder
+	hasReasoning := false
+	for {
+		select {
+		case data := <-dataChan:
+			builder.WriteString(gjson.Get(data, "choices.0.delta.content").String())
+			if gjson.Get(data, "choices.0.delta.reasoning_content").Exists() {
+				hasReasoning = true
+			}
+		case err := <-errChan:
+			if err != io.EOF {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return builder.String(), hasReasoning
+		}
+	}
+}
+
+var reasoningChunks = []string{
+	`{"choices":[{"index":0,"delta":{"role":"assistant","reasoning_content":"step 1"}}]}`,
+	`{"choices":[{"index":0,"delta":{"reasoning_content":", step 2"}}]}`,
+	`{"choices":[{"index":0,"delta":{"content":"answer"}}]}`,
+	`{"choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+}
+
+func TestReasoningFormatStreamThink(t *testing.T) {
+	content, hasReasoning := collectContent(t, ReasoningFormatThink, reasoningChunks)
+	if hasReasoning {
+		t.Fatal("reasoning_content should be removed")
+	}
+	if content != "<think>\nstep 1, step 2\n</think>\n\nanswer" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+}
+
+func TestReasoningFormatStreamThinkClosedOnFinish(t *testing.T) {
+	content, _ := collectContent(t, ReasoningFormatThink, []string{reasoningChunks[0], reasoningChunks[3]})
+	if content != "<think>\nstep 1\n</think>\n\n" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+}
+
+func TestReasoningFormatStreamStrip(t *testing.T) {
+	content, hasReasoning := collectContent(t, ReasoningFormatStrip, reasoningChunks)
+	if hasReasoning || content != "answer" {
+		t.Fatalf("unexpected content=%q hasReasoning=%v", content, hasReasoning)
+	}
+}
+
+func TestApplyReasoningFormat(t *testing.T) {
+	newResponse := func() *types.ChatCompletionResponse {
+		return &types.ChatCompletionResponse{
+			Choices: []types.ChatCompletionChoice{{
+				Message: types.ChatCompletionMessage{Content: "answer", ReasoningContent: "thought"},
+			}},
+		}
+	}
+
+	response := newResponse()
+	ApplyReasoningFormat(response, ReasoningFormatPassthrough)
+	if response.Choices[0].Message.ReasoningContent != "thought" {
+		t.Fatal("passthrough should keep reasoning_content")
+	}
+
+	response = newResponse()
+	ApplyReasoningFormat(response, ReasoningFormatStrip)
+	if response.Choices[0].Message.ReasoningContent != "" || response.Choices[0].Message.Content != "answer" {
+		t.Fatalf("unexpected message: %+v", response.Choices[0].Message)
+	}
+
+	response = newResponse()
+	ApplyReasoningFormat(response, ReasoningFormatThink)
+	if response.Choices[0].Message.Content != "<think>\nthought\n</think>\n\nanswer" {
+		t.Fatalf("unexpected content: %q", response.Choices[0].Message.Content)
+	}
+}
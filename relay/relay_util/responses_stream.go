@@ -60,7 +60,7 @@ func (converter *OpenAIResponsesStreamConverter) initializeResponse(request *typ
 			},
 		},
 		MaxOutputTokens:   request.MaxOutputTokens,
-		ParallelToolCalls: request.ParallelToolCalls,
+		ParallelToolCalls: request.ParallelToolCallsEnabled(),
 		Temperature:       request.Temperature,
 		ToolChoice:        request.ToolChoice,
 		TopP:              request.TopP,
@@ -30,6 +30,10 @@ func buildTaskChannelFilters(c *gin.Context) []model.ChannelsFilterFunc {
 		}
 	}
 
+	if config.MultiTenantEnabled {
+		filters = append(filters, model.FilterTenant(c.GetInt("tenant_id")))
+	}
+
 	return filters
 }
 
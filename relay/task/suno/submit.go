@@ -4,6 +4,7 @@ import (
 	"context"
 	"done-hub/common"
 	"done-hub/common/logger"
+	"done-hub/common/storage"
 	"done-hub/metrics"
 	"done-hub/model"
 	"done-hub/providers"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
+	"gorm.io/datatypes"
 )
 
 type SunoTask struct {
@@ -234,6 +236,7 @@ func updateSunoTaskAll(ctx context.Context, channelId int, taskIds []string, tas
 
 		if responseItem.Status == model.TaskStatusSuccess {
 			task.Progress = 100
+			responseItem.Data = mirrorSunoData(ctx, responseItem.Data)
 		}
 
 		task.Data = responseItem.Data
@@ -245,6 +248,32 @@ func updateSunoTaskAll(ctx context.Context, channelId int, taskIds []string, tas
 	return nil
 }
 
+// mirrorSunoData 将生成的音频、封面和视频转存到自有存储，避免上游链接过期
+func mirrorSunoData(ctx context.Context, data datatypes.JSON) datatypes.JSON {
+	if !storage.MirrorEnabled() || len(data) == 0 {
+		return data
+	}
+
+	var songs []map[string]any
+	if err := json.Unmarshal(data, &songs); err != nil {
+		return data
+	}
+
+	for _, song := range songs {
+		for _, key := range []string{"audio_url", "image_url", "image_large_url", "video_url"} {
+			if fileUrl, ok := song[key].(string); ok && fileUrl != "" {
+				song[key] = storage.MirrorURL(ctx, fileUrl)
+			}
+		}
+	}
+
+	mirrored, err := json.Marshal(songs)
+	if err != nil {
+		return data
+	}
+	return mirrored
+}
+
 func checkTaskNeedUpdate(oldTask *model.Task, newTask sunoProvider.SunoDataResponse) bool {
 
 	if oldTask.SubmitTime != newTask.SubmitTime {
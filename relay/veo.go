@@ -2,9 +2,7 @@ package relay
 
 import (
 	"done-hub/common"
-	"done-hub/common/config"
 	"done-hub/providers/gemini"
-	"done-hub/safty"
 	"done-hub/types"
 	"encoding/json"
 	"errors"
@@ -101,20 +99,6 @@ func (r *relayVeoOnly) send() (err *types.OpenAIErrorWithStatusCode, done bool)
 		return common.StringErrorWrapperLocal("channel not implemented", "channel_error", http.StatusServiceUnavailable), true
 	}
 
-	// 内容审查
-	if config.EnableSafe {
-		for _, instance := range r.veoRequest.Instances {
-			if instance.Prompt != "" {
-				CheckResult, _ := safty.CheckContent(instance.Prompt)
-				if !CheckResult.IsSafe {
-					err = common.StringErrorWrapperLocal(CheckResult.Reason, CheckResult.Code, http.StatusBadRequest)
-					done = true
-					return
-				}
-			}
-		}
-	}
-
 	// 处理视频生成请求（包含轮询和下载）
 	// 重要：使用同一个 geminiProvider 实例，确保整个流程使用相同的 API Key
 	videoData, contentType, errWithCode := geminiProvider.CreateVeoVideoAndDownload(r.veoRequest, r.modelName)
@@ -34,27 +34,27 @@ func SetApiRouter(router *gin.Engine) {
 		apiRouter.GET("/status", controller.GetStatus)
 		apiRouter.GET("/notice", controller.GetNotice)
 		apiRouter.GET("/about", controller.GetAbout)
-		apiRouter.GET("/prices", middleware.PricesAuth(), middleware.CORS(), controller.GetPricesList)
+		apiRouter.GET("/prices", middleware.PricesAuth(), controller.GetPricesList)
 		apiRouter.GET("/ownedby", relay.GetModelOwnedBy)
-		apiRouter.GET("/available_model", middleware.CORS(), middleware.TrySetUserBySession(), relay.AvailableModel)
+		apiRouter.GET("/available_model", middleware.TrySetUserBySession(), relay.AvailableModel)
 		apiRouter.GET("/user_group_map", middleware.TrySetUserBySession(), controller.GetUserGroupRatio)
 		apiRouter.GET("/home_page_content", controller.GetHomePageContent)
-		apiRouter.GET("/verification", middleware.CriticalRateLimit(), middleware.TurnstileCheck(), controller.SendEmailVerification)
-		apiRouter.GET("/reset_password", middleware.CriticalRateLimit(), middleware.TurnstileCheck(), controller.SendPasswordResetEmail)
-		apiRouter.POST("/user/reset", middleware.CriticalRateLimit(), controller.ResetPassword)
-		apiRouter.GET("/oauth/github", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.GitHubOAuth)
-		apiRouter.GET("/oauth/lark", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.LarkOAuth)
-		apiRouter.GET("/oauth/state", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.GenerateOAuthCode)
-		apiRouter.POST("/oauth/invite_code", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.SetOAuthInviteCode)
-		apiRouter.GET("/oauth/wechat", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.WeChatAuth)
-		apiRouter.GET("/oauth/wechat/bind", middleware.CriticalRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.WeChatBind)
-		apiRouter.GET("/oauth/email/bind", middleware.CriticalRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.EmailBind)
-
-		apiRouter.GET("/oauth/endpoint", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.OIDCEndpoint)
-		apiRouter.GET("/oauth/oidc", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.OIDCAuth)
-
-		apiRouter.GET("/oauth/linuxdo", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.LinuxDoOAuth)
-		apiRouter.GET("/oauth/linuxdo/bind", middleware.CriticalRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.LinuxDoBind)
+		apiRouter.GET("/verification", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.TurnstileCheck(), controller.SendEmailVerification)
+		apiRouter.GET("/reset_password", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.TurnstileCheck(), controller.SendPasswordResetEmail)
+		apiRouter.POST("/user/reset", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), controller.ResetPassword)
+		apiRouter.GET("/oauth/github", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.GitHubOAuth)
+		apiRouter.GET("/oauth/lark", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.LarkOAuth)
+		apiRouter.GET("/oauth/state", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.GenerateOAuthCode)
+		apiRouter.POST("/oauth/invite_code", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.SetOAuthInviteCode)
+		apiRouter.GET("/oauth/wechat", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.WeChatAuth)
+		apiRouter.GET("/oauth/wechat/bind", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.WeChatBind)
+		apiRouter.GET("/oauth/email/bind", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.EmailBind)
+
+		apiRouter.GET("/oauth/endpoint", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.OIDCEndpoint)
+		apiRouter.GET("/oauth/oidc", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.OIDCAuth)
+
+		apiRouter.GET("/oauth/linuxdo", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.LinuxDoOAuth)
+		apiRouter.GET("/oauth/linuxdo/bind", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), middleware.UserAuth(), controller.LinuxDoBind)
 
 		webauthnGroup := apiRouter.Group("/webauthn")
 		{
@@ -63,8 +63,8 @@ func SetApiRouter(router *gin.Engine) {
 			webauthnGroup.POST("/registration/finish", middleware.UserAuth(), controller.WebauthnFinishRegistration)
 
 			// 登录相关
-			webauthnGroup.POST("/login/begin", middleware.CriticalRateLimit(), controller.WebauthnBeginLogin)
-			webauthnGroup.POST("/login/finish", middleware.CriticalRateLimit(), controller.WebauthnFinishLogin)
+			webauthnGroup.POST("/login/begin", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), controller.WebauthnBeginLogin)
+			webauthnGroup.POST("/login/finish", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), controller.WebauthnFinishLogin)
 
 			// 凭据管理
 			webauthnGroup.GET("/credentials", middleware.UserAuth(), controller.GetUserWebAuthnCredentials)
@@ -76,8 +76,8 @@ func SetApiRouter(router *gin.Engine) {
 		userRoute := apiRouter.Group("/user")
 		{
 			userRoute.GET("/epay/notify", controller.EpayCallback)
-			userRoute.POST("/register", middleware.CriticalRateLimit(), middleware.TurnstileCheck(), controller.Register)
-			userRoute.POST("/login", middleware.CriticalRateLimit(), middleware.SessionSecurity(), controller.Login)
+			userRoute.POST("/register", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.TurnstileCheck(), controller.Register)
+			userRoute.POST("/login", middleware.CriticalRateLimit(), middleware.AuthIPRateLimit(), middleware.SessionSecurity(), controller.Login)
 			userRoute.GET("/logout", middleware.SessionSecurity(), controller.Logout)
 
 			selfRoute := userRoute.Group("/")
@@ -86,6 +86,7 @@ func SetApiRouter(router *gin.Engine) {
 			{
 				selfRoute.GET("/dashboard", controller.GetUserDashboard)
 				selfRoute.GET("/dashboard/rate", controller.GetRateRealtime)
+				selfRoute.GET("/usage", controller.GetUserUsage)
 				selfRoute.GET("/dashboard/uptimekuma/status-page", controller.UptimeKumaStatusPage)
 				selfRoute.GET("/dashboard/uptimekuma/status-page/heartbeat", controller.UptimeKumaStatusPageHeartbeat)
 				selfRoute.GET("/invoice", controller.GetUserInvoice)
@@ -96,6 +97,7 @@ func SetApiRouter(router *gin.Engine) {
 				// selfRoute.DELETE("/self", controller.DeleteSelf)
 				selfRoute.GET("/token", controller.GenerateAccessToken)
 				selfRoute.GET("/aff", controller.GetAffCode)
+				selfRoute.GET("/aff/referrals", controller.GetSelfReferrals)
 				selfRoute.POST("/topup", controller.TopUp)
 				selfRoute.GET("/payment", controller.GetUserPaymentList)
 				selfRoute.POST("/order", controller.CreateOrder)
@@ -106,12 +108,13 @@ func SetApiRouter(router *gin.Engine) {
 			adminRoute.Use(middleware.AdminAuth())
 			{
 				adminRoute.GET("/", controller.GetUsersList)
-				adminRoute.GET("/:id", controller.GetUser)
+				adminRoute.GET("/referral/report", middleware.TenantRootOnly(), controller.GetReferralReport)
+				adminRoute.GET("/:id", middleware.TenantUserGuard(), controller.GetUser)
 				adminRoute.POST("/", controller.CreateUser)
 				adminRoute.POST("/manage", controller.ManageUser)
-				adminRoute.POST("/quota/:id", controller.ChangeUserQuota)
+				adminRoute.POST("/quota/:id", middleware.TenantUserGuard(), controller.ChangeUserQuota)
 				adminRoute.PUT("/", controller.UpdateUser)
-				adminRoute.DELETE("/:id", controller.DeleteUser)
+				adminRoute.DELETE("/:id", middleware.TenantUserGuard(), controller.DeleteUser)
 			}
 		}
 		optionRoute := apiRouter.Group("/option")
@@ -129,6 +132,8 @@ func SetApiRouter(router *gin.Engine) {
 			optionRoute.POST("/invoice/gen/:time", controller.GenInvoice)
 			optionRoute.POST("/invoice/update/:time", controller.UpdateInvoice)
 			optionRoute.POST("/system_info/log", controller.SystemLog)
+			optionRoute.GET("/email/logs", controller.GetEmailLogsList)
+			optionRoute.POST("/email/test", controller.SendTestEmail)
 		}
 
 		inviteCodeRoute := apiRouter.Group("/invite-code")
@@ -153,6 +158,15 @@ func SetApiRouter(router *gin.Engine) {
 			modelOwnedByRoute.DELETE("/:id", controller.DeleteModelOwnedBy)
 		}
 
+		canaryRoute := apiRouter.Group("/canary")
+		canaryRoute.Use(middleware.AdminAuth(), middleware.TenantRootOnly())
+		{
+			canaryRoute.GET("/", controller.GetAllCanaryRules)
+			canaryRoute.POST("/", controller.CreateCanaryRule)
+			canaryRoute.PUT("/", controller.UpdateCanaryRule)
+			canaryRoute.DELETE("/:id", controller.DeleteCanaryRule)
+		}
+
 		modelInfoRoute := apiRouter.Group("/model_info")
 		modelInfoRoute.GET("/", controller.GetAllModelInfo)
 		modelInfoRoute.Use(middleware.AdminAuth())
@@ -163,6 +177,19 @@ func SetApiRouter(router *gin.Engine) {
 			modelInfoRoute.DELETE("/:id", controller.DeleteModelInfo)
 		}
 
+		tenantRoute := apiRouter.Group("/tenant")
+		tenantRoute.Use(middleware.RootAuth())
+		{
+			tenantRoute.GET("/", controller.GetTenants)
+			tenantRoute.GET("/:id", controller.GetTenantById)
+			tenantRoute.POST("/", controller.AddTenant)
+			tenantRoute.PUT("/", controller.UpdateTenant)
+			tenantRoute.DELETE("/:id", controller.DeleteTenant)
+			tenantRoute.GET("/:id/prices", controller.GetTenantPrices)
+			tenantRoute.POST("/:id/prices", controller.SaveTenantPrice)
+			tenantRoute.DELETE("/:id/prices", controller.DeleteTenantPrice)
+		}
+
 		userGroup := apiRouter.Group("/user_group")
 		userGroup.Use(middleware.AdminAuth())
 		{
@@ -180,17 +207,26 @@ func SetApiRouter(router *gin.Engine) {
 			channelRoute.GET("/", controller.GetChannelsList)
 			channelRoute.GET("/models", relay.ListModelsForAdmin)
 			channelRoute.POST("/provider_models_list", controller.GetModelList)
-			channelRoute.GET("/:id", controller.GetChannel)
-			channelRoute.GET("/test", controller.TestAllChannels)
-			channelRoute.GET("/test/:id", controller.TestChannel)
-			channelRoute.GET("/update_balance", controller.UpdateAllChannelsBalance)
-			channelRoute.GET("/update_balance/:id", controller.UpdateChannelBalance)
+			channelRoute.POST("/transform/preview", controller.PreviewChannelTransform)
+			channelRoute.GET("/:id", middleware.TenantChannelGuard(), controller.GetChannel)
+			channelRoute.GET("/:id/circuit_breaker", middleware.TenantChannelGuard(), controller.GetChannelCircuitBreaker)
+			channelRoute.DELETE("/:id/circuit_breaker", middleware.TenantChannelGuard(), controller.ResetChannelCircuitBreaker)
+			channelRoute.GET("/:id/proxy_health", middleware.TenantChannelGuard(), controller.GetChannelProxyHealth)
+			channelRoute.GET("/test", middleware.TenantRootOnly(), controller.TestAllChannels)
+			channelRoute.GET("/test/:id", middleware.TenantChannelGuard(), controller.TestChannel)
+			channelRoute.GET("/update_balance", middleware.TenantRootOnly(), controller.UpdateAllChannelsBalance)
+			channelRoute.GET("/update_balance/:id", middleware.TenantChannelGuard(), controller.UpdateChannelBalance)
+			channelRoute.GET("/:id/balance", middleware.TenantChannelGuard(), controller.GetChannelBalance)
+			channelRoute.GET("/:id/balance_history", middleware.TenantChannelGuard(), controller.GetChannelBalanceHistory)
 			channelRoute.POST("/", controller.AddChannel)
 			channelRoute.PUT("/", controller.UpdateChannel)
-			channelRoute.PUT("/batch/azure_api", controller.BatchUpdateChannelsAzureApi)
-			channelRoute.PUT("/batch/del_model", controller.BatchDelModelChannels)
-			channelRoute.PUT("/batch/add_model", controller.BatchAddModelToChannels)
-			channelRoute.PUT("/batch/add_user_group", controller.BatchAddUserGroupToChannels)
+			channelRoute.PUT("/batch/azure_api", middleware.TenantRootOnly(), controller.BatchUpdateChannelsAzureApi)
+			channelRoute.PUT("/batch/del_model", middleware.TenantRootOnly(), controller.BatchDelModelChannels)
+			channelRoute.PUT("/batch/add_model", middleware.TenantRootOnly(), controller.BatchAddModelToChannels)
+			channelRoute.PUT("/batch/add_user_group", middleware.TenantRootOnly(), controller.BatchAddUserGroupToChannels)
+			channelRoute.POST("/export", middleware.TenantRootOnly(), controller.ExportChannels)
+			channelRoute.POST("/import/key", middleware.TenantRootOnly(), controller.CreateChannelImportKey)
+			channelRoute.POST("/import", middleware.TenantRootOnly(), controller.ImportChannels)
 		}
 
 		// GeminiCli OAuth routes (no auth required for callback)
@@ -199,10 +235,10 @@ func SetApiRouter(router *gin.Engine) {
 			geminiCliRoute.POST("/oauth/start", middleware.AdminAuth(), controller.StartGeminiCliOAuth)
 			geminiCliRoute.GET("/oauth/callback", controller.GeminiCliOAuthCallback)
 			geminiCliRoute.GET("/oauth/status/:state", middleware.AdminAuth(), controller.GetGeminiCliOAuthStatus)
-			channelRoute.DELETE("/disabled", controller.DeleteDisabledChannel)
-			channelRoute.DELETE("/:id/tag", controller.DeleteChannelTag)
-			channelRoute.DELETE("/:id", controller.DeleteChannel)
-			channelRoute.DELETE("/batch", controller.BatchDeleteChannel)
+			channelRoute.DELETE("/disabled", middleware.TenantRootOnly(), controller.DeleteDisabledChannel)
+			channelRoute.DELETE("/:id/tag", middleware.TenantChannelGuard(), controller.DeleteChannelTag)
+			channelRoute.DELETE("/:id", middleware.TenantChannelGuard(), controller.DeleteChannel)
+			channelRoute.DELETE("/batch", middleware.TenantRootOnly(), controller.BatchDeleteChannel)
 		}
 
 		// ClaudeCode OAuth routes
@@ -211,6 +247,8 @@ func SetApiRouter(router *gin.Engine) {
 		{
 			claudeCodeRoute.POST("/oauth/start", controller.StartClaudeCodeOAuth)
 			claudeCodeRoute.POST("/oauth/exchange-code", controller.ClaudeCodeOAuthCallback)
+			claudeCodeRoute.GET("/channel/:id/usage", middleware.TenantChannelGuard(), controller.GetClaudeCodeChannelUsage)
+			claudeCodeRoute.POST("/channel/:id/refresh", middleware.TenantChannelGuard(), controller.RefreshClaudeCodeChannelCredential)
 		}
 
 		// Codex OAuth routes
@@ -219,8 +257,8 @@ func SetApiRouter(router *gin.Engine) {
 		{
 			codexRoute.POST("/oauth/start", controller.StartCodexOAuth)
 			codexRoute.POST("/oauth/exchange-code", controller.CodexOAuthCallback)
-			codexRoute.GET("/channel/:id/usage", controller.GetCodexChannelUsage)
-			codexRoute.POST("/channel/:id/refresh", controller.RefreshCodexChannelCredential)
+			codexRoute.GET("/channel/:id/usage", middleware.TenantChannelGuard(), controller.GetCodexChannelUsage)
+			codexRoute.POST("/channel/:id/refresh", middleware.TenantChannelGuard(), controller.RefreshCodexChannelCredential)
 		}
 
 		// Antigravity OAuth routes
@@ -232,7 +270,7 @@ func SetApiRouter(router *gin.Engine) {
 		}
 
 		channelTagRoute := apiRouter.Group("/channel_tag")
-		channelTagRoute.Use(middleware.AdminAuth())
+		channelTagRoute.Use(middleware.AdminAuth(), middleware.TenantRootOnly())
 		{
 			channelTagRoute.GET("/_all", controller.GetChannelsTagAllList)
 			channelTagRoute.GET("/:tag/list", controller.GetChannelsTagList)
@@ -251,16 +289,20 @@ func SetApiRouter(router *gin.Engine) {
 			tokenRoute.GET("/playground", controller.GetPlaygroundToken)
 			tokenRoute.GET("/", controller.GetUserTokensList)
 			tokenRoute.GET("/:id", controller.GetToken)
+			tokenRoute.GET("/:id/snippets", controller.GetTokenSnippets)
 			tokenRoute.POST("/", controller.AddToken)
 			tokenRoute.PUT("/", controller.UpdateToken)
 			tokenRoute.DELETE("/:id", controller.DeleteToken)
 		}
 		redemptionRoute := apiRouter.Group("/redemption")
-		redemptionRoute.Use(middleware.AdminAuth())
+		redemptionRoute.Use(middleware.AdminAuth(), middleware.TenantRootOnly())
 		{
 			redemptionRoute.GET("/", controller.GetRedemptionsList)
 			redemptionRoute.GET("/:id", controller.GetRedemption)
 			redemptionRoute.POST("/", controller.AddRedemption)
+			redemptionRoute.POST("/batch", controller.AddRedemptionBatch)
+			redemptionRoute.GET("/batch/:batch_id/export", controller.ExportRedemptionBatch)
+			redemptionRoute.POST("/batch/:batch_id/void", controller.VoidRedemptionBatch)
 			redemptionRoute.PUT("/", controller.UpdateRedemption)
 			redemptionRoute.DELETE("/:id", controller.DeleteRedemption)
 		}
@@ -268,8 +310,11 @@ func SetApiRouter(router *gin.Engine) {
 		{
 			logRoute.GET("/", middleware.AdminAuth(), controller.GetLogsList)
 			logRoute.GET("/export", middleware.AdminAuth(), controller.ExportLogsList)
-			logRoute.DELETE("/", middleware.AdminAuth(), controller.DeleteHistoryLogs)
+			logRoute.GET("/export/batches", middleware.RootAuth(), controller.GetLogExportBatches)
+			logRoute.DELETE("/", middleware.AdminAuth(), middleware.TenantRootOnly(), controller.DeleteHistoryLogs)
 			logRoute.GET("/stat", middleware.AdminAuth(), controller.GetLogsStat)
+			logRoute.GET("/stream", middleware.AdminAuth(), controller.StreamLogs)
+			logRoute.POST("/replay", middleware.RootAuth(), controller.ReplayRequestDebug)
 			logRoute.GET("/self/stat", middleware.UserAuth(), controller.GetLogsSelfStat)
 			// logRoute.GET("/search", middleware.AdminAuth(), controller.SearchAllLogs)
 			logRoute.GET("/self", middleware.UserAuth(), controller.GetUserLogsList)
@@ -285,28 +330,34 @@ func SetApiRouter(router *gin.Engine) {
 		analyticsRoute := apiRouter.Group("/analytics")
 		analyticsRoute.Use(middleware.AdminAuth())
 		{
-			analyticsRoute.GET("/statistics", controller.GetStatisticsDetail)
-			analyticsRoute.GET("/period", controller.GetStatisticsByPeriod)
-			analyticsRoute.GET("/multi_user_stats", controller.GetMultiUserStatistics)
-			analyticsRoute.GET("/multi_user_stats/export", controller.ExportMultiUserStatisticsCSV)
-			analyticsRoute.GET("/recharge", controller.GetRechargeStatisticsByTimeRange)
+			analyticsRoute.GET("/statistics", middleware.TenantRootOnly(), controller.GetStatisticsDetail)
+			analyticsRoute.GET("/period", middleware.TenantRootOnly(), controller.GetStatisticsByPeriod)
+			analyticsRoute.GET("/multi_user_stats", middleware.TenantRootOnly(), controller.GetMultiUserStatistics)
+			analyticsRoute.GET("/multi_user_stats/export", middleware.TenantRootOnly(), controller.ExportMultiUserStatisticsCSV)
+			analyticsRoute.GET("/recharge", middleware.TenantRootOnly(), controller.GetRechargeStatisticsByTimeRange)
+			analyticsRoute.GET("/channel_performance", controller.GetChannelPerformance)
+			analyticsRoute.GET("/top_consumers", controller.GetTopConsumers)
+			analyticsRoute.GET("/anomalies", controller.GetUsageAnomalies)
 		}
 		pricesRoute := apiRouter.Group("/prices")
 		pricesRoute.Use(middleware.AdminAuth())
 		{
 			pricesRoute.GET("/model_list", controller.GetAllModelList)
-			pricesRoute.POST("/single", controller.AddPrice)
-			pricesRoute.PUT("/single/*model", controller.UpdatePrice)
-			pricesRoute.DELETE("/single/*model", controller.DeletePrice)
-			pricesRoute.POST("/multiple", controller.BatchSetPrices)
-			pricesRoute.PUT("/multiple/delete", controller.BatchDeletePrices)
-			pricesRoute.POST("/sync", controller.SyncPricing)
+			pricesRoute.POST("/single", middleware.TenantRootOnly(), controller.AddPrice)
+			pricesRoute.PUT("/single/*model", middleware.TenantRootOnly(), controller.UpdatePrice)
+			pricesRoute.DELETE("/single/*model", middleware.TenantRootOnly(), controller.DeletePrice)
+			pricesRoute.POST("/multiple", middleware.TenantRootOnly(), controller.BatchSetPrices)
+			pricesRoute.PUT("/multiple/delete", middleware.TenantRootOnly(), controller.BatchDeletePrices)
+			pricesRoute.POST("/sync", middleware.TenantRootOnly(), controller.SyncPricing)
+			pricesRoute.GET("/versions", controller.GetPriceVersions)
+			pricesRoute.POST("/versions", middleware.TenantRootOnly(), controller.AddPriceVersion)
+			pricesRoute.DELETE("/versions/:id", middleware.TenantRootOnly(), controller.CancelPriceVersion)
 			pricesRoute.GET("/updateService", controller.GetUpdatePriceService)
 
 		}
 
 		paymentRoute := apiRouter.Group("/payment")
-		paymentRoute.Use(middleware.AdminAuth())
+		paymentRoute.Use(middleware.AdminAuth(), middleware.TenantRootOnly())
 		{
 			paymentRoute.GET("/order", controller.GetOrderList)
 			paymentRoute.GET("/", controller.GetPaymentList)
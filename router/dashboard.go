@@ -9,7 +9,6 @@ import (
 )
 
 func SetDashboardRouter(router *gin.Engine) {
-	router.Use(middleware.CORS())
 	apiRouter := router.Group("/")
 	apiRouter.Use(gzip.Gzip(gzip.DefaultCompression))
 	apiRouter.Use(middleware.GlobalAPIRateLimit())
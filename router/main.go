@@ -1,8 +1,11 @@
 package router
 
 import (
+	"context"
 	"done-hub/common/config"
 	"done-hub/common/logger"
+	"done-hub/common/pathrewrite"
+	"done-hub/middleware"
 	"embed"
 	"fmt"
 	"net/http"
@@ -17,6 +20,9 @@ func SetRouter(router *gin.Engine, buildFS embed.FS, indexPage []byte) {
 	// URL 路径归一化：将 /v1/v1/... 重写为 /v1/...
 	// 兼容 Cherry Studio 等客户端将 Base URL 设为 https://host/v1 后自动拼接 /v1/chat/completions
 	router.Use(urlNormalize(router))
+	// 跨域策略需在全局注册，才能处理未注册 OPTIONS 路由的预检请求
+	router.Use(middleware.CORS())
+	router.Use(middleware.Tenant())
 
 	SetApiRouter(router)
 	SetDashboardRouter(router)
@@ -46,7 +52,11 @@ func SetRouter(router *gin.Engine, buildFS embed.FS, indexPage []byte) {
 	}
 }
 
+// pathRewrittenKey 标记请求已按自定义规则重写过路径
+type pathRewrittenKey struct{}
+
 // urlNormalize 返回 URL 路径归一化中间件
+// 0. 按后台配置的路径重写规则（PathRewriteRules）重写路径，如 /openai/v1/* => /v1/*
 // 1. 将重复的 /v1/v1/ 前缀归一化为 /v1/，兼容 Cherry Studio、NextChat 等
 //    将 Base URL 配置为 https://host/v1 的客户端（客户端自动拼接 /v1/...，导致 /v1/v1/...）
 // 2. 将缺少 /v1 前缀的 OpenAI API 路径自动补充 /v1，兼容直接使用
@@ -73,6 +83,13 @@ func urlNormalize(engine *gin.Engine) gin.HandlerFunc {
 		path := c.Request.URL.Path
 		changed := false
 
+		// 规则 0: 自定义重写规则，重新路由后不再重复应用，避免规则之间循环重写
+		rewritten := false
+		if c.Request.Context().Value(pathRewrittenKey{}) == nil {
+			path, rewritten = pathrewrite.Rewrite(path)
+			changed = rewritten
+		}
+
 		// 规则 1: 循环处理多层重复的 /v1/v1/v1/... → /v1/...
 		for strings.HasPrefix(path, "/v1/v1") {
 			path = strings.TrimPrefix(path, "/v1")
@@ -80,7 +97,7 @@ func urlNormalize(engine *gin.Engine) gin.HandlerFunc {
 		}
 
 		// 规则 2: 缺少 /v1 前缀的 API 路径自动补充
-		if !changed {
+		if !changed || rewritten {
 			for _, apiPath := range bareAPIPaths {
 				if path == apiPath || strings.HasPrefix(path, apiPath+"/") {
 					path = "/v1" + path
@@ -92,7 +109,11 @@ func urlNormalize(engine *gin.Engine) gin.HandlerFunc {
 
 		if changed {
 			c.Request.URL.Path = path
-			if c.Request.URL.RawPath != "" {
+			if rewritten {
+				// 自定义规则重写后的路径与原始编码路径无法对应，交由 URL 重新编码
+				c.Request.URL.RawPath = ""
+				c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), pathRewrittenKey{}, true))
+			} else if c.Request.URL.RawPath != "" {
 				rawPath := c.Request.URL.RawPath
 				// 规则 1 的 RawPath 处理
 				for strings.HasPrefix(rawPath, "/v1/v1") {
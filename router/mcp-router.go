@@ -3,6 +3,7 @@ package router
 import (
 	"done-hub/common/logger"
 	"done-hub/mcp"
+	"done-hub/mcp/caller"
 	"done-hub/middleware"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
@@ -13,26 +14,34 @@ func SetMcpRouter(router *gin.Engine) {
 	mcpServer.RegisterTools()
 
 	mcpRouter := router.Group("/mcp")
-	mcpRouter.Use(middleware.CORS())
 	mcpRouter.Use(gzip.Gzip(gzip.DefaultCompression))
-	mcpRouter.Use(middleware.UserAuth())
+	mcpRouter.Use(middleware.RelayIPRateLimit())
+	mcpRouter.Use(middleware.MCPAuth())
 	mcpRouter.Use(middleware.ContextUserId())
+	mcpRouter.Use(caller.Setup())
 	{
-		mcpRouter.POST("/:accessToken", mcpServer.HandleStreamable)
-		mcpRouter.GET("/sse/:accessToken", mcpServer.HandleSSE)
-		mcpRouter.POST("/message/:accessToken", mcpServer.HandleMessage)
+		// 令牌通过 Authorization 请求头传递，或者放在路径中
+		// Streamable HTTP 使用 POST 发送消息，GET 接收服务端推送，DELETE 结束会话
+		for _, path := range []string{"", "/:token"} {
+			mcpRouter.POST(path, mcpServer.HandleStreamable)
+			mcpRouter.GET(path, mcpServer.HandleStreamable)
+			mcpRouter.DELETE(path, mcpServer.HandleStreamable)
+		}
+		mcpRouter.GET("/sse", mcpServer.HandleSSE)
+		mcpRouter.POST("/message", mcpServer.HandleMessage)
+		mcpRouter.GET("/sse/:token", mcpServer.HandleSSE)
+		mcpRouter.POST("/message/:token", mcpServer.HandleMessage)
 	}
 
+	// 两个服务的 Run 都会阻塞，需要分别启动
 	go func() {
-		err := mcpServer.SSEServer.Run()
-		if err != nil {
+		if err := mcpServer.SSEServer.Run(); err != nil {
 			logger.SysError("mcp sse server error: " + err.Error())
-			return
 		}
-		err = mcpServer.StreamableServer.Run()
-		if err != nil {
+	}()
+	go func() {
+		if err := mcpServer.StreamableServer.Run(); err != nil {
 			logger.SysError("mcp streamable server error: " + err.Error())
-			return
 		}
 	}()
 
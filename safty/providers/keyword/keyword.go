@@ -67,16 +67,23 @@ func (k *KeywordChecker) Check(data string) (types.CheckResult, error) {
 		Details:   make([]string, 0),
 	}
 
+	lowerData := strings.ToLower(data)
 	for _, keyword := range config.SafeKeyWords {
-		if strings.Contains(strings.ToLower(data), strings.ToLower(keyword)) {
-			result.IsSafe = false
-			result.Details = append(result.Details, types.SafeDefaultErrorMessage)
-			result.Code = types.SafeDefaultErrorCode
-			result.Reason = types.SafeDefaultErrorMessage
-			result.RiskLevel = 10
-			return result, nil
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" {
+			continue
 		}
+		if strings.Contains(lowerData, strings.ToLower(keyword)) {
+			result.Matches = append(result.Matches, keyword)
+		}
+	}
+
+	if len(result.Matches) > 0 {
+		result.Details = append(result.Details, types.SafeDefaultErrorMessage)
+		result.RiskLevel = 10
+		return result, nil
 	}
+
 	result.Code = types.SafeDefaultSuccessCode
 	result.Reason = types.SafeDefaultSuccessMessage
 	result.RiskLevel = 0
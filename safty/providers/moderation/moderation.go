@@ -0,0 +1,112 @@
+package moderation
+
+import (
+	"context"
+	"done-hub/common/config"
+	"done-hub/common/requester"
+	"done-hub/safty/types"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// moderationTimeout 单次审查请求的超时时间
+const moderationTimeout = 15 * time.Second
+
+// ModerationChecker 调用外部审查模型（OpenAI moderations 兼容接口）检查内容
+type ModerationChecker struct{}
+
+type moderationRequest struct {
+	Model string `json:"model,omitempty"`
+	Input string `json:"input"`
+}
+
+type moderationResponse struct {
+	Results []struct {
+		Flagged    bool            `json:"flagged"`
+		Categories map[string]bool `json:"categories"`
+	} `json:"results"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// NewModerationChecker 创建新的审查模型检查器实例
+func NewModerationChecker() *ModerationChecker {
+	return &ModerationChecker{}
+}
+
+// Name 返回检查器名称
+func (m *ModerationChecker) Name() string {
+	return "Moderation"
+}
+
+// Init 审查模型的配置在每次检查时读取，无需初始化
+func (m *ModerationChecker) Init() error {
+	return nil
+}
+
+// Check 调用审查模型，被标记时返回命中的分类；接口调用失败时视为不安全
+func (m *ModerationChecker) Check(data string) (types.CheckResult, error) {
+	result := types.CheckResult{
+		IsSafe:    false,
+		RiskLevel: 1,
+		Code:      types.SafeDefaultErrorCode,
+		Reason:    types.SafeDefaultErrorMessage,
+		Details:   make([]string, 0),
+	}
+
+	if config.SafeModerationSecret == "" {
+		return result, errors.New("moderation secret is not configured")
+	}
+
+	url := strings.TrimSuffix(config.SafeModerationBaseURL, "/") + "/v1/moderations"
+	ctx, cancel := context.WithTimeout(context.Background(), moderationTimeout)
+	defer cancel()
+
+	client := requester.NewHTTPRequester("", nil)
+	client.Context = ctx
+	headers := requester.GetJsonHeaders()
+	headers["Authorization"] = "Bearer " + config.SafeModerationSecret
+
+	req, err := client.NewRequest(http.MethodPost, url, client.WithHeader(headers), client.WithBody(moderationRequest{
+		Model: config.SafeModerationModel,
+		Input: data,
+	}))
+	if err != nil {
+		return result, err
+	}
+
+	var moderation moderationResponse
+	if _, errWithCode := client.SendRequest(req, &moderation, false); errWithCode != nil {
+		return result, errWithCode
+	}
+	if moderation.Error != nil {
+		return result, errors.New(moderation.Error.Message)
+	}
+
+	flagged := false
+	for _, item := range moderation.Results {
+		if !item.Flagged {
+			continue
+		}
+		flagged = true
+		for category, hit := range item.Categories {
+			if hit {
+				result.Details = append(result.Details, category)
+			}
+		}
+	}
+
+	if flagged {
+		result.RiskLevel = 10
+		return result, nil
+	}
+
+	result.IsSafe = true
+	result.RiskLevel = 0
+	result.Code = types.SafeDefaultSuccessCode
+	result.Reason = types.SafeDefaultSuccessMessage
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package regex
+
+import (
+	"done-hub/common/config"
+	"done-hub/common/logger"
+	"done-hub/safty/types"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RegexChecker 基于正则表达式的内容安全检查器
+type RegexChecker struct {
+	mu       sync.Mutex
+	rulesKey string
+	patterns []*regexp.Regexp
+}
+
+// NewRegexChecker 创建新的正则检查器实例
+func NewRegexChecker() *RegexChecker {
+	return &RegexChecker{}
+}
+
+// Name 返回检查器名称
+func (r *RegexChecker) Name() string {
+	return "Regex"
+}
+
+// Init 初始化正则检查器，预编译规则
+func (r *RegexChecker) Init() error {
+	patterns := r.getPatterns()
+	logger.SysLog(fmt.Sprintf("SafeTools %s load rules：%d pcs", r.Name(), len(patterns)))
+	return nil
+}
+
+// getPatterns 规则在后台修改后重新编译，无效的规则会被跳过
+func (r *RegexChecker) getPatterns() []*regexp.Regexp {
+	rulesKey := strings.Join(config.SafeRegexRules, "\n")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.patterns != nil && r.rulesKey == rulesKey {
+		return r.patterns
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(config.SafeRegexRules))
+	for _, rule := range config.SafeRegexRules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			logger.SysError(fmt.Sprintf("SafeTools %s invalid rule %s: %s", r.Name(), rule, err.Error()))
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	r.rulesKey = rulesKey
+	r.patterns = patterns
+	return patterns
+}
+
+// Check 执行正则检查，返回所有命中的片段
+func (r *RegexChecker) Check(data string) (types.CheckResult, error) {
+	result := types.CheckResult{
+		IsSafe:    true,
+		RiskLevel: 0,
+		Code:      types.SafeDefaultSuccessCode,
+		Reason:    types.SafeDefaultSuccessMessage,
+		Details:   make([]string, 0),
+	}
+
+	for _, pattern := range r.getPatterns() {
+		matches := pattern.FindAllString(data, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		result.Matches = append(result.Matches, matches...)
+		result.Details = append(result.Details, pattern.String())
+	}
+
+	if len(result.Matches) > 0 {
+		result.IsSafe = false
+		result.RiskLevel = 10
+		result.Code = types.SafeDefaultErrorCode
+		result.Reason = types.SafeDefaultErrorMessage
+	}
+
+	return result, nil
+}
@@ -3,6 +3,8 @@ package safty
 import (
 	"done-hub/common/logger"
 	"done-hub/safty/providers/keyword"
+	"done-hub/safty/providers/moderation"
+	"done-hub/safty/providers/regex"
 	"done-hub/safty/types"
 	"fmt"
 )
@@ -37,6 +39,8 @@ func InitSaftyTools() error {
 	// 注册关键词检查器
 	keywordChecker := keyword.NewKeywordChecker()
 	RegisterTool("Keyword", keywordChecker)
+	RegisterTool("Regex", regex.NewRegexChecker())
+	RegisterTool("Moderation", moderation.NewModerationChecker())
 
 	// 初始化所有已注册的检查器
 	for name, tool := range Tools {
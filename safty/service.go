@@ -6,6 +6,10 @@ import (
 	"done-hub/safty/types"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
 )
 
 // RegisterTool 注册一个新的安全检查器
@@ -143,7 +147,7 @@ func CheckContent(content interface{}) (types.CheckResult, error) {
 	result.Code = types.SafeDefaultSuccessCode
 	result.Reason = types.SafeDefaultErrorMessage
 	result.Details = make([]string, 0)
-	tool, err := getTool(config.SafeToolName)
+	tools, err := getConfiguredTools()
 	if err != nil {
 		result.RiskLevel = 1
 		result.Code = types.SafeDefaultErrorCode
@@ -171,5 +175,88 @@ func CheckContent(content interface{}) (types.CheckResult, error) {
 		return result, nil
 	}
 
-	return tool.Check(contentStr)
+	return checkWithTools(tools, contentStr)
+}
+
+// getConfiguredTools 获取配置的检查器，多个检查器用逗号分隔
+func getConfiguredTools() ([]SaftyTool, error) {
+	tools := make([]SaftyTool, 0)
+	for _, name := range strings.Split(config.SafeToolName, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		tool, err := getTool(name)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, tool)
+	}
+	if len(tools) == 0 {
+		return nil, errors.New("safety tool not found")
+	}
+	return tools, nil
+}
+
+// checkWithTools 依次使用检查器检查，汇总所有不安全的结果
+// 任一检查器无法定位命中内容（如审查模型）时，结果中不包含 Matches，无法进行替换处理
+func checkWithTools(tools []SaftyTool, content string) (types.CheckResult, error) {
+	var unsafe *types.CheckResult
+	redactable := true
+
+	for _, tool := range tools {
+		result, err := tool.Check(content)
+		if err != nil {
+			logger.SysError(fmt.Sprintf("Safety tool %s check failed: %s", tool.Name(), err.Error()))
+		}
+		if result.IsSafe {
+			continue
+		}
+
+		if len(result.Matches) == 0 {
+			redactable = false
+		}
+		if unsafe == nil {
+			unsafe = &result
+			continue
+		}
+		unsafe.Details = append(unsafe.Details, result.Details...)
+		unsafe.Matches = append(unsafe.Matches, result.Matches...)
+		unsafe.RiskLevel = max(unsafe.RiskLevel, result.RiskLevel)
+	}
+
+	if unsafe == nil {
+		return types.CheckResult{
+			IsSafe:    true,
+			RiskLevel: 0,
+			Code:      types.SafeDefaultSuccessCode,
+			Reason:    types.SafeDefaultSuccessMessage,
+			Details:   make([]string, 0),
+		}, nil
+	}
+
+	if !redactable {
+		unsafe.Matches = nil
+	}
+	return *unsafe, nil
+}
+
+// Redact 将内容中命中的片段替换为 *
+func Redact(content string, matches []string) string {
+	// 先替换较长的片段，避免被其中包含的短片段拆开
+	sorted := append([]string(nil), matches...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	for _, match := range sorted {
+		if match == "" {
+			continue
+		}
+		pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(match))
+		content = pattern.ReplaceAllStringFunc(content, func(s string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(s))
+		})
+	}
+	return content
 }
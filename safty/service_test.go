@@ -0,0 +1,26 @@
+package safty
+
+import "testing"
+
+// TestRedact 测试命中内容的替换
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		matches []string
+		want    string
+	}{
+		{"无命中", "hello world", nil, "hello world"},
+		{"忽略大小写", "Hello WORLD", []string{"world"}, "Hello *****"},
+		{"中文按字符替换", "这是敏感词内容", []string{"敏感词"}, "这是***内容"},
+		{"优先替换长片段", "abc ab", []string{"ab", "abc"}, "*** **"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.content, tt.matches); got != tt.want {
+				t.Errorf("Redact() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
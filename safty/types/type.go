@@ -6,6 +6,13 @@ const SafeDefaultErrorMessage = "content contains sensitive information"
 const SafeDefaultSuccessCode = "content_security_policy_through"
 const SafeDefaultSuccessMessage = "content safe"
 
+// 命中后的处理方式
+const (
+	SafeActionBlock  = "block"  // 拦截请求
+	SafeActionFlag   = "flag"   // 记录日志后放行
+	SafeActionRedact = "redact" // 替换命中内容后放行
+)
+
 // CheckResult 定义了内容安全检查的结果
 type CheckResult struct {
 	// IsSafe 表示内容是否安全
@@ -18,6 +25,8 @@ type CheckResult struct {
 	Details []string `json:"details,omitempty"`
 	// RiskLevel 风险等级，数值越大风险越高
 	RiskLevel int `json:"risk_level,omitempty"`
+	// Matches 命中的原文片段，用于替换处理；外部审查模型无法定位命中内容时为空
+	Matches []string `json:"matches,omitempty"`
 }
 
 // CheckConfig 定义了安全检查器的配置
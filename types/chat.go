@@ -1,6 +1,9 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 const (
 	ContentTypeText     = "text"
@@ -157,6 +160,8 @@ type ChatMessagePart struct {
 	// Thinking 相关字段 - 用于 Claude thinking 块的转换
 	Thinking          string `json:"thinking,omitempty"`
 	ThinkingSignature string `json:"thinking_signature,omitempty"`
+
+	CacheControl any `json:"cache_control,omitempty"` // Anthropic 提示缓存断点
 }
 
 type InputAudio struct {
@@ -206,7 +211,7 @@ type ChatCompletionRequest struct {
 	FunctionCall        any                           `json:"function_call,omitempty"`
 	Tools               []*ChatCompletionTool         `json:"tools,omitempty"`
 	ToolChoice          any                           `json:"tool_choice,omitempty"`
-	ParallelToolCalls   bool                          `json:"parallel_tool_calls,omitempty"`
+	ParallelToolCalls   *bool                         `json:"parallel_tool_calls,omitempty"`
 	Modalities          []string                      `json:"modalities,omitempty"`
 	Audio               *ChatAudio                    `json:"audio,omitempty"`
 	ReasoningEffort     *string                       `json:"reasoning_effort,omitempty"`
@@ -229,6 +234,103 @@ type ChatCompletionRequest struct {
 	OneOtherArg string `json:"-"`
 }
 
+// WithoutCacheControl 返回去掉 Anthropic cache_control 的请求副本，供 OpenAI 兼容的上游使用。
+// 原请求保持不变，重试到 Claude 渠道时仍能使用缓存断点
+func (r *ChatCompletionRequest) WithoutCacheControl() *ChatCompletionRequest {
+	if !r.hasCacheControl() {
+		return r
+	}
+
+	request := *r
+	request.Messages = make([]ChatCompletionMessage, len(r.Messages))
+	for i, message := range r.Messages {
+		message.CacheControl = nil
+		message.Content = stripContentCacheControl(message.Content)
+		request.Messages[i] = message
+	}
+
+	if r.Tools != nil {
+		request.Tools = make([]*ChatCompletionTool, len(r.Tools))
+		for i, tool := range r.Tools {
+			if tool != nil && tool.CacheControl != nil {
+				stripped := *tool
+				stripped.CacheControl = nil
+				tool = &stripped
+			}
+			request.Tools[i] = tool
+		}
+	}
+
+	return &request
+}
+
+func (r *ChatCompletionRequest) hasCacheControl() bool {
+	for _, message := range r.Messages {
+		if message.CacheControl != nil || contentHasCacheControl(message.Content) {
+			return true
+		}
+	}
+	for _, tool := range r.Tools {
+		if tool != nil && tool.CacheControl != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// contentHasCacheControl 检查消息内容中是否带有 cache_control，
+// 内容可能是 ChatMessagePart 列表，也可能是直接从 JSON 解码得到的 []any
+func contentHasCacheControl(content any) bool {
+	switch parts := content.(type) {
+	case []ChatMessagePart:
+		for _, part := range parts {
+			if part.CacheControl != nil {
+				return true
+			}
+		}
+	case []any:
+		for _, part := range parts {
+			if item, ok := part.(map[string]any); ok {
+				if _, ok := item["cache_control"]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// stripContentCacheControl 返回去掉 cache_control 的消息内容副本，不修改原内容
+func stripContentCacheControl(content any) any {
+	switch parts := content.(type) {
+	case []ChatMessagePart:
+		stripped := make([]ChatMessagePart, len(parts))
+		for i, part := range parts {
+			part.CacheControl = nil
+			stripped[i] = part
+		}
+		return stripped
+	case []any:
+		stripped := make([]any, len(parts))
+		for i, part := range parts {
+			if item, ok := part.(map[string]any); ok {
+				if _, ok := item["cache_control"]; ok {
+					copied := make(map[string]any, len(item))
+					for key, value := range item {
+						if key != "cache_control" {
+							copied[key] = value
+						}
+					}
+					part = copied
+				}
+			}
+			stripped[i] = part
+		}
+		return stripped
+	}
+	return content
+}
+
 type ChatReasoning struct {
 	MaxTokens int     `json:"max_tokens,omitempty"`
 	Effort    string  `json:"effort,omitempty"`
@@ -290,8 +392,9 @@ type ChatCompletionFunction struct {
 }
 
 type ChatCompletionTool struct {
-	Type     string                 `json:"type"`
-	Function ChatCompletionFunction `json:"function,omitzero"`
+	Type         string                 `json:"type"`
+	Function     ChatCompletionFunction `json:"function,omitzero"`
+	CacheControl any                    `json:"cache_control,omitempty"` // Anthropic 提示缓存断点
 
 	ResponsesTools
 }
@@ -321,6 +424,7 @@ type ChatCompletionResponse struct {
 	Usage               *Usage                 `json:"usage,omitempty"`
 	SystemFingerprint   string                 `json:"system_fingerprint,omitempty"`
 	PromptFilterResults any                    `json:"prompt_filter_results,omitempty"`
+	Citations           any                    `json:"citations,omitempty"`
 }
 
 func (cc *ChatCompletionResponse) GetContent() string {
@@ -331,6 +435,39 @@ func (cc *ChatCompletionResponse) GetContent() string {
 	return content
 }
 
+// GetUsageText 返回用于本地计算 completion tokens 的全部输出文本（含推理内容与工具调用）
+func (cc *ChatCompletionResponse) GetUsageText() string {
+	var builder strings.Builder
+	for _, choice := range cc.Choices {
+		builder.WriteString(reasoningText(choice.Message.ReasoningContent, choice.Message.Reasoning))
+		builder.WriteString(choice.Message.StringContent())
+		writeToolCallsText(&builder, choice.Message.FunctionCall, choice.Message.ToolCalls)
+	}
+	return builder.String()
+}
+
+// reasoningText 部分上游会在 reasoning_content 与 reasoning 中返回相同的推理内容，只计算一次
+func reasoningText(reasoningContent, reasoning string) string {
+	if reasoningContent != "" {
+		return reasoningContent
+	}
+	return reasoning
+}
+
+func writeToolCallsText(builder *strings.Builder, functionCall *ChatCompletionToolCallsFunction, toolCalls []*ChatCompletionToolCalls) {
+	if functionCall != nil {
+		builder.WriteString(functionCall.Name)
+		builder.WriteString(functionCall.Arguments)
+	}
+	for _, toolCall := range toolCalls {
+		if toolCall == nil || toolCall.Function == nil {
+			continue
+		}
+		builder.WriteString(toolCall.Function.Name)
+		builder.WriteString(toolCall.Function.Arguments)
+	}
+}
+
 func (c ChatCompletionStreamChoice) ConvertOpenaiStream() []ChatCompletionStreamChoice {
 	var choices []ChatCompletionStreamChoice
 	var stopFinish string
@@ -389,7 +526,7 @@ func (f *ChatCompletionToolCallsFunction) Split(c *ChatCompletionStreamChoice, s
 			}
 
 			if fIndex == 0 {
-				toolCalls.Id = c.Delta.ToolCalls[0].Id
+				toolCalls.Id = c.Delta.ToolCalls[index].Id
 			}
 			choice.Delta.ToolCalls = []*ChatCompletionToolCalls{toolCalls}
 		}
@@ -449,6 +586,7 @@ type ChatCompletionStreamResponse struct {
 	Choices           []ChatCompletionStreamChoice `json:"choices"`
 	PromptAnnotations any                          `json:"prompt_annotations,omitempty"`
 	Usage             *Usage                       `json:"usage,omitempty"`
+	Citations         any                          `json:"citations,omitempty"`
 }
 
 func (c *ChatCompletionStreamResponse) GetResponseText() (responseText string) {
@@ -459,6 +597,17 @@ func (c *ChatCompletionStreamResponse) GetResponseText() (responseText string) {
 	return
 }
 
+// GetUsageText 返回用于本地计算 completion tokens 的增量文本（含推理内容与工具调用）
+func (c *ChatCompletionStreamResponse) GetUsageText() string {
+	var builder strings.Builder
+	for _, choice := range c.Choices {
+		builder.WriteString(reasoningText(choice.Delta.ReasoningContent, choice.Delta.Reasoning))
+		builder.WriteString(choice.Delta.Content)
+		writeToolCallsText(&builder, choice.Delta.FunctionCall, choice.Delta.ToolCalls)
+	}
+	return builder.String()
+}
+
 type ChatAudio struct {
 	Voice  string `json:"voice"`
 	Format string `json:"format"`
@@ -518,8 +667,8 @@ func (c *ChatCompletionRequest) ToResponsesRequest() *OpenAIResponsesRequest {
 			Summary: c.Reasoning.Summary,
 		}
 
-		if c.Reasoning.Effort != "" {
-			res.Reasoning.Effort = &c.Reasoning.Effort
+		if effort := c.ReasoningEffortFromBudget(); effort != "" {
+			res.Reasoning.Effort = &effort
 		}
 	}
 
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithoutCacheControl(t *testing.T) {
+	cacheControl := map[string]string{"type": "ephemeral"}
+	request := &ChatCompletionRequest{
+		Model: "gpt-4o",
+		Messages: []ChatCompletionMessage{
+			{Role: ChatMessageRoleSystem, Content: "system", CacheControl: cacheControl},
+			{Role: ChatMessageRoleUser, Content: []ChatMessagePart{{Type: ContentTypeText, Text: "hi", CacheControl: cacheControl}}},
+		},
+		Tools: []*ChatCompletionTool{
+			{Type: "function", Function: ChatCompletionFunction{Name: "search"}, CacheControl: cacheControl},
+		},
+	}
+
+	stripped := request.WithoutCacheControl()
+	body, err := json.Marshal(stripped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "cache_control") {
+		t.Fatalf("cache_control should be removed: %s", body)
+	}
+
+	// 原请求保持不变
+	if request.Messages[0].CacheControl == nil || request.Tools[0].CacheControl == nil {
+		t.Fatal("original request should keep cache_control")
+	}
+	if parts := request.Messages[1].Content.([]ChatMessagePart); parts[0].CacheControl == nil {
+		t.Fatal("original content parts should keep cache_control")
+	}
+
+	plain := &ChatCompletionRequest{Model: "gpt-4o", Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "hi"}}}
+	if plain.WithoutCacheControl() != plain {
+		t.Fatal("request without cache_control should not be copied")
+	}
+}
+
+// TestWithoutCacheControlDecoded 测试从 JSON 解码的请求（内容为 []any）也能去掉 cache_control
+func TestWithoutCacheControlDecoded(t *testing.T) {
+	body := `{"model":"gpt-4o","messages":[{"role":"user","content":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}},{"type":"text","text":"there"}]}]}`
+	request := &ChatCompletionRequest{}
+	if err := json.Unmarshal([]byte(body), request); err != nil {
+		t.Fatal(err)
+	}
+
+	stripped, err := json.Marshal(request.WithoutCacheControl())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(stripped), "cache_control") {
+		t.Fatalf("cache_control should be removed: %s", stripped)
+	}
+	if !strings.Contains(string(stripped), `"text":"there"`) {
+		t.Fatalf("other content parts should be kept: %s", stripped)
+	}
+
+	// 原请求保持不变
+	original, _ := json.Marshal(request)
+	if !strings.Contains(string(original), "cache_control") {
+		t.Fatal("original request should keep cache_control")
+	}
+}
+
+func TestGetUsageTextReasoning(t *testing.T) {
+	response := &ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+		Message: ChatCompletionMessage{Content: "answer", ReasoningContent: "think", Reasoning: "think"},
+	}}}
+	if text := response.GetUsageText(); text != "thinkanswer" {
+		t.Fatalf("reasoning should be counted once, got %q", text)
+	}
+
+	stream := &ChatCompletionStreamResponse{Choices: []ChatCompletionStreamChoice{{
+		Delta: ChatCompletionStreamChoiceDelta{Content: "answer", Reasoning: "think"},
+	}}}
+	if text := stream.GetUsageText(); text != "thinkanswer" {
+		t.Fatalf("reasoning should be counted when only reasoning is set, got %q", text)
+	}
+}
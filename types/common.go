@@ -13,6 +13,7 @@ type Usage struct {
 	TotalTokens             int                     `json:"total_tokens"`
 	PromptTokensDetails     PromptTokensDetails     `json:"prompt_tokens_details"`
 	CompletionTokensDetails CompletionTokensDetails `json:"completion_tokens_details"`
+	NumSourcesUsed          int                     `json:"num_sources_used,omitempty"` // xAI Live Search 使用的来源数
 
 	ExtraTokens  map[string]int          `json:"-"`
 	ExtraBilling map[string]ExtraBilling `json:"-"`
@@ -99,8 +100,9 @@ type PromptTokensDetails struct {
 	ImageTokens          int `json:"image_tokens,omitempty"`
 	CachedTokensInternal int `json:"cached_tokens_internal,omitempty"`
 
-	CachedWriteTokens int `json:"-"`
-	CachedReadTokens  int `json:"-"`
+	// Anthropic 提示缓存写入/读取的 token 数，返回给客户端用于核对缓存命中
+	CachedWriteTokens int `json:"cache_creation_tokens,omitempty"`
+	CachedReadTokens  int `json:"cache_read_tokens,omitempty"`
 }
 
 type CompletionTokensDetails struct {
@@ -0,0 +1,33 @@
+package types
+
+// OCRRequest 文档识别请求（Mistral OCR 格式）
+type OCRRequest struct {
+	Model                    string `json:"model" binding:"required"`
+	Id                       string `json:"id,omitempty"`
+	Document                 any    `json:"document" binding:"required"`
+	Pages                    any    `json:"pages,omitempty"`
+	IncludeImageBase64       *bool  `json:"include_image_base64,omitempty"`
+	ImageLimit               *int   `json:"image_limit,omitempty"`
+	ImageMinSize             *int   `json:"image_min_size,omitempty"`
+	BboxAnnotationFormat     any    `json:"bbox_annotation_format,omitempty"`
+	DocumentAnnotationFormat any    `json:"document_annotation_format,omitempty"`
+}
+
+type OCRPage struct {
+	Index      int    `json:"index"`
+	Markdown   string `json:"markdown"`
+	Images     any    `json:"images,omitempty"`
+	Dimensions any    `json:"dimensions,omitempty"`
+}
+
+type OCRUsageInfo struct {
+	PagesProcessed int  `json:"pages_processed"`
+	DocSizeBytes   *int `json:"doc_size_bytes,omitempty"`
+}
+
+type OCRResponse struct {
+	Pages              []OCRPage    `json:"pages"`
+	Model              string       `json:"model"`
+	DocumentAnnotation any          `json:"document_annotation,omitempty"`
+	UsageInfo          OCRUsageInfo `json:"usage_info"`
+}
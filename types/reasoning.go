@@ -0,0 +1,68 @@
+package types
+
+import (
+	"done-hub/common/config"
+	"strings"
+)
+
+// ReasoningEffortSuffixes 模型名中支持的推理力度后缀
+var ReasoningEffortSuffixes = []string{"-minimal", "-high", "-medium", "-low"}
+
+// ParseReasoningEffortSuffix 从模型名中解析推理力度后缀
+// 例如: "gpt-5-codex-high" → effort="high", model="gpt-5-codex"
+//
+//	"claude-sonnet-4-5-low" → effort="low", model="claude-sonnet-4-5"
+//	"gpt-5-codex" → effort="", model="gpt-5-codex" (无变化)
+func ParseReasoningEffortSuffix(model string) (effort string, originModel string) {
+	for _, suffix := range ReasoningEffortSuffixes {
+		if strings.HasSuffix(model, suffix) {
+			return suffix[1:], model[:len(model)-len(suffix)]
+		}
+	}
+	return "", model
+}
+
+// ApplyReasoningEffortSuffix 去掉模型名中的推理力度后缀，请求中未指定推理力度时使用后缀的值，
+// 只处理推理力度对照表中存在的后缀，避免误改以这些词结尾的模型名
+func (c *ChatCompletionRequest) ApplyReasoningEffortSuffix() {
+	effort, model := ParseReasoningEffortSuffix(c.Model)
+	if effort == "" {
+		return
+	}
+	if _, ok := config.ReasoningSettingsInstance.GetBudgetByEffort(effort); !ok {
+		return
+	}
+
+	c.Model = model
+	if c.ReasoningEffort == nil && (c.Reasoning == nil || (c.Reasoning.Effort == "" && c.Reasoning.MaxTokens == 0)) {
+		c.ReasoningEffort = &effort
+	}
+}
+
+// NormalizeReasoning 将 reasoning_effort 合并到 reasoning 中，供 Claude、Gemini 等使用思考预算的渠道在转换请求前调用
+func (c *ChatCompletionRequest) NormalizeReasoning() {
+	if c.ReasoningEffort == nil || *c.ReasoningEffort == "" {
+		return
+	}
+
+	if c.Reasoning == nil {
+		c.Reasoning = &ChatReasoning{}
+	}
+	if c.Reasoning.Effort == "" && c.Reasoning.MaxTokens == 0 {
+		c.Reasoning.Effort = *c.ReasoningEffort
+	}
+}
+
+// ReasoningEffortFromBudget 请求只指定了思考预算时，按对照表反推推理力度
+func (c *ChatCompletionRequest) ReasoningEffortFromBudget() string {
+	if c.Reasoning == nil {
+		return ""
+	}
+	if c.Reasoning.Effort != "" {
+		return c.Reasoning.Effort
+	}
+	if c.Reasoning.MaxTokens > 0 {
+		return config.ReasoningSettingsInstance.GetEffortByBudget(c.Reasoning.MaxTokens)
+	}
+	return ""
+}
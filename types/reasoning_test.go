@@ -0,0 +1,83 @@
+package types
+
+import (
+	"done-hub/common/config"
+	"testing"
+)
+
+func TestParseReasoningEffortSuffix(t *testing.T) {
+	tests := []struct {
+		model  string
+		effort string
+		origin string
+	}{
+		{"gpt-5-codex-high", "high", "gpt-5-codex"},
+		{"gpt-5-codex-mini", "", "gpt-5-codex-mini"},
+		{"gpt-5-minimal", "minimal", "gpt-5"},
+		{"claude-sonnet-4-5-low", "low", "claude-sonnet-4-5"},
+		{"gemini-2.5-pro", "", "gemini-2.5-pro"},
+	}
+
+	for _, tt := range tests {
+		effort, origin := ParseReasoningEffortSuffix(tt.model)
+		if effort != tt.effort || origin != tt.origin {
+			t.Errorf("%s: got (%q, %q), want (%q, %q)", tt.model, effort, origin, tt.effort, tt.origin)
+		}
+	}
+}
+
+func TestApplyReasoningEffortSuffix(t *testing.T) {
+	request := &ChatCompletionRequest{Model: "claude-sonnet-4-5-medium"}
+	request.ApplyReasoningEffortSuffix()
+	request.NormalizeReasoning()
+	if request.Model != "claude-sonnet-4-5" || request.Reasoning == nil || request.Reasoning.Effort != "medium" {
+		t.Fatalf("unexpected request: model=%s reasoning=%+v", request.Model, request.Reasoning)
+	}
+
+	// 请求中已指定的推理参数优先于后缀
+	request = &ChatCompletionRequest{Model: "gemini-2.5-flash-high", Reasoning: &ChatReasoning{MaxTokens: 2048}}
+	request.ApplyReasoningEffortSuffix()
+	request.NormalizeReasoning()
+	if request.Model != "gemini-2.5-flash" || request.Reasoning.Effort != "" || request.Reasoning.MaxTokens != 2048 {
+		t.Fatalf("unexpected request: model=%s reasoning=%+v", request.Model, request.Reasoning)
+	}
+}
+
+func TestApplyReasoningEffortSuffixUnknownEffort(t *testing.T) {
+	budgets := config.ReasoningSettingsInstance.EffortBudgets
+	defer func() { config.ReasoningSettingsInstance.EffortBudgets = budgets }()
+	config.ReasoningSettingsInstance.EffortBudgets = map[string]int{"low": 1024, "high": 24576}
+
+	// 对照表中没有的推理力度不当作后缀处理
+	request := &ChatCompletionRequest{Model: "claude-sonnet-4-5-minimal"}
+	request.ApplyReasoningEffortSuffix()
+	if request.Model != "claude-sonnet-4-5-minimal" || request.ReasoningEffort != nil {
+		t.Fatalf("unexpected request: model=%s effort=%v", request.Model, request.ReasoningEffort)
+	}
+
+	request = &ChatCompletionRequest{Model: "claude-sonnet-4-5-high"}
+	request.ApplyReasoningEffortSuffix()
+	if request.Model != "claude-sonnet-4-5" || request.ReasoningEffort == nil || *request.ReasoningEffort != "high" {
+		t.Fatalf("unexpected request: model=%s effort=%v", request.Model, request.ReasoningEffort)
+	}
+}
+
+func TestReasoningEffortFromBudget(t *testing.T) {
+	tests := []struct {
+		budget int
+		effort string
+	}{
+		{256, "low"},
+		{1024, "low"},
+		{8192, "medium"},
+		{16000, "medium"},
+		{32000, "high"},
+	}
+
+	for _, tt := range tests {
+		request := &ChatCompletionRequest{Reasoning: &ChatReasoning{MaxTokens: tt.budget}}
+		if effort := request.ReasoningEffortFromBudget(); effort != tt.effort {
+			t.Errorf("budget %d: got %q, want %q", tt.budget, effort, tt.effort)
+		}
+	}
+}
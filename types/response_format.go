@@ -0,0 +1,229 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	ResponseFormatTypeText       = "text"
+	ResponseFormatTypeJsonObject = "json_object"
+	ResponseFormatTypeJsonSchema = "json_schema"
+)
+
+// maxSchemaValidateDepth 校验的最大嵌套深度，超过后不再深入
+const maxSchemaValidateDepth = 32
+
+// IsJSON 是否要求模型输出 JSON
+func (r *ChatCompletionResponseFormat) IsJSON() bool {
+	return r != nil && (r.Type == ResponseFormatTypeJsonObject || r.Type == ResponseFormatTypeJsonSchema)
+}
+
+// GetSchema 获取 json_schema 中的 schema，没有时返回 nil
+func (r *ChatCompletionResponseFormat) GetSchema() map[string]any {
+	if r == nil || r.Type != ResponseFormatTypeJsonSchema || r.JsonSchema == nil {
+		return nil
+	}
+
+	switch schema := r.JsonSchema.Schema.(type) {
+	case map[string]any:
+		return schema
+	case nil:
+		return nil
+	default:
+		// 其他类型（如 json.RawMessage）统一转换为 map
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return nil
+		}
+		var result map[string]any
+		if json.Unmarshal(data, &result) != nil {
+			return nil
+		}
+		return result
+	}
+}
+
+// ValidateOutput 校验模型输出的内容是否符合 response_format 的要求
+func (r *ChatCompletionResponseFormat) ValidateOutput(content string) error {
+	if !r.IsJSON() {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &value); err != nil {
+		return fmt.Errorf("model output is not valid JSON: %s", err.Error())
+	}
+
+	if r.Type == ResponseFormatTypeJsonObject {
+		if _, ok := value.(map[string]any); !ok {
+			return fmt.Errorf("model output is not a JSON object")
+		}
+		return nil
+	}
+
+	schema := r.GetSchema()
+	if schema == nil {
+		return nil
+	}
+
+	return validateSchema(value, schema, schema, "$", 0)
+}
+
+// validateSchema 按 JSON Schema 的常用关键字校验，未支持的关键字忽略
+func validateSchema(value any, schema, root map[string]any, path string, depth int) error {
+	if depth > maxSchemaValidateDepth {
+		return nil
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved := resolveSchemaRef(root, ref)
+		if resolved == nil {
+			return nil
+		}
+		return validateSchema(value, resolved, root, path, depth+1)
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsJSONValue(enum, value) {
+		return fmt.Errorf("%s: value is not one of the allowed enum values", path)
+	}
+
+	if constVal, ok := schema["const"]; ok && !jsonValueEqual(constVal, value) {
+		return fmt.Errorf("%s: value does not match const", path)
+	}
+
+	if anyOf, ok := schema["anyOf"].([]any); ok && len(anyOf) > 0 {
+		matched := false
+		for _, sub := range anyOf {
+			subSchema, ok := sub.(map[string]any)
+			if !ok || validateSchema(value, subSchema, root, path, depth+1) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value does not match any schema in anyOf", path)
+		}
+	}
+
+	if !matchSchemaType(value, schema["type"]) {
+		return fmt.Errorf("%s: expected type %v", path, schema["type"])
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				key, _ := name.(string)
+				if _, exists := v[key]; key != "" && !exists {
+					return fmt.Errorf("%s: missing required property %q", path, key)
+				}
+			}
+		}
+		for key, item := range v {
+			if propSchema, ok := properties[key].(map[string]any); ok {
+				if err := validateSchema(item, propSchema, root, path+"."+key, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				return fmt.Errorf("%s: unexpected property %q", path, key)
+			}
+		}
+	case []any:
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validateSchema(item, itemSchema, root, fmt.Sprintf("%s[%d]", path, i), depth+1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchSchemaType(value any, schemaType any) bool {
+	switch t := schemaType.(type) {
+	case string:
+		return matchJSONType(value, t)
+	case []any:
+		for _, item := range t {
+			if name, ok := item.(string); ok && matchJSONType(value, name) {
+				return true
+			}
+		}
+		return len(t) == 0
+	default:
+		return true
+	}
+}
+
+func matchJSONType(value any, typeName string) bool {
+	switch typeName {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// resolveSchemaRef 只支持文档内部引用，如 #/$defs/xxx
+func resolveSchemaRef(root map[string]any, ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+
+	var current any = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = node[part]
+	}
+
+	resolved, _ := current.(map[string]any)
+	return resolved
+}
+
+func containsJSONValue(list []any, value any) bool {
+	for _, item := range list {
+		if jsonValueEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonValueEqual(a, b any) bool {
+	aData, _ := json.Marshal(a)
+	bData, _ := json.Marshal(b)
+	return string(aData) == string(bData)
+}
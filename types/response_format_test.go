@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+)
+
+// TestResponseFormatValidateOutput 测试结构化输出的校验
+func TestResponseFormatValidateOutput(t *testing.T) {
+	schemaFormat := &ChatCompletionResponseFormat{
+		Type: ResponseFormatTypeJsonSchema,
+		JsonSchema: &FormatJsonSchema{
+			Name: "person",
+			Schema: map[string]any{
+				"type":     "object",
+				"required": []any{"name", "age"},
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+					"age":  map[string]any{"type": "integer"},
+					"tags": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"$ref": "#/$defs/tag"},
+					},
+				},
+				"additionalProperties": false,
+				"$defs": map[string]any{
+					"tag": map[string]any{"type": "string", "enum": []any{"a", "b"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  *ChatCompletionResponseFormat
+		content string
+		wantErr bool
+	}{
+		{"无格式要求", nil, "hello", false},
+		{"json_object 合法", &ChatCompletionResponseFormat{Type: ResponseFormatTypeJsonObject}, ` {"a":1} `, false},
+		{"json_object 非对象", &ChatCompletionResponseFormat{Type: ResponseFormatTypeJsonObject}, `[1]`, true},
+		{"json_object 非 JSON", &ChatCompletionResponseFormat{Type: ResponseFormatTypeJsonObject}, "```json\n{}\n```", true},
+		{"schema 合法", schemaFormat, `{"name":"tom","age":3,"tags":["a"]}`, false},
+		{"缺少必填字段", schemaFormat, `{"name":"tom"}`, true},
+		{"类型错误", schemaFormat, `{"name":"tom","age":3.5}`, true},
+		{"多余字段", schemaFormat, `{"name":"tom","age":3,"x":1}`, true},
+		{"引用枚举不匹配", schemaFormat, `{"name":"tom","age":3,"tags":["c"]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.ValidateOutput(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
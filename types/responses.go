@@ -12,6 +12,7 @@ const (
 	APITollTypeFileSearch       = "file_search"
 	APITollTypeCodeInterpreter  = "code_interpreter"
 	APITollTypeImageGeneration  = "image_generation"
+	APITollTypeLiveSearch       = "live_search"
 )
 
 // message / file_search_call / computer_call / web_search_call / computer_call_output / function_call / function_call_output / reasoning / image_generation_call / code_interpreter_call / local_shell_call / local_shell_call_output / mcp_list_tools / mcp_approval_request / mcp_approval_response / mcp_call
@@ -63,7 +64,7 @@ type OpenAIResponsesRequest struct {
 	MaxOutputTokens    int              `json:"max_output_tokens,omitempty"`
 	MaxTokens          int              `json:"max_tokens,omitempty"`
 	MaxToolCalls       *int             `json:"max_tool_calls,omitempty"`
-	ParallelToolCalls  bool             `json:"parallel_tool_calls,omitempty"`
+	ParallelToolCalls  *bool            `json:"parallel_tool_calls,omitempty"`
 	PreviousResponseID string           `json:"previous_response_id,omitempty"`
 	Reasoning          *ReasoningEffort `json:"reasoning,omitempty"`
 	Store              *bool            `json:"store,omitempty"` // 是否存储响应结果
@@ -749,7 +750,7 @@ func (cc *ChatCompletionResponse) ToResponses(request *OpenAIResponsesRequest) *
 			},
 		},
 		MaxOutputTokens:   request.MaxOutputTokens,
-		ParallelToolCalls: request.ParallelToolCalls,
+		ParallelToolCalls: request.ParallelToolCallsEnabled(),
 		Temperature:       request.Temperature,
 		ToolChoice:        request.ToolChoice,
 		TopP:              request.TopP,
@@ -0,0 +1,50 @@
+package types
+
+// ToolCallIndexer 在流式转换中为工具调用分配连续的 index
+// OpenAI 客户端按 index 合并流式的工具调用分片，并行的工具调用必须使用不同的 index
+type ToolCallIndexer struct {
+	count int
+}
+
+// Next 开始一个新的工具调用，返回其 index
+func (t *ToolCallIndexer) Next() int {
+	t.count++
+	return t.count - 1
+}
+
+// Current 返回当前工具调用的 index
+func (t *ToolCallIndexer) Current() int {
+	if t.count == 0 {
+		return 0
+	}
+	return t.count - 1
+}
+
+// Reserve 一次开始 n 个工具调用，返回第一个的 index
+func (t *ToolCallIndexer) Reserve(n int) int {
+	offset := t.count
+	t.count += n
+	return offset
+}
+
+// Count 已开始的工具调用数量
+func (t *ToolCallIndexer) Count() int {
+	return t.count
+}
+
+// ReindexToolCalls 按顺序设置非流式响应中工具调用的 index
+func ReindexToolCalls(toolCalls []*ChatCompletionToolCalls) {
+	for i, toolCall := range toolCalls {
+		toolCall.Index = i
+	}
+}
+
+// ParallelToolCallsDisabled 客户端是否显式关闭了并行工具调用
+func (r *ChatCompletionRequest) ParallelToolCallsDisabled() bool {
+	return r.ParallelToolCalls != nil && !*r.ParallelToolCalls
+}
+
+// ParallelToolCallsEnabled 是否允许并行工具调用，未设置时默认允许
+func (r *OpenAIResponsesRequest) ParallelToolCallsEnabled() bool {
+	return r.ParallelToolCalls == nil || *r.ParallelToolCalls
+}
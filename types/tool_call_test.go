@@ -0,0 +1,41 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParallelToolCallsConversion(t *testing.T) {
+	disabled := false
+
+	// chat -> responses 保留显式关闭
+	responses := (&ChatCompletionRequest{Model: "gpt-4o", ParallelToolCalls: &disabled}).ToResponsesRequest()
+	body, _ := json.Marshal(responses)
+	if !strings.Contains(string(body), `"parallel_tool_calls":false`) {
+		t.Fatalf("parallel_tool_calls=false should be kept: %s", body)
+	}
+	if responses.ParallelToolCallsEnabled() {
+		t.Fatal("parallel tool calls should be disabled")
+	}
+
+	// responses -> chat 保留显式关闭
+	var request OpenAIResponsesRequest
+	if err := json.Unmarshal([]byte(`{"model":"gpt-4o","parallel_tool_calls":false}`), &request); err != nil {
+		t.Fatal(err)
+	}
+	chat, err := request.ToChatCompletionRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !chat.ParallelToolCallsDisabled() {
+		t.Fatal("parallel_tool_calls=false should be kept when converting to chat")
+	}
+
+	// 未设置时不下发，默认允许并行
+	unset := (&ChatCompletionRequest{Model: "gpt-4o"}).ToResponsesRequest()
+	body, _ = json.Marshal(unset)
+	if strings.Contains(string(body), "parallel_tool_calls") || !unset.ParallelToolCallsEnabled() {
+		t.Fatalf("unset parallel_tool_calls should default to enabled: %s", body)
+	}
+}
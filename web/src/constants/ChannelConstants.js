@@ -208,6 +208,13 @@ export const CHANNEL_OPTIONS = {
     color: 'orange',
     url: 'https://console.cloud.google.com/'
   },
+  62: {
+    key: 62,
+    text: 'GitHub Copilot',
+    value: 62,
+    color: 'default',
+    url: 'https://github.com/features/copilot'
+  },
   45: {
     key: 45,
     text: 'Siliconflow',
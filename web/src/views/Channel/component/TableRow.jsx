@@ -150,6 +150,7 @@ export default function ChannelTableRow({
     response_time: item.response_time
   })
   const [itemBalance, setItemBalance] = useState(item.balance)
+  const [balanceDetail, setBalanceDetail] = useState(null)
 
   const [openRow, setOpenRow] = useState(false)
   let modelMap = []
@@ -327,10 +328,11 @@ export default function ChannelTableRow({
 
   const updateChannelBalance = async() => {
     try {
-      const res = await API.get(`/api/channel/update_balance/${item.id}`)
-      const { success, message, balance } = res.data
+      const res = await API.get(`/api/channel/${item.id}/balance`)
+      const { success, message, data } = res.data
       if (success) {
-        setItemBalance(balance)
+        setItemBalance(data.channel_balance)
+        setBalanceDetail(data)
 
         showInfo(t('channel_row.updateOk'))
       } else {
@@ -480,18 +482,20 @@ export default function ChannelTableRow({
           {!item.tag && (
             <Stack spacing={0.5} alignItems="center">
               <Typography variant="body1">{renderQuota(item.used_quota)}</Typography>
-              <Typography
-                variant="caption"
-                sx={{
-                  color: 'success.main',
-                  fontWeight: 600,
-                  cursor: 'pointer',
-                  '&:hover': { textDecoration: 'underline' }
-                }}
-                onClick={updateChannelBalance}
-              >
-                {renderBalance(item.type, itemBalance)}
-              </Typography>
+              <Tooltip title={renderBalanceDetail(balanceDetail)} placement="top">
+                <Typography
+                  variant="caption"
+                  sx={{
+                    color: 'success.main',
+                    fontWeight: 600,
+                    cursor: 'pointer',
+                    '&:hover': { textDecoration: 'underline' }
+                  }}
+                  onClick={updateChannelBalance}
+                >
+                  {renderBalance(item.type, itemBalance)}
+                </Typography>
+              </Tooltip>
             </Stack>
           )}
         </TableCell>
@@ -1513,6 +1517,25 @@ ChannelTableRow.propTypes = {
   prices: PropTypes.array
 }
 
+function renderBalanceDetail(detail) {
+  if (!detail) {
+    return ''
+  }
+
+  const lines = [`${detail.currency} ${detail.balance.toFixed(2)}`]
+  if (detail.total) {
+    lines.push(`total: ${detail.total.toFixed(2)}`)
+  }
+  if (detail.used) {
+    lines.push(`used: ${detail.used.toFixed(2)}`)
+  }
+  Object.entries(detail.details || {}).forEach(([key, value]) => {
+    lines.push(`${key}: ${value.toFixed(2)}`)
+  })
+
+  return <span style={{ whiteSpace: 'pre-line' }}>{lines.join('\n')}</span>
+}
+
 function renderBalance(type, balance) {
   switch (type) {
     case 28: // Deepseek
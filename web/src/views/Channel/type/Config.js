@@ -294,7 +294,8 @@ const typeConfig = {
         'mistral-small-latest',
         'mistral-medium-latest',
         'mistral-large-latest',
-        'mistral-embed'
+        'mistral-embed',
+        'mistral-ocr-latest'
       ],
       test_model: 'open-mistral-7b'
     },
@@ -597,6 +598,20 @@ const typeConfig = {
       other: '格式：us-central1|your-project-id'
     },
     modelGroup: 'VertexAI Express'
+  },
+  62: {
+    input: {
+      models: ['gpt-4.1', 'gpt-4o', 'gpt-5-mini', 'claude-sonnet-4', 'gemini-2.5-pro'],
+      test_model: 'gpt-4.1'
+    },
+    inputLabel: {
+      provider_models_list: '从Copilot获取模型列表'
+    },
+    prompt: {
+      key: '请输入拥有 Copilot 订阅的 GitHub OAuth token（gho_ 或 ghu_ 开头），系统会自动换取 Copilot token',
+      base_url: '留空将使用换取 token 时返回的地址（个人版为 https://api.individual.githubcopilot.com）'
+    },
+    modelGroup: 'Copilot'
   }
 }
 